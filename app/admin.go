@@ -0,0 +1,67 @@
+/* admin.go
+ * Contains the app logic behind the admin commands: removing a user's picks and reading and writing the
+ * audit log every admin command is recorded in.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/store"
+)
+
+// AuditResultOK and AuditResultDenied are the audit log results for successful and unauthorised commands.
+// Failed commands record their error message instead.
+const (
+	AuditResultOK     = "ok"
+	AuditResultDenied = "denied"
+)
+
+// DeleteUserPrediction removes a user's prediction for the current round and regenerates the leaderboard so
+// they drop off it immediately. Deleting the last prediction leaves an empty leaderboard. Returns store.ErrNotFound
+// when the user has no prediction stored.
+func (a *App) DeleteUserPrediction(ctx context.Context, userID string) error {
+	if err := a.Store.DeleteUserPrediction(ctx, userID); err != nil {
+		return err
+	}
+
+	// GenerateLeaderboard needs at least one prediction to score, so clear the leaderboard when none are left
+	remaining, err := a.Store.GetAllUserPredictions(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if len(remaining) == 0 {
+		return a.Store.StoreLeaderboard(ctx, store.Leaderboard{Round: a.Store.GetRound()})
+	}
+	return a.GenerateLeaderboard(ctx)
+}
+
+// FindPredictor returns the user behind a stored prediction for the current round, looked up by user ID or,
+// failing that, by username (case-insensitive) as CheckPredictionByUsername does. Returns store.ErrNotFound when
+// neither matches.
+func (a *App) FindPredictor(ctx context.Context, idOrUsername string) (models.User, error) {
+	pred, err := a.Store.GetUserPrediction(ctx, idOrUsername)
+	if errors.Is(err, store.ErrNotFound) {
+		pred, err = a.findPredictionByUsername(ctx, idOrUsername, a.Store.GetRound())
+	}
+	if err != nil {
+		return models.User{}, err
+	}
+	return models.User{UserID: pred.UserID, Username: pred.Username}, nil
+}
+
+// RecordAdminAction appends an entry to the audit log, stamping it with the current round and time.
+func (a *App) RecordAdminAction(ctx context.Context, entry store.AuditEntry) error {
+	entry.Round = a.Store.GetRound()
+	entry.Timestamp = time.Now().UTC()
+	return a.Store.StoreAuditEntry(ctx, entry)
+}
+
+// GetAuditLog returns up to limit of a guild's most recent audit log entries, newest first.
+func (a *App) GetAuditLog(ctx context.Context, guildID string, limit int) ([]store.AuditEntry, error) {
+	return a.Store.FetchAuditEntries(ctx, guildID, limit)
+}
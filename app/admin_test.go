@@ -0,0 +1,141 @@
+/* admin_test.go
+ * Contains unit tests for admin.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// region DeleteUserPrediction tests
+
+func TestDeleteUserPrediction_RemovesPickAndRegeneratesLeaderboard(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetSwissResults(map[string]string{"Team A": "3-0"})
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockStore.Predictions["user2"] = models.Prediction{UserID: "user2", Username: "two", Format: "swiss", Round: "test_round"}
+	mockStore.Leaderboard = []store.LeaderboardEntry{{UserID: "user1", Username: "one"}, {UserID: "user2", Username: "two"}}
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.DeleteUserPrediction(context.Background(), "user1"))
+	assert.NotContains(t, mockStore.Predictions, "user1")
+	require.Len(t, mockStore.Leaderboard, 1)
+	assert.Equal(t, "user2", mockStore.Leaderboard[0].UserID)
+}
+
+func TestDeleteUserPrediction_LastPredictionClearsLeaderboard(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetSwissResults(map[string]string{"Team A": "3-0"})
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockStore.Leaderboard = []store.LeaderboardEntry{{UserID: "user1", Username: "one"}}
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.DeleteUserPrediction(context.Background(), "user1"))
+	assert.Empty(t, mockStore.Predictions)
+	assert.Empty(t, mockStore.Leaderboard)
+}
+
+func TestDeleteUserPrediction_LastPredictionInMemoryStore(t *testing.T) {
+	memoryStore := store.NewMemoryStore("db", "stage_1", nil, nil)
+	require.NoError(t, memoryStore.StoreUserPrediction(context.Background(), "user1", models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "stage_1"}))
+	require.NoError(t, memoryStore.StoreLeaderboard(context.Background(), store.Leaderboard{Round: "stage_1", Entries: []store.LeaderboardEntry{{UserID: "user1"}}}))
+	a := &App{Store: memoryStore}
+
+	require.NoError(t, a.DeleteUserPrediction(context.Background(), "user1"))
+	entries, err := memoryStore.FetchLeaderboardFromDB(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDeleteUserPrediction_NotFound(t *testing.T) {
+	a := &App{Store: NewMockStore("swiss", "test_round")}
+
+	err := a.DeleteUserPrediction(context.Background(), "nobody")
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+}
+
+func TestDeleteUserPrediction_LeaderboardError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1"}
+	mockStore.Predictions["user2"] = models.Prediction{UserID: "user2"}
+	mockStore.GetMatchResultsError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	assert.Error(t, a.DeleteUserPrediction(context.Background(), "user1"))
+	assert.NotContains(t, mockStore.Predictions, "user1")
+}
+
+// endregion
+
+// region FindPredictor tests
+
+func TestFindPredictor_ByIDThenUsername(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "PlayerOne"}
+	a := &App{Store: mockStore}
+
+	user, err := a.FindPredictor(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, models.User{UserID: "user1", Username: "PlayerOne"}, user)
+
+	user, err = a.FindPredictor(context.Background(), "playerone")
+	require.NoError(t, err)
+	assert.Equal(t, "user1", user.UserID)
+
+	_, err = a.FindPredictor(context.Background(), "nobody")
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+}
+
+func TestFindPredictor_StoreError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GetUserPredictionError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.FindPredictor(context.Background(), "user1")
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region audit log tests
+
+func TestRecordAdminAction_StampsRoundAndTime(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.RecordAdminAction(context.Background(), store.AuditEntry{GuildID: "guild1", UserID: "admin", Command: "refresh", Result: AuditResultOK}))
+	require.Len(t, mockStore.AuditLog, 1)
+	assert.Equal(t, "test_round", mockStore.AuditLog[0].Round)
+	assert.False(t, mockStore.AuditLog[0].Timestamp.IsZero())
+}
+
+func TestGetAuditLog_NewestFirstForGuild(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.AuditLog = []store.AuditEntry{
+		{GuildID: "guild1", Command: "refresh"},
+		{GuildID: "guild2", Command: "render"},
+		{GuildID: "guild1", Command: "rescore"},
+	}
+	a := &App{Store: mockStore}
+
+	entries, err := a.GetAuditLog(context.Background(), "guild1", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "rescore", entries[0].Command)
+	assert.Equal(t, "refresh", entries[1].Command)
+}
+
+// endregion
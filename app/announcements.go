@@ -0,0 +1,130 @@
+/* announcements.go
+ * Contains the logic for working out which match results are new after an update, so they can be announced
+ * to a channel. Delivering the announcement is the bot's responsibility.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+)
+
+// ResultsSnapshot captures the stored match nodes and each user's pending pick count at a point in time.
+// Take one before running the update pipeline and pass it to ResultsAnnouncement afterwards.
+type ResultsSnapshot struct {
+	Nodes   map[string]sources.MatchNode // match ID -> node
+	Pending map[string]int               // user ID -> pending picks
+}
+
+// FinishedMatch is a single newly decided match
+type FinishedMatch struct {
+	Team1   string
+	Team2   string
+	Winner  string
+	Score   string
+	Section string
+	// Records holds each team's Swiss record after this match (e.g. "2-1"). Empty for other formats.
+	Records map[string]string
+}
+
+// ResultsAnnouncement describes every match decided by a single update, plus how many users had at
+// least one pick decided by it
+type ResultsAnnouncement struct {
+	Round        string
+	Matches      []FinishedMatch
+	PicksDecided int
+}
+
+// SnapshotResults captures the current stored match nodes and leaderboard. Missing data (e.g. before the
+// first update of a round) produces an empty snapshot rather than an error.
+func (a *App) SnapshotResults(ctx context.Context) (ResultsSnapshot, error) {
+	snapshot := ResultsSnapshot{
+		Nodes:   make(map[string]sources.MatchNode),
+		Pending: make(map[string]int),
+	}
+
+	nodes, _, err := a.MatchNodes(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return ResultsSnapshot{}, err
+	}
+	for _, n := range nodes {
+		snapshot.Nodes[n.ID] = n
+	}
+
+	entries, err := a.Store.FetchLeaderboardFromDB(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return ResultsSnapshot{}, err
+	}
+	for _, e := range entries {
+		snapshot.Pending[e.UserID] = e.Pending
+	}
+	return snapshot, nil
+}
+
+// decided reports whether a match node has a winner. The sources mark undecided matches with "TBD" as well as "".
+func decided(node sources.MatchNode) bool {
+	return node.Winner != "" && node.Winner != "TBD"
+}
+
+// ResultsAnnouncement compares the current stored results against before and returns the matches that have
+// gained a winner since. Matches that were already decided in before are never reported again.
+func (a *App) ResultsAnnouncement(ctx context.Context, before ResultsSnapshot) (ResultsAnnouncement, error) {
+	after, err := a.SnapshotResults(ctx)
+	if err != nil {
+		return ResultsAnnouncement{}, err
+	}
+
+	announcement := ResultsAnnouncement{Round: a.Store.GetRound()}
+	for id, node := range after.Nodes {
+		if !decided(node) {
+			continue
+		}
+		if prev, ok := before.Nodes[id]; ok && decided(prev) {
+			continue
+		}
+		announcement.Matches = append(announcement.Matches, FinishedMatch{
+			Team1:   node.Team1,
+			Team2:   node.Team2,
+			Winner:  node.Winner,
+			Score:   node.Score,
+			Section: node.Section,
+		})
+	}
+	if len(announcement.Matches) == 0 {
+		return announcement, nil
+	}
+	sort.Slice(announcement.Matches, func(i, j int) bool {
+		if announcement.Matches[i].Section != announcement.Matches[j].Section {
+			return announcement.Matches[i].Section < announcement.Matches[j].Section
+		}
+		return announcement.Matches[i].Team1 < announcement.Matches[j].Team1
+	})
+
+	// Attach Swiss records so the announcement shows where each team now stands
+	results, err := a.Store.GetMatchResults(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return ResultsAnnouncement{}, err
+	}
+	if swiss, ok := results.(tournament.SwissResult); ok {
+		for i, m := range announcement.Matches {
+			announcement.Matches[i].Records = map[string]string{
+				m.Team1: swiss.Teams[m.Team1],
+				m.Team2: swiss.Teams[m.Team2],
+			}
+		}
+	}
+
+	// A user's picks were decided by this update if they now have fewer pending picks than before
+	for userID, pending := range after.Pending {
+		if prev, ok := before.Pending[userID]; ok && pending < prev {
+			announcement.PicksDecided++
+		}
+	}
+	return announcement, nil
+}
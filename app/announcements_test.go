@@ -0,0 +1,149 @@
+/* announcements_test.go
+ * Contains unit tests for announcements.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// region SnapshotResults tests
+
+func TestSnapshotResults_CapturesNodesAndPending(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B"}}
+	mockStore.Leaderboard = []store.LeaderboardEntry{{UserID: "user1", ScoreResult: models.ScoreResult{Pending: 5}}}
+	a := &App{Store: mockStore}
+
+	snapshot, err := a.SnapshotResults(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, snapshot.Nodes, "m1")
+	assert.Equal(t, 5, snapshot.Pending["user1"])
+}
+
+func TestSnapshotResults_NoDocumentsIsEmpty(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchNodesFromDbError = mongo.ErrNoDocuments
+	mockStore.FetchLeaderboardFromDBError = mongo.ErrNoDocuments
+	a := &App{Store: mockStore}
+
+	snapshot, err := a.SnapshotResults(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Nodes)
+	assert.Empty(t, snapshot.Pending)
+}
+
+func TestSnapshotResults_Errors(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchNodesFromDbError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.SnapshotResults(context.Background())
+	assert.Error(t, err)
+
+	mockStore.FetchMatchNodesFromDbError = nil
+	mockStore.FetchLeaderboardFromDBError = errors.New("db down")
+	_, err = a.SnapshotResults(context.Background())
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region ResultsAnnouncement tests
+
+func TestResultsAnnouncement_ReportsOnlyNewWinners(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-0", Section: "Round 1"},
+		{ID: "m2", Team1: "Team C", Team2: "Team D"},
+	}
+	mockStore.Leaderboard = []store.LeaderboardEntry{
+		{UserID: "user1", ScoreResult: models.ScoreResult{Pending: 10}},
+		{UserID: "user2", ScoreResult: models.ScoreResult{Pending: 10}},
+	}
+	a := &App{Store: mockStore}
+	before, err := a.SnapshotResults(context.Background())
+	require.NoError(t, err)
+
+	// m2 finishes, user1 has a pick decided
+	mockStore.MatchNodes[1].Winner = "Team D"
+	mockStore.MatchNodes[1].Score = "2-1"
+	mockStore.Leaderboard[0].Pending = 9
+	mockStore.SetSwissResults(map[string]string{"Team C": "0-1", "Team D": "1-0"})
+
+	announcement, err := a.ResultsAnnouncement(context.Background(), before)
+	require.NoError(t, err)
+	require.Len(t, announcement.Matches, 1)
+	m := announcement.Matches[0]
+	assert.Equal(t, "Team D", m.Winner)
+	assert.Equal(t, "2-1", m.Score)
+	assert.Equal(t, map[string]string{"Team C": "0-1", "Team D": "1-0"}, m.Records)
+	assert.Equal(t, 1, announcement.PicksDecided)
+	assert.Equal(t, "test_round", announcement.Round)
+}
+
+func TestResultsAnnouncement_NothingNew(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A"}}
+	a := &App{Store: mockStore}
+	before, err := a.SnapshotResults(context.Background())
+	require.NoError(t, err)
+
+	announcement, err := a.ResultsAnnouncement(context.Background(), before)
+	require.NoError(t, err)
+	assert.Empty(t, announcement.Matches)
+}
+
+func TestResultsAnnouncement_SkipsTBDWinners(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "TBD"}}
+	a := &App{Store: mockStore}
+	before, err := a.SnapshotResults(context.Background())
+	require.NoError(t, err)
+
+	// The next round's pairing appears undecided, and m1 is decided
+	mockStore.MatchNodes[0].Winner = "Team A"
+	mockStore.MatchNodes = append(mockStore.MatchNodes, sources.MatchNode{ID: "m2", Team1: "Team A", Team2: "Team C", Winner: "TBD"})
+
+	announcement, err := a.ResultsAnnouncement(context.Background(), before)
+	require.NoError(t, err)
+	require.Len(t, announcement.Matches, 1)
+	assert.Equal(t, "Team A", announcement.Matches[0].Winner)
+	assert.Equal(t, "Team B", announcement.Matches[0].Team2)
+}
+
+func TestResultsAnnouncement_NonSwissHasNoRecords(t *testing.T) {
+	mockStore := NewMockStore("single-elimination", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B"}}
+	mockStore.MatchResults = tournament.EliminationResult{}
+	a := &App{Store: mockStore}
+
+	announcement, err := a.ResultsAnnouncement(context.Background(), ResultsSnapshot{})
+	require.NoError(t, err)
+	require.Len(t, announcement.Matches, 1)
+	assert.Nil(t, announcement.Matches[0].Records)
+}
+
+func TestResultsAnnouncement_MatchResultsError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B"}}
+	mockStore.GetMatchResultsError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.ResultsAnnouncement(context.Background(), ResultsSnapshot{})
+	assert.Error(t, err)
+}
+
+// endregion
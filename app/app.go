@@ -8,7 +8,7 @@ package app
 
 import (
 	"cmp"
-	"errors"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -34,6 +34,12 @@ type App struct {
 	Store       store.Interface
 	rateLimiter *rate.Limiter
 	log         *slog.Logger
+	// tournamentStart is the configured date $team shows rankings as of. Zero falls back to the round's schedule.
+	tournamentStart time.Time
+	// baseCtx is the app's lifetime context and backgroundTimeout bounds each piece of work run under it; see
+	// backgroundContext.
+	baseCtx           context.Context
+	backgroundTimeout time.Duration
 }
 
 // logger returns the app's logger, falling back to the global default when none was injected.
@@ -44,19 +50,22 @@ func (a *App) logger() *slog.Logger {
 	return a.log
 }
 
-// NewApp creates a new App instance with the provided configuration.
-// log may be nil; if so the global slog default is used.
-func NewApp(cfg config.Config, mongoURI string, log *slog.Logger) (*App, error) {
+// NewApp creates a new App instance with the provided configuration. ctx bounds the database setup done at startup
+// and is kept as the app's lifetime context, so it should only be cancelled on shutdown. log may be nil; if so the
+// global slog default is used.
+func NewApp(ctx context.Context, cfg config.Config, mongoURI string, log *slog.Logger) (*App, error) {
 	var fetcher store.DataSourceFetcher
 	var limiter *rate.Limiter
 	switch cfg.DataSource {
 	case "liquipedia":
-		fetcher = store.NewLiquipediaFetcher(cfg.Liquipedia.APIURL, os.Getenv("LIQUIDPEDIADB_API_KEY"), cfg.Liquipedia.Page)
-		limiter = rate.NewLimiter(rate.Every(time.Minute), 10) // 60/hr per API guidelines
+		limiter = rate.NewLimiter(rate.Every(time.Minute), 10) // 60/hr per API guidelines, counted per request
+		fetcher = store.NewLiquipediaFetcher(cfg.Liquipedia.APIURL, os.Getenv("LIQUIDPEDIADB_API_KEY"), cfg.Liquipedia.Page).
+			WithMaxPages(cfg.Liquipedia.MaxPages).WithLimiter(limiter)
 
 	case "pandascore":
-		fetcher = store.NewPandaScoreFetcher(cfg.PandaScore.APIURL, os.Getenv("PANDASCORE_API_KEY"), cfg.PandaScore.SeriesID, cfg.PandaScore.TournamentID)
 		limiter = rate.NewLimiter(rate.Every(4*time.Second), 5) // ~900/hr, less than the 1000 limit of our api plan
+		fetcher = store.NewPandaScoreFetcher(cfg.PandaScore.APIURL, os.Getenv("PANDASCORE_API_KEY"), cfg.PandaScore.SeriesID, cfg.PandaScore.TournamentID).
+			WithMaxPages(cfg.PandaScore.MaxPages).WithLimiter(limiter)
 
 	default:
 		return nil, fmt.Errorf("unsupported data source: %s", cfg.DataSource)
@@ -69,26 +78,97 @@ func NewApp(cfg config.Config, mongoURI string, log *slog.Logger) (*App, error)
 		appLog = log.With("component", "app")
 		storeLog = log.With("component", "store")
 	}
-	s, err := store.NewStore(cfg.TournamentName, mongoURI, cfg.Round, fetcher, storeLog)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize store: %w", err)
+	var s store.Interface
+	switch cfg.Storage.Backend {
+	case config.StorageMemory:
+		memoryStore := store.NewMemoryStore(cfg.TournamentName, cfg.Round, fetcher, storeLog)
+		memoryStore.FetchTimeout = cfg.Timeouts.DataSourceDuration
+		s = memoryStore
+
+	case config.StorageSQLite, config.StoragePostgres:
+		dialect := store.SQLite
+		if cfg.Storage.Backend == config.StoragePostgres {
+			dialect = store.Postgres
+		}
+		sqlStore, err := store.NewSQLStore(ctx, dialect, cfg.Storage.DSN, cfg.TournamentName, cfg.Round, fetcher, storeLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize store: %w", err)
+		}
+		sqlStore.OpTimeout = cfg.Timeouts.DatabaseDuration
+		sqlStore.FetchTimeout = cfg.Timeouts.DataSourceDuration
+		s = sqlStore
+
+	default:
+		mongoStore, err := store.NewStore(cfg.TournamentName, mongoURI, cfg.Round, fetcher, storeLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize store: %w", err)
+		}
+		mongoStore.OpTimeout = cfg.Timeouts.DatabaseDuration
+		mongoStore.FetchTimeout = cfg.Timeouts.DataSourceDuration
+		if cfg.VRS.Database != "" {
+			mongoStore.VRSDatabase = mongoStore.Client.Database(cfg.VRS.Database)
+		}
+		mongoStore.VRSCollections = cfg.VRS.Collections
+		if err := mongoStore.Migrate(ctx); err != nil {
+			_ = mongoStore.Client.Disconnect(ctx)
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+		if err := mongoStore.EnsureIndexes(ctx); err != nil {
+			_ = mongoStore.Client.Disconnect(ctx)
+			return nil, fmt.Errorf("failed to create indexes: %w", err)
+		}
+		s = mongoStore
+	}
+	// The memory backend already serves every read from memory, so only the database backends are cached
+	if cfg.Storage.Backend != config.StorageMemory {
+		s = store.NewCachedStore(s, store.CacheTTLs{
+			VRS:      cfg.Cache.VRSDuration,
+			Results:  cfg.Cache.ResultsDuration,
+			Schedule: cfg.Cache.ScheduleDuration,
+		})
 	}
 
 	return &App{
-		Store:       s,
-		rateLimiter: limiter,
-		log:         appLog,
+		Store:             s,
+		rateLimiter:       limiter,
+		log:               appLog,
+		tournamentStart:   cfg.VRS.TournamentStartDate,
+		baseCtx:           ctx,
+		backgroundTimeout: cfg.Timeouts.CommandDuration,
 	}, nil
 }
 
-// Allow calls the app's configured rate limiter's Allow() function.
+// backgroundContext returns a context for work that outlives the command that started it, such as regenerating the
+// leaderboard after $set. It is cancelled when the app's lifetime context is and times out after the configured
+// command timeout. Apps built without NewApp fall back to context.Background with no timeout.
+func (a *App) backgroundContext() (context.Context, context.CancelFunc) {
+	base := a.baseCtx
+	if base == nil {
+		base = context.Background()
+	}
+	if a.backgroundTimeout <= 0 {
+		return context.WithCancel(base)
+	}
+	return context.WithTimeout(base, a.backgroundTimeout)
+}
+
+// Allow reports whether the app's rate limiter has a token for the next data source request, without taking it: the
+// fetchers take a token for every request they send, so an update that pages or retries is charged for each one.
 // Returns false if the limiter is nil or the limit has been reached.
-// Required since we are treating it as a singleton
 func (a *App) Allow() bool {
 	if a.rateLimiter == nil {
 		return false
 	}
-	return a.rateLimiter.Allow()
+	return a.rateLimiter.Tokens() >= 1
+}
+
+// RateLimiter returns the limiter data source requests made outside the store, such as the poller's, must take a
+// token from. Returns nil if the app has no limiter.
+func (a *App) RateLimiter() sources.Limiter {
+	if a.rateLimiter == nil {
+		return nil
+	}
+	return a.rateLimiter
 }
 
 // SetUserPrediction contains the logic to set a user prediction in the DB.
@@ -96,14 +176,14 @@ func (a *App) Allow() bool {
 // and strings containing dbName, collName and round.
 // It updates the user's predictions in the database, or returns an error if it occurs.
 // As a side effect, a successful set also triggers the leaderboard being updated
-func (a *App) SetUserPrediction(user models.User, inputTeams []string, round string) (models.Prediction, error) {
-	err := a.Store.EnsureScheduledMatches()
+func (a *App) SetUserPrediction(ctx context.Context, user models.User, inputTeams []string, round string) (models.Prediction, error) {
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return models.Prediction{}, err
 	}
 
 	// Get valid team names
-	validTeams, formatName, err := a.Store.GetValidTeams()
+	validTeams, formatName, err := a.Store.GetValidTeams(ctx)
 	if err != nil {
 		return models.Prediction{}, err
 	}
@@ -118,7 +198,7 @@ func (a *App) SetUserPrediction(user models.User, inputTeams []string, round str
 
 	// Check num required teams is correct
 	if len(inputTeams) != requiredPredictions {
-		return models.Prediction{}, fmt.Errorf("incorrect number of teams arguments, expected %d but got %d", requiredPredictions, len(inputTeams))
+		return models.Prediction{}, &PredictionError{Kind: PredictionWrongCount, Expected: requiredPredictions, Got: len(inputTeams)}
 	}
 
 	// Fix formatting on input teams
@@ -128,15 +208,15 @@ func (a *App) SetUserPrediction(user models.User, inputTeams []string, round str
 		inputTeams[i] = strings.ReplaceAll(inputTeams[i], "”", "")
 	}
 
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+
 	// Validate input teams
-	teams, invalidTeams := scoring.CheckTeamNames(inputTeams, validTeams)
+	teams, invalidTeams := scoring.CheckTeamNames(inputTeams, validTeams, aliases)
 	if len(invalidTeams) > 0 {
-		var str strings.Builder
-		str.WriteString("the following team names are invalid:")
-		for i := range invalidTeams {
-			str.WriteString(fmt.Sprintf(" '%s'", invalidTeams[i]))
-		}
-		return models.Prediction{}, errors.New(str.String())
+		return models.Prediction{}, &PredictionError{Kind: PredictionInvalidTeams, Teams: invalidTeams}
 	}
 
 	// Check for unique team names
@@ -144,9 +224,9 @@ func (a *App) SetUserPrediction(user models.User, inputTeams []string, round str
 	for i, team := range teams {
 		if original, exists := seen[team]; exists {
 			if original == inputTeams[i] {
-				return models.Prediction{}, fmt.Errorf("'%s' entered multiple times, stored prediction was not updated", team)
+				return models.Prediction{}, &PredictionError{Kind: PredictionDuplicateTeam, Teams: []string{team}}
 			}
-			return models.Prediction{}, fmt.Errorf("'%s' and '%s' both resolved to '%s'. Please enter a more specific name for one of them", original, inputTeams[i], team)
+			return models.Prediction{}, &PredictionError{Kind: PredictionAmbiguousTeams, Teams: []string{original, inputTeams[i], team}}
 		}
 		seen[team] = inputTeams[i]
 	}
@@ -158,14 +238,17 @@ func (a *App) SetUserPrediction(user models.User, inputTeams []string, round str
 	}
 
 	// Insert prediction to db
-	err = a.Store.StoreUserPrediction(user.UserID, prediction)
+	err = a.Store.StoreUserPrediction(ctx, user.UserID, prediction)
 	if err != nil {
 		return models.Prediction{}, err
 	}
 
-	// Update the leaderboard with the new user's prediction
+	// Update the leaderboard with the new user's prediction. The caller's context usually ends when the command
+	// returns, so the regen runs under the app's own context instead.
+	regenCtx, cancel := a.backgroundContext()
 	go func() {
-		if err := a.GenerateLeaderboard(); err != nil {
+		defer cancel()
+		if err := a.GenerateLeaderboard(regenCtx); err != nil {
 			a.logger().Error("leaderboard regen after set failed", "error", err)
 		}
 	}()
@@ -176,46 +259,56 @@ func (a *App) SetUserPrediction(user models.User, inputTeams []string, round str
 // CheckPrediction contains the logic required to check a prediction.
 // It receives a user struct and receiver pointer to api.
 // It returns a ScoreReport containing the results of the user's predictions, or an error if it occurs.
-func (a *App) CheckPrediction(user models.User) (tournament.ScoreReport, error) {
-	err := a.Store.EnsureScheduledMatches()
+func (a *App) CheckPrediction(ctx context.Context, user models.User) (tournament.ScoreReport, error) {
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return nil, err
 	}
 	// Fetch prediction from db
-	doc, err := a.Store.GetUserPrediction(user.UserID)
+	doc, err := a.Store.GetUserPrediction(ctx, user.UserID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Fetch match results from db
-	results, err := a.Store.GetMatchResults()
+	results, err := a.Store.GetMatchResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := a.TeamAliases(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Evaluate scores
-	report, err := scoring.CalculateUserScore(doc, results)
+	report, err := scoring.CalculateUserScore(doc, results, aliases)
 	if err != nil {
 		return nil, err
 	}
 	return report, nil
 }
 
-// CheckPredictionByUsername looks up picks by username (case-insensitive) and scores them.
-func (a *App) CheckPredictionByUsername(username string) (models.User, tournament.ScoreReport, error) {
-	err := a.Store.EnsureScheduledMatches()
+// CheckPredictionByUsername looks up picks by username (case-insensitive), preferring the user currently going by it,
+// and scores them.
+func (a *App) CheckPredictionByUsername(ctx context.Context, username string) (models.User, tournament.ScoreReport, error) {
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return models.User{}, nil, err
 	}
-	doc, err := a.Store.GetUserPredictionByUsername(username)
+	doc, err := a.findPredictionByUsername(ctx, username, a.Store.GetRound())
 	if err != nil {
 		return models.User{}, nil, err
 	}
-	results, err := a.Store.GetMatchResults()
+	results, err := a.Store.GetMatchResults(ctx)
 	if err != nil {
 		return models.User{}, nil, err
 	}
-	report, err := scoring.CalculateUserScore(doc, results)
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	report, err := scoring.CalculateUserScore(doc, results, aliases)
 	if err != nil {
 		return models.User{}, nil, err
 	}
@@ -225,24 +318,29 @@ func (a *App) CheckPredictionByUsername(username string) (models.User, tournamen
 // GenerateLeaderboard contains the logic required to generate a leaderboard.
 // Preconditions: Receives receiver pointer to api
 // Postconditions: Generates the leaderboard, updates it in the DB and returns nil, or returns an error if it occurs
-func (a *App) GenerateLeaderboard() error {
+func (a *App) GenerateLeaderboard(ctx context.Context) error {
 	timer := prometheus.NewTimer(metrics.LeaderboardDuration)
 	defer timer.ObserveDuration()
 
 	// Check if results have been initialised
-	err := a.Store.EnsureScheduledMatches()
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Fetch match results from db
-	results, err := a.Store.GetMatchResults()
+	results, err := a.Store.GetMatchResults(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Fetch all predictions
-	preds, err := a.Store.GetAllUserPredictions()
+	preds, err := a.Store.GetAllUserPredictions(ctx)
+	if err != nil {
+		return err
+	}
+
+	aliases, err := a.TeamAliases(ctx)
 	if err != nil {
 		return err
 	}
@@ -251,9 +349,10 @@ func (a *App) GenerateLeaderboard() error {
 	leaderboard.Round = a.Store.GetRound()
 
 	// Iterate over each user's predictions, calculate their score and append the leaderboardEntry to the leaderboard object
+	var reports []tournament.ScoreReport
 	for _, pred := range preds {
 		var leaderboardEntry store.LeaderboardEntry
-		scoreReport, err := scoring.CalculateUserScore(pred, results)
+		scoreReport, err := scoring.CalculateUserScore(pred, results, aliases)
 		if err != nil {
 			// Skip predictions that can't be scored — most likely a stale entry
 			// stored by an older code version or a different format for this round.
@@ -264,11 +363,12 @@ func (a *App) GenerateLeaderboard() error {
 			)
 			continue
 		}
+		reports = append(reports, scoreReport)
 		scores := scoreReport.GetScore()
 
 		leaderboardEntry.UserID = pred.UserID
 		leaderboardEntry.Username = pred.Username
-		leaderboardEntry.Score = (scores.Successes * 3) + (scores.Pending * 1) + (scores.Failed * 0) // Example scoring: 3 points per success, 1 per pending, 0 per failure
+		leaderboardEntry.Score = Points(scores)
 		leaderboardEntry.ScoreResult.Successes = scores.Successes
 		leaderboardEntry.ScoreResult.Pending = scores.Pending
 		leaderboardEntry.ScoreResult.Failed = scores.Failed
@@ -276,25 +376,38 @@ func (a *App) GenerateLeaderboard() error {
 		leaderboard.Entries = append(leaderboard.Entries, leaderboardEntry)
 	}
 
-	err = a.Store.StoreLeaderboard(leaderboard)
+	leaderboard.Entries = a.withCurrentDisplayNames(ctx, leaderboard.Entries)
+	err = a.Store.StoreLeaderboard(ctx, leaderboard)
 	if err != nil {
 		return err
 	}
+
+	// Keep the pick gauges current without waiting for someone to run $stats
+	stats, err := buildPickStats(reports)
+	if err != nil {
+		a.logger().Warn("failed to build pick stats", "error", fmt.Errorf("GenerateLeaderboard: %w", err))
+		return nil
+	}
+	recordPickStats(stats)
 	return nil
 }
 
 // GetLeaderboard fetches the leaderboard from the db and generates a response string
 // Preconditions: Receives receiver pointer to api
 // Postconditions: Returns a string with the summary of the leaderboard for this round of the tournament
-func (a *App) GetLeaderboard() ([]LeaderboardUser, error) {
+func (a *App) GetLeaderboard(ctx context.Context) ([]LeaderboardUser, error) {
 	// Fetch leaderboard from DB
-	entries, err := a.Store.FetchLeaderboardFromDB()
+	entries, err := a.Store.FetchLeaderboardFromDB(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return rankLeaderboard(a.withCurrentDisplayNames(ctx, entries)), nil
+}
 
-	// Order the leaderboard in descending order so that the user with the highest score appear at the top. Note score = successes - failures and there is no tie breaker
-	sort.Slice(entries, func(i, j int) bool {
+// rankLeaderboard orders stored leaderboard entries by score and assigns each user their rank
+func rankLeaderboard(entries []store.LeaderboardEntry) []LeaderboardUser {
+	// Order the leaderboard in descending order so that the user with the highest score appear at the top. Note score = successes - failures and there is no tie breaker. The sort is stable so tied users keep their stored order across pages
+	sort.SliceStable(entries, func(i, j int) bool {
 		return (entries[i].Score) > (entries[j].Score)
 	})
 
@@ -302,28 +415,35 @@ func (a *App) GetLeaderboard() ([]LeaderboardUser, error) {
 	response := make([]LeaderboardUser, 0, len(entries))
 	for i, user := range entries {
 		entry := LeaderboardUser{
+			UserID:    user.UserID,
 			Username:  user.Username,
 			Rank:      i + 1,
+			Score:     user.Score,
 			Successes: user.ScoreResult.Successes,
 			Failures:  user.ScoreResult.Failed,
 		}
 		response = append(response, entry)
 	}
 
-	return response, nil
+	return response
 }
 
 // GetTeams gets a list of all valid team names.
 // The valid teams list must be initialized in db.
 // It returns a string slice containing all valid teams for this round.
-func (a *App) GetTeams() ([]Team, error) {
+func (a *App) GetTeams(ctx context.Context) ([]Team, error) {
 	// Get valid team names
-	validTeams, _, err := a.Store.GetValidTeams()
+	validTeams, _, err := a.Store.GetValidTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	VRSEntries, err := a.Store.FetchVrsDataFromDB(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	VRSEntries, err := a.Store.FetchVrsDataFromDB()
+	aliases, err := a.TeamAliases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -333,14 +453,14 @@ func (a *App) GetTeams() ([]Team, error) {
 	vrsNorm := make(map[string]int, len(VRSEntries))
 	vrsNormKeys := make([]string, 0, len(VRSEntries))
 	for _, entry := range VRSEntries {
-		key := sources.NormalizeTeamName(entry.TeamName)
+		key := aliases.Key(entry.TeamName)
 		vrsNorm[key] = entry.Standing
 		vrsNormKeys = append(vrsNormKeys, key)
 	}
 
 	var result []Team
 	for _, teamName := range validTeams {
-		norm := sources.NormalizeTeamName(teamName)
+		norm := aliases.Key(teamName)
 		ranking, ok := vrsNorm[norm]
 		if !ok {
 			// Normalised exact match failed — spacing/punctuation difference; try fuzzy
@@ -358,47 +478,35 @@ func (a *App) GetTeams() ([]Team, error) {
 }
 
 // GetTeam returns the VRS information for a given team
-func (a *App) GetTeam(teamName string) (store.VRSEntry, error) {
+func (a *App) GetTeam(ctx context.Context, teamName string) (store.VRSEntry, error) {
 	if teamName == "" {
 		return store.VRSEntry{}, fmt.Errorf("cannot lookup empty team name")
 	}
 
-	VRSEntries, err := a.Store.FetchVrsDataFromDB()
+	VRSEntries, err := a.Store.FetchVrsDataFromDB(ctx)
 	if err != nil {
 		return store.VRSEntry{}, err
 	}
 
-	norm := sources.NormalizeTeamName(teamName)
-	for _, entry := range VRSEntries {
-		if sources.NormalizeTeamName(entry.TeamName) == norm {
-			return entry, nil
-		}
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return store.VRSEntry{}, err
 	}
 
-	// Exact normalised match failed — try fuzzy
-	keys := make([]string, len(VRSEntries))
-	for i, e := range VRSEntries {
-		keys[i] = sources.NormalizeTeamName(e.TeamName)
-	}
-	if matches := fuzzy.RankFind(norm, keys); len(matches) > 0 {
-		for _, entry := range VRSEntries {
-			if sources.NormalizeTeamName(entry.TeamName) == matches[0].Target {
-				return entry, nil
-			}
-		}
+	if entry, ok := findVRSEntry(VRSEntries, aliases, teamName, true); ok {
+		return entry, nil
 	}
-
 	return store.VRSEntry{}, fmt.Errorf("no VRS data found for %q", teamName)
 }
 
 // GetUpcomingMatches gets the upcoming matches for this round of the tournament. Will only follow the correct path if the scheduled matches data has been initialized.
-func (a *App) GetUpcomingMatches() ([]sources.ScheduledMatch, error) {
-	err := a.Store.EnsureScheduledMatches()
+func (a *App) GetUpcomingMatches(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	scheduledMatches, err := a.Store.FetchMatchSchedule()
+	scheduledMatches, err := a.Store.FetchMatchSchedule(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -427,14 +535,14 @@ func (a *App) GetUpcomingMatches() ([]sources.ScheduledMatch, error) {
 
 // GetTournamentInfo gets the following information about the tournament: Tournament Name, Round, Format, RequiredPredictions.
 // It returns a string slice with the contents attribute : value containing the information listed above.
-func (a *App) GetTournamentInfo() (TournamentInfo, error) {
-	err := a.Store.EnsureScheduledMatches()
+func (a *App) GetTournamentInfo(ctx context.Context) (TournamentInfo, error) {
+	err := a.Store.EnsureScheduledMatches(ctx)
 	if err != nil {
 		return TournamentInfo{}, err
 	}
 
 	// Get valid team names
-	validTeams, formatName, err := a.Store.GetValidTeams()
+	validTeams, formatName, err := a.Store.GetValidTeams(ctx)
 	if err != nil {
 		return TournamentInfo{}, err
 	}
@@ -455,17 +563,17 @@ func (a *App) GetTournamentInfo() (TournamentInfo, error) {
 
 // PopulateMatches fetches and stores the match schedule via the configured data source.
 // When scheduleOnly is false, match results are also fetched and stored.
-func (a *App) PopulateMatches(scheduleOnly bool) error {
+func (a *App) PopulateMatches(ctx context.Context, scheduleOnly bool) error {
 	if !a.Allow() {
 		return fmt.Errorf("rate limiter limit reached")
 	}
 
-	if err := a.Store.FetchAndStoreSchedule(); err != nil {
+	if err := a.Store.FetchAndStoreSchedule(ctx); err != nil {
 		return err
 	}
 
 	if !scheduleOnly {
-		if err := a.Store.FetchAndUpdateMatchResults(); err != nil {
+		if err := a.Store.FetchAndUpdateMatchResults(ctx); err != nil {
 			return err
 		}
 	}
@@ -473,29 +581,42 @@ func (a *App) PopulateMatches(scheduleOnly bool) error {
 }
 
 // UpdateMatchSchedule is a rate-limited wrapper around Store.FetchAndStoreSchedule.
-func (a *App) UpdateMatchSchedule() error {
+func (a *App) UpdateMatchSchedule(ctx context.Context) error {
 	if !a.Allow() {
 		return fmt.Errorf("rate limiter exceeded, skipping match schedule update")
 	}
-	return a.Store.FetchAndStoreSchedule()
+	return a.Store.FetchAndStoreSchedule(ctx)
 }
 
 // StoreSchedule persists a pre-fetched schedule slice. Used by the PandaScore poller
 // to reuse already-fetched data instead of making a second API call.
-func (a *App) StoreSchedule(matches []sources.ScheduledMatch) error {
-	return a.Store.StoreMatchSchedule(matches)
+func (a *App) StoreSchedule(ctx context.Context, matches []sources.ScheduledMatch) error {
+	return a.Store.StoreMatchSchedule(ctx, matches)
 }
 
-// UpdateMatchResults is a wrapper function for App.Store.FetchAndUpdateMatchResults() that enforces rate limiting
+// UpdateMatchResults is a wrapper function for App.Store.FetchAndUpdateMatchResults(ctx) that enforces rate limiting
 // across the app and ensuring we comply with api specifications
-func (a *App) UpdateMatchResults() error {
+func (a *App) UpdateMatchResults(ctx context.Context) error {
 	if !a.Allow() {
 		return fmt.Errorf("rate limiter exceeded, skipping match result update")
 	}
 
-	if err := a.Store.FetchAndUpdateMatchResults(); err != nil {
+	if err := a.Store.FetchAndUpdateMatchResults(ctx); err != nil {
 		return err
 	}
 	metrics.MatchUpdatesTotal.Inc()
 	return nil
 }
+
+// cacheInvalidator is implemented by stores that cache reads, such as store.CachedStore
+type cacheInvalidator interface {
+	Invalidate(groups ...store.CacheGroup)
+}
+
+// InvalidateCache drops the store's cached reads of the given groups, or of every group if none are given. The update
+// pipelines call it once they have stored new data. It does nothing if the store doesn't cache reads.
+func (a *App) InvalidateCache(groups ...store.CacheGroup) {
+	if c, ok := a.Store.(cacheInvalidator); ok {
+		c.Invalidate(groups...)
+	}
+}
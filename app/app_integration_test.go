@@ -30,7 +30,7 @@ func loadIntegrationConfig(t *testing.T) config.Config {
 // t.Cleanup disconnects the client and drops the test database.
 func newTestApp(t *testing.T, cfg config.Config, mongoURI string) *App {
 	t.Helper()
-	a, err := NewApp(cfg, mongoURI, slog.Default())
+	a, err := NewApp(context.Background(), cfg, mongoURI, slog.Default())
 	require.NoError(t, err, "NewApp failed")
 
 	// Override the rate limiter to allow unlimited calls so rapid sequential
@@ -56,9 +56,9 @@ func TestApp_PandaScore_InitialFetch(t *testing.T) {
 	cfg := loadIntegrationConfig(t)
 	a := newTestApp(t, cfg, mongoURI)
 
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
-	nodes, _, err := a.Store.FetchMatchNodesFromDb()
+	nodes, _, err := a.Store.FetchMatchNodesFromDb(context.Background())
 	require.NoError(t, err)
 	assert.NotEmpty(t, nodes, "expected match nodes to be stored after PopulateMatches")
 }
@@ -75,10 +75,10 @@ func TestApp_PandaScore_Empty(t *testing.T) {
 	cfg := loadIntegrationConfig(t)
 	a := newTestApp(t, cfg, mongoURI)
 
-	err := a.PopulateMatches(false)
+	err := a.PopulateMatches(context.Background(), false)
 	// Either an error is returned, or the store is empty — the bot must not crash.
 	if err == nil {
-		nodes, _, dbErr := a.Store.FetchMatchNodesFromDb()
+		nodes, _, dbErr := a.Store.FetchMatchNodesFromDb(context.Background())
 		require.NoError(t, dbErr)
 		assert.Empty(t, nodes, "expected no match nodes stored for empty response")
 	}
@@ -96,7 +96,7 @@ func TestApp_PandaScore_NotFound(t *testing.T) {
 	cfg.PandaScore.SeriesID = 12345 // anything other than 99001 triggers 404
 	a := newTestApp(t, cfg, mongoURI)
 
-	err := a.PopulateMatches(false)
+	err := a.PopulateMatches(context.Background(), false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, sources.ErrUnrecoverable),
 		"expected ErrUnrecoverable for unknown series ID, got: %v", err)
@@ -113,7 +113,7 @@ func TestApp_PandaScore_WrongKey(t *testing.T) {
 	cfg := loadIntegrationConfig(t)
 	a := newTestApp(t, cfg, mongoURI)
 
-	err := a.PopulateMatches(false)
+	err := a.PopulateMatches(context.Background(), false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, sources.ErrUnrecoverable),
 		"expected ErrUnrecoverable for bad API key, got: %v", err)
@@ -133,9 +133,9 @@ func TestApp_Liquipedia_InitialFetch(t *testing.T) {
 	cfg.DataSource = "liquipedia"
 	a := newTestApp(t, cfg, mongoURI)
 
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
-	nodes, _, err := a.Store.FetchMatchNodesFromDb()
+	nodes, _, err := a.Store.FetchMatchNodesFromDb(context.Background())
 	require.NoError(t, err)
 	assert.NotEmpty(t, nodes, "expected match nodes to be stored after Liquipedia fetch")
 }
@@ -152,9 +152,9 @@ func TestApp_Liquipedia_Empty(t *testing.T) {
 	cfg.DataSource = "liquipedia"
 	a := newTestApp(t, cfg, mongoURI)
 
-	err := a.PopulateMatches(false)
+	err := a.PopulateMatches(context.Background(), false)
 	if err == nil {
-		nodes, _, dbErr := a.Store.FetchMatchNodesFromDb()
+		nodes, _, dbErr := a.Store.FetchMatchNodesFromDb(context.Background())
 		require.NoError(t, dbErr)
 		assert.Empty(t, nodes, "expected no match nodes stored for empty Liquipedia response")
 	}
@@ -171,6 +171,6 @@ func TestApp_Liquipedia_WrongKey(t *testing.T) {
 	cfg.DataSource = "liquipedia"
 	a := newTestApp(t, cfg, mongoURI)
 
-	err := a.PopulateMatches(false)
+	err := a.PopulateMatches(context.Background(), false)
 	require.Error(t, err, "expected error for bad Liquipedia API key")
 }
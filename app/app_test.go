@@ -6,11 +6,14 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"pickems-bot/config"
 	"pickems-bot/models"
 	"pickems-bot/sources"
 	"pickems-bot/store"
+	"pickems-bot/tournament"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -21,7 +24,7 @@ import (
 // region NewApp tests
 
 func TestNewApp_UnsupportedDataSource(t *testing.T) {
-	_, err := NewApp(config.Config{DataSource: "unknown", TournamentName: "db", Round: "r1"}, "mongodb://localhost", nil)
+	_, err := NewApp(context.Background(), config.Config{DataSource: "unknown", TournamentName: "db", Round: "r1"}, "mongodb://localhost", nil)
 	if err == nil {
 		t.Error("Expected error for unsupported data source, got nil")
 	}
@@ -43,7 +46,7 @@ func TestSetUserPrediction_SwissFormat_Success(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -69,7 +72,7 @@ func TestSetUserPrediction_SingleEliminationFormat_Success(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team B", "Team C", "Team D"} // 4 teams for 8-team bracket
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -84,7 +87,7 @@ func TestSetUserPrediction_WrongNumberOfTeams(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team B"} // Only 2 teams, need 10 for Swiss
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error for wrong number of teams, got nil")
 	}
@@ -103,7 +106,7 @@ func TestSetUserPrediction_InvalidTeamNames(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Invalid1", "Invalid2", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error for invalid team names, got nil")
 	}
@@ -122,7 +125,7 @@ func TestSetUserPrediction_DuplicateTeams(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team A", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error for duplicate teams, got nil")
 	}
@@ -142,7 +145,7 @@ func TestSetUserPrediction_FuzzyCollision(t *testing.T) {
 	// "Team A" and "team a" are different inputs that both fuzzy-resolve to "Team A"
 	teams := []string{"Team A", "team a", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error for fuzzy collision, got nil")
 	}
@@ -161,7 +164,7 @@ func TestSetUserPrediction_NoScheduledMatches(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error when no scheduled matches exist, got nil")
 	}
@@ -177,7 +180,7 @@ func TestSetUserPrediction_StoreError(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "testuser"}
 	teams := []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err == nil {
 		t.Error("Expected error from store, got nil")
 	}
@@ -215,7 +218,7 @@ func TestCheckPrediction_Success(t *testing.T) {
 	api := &App{Store: mockStore}
 	user := models.User{UserID: "user1", Username: "testuser"}
 
-	result, err := api.CheckPrediction(user)
+	result, err := api.CheckPrediction(context.Background(), user)
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -233,7 +236,7 @@ func TestCheckPrediction_NoPredictionFound(t *testing.T) {
 	api := &App{Store: mockStore}
 	user := models.User{UserID: "nonexistent", Username: "testuser"}
 
-	_, err := api.CheckPrediction(user)
+	_, err := api.CheckPrediction(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error when no prediction found, got nil")
 	}
@@ -245,7 +248,7 @@ func TestCheckPrediction_NoScheduledMatches(t *testing.T) {
 	api := &App{Store: mockStore}
 	user := models.User{UserID: "user1", Username: "testuser"}
 
-	_, err := api.CheckPrediction(user)
+	_, err := api.CheckPrediction(context.Background(), user)
 	if err == nil {
 		t.Error("Expected error when no scheduled matches, got nil")
 	}
@@ -260,7 +263,7 @@ func TestCheckPrediction_GetMatchResultsError(t *testing.T) {
 	api := &App{Store: mockStore}
 	user := models.User{UserID: "user1", Username: "testuser"}
 
-	_, err := api.CheckPrediction(user)
+	_, err := api.CheckPrediction(context.Background(), user)
 	if err == nil || !strings.Contains(err.Error(), "results error") {
 		t.Errorf("Expected match results error, got: %v", err)
 	}
@@ -289,7 +292,7 @@ func TestCheckPredictionByUsername_Success(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	user, report, err := api.CheckPredictionByUsername("pickemsbot")
+	user, report, err := api.CheckPredictionByUsername(context.Background(), "pickemsbot")
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -308,7 +311,7 @@ func TestCheckPredictionByUsername_NotFound(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, _, err := api.CheckPredictionByUsername("ghost")
+	_, _, err := api.CheckPredictionByUsername(context.Background(), "ghost")
 	if err == nil {
 		t.Error("Expected error when username not found, got nil")
 	}
@@ -319,7 +322,7 @@ func TestCheckPredictionByUsername_NoScheduledMatches(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, _, err := api.CheckPredictionByUsername("PickemsBot")
+	_, _, err := api.CheckPredictionByUsername(context.Background(), "PickemsBot")
 	if err == nil {
 		t.Error("Expected error when no scheduled matches, got nil")
 	}
@@ -385,7 +388,7 @@ func TestGenerateLeaderboard_Success(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	err := api.GenerateLeaderboard()
+	err := api.GenerateLeaderboard(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -403,7 +406,7 @@ func TestGenerateLeaderboard_NoPredictions(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	err := api.GenerateLeaderboard()
+	err := api.GenerateLeaderboard(context.Background())
 	// When there are no predictions, GetAllUserPredictions returns mongo.ErrNoDocuments
 	if err == nil {
 		t.Error("Expected error when no predictions exist, got nil")
@@ -415,7 +418,7 @@ func TestGenerateLeaderboard_NoScheduledMatches(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	err := api.GenerateLeaderboard()
+	err := api.GenerateLeaderboard(context.Background())
 	if err == nil {
 		t.Error("Expected error when no scheduled matches, got nil")
 	}
@@ -428,7 +431,7 @@ func TestGenerateLeaderboard_GetMatchResultsError(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	err := api.GenerateLeaderboard()
+	err := api.GenerateLeaderboard(context.Background())
 	if err == nil || !strings.Contains(err.Error(), "results fetch failed") {
 		t.Errorf("Expected match results error, got: %v", err)
 	}
@@ -442,6 +445,19 @@ func TestNewTestApp_HasRateLimiter(t *testing.T) {
 	}
 }
 
+func TestAllow_DoesNotTakeAToken(t *testing.T) {
+	a := &App{rateLimiter: rate.NewLimiter(rate.Every(time.Hour), 1)}
+	if !a.Allow() || !a.Allow() {
+		t.Error("Expected Allow to leave the token for the data source request")
+	}
+	if a.RateLimiter() == nil {
+		t.Error("Expected RateLimiter to return the app's limiter")
+	}
+	if (&App{}).RateLimiter() != nil {
+		t.Error("Expected RateLimiter to return nil without a limiter")
+	}
+}
+
 func TestGetLeaderboard_Success(t *testing.T) {
 	mockStore := NewMockStore("swiss", "test_round")
 
@@ -453,7 +469,7 @@ func TestGetLeaderboard_Success(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	result, err := api.GetLeaderboard()
+	result, err := api.GetLeaderboard(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -467,13 +483,37 @@ func TestGetLeaderboard_Success(t *testing.T) {
 	}
 }
 
+func TestGetLeaderboard_RanksAndKeepsTieOrder(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.Leaderboard = []store.LeaderboardEntry{
+		{UserID: "user1", Username: "player1", Score: 3},
+		{UserID: "user2", Username: "player2", Score: 7},
+		{UserID: "user3", Username: "player3", Score: 3},
+	}
+	api := &App{Store: mockStore}
+
+	result, err := api.GetLeaderboard(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err.Error())
+	}
+
+	want := []LeaderboardUser{
+		{UserID: "user2", Username: "player2", Rank: 1, Score: 7},
+		{UserID: "user1", Username: "player1", Rank: 2, Score: 3},
+		{UserID: "user3", Username: "player3", Rank: 3, Score: 3},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Expected %+v, got %+v", want, result)
+	}
+}
+
 func TestGetLeaderboard_NoLeaderboard(t *testing.T) {
 	mockStore := NewMockStore("swiss", "test_round")
 	mockStore.FetchLeaderboardFromDBError = fmt.Errorf("no leaderboard found")
 
 	api := &App{Store: mockStore}
 
-	_, err := api.GetLeaderboard()
+	_, err := api.GetLeaderboard(context.Background())
 	if err == nil {
 		t.Error("Expected error when no leaderboard exists, got nil")
 	}
@@ -497,7 +537,7 @@ func TestSetUserPrediction_TriggersLeaderboardRegen(t *testing.T) {
 	user := models.User{UserID: "user1", Username: "player1"}
 	teams := []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
 
-	_, err := api.SetUserPrediction(user, teams, "test_round")
+	_, err := api.SetUserPrediction(context.Background(), user, teams, "test_round")
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -510,6 +550,34 @@ func TestSetUserPrediction_TriggersLeaderboardRegen(t *testing.T) {
 	}
 }
 
+func TestBackgroundContext(t *testing.T) {
+	base, stop := context.WithCancel(context.Background())
+	api := &App{baseCtx: base, backgroundTimeout: time.Minute}
+
+	ctx, cancel := api.backgroundContext()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Expected a deadline within a minute, got %v (set: %v)", deadline, ok)
+	}
+
+	stop()
+	if ctx.Err() == nil {
+		t.Error("Expected the background context to be cancelled with the app's base context")
+	}
+}
+
+func TestBackgroundContext_WithoutNewApp(t *testing.T) {
+	ctx, cancel := (&App{}).backgroundContext()
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline when no timeout is configured")
+	}
+	if ctx.Err() != nil {
+		t.Errorf("Expected a live context, got %v", ctx.Err())
+	}
+}
+
 // endregion
 
 // region GetTeams tests
@@ -524,7 +592,7 @@ func TestGetTeams_Success(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	teams, err := api.GetTeams()
+	teams, err := api.GetTeams(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -540,7 +608,7 @@ func TestGetTeams_StoreError(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTeams()
+	_, err := api.GetTeams(context.Background())
 	if err == nil {
 		t.Error("Expected error when store returns an error, got nil")
 	}
@@ -589,7 +657,7 @@ func TestGetTeam_Success(t *testing.T) {
 	})
 	api := &App{Store: mockStore}
 
-	entry, err := api.GetTeam("FaZe")
+	entry, err := api.GetTeam(context.Background(), "FaZe")
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -605,7 +673,7 @@ func TestGetTeam_NormalisationMatch(t *testing.T) {
 	})
 	api := &App{Store: mockStore}
 
-	entry, err := api.GetTeam("Team Liquid")
+	entry, err := api.GetTeam(context.Background(), "Team Liquid")
 	if err != nil {
 		t.Fatalf("expected no error for normalised match, got: %v", err)
 	}
@@ -618,7 +686,7 @@ func TestGetTeam_EmptyName(t *testing.T) {
 	mockStore := NewMockStore("swiss", "test_round")
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTeam("")
+	_, err := api.GetTeam(context.Background(), "")
 	if err == nil {
 		t.Error("expected error for empty team name, got nil")
 	}
@@ -631,7 +699,7 @@ func TestGetTeam_NotFound(t *testing.T) {
 	})
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTeam("Unknown Team")
+	_, err := api.GetTeam(context.Background(), "Unknown Team")
 	if err == nil {
 		t.Error("expected error for unknown team, got nil")
 	}
@@ -645,7 +713,7 @@ func TestGetTeam_FuzzyMatch(t *testing.T) {
 	api := &App{Store: mockStore}
 
 	// "THUNDERdOWNUNDER" vs "THUNDER dOWNUNDER" — spacing difference, should fuzzy match
-	entry, err := api.GetTeam("THUNDERdOWNUNDER")
+	entry, err := api.GetTeam(context.Background(), "THUNDERdOWNUNDER")
 	if err != nil {
 		t.Fatalf("expected fuzzy match to succeed, got: %v", err)
 	}
@@ -659,7 +727,7 @@ func TestGetTeam_FetchError(t *testing.T) {
 	mockStore.FetchVrsDataFromDBError = fmt.Errorf("db connection failed")
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTeam("FaZe")
+	_, err := api.GetTeam(context.Background(), "FaZe")
 	if err == nil {
 		t.Error("expected error when VRS fetch fails, got nil")
 	}
@@ -678,7 +746,7 @@ func TestGetTeams_WithVRSData(t *testing.T) {
 	})
 	api := &App{Store: mockStore}
 
-	teams, err := api.GetTeams()
+	teams, err := api.GetTeams(context.Background())
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -703,7 +771,7 @@ func TestGetTeams_UnmatchedTeamGetsZero(t *testing.T) {
 	})
 	api := &App{Store: mockStore}
 
-	teams, err := api.GetTeams()
+	teams, err := api.GetTeams(context.Background())
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -721,7 +789,7 @@ func TestGetTeams_VRSFetchError(t *testing.T) {
 	mockStore.FetchVrsDataFromDBError = fmt.Errorf("db error")
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTeams()
+	_, err := api.GetTeams(context.Background())
 	if err == nil {
 		t.Error("expected error when VRS fetch fails, got nil")
 	}
@@ -748,7 +816,7 @@ func TestGetUpcomingMatches_Success(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	matches, err := api.GetUpcomingMatches()
+	matches, err := api.GetUpcomingMatches(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -789,7 +857,7 @@ func TestGetUpcomingMatches_LiveMatchIncluded(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	matches, err := api.GetUpcomingMatches()
+	matches, err := api.GetUpcomingMatches(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -813,7 +881,7 @@ func TestGetUpcomingMatches_FetchScheduleError(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, err := api.GetUpcomingMatches()
+	_, err := api.GetUpcomingMatches(context.Background())
 	if err == nil || !strings.Contains(err.Error(), "fetch failed") {
 		t.Errorf("Expected fetch error, got: %v", err)
 	}
@@ -837,7 +905,7 @@ func TestGetUpcomingMatches_FiltersFinishedMatches(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	matches, err := api.GetUpcomingMatches()
+	matches, err := api.GetUpcomingMatches(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -874,7 +942,7 @@ func TestGetUpcomingMatches_DisplaysInChronologicalOrder(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	matches, err := api.GetUpcomingMatches()
+	matches, err := api.GetUpcomingMatches(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -900,7 +968,7 @@ func TestGetTournamentInfo_Swiss(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	info, err := api.GetTournamentInfo()
+	info, err := api.GetTournamentInfo(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -920,7 +988,7 @@ func TestGetTournamentInfo_SingleElimination(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	info, err := api.GetTournamentInfo()
+	info, err := api.GetTournamentInfo(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -938,7 +1006,7 @@ func TestGetTournamentInfo_GetValidTeamsError(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTournamentInfo()
+	_, err := api.GetTournamentInfo(context.Background())
 	if err == nil {
 		t.Error("expected error when GetValidTeams fails, got nil")
 	}
@@ -950,7 +1018,7 @@ func TestGetTournamentInfo_UnknownFormat(t *testing.T) {
 
 	api := &App{Store: mockStore}
 
-	_, err := api.GetTournamentInfo()
+	_, err := api.GetTournamentInfo(context.Background())
 	if err == nil {
 		t.Error("expected error for unknown tournament format, got nil")
 	}
@@ -969,7 +1037,7 @@ func TestPopulateMatches_ScheduleError(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.PopulateMatches(true)
+	err := api.PopulateMatches(context.Background(), true)
 	if err == nil {
 		t.Error("expected error when schedule fetch fails, got nil")
 	}
@@ -984,7 +1052,7 @@ func TestPopulateMatches_ResultsError(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.PopulateMatches(false)
+	err := api.PopulateMatches(context.Background(), false)
 	if err == nil {
 		t.Error("expected error when results fetch fails, got nil")
 	}
@@ -1000,7 +1068,7 @@ func TestUpdateMatchSchedule_Success(t *testing.T) {
 		Store:       mockStore,
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
-	if err := api.UpdateMatchSchedule(); err != nil {
+	if err := api.UpdateMatchSchedule(context.Background()); err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
 }
@@ -1011,7 +1079,7 @@ func TestUpdateMatchSchedule_RateLimitExceeded(t *testing.T) {
 	limiter.Allow()
 	api := &App{Store: mockStore, rateLimiter: limiter}
 
-	err := api.UpdateMatchSchedule()
+	err := api.UpdateMatchSchedule(context.Background())
 	if err == nil || !strings.Contains(err.Error(), "rate limiter exceeded") {
 		t.Errorf("Expected rate limiter error, got: %v", err)
 	}
@@ -1025,7 +1093,7 @@ func TestUpdateMatchSchedule_StoreError(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.UpdateMatchSchedule()
+	err := api.UpdateMatchSchedule(context.Background())
 	if err == nil || !strings.Contains(err.Error(), "schedule fetch failed") {
 		t.Errorf("Expected store error, got: %v", err)
 	}
@@ -1041,7 +1109,7 @@ func TestStoreSchedule_Success(t *testing.T) {
 	matches := []sources.ScheduledMatch{
 		{Team1: "Team A", Team2: "Team B", EpochTime: 1000, BestOf: "3"},
 	}
-	if err := api.StoreSchedule(matches); err != nil {
+	if err := api.StoreSchedule(context.Background(), matches); err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
 }
@@ -1051,7 +1119,7 @@ func TestStoreSchedule_StoreError(t *testing.T) {
 	mockStore.StoreMatchScheduleError = fmt.Errorf("db write failed")
 	api := &App{Store: mockStore}
 
-	err := api.StoreSchedule([]sources.ScheduledMatch{{Team1: "A", Team2: "B", EpochTime: 1000, BestOf: "3"}})
+	err := api.StoreSchedule(context.Background(), []sources.ScheduledMatch{{Team1: "A", Team2: "B", EpochTime: 1000, BestOf: "3"}})
 	if err == nil || !strings.Contains(err.Error(), "db write failed") {
 		t.Errorf("Expected store error, got: %v", err)
 	}
@@ -1070,7 +1138,7 @@ func TestUpdateMatchResults_Success(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.UpdateMatchResults()
+	err := api.UpdateMatchResults(context.Background())
 	if err != nil {
 		t.Errorf("Expected no error, got: %s", err.Error())
 	}
@@ -1084,7 +1152,7 @@ func TestUpdateMatchResults_RateLimiterNil(t *testing.T) {
 		rateLimiter: nil,
 	}
 
-	err := api.UpdateMatchResults()
+	err := api.UpdateMatchResults(context.Background())
 	if err == nil {
 		t.Error("Expected error when rate limiter is nil, got nil")
 	}
@@ -1106,7 +1174,7 @@ func TestUpdateMatchResults_RateLimitExceeded(t *testing.T) {
 		rateLimiter: limiter,
 	}
 
-	err := api.UpdateMatchResults()
+	err := api.UpdateMatchResults(context.Background())
 	if err == nil {
 		t.Error("Expected error when rate limit exceeded, got nil")
 	}
@@ -1125,7 +1193,7 @@ func TestUpdateMatchResults_StoreError(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.UpdateMatchResults()
+	err := api.UpdateMatchResults(context.Background())
 	if err == nil {
 		t.Error("Expected error from store, got nil")
 	}
@@ -1160,7 +1228,7 @@ func TestPopulateMatches_ScheduleOnly_Success(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.PopulateMatches(true)
+	err := api.PopulateMatches(context.Background(), true)
 	if err != nil {
 		t.Errorf("Expected no error for scheduleOnly=true, got: %s", err.Error())
 	}
@@ -1174,7 +1242,7 @@ func TestPopulateMatches_FullUpdate_Success(t *testing.T) {
 		rateLimiter: rate.NewLimiter(rate.Every(time.Second), 10),
 	}
 
-	err := api.PopulateMatches(false)
+	err := api.PopulateMatches(context.Background(), false)
 	if err != nil {
 		t.Errorf("Expected no error for scheduleOnly=false, got: %s", err.Error())
 	}
@@ -1192,7 +1260,7 @@ func TestPopulateMatches_RateLimiterNil(t *testing.T) {
 		rateLimiter: nil,
 	}
 
-	err := api.PopulateMatches(true)
+	err := api.PopulateMatches(context.Background(), true)
 	if err == nil {
 		t.Error("Expected error when rate limiter is nil, got nil")
 	}
@@ -1214,7 +1282,7 @@ func TestPopulateMatches_RateLimitExceeded(t *testing.T) {
 		rateLimiter: limiter,
 	}
 
-	err := api.PopulateMatches(true)
+	err := api.PopulateMatches(context.Background(), true)
 	if err == nil {
 		t.Error("Expected error when rate limit exceeded, got nil")
 	}
@@ -1225,3 +1293,107 @@ func TestPopulateMatches_RateLimitExceeded(t *testing.T) {
 }
 
 // endregion
+
+// region MemoryStore tests
+
+func TestNewApp_MemoryBackend(t *testing.T) {
+	cfg := config.Config{DataSource: "liquipedia", TournamentName: "db", Round: "r1", Storage: config.StorageConfig{Backend: config.StorageMemory}}
+	api, err := NewApp(context.Background(), cfg, "", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := api.Store.(*store.MemoryStore); !ok {
+		t.Errorf("Expected *store.MemoryStore, got %T", api.Store)
+	}
+}
+
+func TestNewApp_SQLiteBackend(t *testing.T) {
+	cfg := config.Config{DataSource: "liquipedia", TournamentName: "db", Round: "r1", Storage: config.StorageConfig{Backend: config.StorageSQLite, DSN: ":memory:"}}
+	api, err := NewApp(context.Background(), cfg, "", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer api.Store.GetClient().Disconnect(context.Background())
+	cachedStore, ok := api.Store.(*store.CachedStore)
+	if !ok {
+		t.Fatalf("Expected *store.CachedStore, got %T", api.Store)
+	}
+	if _, ok := cachedStore.Interface.(*store.SQLStore); !ok {
+		t.Errorf("Expected *store.SQLStore behind the cache, got %T", cachedStore.Interface)
+	}
+}
+
+func TestInvalidateCache(t *testing.T) {
+	ctx := context.Background()
+	memoryStore := store.NewMemoryStore("db", "stage_1", nil, nil)
+	if err := memoryStore.SetVRSData(ctx, []store.VRSEntry{{Standing: 1, TeamName: "Alpha"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	api := &App{Store: store.NewCachedStore(memoryStore, store.CacheTTLs{VRS: time.Hour})}
+	if _, err := api.Store.FetchVrsDataFromDB(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := memoryStore.SetVRSData(ctx, []store.VRSEntry{{Standing: 1, TeamName: "Bravo"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	api.InvalidateCache(store.CacheVRS)
+	entries, err := api.Store.FetchVrsDataFromDB(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entries[0].TeamName != "Bravo" {
+		t.Errorf("Expected the invalidated rankings to be reloaded, got %s", entries[0].TeamName)
+	}
+
+	// Stores that don't cache are left alone
+	(&App{Store: memoryStore}).InvalidateCache()
+}
+
+// TestApp_MemoryStore_PredictAndScore runs a prediction through set, check and leaderboard against a real store
+func TestApp_MemoryStore_PredictAndScore(t *testing.T) {
+	ctx := context.Background()
+	memoryStore := store.NewMemoryStore("db", "stage_1", nil, nil)
+	api := &App{Store: memoryStore, rateLimiter: rate.NewLimiter(rate.Inf, 1)}
+
+	teams := make([]string, 16)
+	records := make(map[string]string, 16)
+	for i := range teams {
+		teams[i] = fmt.Sprintf("Team %c", 'A'+i)
+		records[teams[i]] = "0-0"
+	}
+	records["Team A"] = "3-0"
+	if err := memoryStore.StoreMatchResults(ctx, tournament.SwissResult{Round: "stage_1", Teams: records}); err != nil {
+		t.Fatalf("StoreMatchResults: %v", err)
+	}
+	schedule := []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", EpochTime: time.Now().Add(time.Hour).Unix()}}
+	if err := memoryStore.StoreMatchSchedule(ctx, schedule); err != nil {
+		t.Fatalf("StoreMatchSchedule: %v", err)
+	}
+
+	user := models.User{UserID: "u1", Username: "Alice"}
+	if _, err := api.SetUserPrediction(ctx, user, append([]string(nil), teams[:10]...), "stage_1"); err != nil {
+		t.Fatalf("SetUserPrediction: %v", err)
+	}
+
+	report, err := api.CheckPrediction(ctx, user)
+	if err != nil {
+		t.Fatalf("CheckPrediction: %v", err)
+	}
+	if report == nil {
+		t.Fatal("Expected a score report, got nil")
+	}
+
+	if err := api.GenerateLeaderboard(ctx); err != nil {
+		t.Fatalf("GenerateLeaderboard: %v", err)
+	}
+	leaderboard, err := api.GetLeaderboard(ctx)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if len(leaderboard) != 1 || leaderboard[0].UserID != "u1" || leaderboard[0].Successes != 1 {
+		t.Errorf("Expected one entry for u1 with 1 success, got %+v", leaderboard)
+	}
+}
+
+// endregion
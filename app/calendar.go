@@ -0,0 +1,127 @@
+/* calendar.go
+ * Contains the logic behind $calendar and the calendar feed: the current round's schedule as an iCalendar
+ * (RFC 5545) document that calendar apps can import or subscribe to.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"pickems-bot/sources"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	// icsLineLimit is the longest a content line may be, in octets, before it must be folded
+	icsLineLimit = 75
+)
+
+// uidUnsafe matches the characters stripped from teams and rounds when building event UIDs
+var uidUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GetCalendar returns the current round's scheduled matches as an iCalendar document, stamped with now.
+func (a *App) GetCalendar(ctx context.Context, now time.Time) ([]byte, error) {
+	matches, err := a.Store.FetchMatchSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("%s %s", strings.ReplaceAll(a.Store.GetDatabase().Name(), "_", " "), a.Store.GetRound())
+	return BuildCalendar(name, a.Store.GetRound(), matches, now), nil
+}
+
+// BuildCalendar renders matches as an iCalendar document named name. Matches without a start time or with an
+// undecided team are left out. Each event runs for roughly an hour per map, and event UIDs are derived from the
+// round and teams so that calendar apps update rescheduled matches in place.
+func BuildCalendar(name, round string, matches []sources.ScheduledMatch, now time.Time) []byte {
+	var sb strings.Builder
+	writeLine := func(line string) { sb.WriteString(foldICSLine(line) + "\r\n") }
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//pickems-bot//Pick'Ems Schedule//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICSText(name))
+	writeLine("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeLine("X-PUBLISHED-TTL:PT1H")
+
+	stamp := now.UTC().Format(icsTimeFormat)
+	for _, m := range matches {
+		if m.EpochTime <= 0 || m.Team1 == "TBD" || m.Team2 == "TBD" {
+			continue
+		}
+		start := time.Unix(m.EpochTime, 0).UTC()
+		summary := fmt.Sprintf("%s vs %s", m.Team1, m.Team2)
+		if m.BestOf != "" {
+			summary += fmt.Sprintf(" (Bo%s)", m.BestOf)
+		}
+		description := name
+		if m.StreamURL != "" {
+			description += "\nStream: " + m.StreamURL
+		}
+
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + eventUID(round, m))
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART:" + start.Format(icsTimeFormat))
+		writeLine("DTEND:" + start.Add(matchDuration(m.BestOf)).Format(icsTimeFormat))
+		writeLine("SUMMARY:" + escapeICSText(summary))
+		writeLine("DESCRIPTION:" + escapeICSText(description))
+		if m.StreamURL != "" {
+			writeLine("URL:" + m.StreamURL)
+		}
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return []byte(sb.String())
+}
+
+// eventUID returns a UID that stays the same when a match is rescheduled, e.g. "playoffs-team-a-team-b@pickems-bot"
+func eventUID(round string, m sources.ScheduledMatch) string {
+	parts := make([]string, 0, 3)
+	for _, s := range []string{round, m.Team1, m.Team2} {
+		parts = append(parts, strings.Trim(uidUnsafe.ReplaceAllString(strings.ToLower(s), "-"), "-"))
+	}
+	return strings.Join(parts, "-") + "@pickems-bot"
+}
+
+// matchDuration estimates how long a match lasts from its best-of, at about an hour per map
+func matchDuration(bestOf string) time.Duration {
+	maps, err := strconv.Atoi(bestOf)
+	if err != nil || maps < 1 {
+		maps = 1
+	}
+	return time.Duration(maps) * time.Hour
+}
+
+// escapeICSText escapes a TEXT property value per RFC 5545 section 3.3.11
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine splits lines longer than 75 octets into continuation lines that start with a space, without
+// splitting a multi-byte character
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line
+	}
+	var sb strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > icsLineLimit {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+/* calendar_test.go
+ * Contains unit tests for calendar.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region BuildCalendar tests
+
+func TestBuildCalendar_Events(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	matches := []sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "The MongolZ", BestOf: "3", EpochTime: time.Date(2026, 6, 14, 16, 30, 0, 0, time.UTC).Unix(), StreamURL: "https://twitch.tv/esl_csgo"},
+		{Team1: "Team C", Team2: "Team D", BestOf: "1", EpochTime: time.Date(2026, 6, 14, 20, 0, 0, 0, time.UTC).Unix()},
+		{Team1: "TBD", Team2: "Team E", BestOf: "3", EpochTime: time.Date(2026, 6, 15, 16, 0, 0, 0, time.UTC).Unix()},
+		{Team1: "Team F", Team2: "Team G", BestOf: "3"}, // no start time yet
+	}
+
+	ics := string(BuildCalendar("IEM Cologne Stage 1", "Stage_1", matches, now))
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "X-WR-CALNAME:IEM Cologne Stage 1\r\n")
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Contains(t, ics, "BEGIN:VEVENT\r\n"+
+		"UID:stage-1-team-a-the-mongolz@pickems-bot\r\n"+
+		"DTSTAMP:20260601T120000Z\r\n"+
+		"DTSTART:20260614T163000Z\r\n"+
+		"DTEND:20260614T193000Z\r\n"+
+		"SUMMARY:Team A vs The MongolZ (Bo3)\r\n"+
+		"DESCRIPTION:IEM Cologne Stage 1\\nStream: https://twitch.tv/esl_csgo\r\n"+
+		"URL:https://twitch.tv/esl_csgo\r\n"+
+		"END:VEVENT\r\n")
+	assert.Contains(t, ics, "DTSTART:20260614T200000Z\r\nDTEND:20260614T210000Z\r\n")
+	assert.NotContains(t, ics, "TBD")
+	assert.NotContains(t, ics, "Team F")
+}
+
+func TestBuildCalendar_Empty(t *testing.T) {
+	ics := string(BuildCalendar("Event", "Round", nil, time.Now()))
+
+	assert.NotContains(t, ics, "VEVENT")
+	assert.Contains(t, ics, "END:VCALENDAR\r\n")
+}
+
+func TestEscapeICSText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, escapeICSText("a, b; c\\d\ne"))
+}
+
+func TestFoldICSLine(t *testing.T) {
+	short := "SUMMARY:short"
+	assert.Equal(t, short, foldICSLine(short))
+
+	long := "DESCRIPTION:" + strings.Repeat("ż", 50) // 2 bytes per rune
+	folded := foldICSLine(long)
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLimit)
+	}
+	assert.Equal(t, long, strings.ReplaceAll(folded, "\r\n ", ""))
+}
+
+func TestMatchDuration(t *testing.T) {
+	assert.Equal(t, time.Hour, matchDuration("1"))
+	assert.Equal(t, 5*time.Hour, matchDuration("5"))
+	assert.Equal(t, time.Hour, matchDuration(""))
+}
+
+// endregion
+
+// region GetCalendar tests
+
+func TestGetCalendar(t *testing.T) {
+	mockStore := NewMockStore("swiss", "Stage_1")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "Team B", BestOf: "3", EpochTime: 1700000000},
+	})
+	a := &App{Store: mockStore}
+
+	ics, err := a.GetCalendar(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, string(ics), "X-WR-CALNAME:test db Stage_1\r\n")
+	assert.Contains(t, string(ics), "SUMMARY:Team A vs Team B (Bo3)\r\n")
+}
+
+func TestGetCalendar_Error(t *testing.T) {
+	mockStore := NewMockStore("swiss", "Stage_1")
+	mockStore.FetchMatchScheduleError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetCalendar(context.Background(), time.Now())
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
@@ -0,0 +1,168 @@
+/* compare.go
+ * Contains the app logic behind $compare: scoring two users' predictions against the current results and
+ * splitting their picks into the ones they share and the ones that differ.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"pickems-bot/models"
+	"pickems-bot/tournament"
+)
+
+// Pick is one team a user has picked, the slot they picked it for (e.g. "3-0", "Advance", "Champion") and how
+// the pick stands against the current results.
+type Pick struct {
+	Team   string
+	Slot   string
+	Record string // current Swiss record, e.g. "2-1"; empty for other formats
+	Status tournament.BucketStatus
+}
+
+// DecidingTeam is a team the two users picked differently where at least one of the picks is still pending,
+// so its remaining matches decide who finishes ahead. A nil pick means that user didn't pick the team.
+type DecidingTeam struct {
+	Team  string
+	PickA *Pick
+	PickB *Pick
+}
+
+// Comparison is the head-to-head of two users' predictions for the current round.
+type Comparison struct {
+	UserA, UserB   models.User
+	ScoreA, ScoreB models.ScoreResult
+	Shared         []Pick // picked for the same slot by both users
+	OnlyA, OnlyB   []Pick // picks the other user doesn't share
+	Deciding       []DecidingTeam
+}
+
+// Points returns the leaderboard points for a score: 3 per success, 1 per pending pick and 0 per failure
+func Points(score models.ScoreResult) int {
+	return score.Successes*3 + score.Pending
+}
+
+// ComparePredictions scores both users' predictions and compares them pick by pick. Returns
+// store.ErrNotFound when either user has no prediction stored.
+func (a *App) ComparePredictions(ctx context.Context, userA, userB models.User) (Comparison, error) {
+	if userA.UserID == userB.UserID {
+		return Comparison{}, errors.New("pick two different users to compare")
+	}
+	reportA, err := a.CheckPrediction(ctx, userA)
+	if err != nil {
+		return Comparison{}, err
+	}
+	reportB, err := a.CheckPrediction(ctx, userB)
+	if err != nil {
+		return Comparison{}, err
+	}
+	picksA, err := reportPicks(reportA)
+	if err != nil {
+		return Comparison{}, err
+	}
+	picksB, err := reportPicks(reportB)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	comparison := Comparison{
+		UserA:  userA,
+		UserB:  userB,
+		ScoreA: reportA.GetScore(),
+		ScoreB: reportB.GetScore(),
+	}
+	comparison.Shared, comparison.OnlyA, comparison.OnlyB, comparison.Deciding = comparePicks(picksA, picksB)
+	return comparison, nil
+}
+
+// reportPicks flattens a format-specific score report into a list of picks
+func reportPicks(report tournament.ScoreReport) ([]Pick, error) {
+	var picks []Pick
+	switch r := report.(type) {
+	case tournament.SwissReport:
+		buckets := []struct {
+			slot    string
+			entries []tournament.BucketEntry
+		}{
+			{"3-0", r.WinPicks},
+			{"Advance", r.AdvancePicks},
+			{"0-3", r.LosePicks},
+		}
+		for _, bucket := range buckets {
+			for _, e := range bucket.entries {
+				picks = append(picks, Pick{Team: e.Team, Slot: bucket.slot, Record: e.Score, Status: e.Status})
+			}
+		}
+	case tournament.SingleElimReport:
+		for _, e := range r.Predictions {
+			slot := e.Round
+			if e.ToWin {
+				slot = "Champion"
+			}
+			picks = append(picks, Pick{Team: e.Team, Slot: slot, Status: e.Status})
+		}
+		// The report is built from a map, so give it a stable order
+		sort.Slice(picks, func(i, j int) bool { return picks[i].Team < picks[j].Team })
+	default:
+		return nil, fmt.Errorf("comparing predictions is not supported for the %s format", report.FormatKind())
+	}
+	return picks, nil
+}
+
+// comparePicks splits two users' picks into shared picks, picks unique to each user and the teams whose
+// pending results decide who finishes ahead. Picks keep their report order; deciding teams are sorted by name.
+func comparePicks(picksA, picksB []Pick) (shared, onlyA, onlyB []Pick, deciding []DecidingTeam) {
+	byTeamA := make(map[string]Pick, len(picksA))
+	for _, p := range picksA {
+		byTeamA[p.Team] = p
+	}
+	byTeamB := make(map[string]Pick, len(picksB))
+	for _, p := range picksB {
+		byTeamB[p.Team] = p
+	}
+
+	decidingByTeam := make(map[string]*DecidingTeam)
+	addDeciding := func(p Pick) {
+		if decidingByTeam[p.Team] != nil {
+			return
+		}
+		team := &DecidingTeam{Team: p.Team}
+		if pick, ok := byTeamA[p.Team]; ok {
+			team.PickA = &pick
+		}
+		if pick, ok := byTeamB[p.Team]; ok {
+			team.PickB = &pick
+		}
+		decidingByTeam[p.Team] = team
+	}
+
+	for _, p := range picksA {
+		if other, ok := byTeamB[p.Team]; ok && other.Slot == p.Slot {
+			shared = append(shared, p)
+			continue
+		}
+		onlyA = append(onlyA, p)
+		if p.Status == tournament.StatusPending {
+			addDeciding(p)
+		}
+	}
+	for _, p := range picksB {
+		if other, ok := byTeamA[p.Team]; ok && other.Slot == p.Slot {
+			continue
+		}
+		onlyB = append(onlyB, p)
+		if p.Status == tournament.StatusPending {
+			addDeciding(p)
+		}
+	}
+
+	for _, team := range decidingByTeam {
+		deciding = append(deciding, *team)
+	}
+	sort.Slice(deciding, func(i, j int) bool { return deciding[i].Team < deciding[j].Team })
+	return shared, onlyA, onlyB, deciding
+}
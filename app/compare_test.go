@@ -0,0 +1,161 @@
+/* compare_test.go
+ * Contains unit tests for compare.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	compareUserA = models.User{UserID: "userA", Username: "alice"}
+	compareUserB = models.User{UserID: "userB", Username: "bob"}
+)
+
+// newCompareStore returns a mock Swiss store with two overlapping predictions and results mid-stage
+func newCompareStore() *MockStore {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.Predictions["userA"] = models.Prediction{
+		UserID: "userA", Username: "alice", Format: "swiss", Round: "test_round",
+		Win:     []string{"Team A", "Team B"},
+		Advance: []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+		Lose:    []string{"Team I", "Team J"},
+	}
+	mockStore.Predictions["userB"] = models.Prediction{
+		UserID: "userB", Username: "bob", Format: "swiss", Round: "test_round",
+		Win:     []string{"Team A", "Team C"},
+		Advance: []string{"Team B", "Team D", "Team E", "Team F", "Team G", "Team K"},
+		Lose:    []string{"Team I", "Team L"},
+	}
+	mockStore.SetSwissResults(map[string]string{
+		"Team A": "3-0", "Team B": "2-0", "Team C": "2-1", "Team D": "3-1",
+		"Team E": "3-2", "Team F": "3-1", "Team G": "2-2", "Team H": "1-2",
+		"Team I": "0-3", "Team J": "0-2", "Team K": "2-2", "Team L": "0-2",
+	})
+	return mockStore
+}
+
+// teams returns the team names of a list of picks
+func teams(picks []Pick) []string {
+	var out []string
+	for _, p := range picks {
+		out = append(out, p.Team)
+	}
+	return out
+}
+
+// region ComparePredictions tests
+
+func TestComparePredictions_Swiss(t *testing.T) {
+	api := &App{Store: newCompareStore()}
+
+	comparison, err := api.ComparePredictions(context.Background(), compareUserA, compareUserB)
+	require.NoError(t, err)
+
+	assert.Equal(t, compareUserA, comparison.UserA)
+	assert.Equal(t, compareUserB, comparison.UserB)
+	assert.Equal(t, []string{"Team A", "Team D", "Team E", "Team F", "Team G", "Team I"}, teams(comparison.Shared))
+	assert.Equal(t, []string{"Team B", "Team C", "Team H", "Team J"}, teams(comparison.OnlyA))
+	assert.Equal(t, []string{"Team C", "Team B", "Team K", "Team L"}, teams(comparison.OnlyB))
+
+	// Team C can no longer go 3-0, so only alice's Advance pick is still live
+	assert.Equal(t, models.ScoreResult{Successes: 5, Pending: 5, Failed: 0}, comparison.ScoreA)
+	assert.Equal(t, models.ScoreResult{Successes: 5, Pending: 4, Failed: 1}, comparison.ScoreB)
+
+	var deciding []string
+	for _, d := range comparison.Deciding {
+		deciding = append(deciding, d.Team)
+	}
+	assert.Equal(t, []string{"Team B", "Team C", "Team H", "Team J", "Team K", "Team L"}, deciding)
+
+	teamC := comparison.Deciding[1]
+	require.NotNil(t, teamC.PickA)
+	require.NotNil(t, teamC.PickB)
+	assert.Equal(t, "Advance", teamC.PickA.Slot)
+	assert.Equal(t, tournament.StatusPending, teamC.PickA.Status)
+	assert.Equal(t, "3-0", teamC.PickB.Slot)
+	assert.Equal(t, tournament.StatusFailed, teamC.PickB.Status)
+	assert.Equal(t, "2-1", teamC.PickB.Record)
+
+	teamK := comparison.Deciding[4]
+	assert.Nil(t, teamK.PickA, "alice didn't pick Team K")
+	require.NotNil(t, teamK.PickB)
+	assert.Equal(t, "Advance", teamK.PickB.Slot)
+}
+
+func TestComparePredictions_SingleElim(t *testing.T) {
+	mockStore := NewMockStore("single-elimination", "playoffs")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.Predictions["userA"] = models.Prediction{
+		UserID: "userA", Username: "alice", Format: "single-elimination", Round: "playoffs",
+		Progression: map[string]models.TeamProgress{
+			"Team A": {Round: "Grand Final", Status: "advanced"},
+			"Team B": {Round: "Grand Final", Status: "eliminated"},
+		},
+	}
+	mockStore.Predictions["userB"] = models.Prediction{
+		UserID: "userB", Username: "bob", Format: "single-elimination", Round: "playoffs",
+		Progression: map[string]models.TeamProgress{
+			"Team A": {Round: "Grand Final", Status: "advanced"},
+			"Team C": {Round: "Grand Final", Status: "eliminated"},
+		},
+	}
+	mockStore.SetEliminationResults(map[string]models.TeamProgress{
+		"Team A": {Round: "Grand Final", Status: "pending"},
+		"Team B": {Round: "Semi Final", Status: "eliminated"},
+		"Team C": {Round: "Grand Final", Status: "pending"},
+	})
+	api := &App{Store: mockStore}
+
+	comparison, err := api.ComparePredictions(context.Background(), compareUserA, compareUserB)
+	require.NoError(t, err)
+
+	require.Len(t, comparison.Shared, 1)
+	assert.Equal(t, Pick{Team: "Team A", Slot: "Champion", Status: tournament.StatusPending}, comparison.Shared[0])
+	assert.Equal(t, []Pick{{Team: "Team B", Slot: "Grand Final", Status: tournament.StatusFailed}}, comparison.OnlyA)
+	assert.Equal(t, []Pick{{Team: "Team C", Slot: "Grand Final", Status: tournament.StatusPending}}, comparison.OnlyB)
+
+	// Team B is already out, so only Team C's final decides anything
+	require.Len(t, comparison.Deciding, 1)
+	assert.Equal(t, "Team C", comparison.Deciding[0].Team)
+	assert.Nil(t, comparison.Deciding[0].PickA)
+}
+
+func TestComparePredictions_SameUser(t *testing.T) {
+	api := &App{Store: newCompareStore()}
+
+	_, err := api.ComparePredictions(context.Background(), compareUserA, compareUserA)
+	assert.ErrorContains(t, err, "two different users")
+}
+
+func TestComparePredictions_NoPrediction(t *testing.T) {
+	mockStore := newCompareStore()
+	delete(mockStore.Predictions, "userB")
+	api := &App{Store: mockStore}
+
+	_, err := api.ComparePredictions(context.Background(), compareUserA, compareUserB)
+	assert.True(t, errors.Is(err, mongo.ErrNoDocuments), "expected ErrNoDocuments, got %v", err)
+}
+
+// endregion
+
+// region Points tests
+
+func TestPoints(t *testing.T) {
+	assert.Equal(t, 0, Points(models.ScoreResult{}))
+	assert.Equal(t, 11, Points(models.ScoreResult{Successes: 3, Pending: 2, Failed: 4}))
+}
+
+// endregion
@@ -0,0 +1,189 @@
+/* guild_settings.go
+ * Contains the logic for reading and validating per-guild settings.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"pickems-bot/i18n"
+	"pickems-bot/store"
+)
+
+// Defaults applied to any guild setting that hasn't been configured
+const (
+	DefaultPrefix   = "$"
+	DefaultLocale   = i18n.DefaultLocale
+	DefaultTimezone = "UTC"
+)
+
+// GuildSettingKeys lists the settings that can be changed with SetGuildSetting, in display order
+var GuildSettingKeys = []string{"prefix", "announcement_channel", "reminder_channel", "admin_role", "locale", "timezone"}
+
+// channelMention and roleMention match Discord mentions (or bare snowflake IDs) for channels and roles
+var (
+	channelMention = regexp.MustCompile(`^(?:<#)?(\d+)>?$`)
+	roleMention    = regexp.MustCompile(`^(?:<@&)?(\d+)>?$`)
+)
+
+// GuildSettingErrorKind identifies which check the arguments given to SetGuildSetting failed
+type GuildSettingErrorKind int
+
+const (
+	// GuildSettingUnknownKey means Key is not one of GuildSettingKeys
+	GuildSettingUnknownKey GuildSettingErrorKind = iota
+	// GuildSettingInvalidPrefix means Value is too long or contains a forbidden character
+	GuildSettingInvalidPrefix
+	// GuildSettingInvalidChannel means Value is not a channel mention or ID
+	GuildSettingInvalidChannel
+	// GuildSettingInvalidRole means Value is not a role mention or ID
+	GuildSettingInvalidRole
+	// GuildSettingUnsupportedLocale means Value is not one of i18n.SupportedLocales
+	GuildSettingUnsupportedLocale
+	// GuildSettingUnknownTimezone means Value is not an IANA timezone name
+	GuildSettingUnknownTimezone
+)
+
+// GuildSettingError is returned by SetGuildSetting when the setting or its value fails validation
+type GuildSettingError struct {
+	Kind  GuildSettingErrorKind
+	Key   string
+	Value string
+}
+
+// Error describes the validation failure in English
+func (e *GuildSettingError) Error() string {
+	switch e.Kind {
+	case GuildSettingUnknownKey:
+		return fmt.Sprintf("unknown setting %q, available settings are: %s", e.Key, strings.Join(GuildSettingKeys, ", "))
+	case GuildSettingInvalidPrefix:
+		return "prefix must be 1-3 characters with no spaces, backticks, `@` or `#`"
+	case GuildSettingInvalidChannel:
+		return fmt.Sprintf("%s must be a channel mention (e.g. #results) or channel ID", e.Key)
+	case GuildSettingInvalidRole:
+		return fmt.Sprintf("%s must be a role mention or role ID", e.Key)
+	case GuildSettingUnsupportedLocale:
+		return fmt.Sprintf("unsupported locale %q, supported locales are: %s", e.Value, strings.Join(i18n.SupportedLocales, ", "))
+	case GuildSettingUnknownTimezone:
+		return fmt.Sprintf("unknown timezone %q, use an IANA name such as Europe/Berlin", e.Value)
+	}
+	return "invalid setting"
+}
+
+// GetGuildSettings returns a guild's settings with defaults applied. Guilds that have never been configured
+// get the defaults.
+func (a *App) GetGuildSettings(ctx context.Context, guildID string) (store.GuildSettings, error) {
+	settings, err := a.Store.GetGuildSettings(ctx, guildID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return store.GuildSettings{}, err
+	}
+	settings.GuildID = guildID
+	return withGuildDefaults(settings), nil
+}
+
+// ListGuildSettings returns the settings of every configured guild with defaults applied.
+func (a *App) ListGuildSettings(ctx context.Context) ([]store.GuildSettings, error) {
+	all, err := a.Store.FetchAllGuildSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		all[i] = withGuildDefaults(all[i])
+	}
+	return all, nil
+}
+
+// SetGuildSetting validates and stores a single setting for a guild, returning the updated settings. An
+// empty value resets the setting to its default. Invalid settings or values return a *GuildSettingError.
+func (a *App) SetGuildSetting(ctx context.Context, guildID, key, value string) (store.GuildSettings, error) {
+	settings, err := a.Store.GetGuildSettings(ctx, guildID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return store.GuildSettings{}, err
+	}
+	settings.GuildID = guildID
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case "prefix":
+		if value != "" && (len(value) > 3 || strings.ContainsAny(value, " \t\n`@#")) {
+			return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingInvalidPrefix, Key: key, Value: value}
+		}
+		settings.Prefix = value
+	case "announcement_channel", "reminder_channel":
+		id, err := parseMention(channelMention, value)
+		if err != nil {
+			return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingInvalidChannel, Key: key, Value: value}
+		}
+		if key == "announcement_channel" {
+			settings.AnnouncementChannel = id
+		} else {
+			settings.ReminderChannel = id
+		}
+	case "admin_role":
+		id, err := parseMention(roleMention, value)
+		if err != nil {
+			return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingInvalidRole, Key: key, Value: value}
+		}
+		settings.AdminRoleID = id
+	case "locale":
+		if value != "" && !i18n.IsSupported(value) {
+			return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingUnsupportedLocale, Key: key, Value: value}
+		}
+		settings.Locale = value
+	case "timezone":
+		if value != "" {
+			if _, err := time.LoadLocation(value); err != nil {
+				return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingUnknownTimezone, Key: key, Value: value}
+			}
+		}
+		settings.Timezone = value
+	default:
+		return store.GuildSettings{}, &GuildSettingError{Kind: GuildSettingUnknownKey, Key: key, Value: value}
+	}
+
+	if err := a.Store.StoreGuildSettings(ctx, settings); err != nil {
+		return store.GuildSettings{}, err
+	}
+	return withGuildDefaults(settings), nil
+}
+
+// GuildLocation returns the time.Location of a guild's configured timezone, falling back to UTC.
+func GuildLocation(settings store.GuildSettings) *time.Location {
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// withGuildDefaults fills any unset field that has a default
+func withGuildDefaults(settings store.GuildSettings) store.GuildSettings {
+	if settings.Prefix == "" {
+		settings.Prefix = DefaultPrefix
+	}
+	if settings.Locale == "" {
+		settings.Locale = DefaultLocale
+	}
+	if settings.Timezone == "" {
+		settings.Timezone = DefaultTimezone
+	}
+	return settings
+}
+
+// parseMention extracts the ID from a mention or bare ID. An empty value is returned as-is (reset).
+func parseMention(pattern *regexp.Regexp, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	m := pattern.FindStringSubmatch(value)
+	if m == nil {
+		return "", fmt.Errorf("invalid mention %q", value)
+	}
+	return m[1], nil
+}
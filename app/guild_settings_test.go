@@ -0,0 +1,162 @@
+/* guild_settings_test.go
+ * Contains unit tests for guild_settings.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region GetGuildSettings tests
+
+func TestGetGuildSettings_DefaultsForUnconfiguredGuild(t *testing.T) {
+	a := &App{Store: NewMockStore("swiss", "test_round")}
+
+	settings, err := a.GetGuildSettings(context.Background(), "guild1")
+	require.NoError(t, err)
+	assert.Equal(t, "guild1", settings.GuildID)
+	assert.Equal(t, DefaultPrefix, settings.Prefix)
+	assert.Equal(t, DefaultLocale, settings.Locale)
+	assert.Equal(t, DefaultTimezone, settings.Timezone)
+}
+
+func TestGetGuildSettings_KeepsConfiguredValues(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", Prefix: "!", Locale: "pl"}
+	a := &App{Store: mockStore}
+
+	settings, err := a.GetGuildSettings(context.Background(), "guild1")
+	require.NoError(t, err)
+	assert.Equal(t, "!", settings.Prefix)
+	assert.Equal(t, "pl", settings.Locale)
+	assert.Equal(t, DefaultTimezone, settings.Timezone)
+}
+
+func TestGetGuildSettings_Error(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GetGuildSettingsError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetGuildSettings(context.Background(), "guild1")
+	assert.Error(t, err)
+}
+
+func TestListGuildSettings_AppliesDefaults(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", AnnouncementChannel: "chan1"}
+	a := &App{Store: mockStore}
+
+	all, err := a.ListGuildSettings(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, DefaultPrefix, all[0].Prefix)
+	assert.Equal(t, "chan1", all[0].AnnouncementChannel)
+
+	mockStore.FetchAllGuildSettingsError = errors.New("db down")
+	_, err = a.ListGuildSettings(context.Background())
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region SetGuildSetting tests
+
+func TestSetGuildSetting_ValidValues(t *testing.T) {
+	cases := []struct {
+		key, value string
+		check      func(t *testing.T, s store.GuildSettings)
+	}{
+		{"prefix", "!", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "!", s.Prefix) }},
+		{"announcement_channel", "<#123>", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "123", s.AnnouncementChannel) }},
+		{"reminder_channel", "456", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "456", s.ReminderChannel) }},
+		{"admin_role", "<@&789>", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "789", s.AdminRoleID) }},
+		{"locale", "pt", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "pt", s.Locale) }},
+		{"timezone", "Europe/Warsaw", func(t *testing.T, s store.GuildSettings) { assert.Equal(t, "Europe/Warsaw", s.Timezone) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			mockStore := NewMockStore("swiss", "test_round")
+			a := &App{Store: mockStore}
+
+			settings, err := a.SetGuildSetting(context.Background(), "guild1", tc.key, tc.value)
+			require.NoError(t, err)
+			tc.check(t, settings)
+			tc.check(t, mockStore.GuildSettings["guild1"])
+		})
+	}
+}
+
+func TestSetGuildSetting_InvalidValues(t *testing.T) {
+	cases := map[string]struct {
+		key, value string
+		kind       GuildSettingErrorKind
+	}{
+		"prefix too long":     {"prefix", "!!!!", GuildSettingInvalidPrefix},
+		"prefix with space":   {"prefix", "a b", GuildSettingInvalidPrefix},
+		"channel not mention": {"announcement_channel", "general", GuildSettingInvalidChannel},
+		"role not mention":    {"admin_role", "@admins", GuildSettingInvalidRole},
+		"unsupported locale":  {"locale", "xx", GuildSettingUnsupportedLocale},
+		"unknown timezone":    {"timezone", "Mars/Olympus", GuildSettingUnknownTimezone},
+		"unknown key":         {"colour", "red", GuildSettingUnknownKey},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockStore := NewMockStore("swiss", "test_round")
+			a := &App{Store: mockStore}
+
+			_, err := a.SetGuildSetting(context.Background(), "guild1", tc.key, tc.value)
+			var settingErr *GuildSettingError
+			require.ErrorAs(t, err, &settingErr)
+			assert.Equal(t, tc.kind, settingErr.Kind)
+			assert.Empty(t, mockStore.GuildSettings)
+		})
+	}
+}
+
+func TestSetGuildSetting_EmptyValueResets(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", Prefix: "!", ReminderChannel: "456"}
+	a := &App{Store: mockStore}
+
+	settings, err := a.SetGuildSetting(context.Background(), "guild1", "prefix", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPrefix, settings.Prefix)
+	assert.Equal(t, "456", settings.ReminderChannel)
+	assert.Empty(t, mockStore.GuildSettings["guild1"].Prefix)
+}
+
+func TestSetGuildSetting_StoreErrors(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GetGuildSettingsError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.SetGuildSetting(context.Background(), "guild1", "prefix", "!")
+	assert.Error(t, err)
+
+	mockStore.GetGuildSettingsError = nil
+	mockStore.StoreGuildSettingsError = errors.New("db down")
+	_, err = a.SetGuildSetting(context.Background(), "guild1", "prefix", "!")
+	assert.Error(t, err)
+	var settingErr *GuildSettingError
+	assert.False(t, errors.As(err, &settingErr))
+}
+
+// endregion
+
+// region GuildLocation tests
+
+func TestGuildLocation(t *testing.T) {
+	assert.Equal(t, "Europe/Warsaw", GuildLocation(store.GuildSettings{Timezone: "Europe/Warsaw"}).String())
+	assert.Equal(t, time.UTC, GuildLocation(store.GuildSettings{Timezone: "Mars/Olympus"}))
+}
+
+// endregion
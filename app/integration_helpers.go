@@ -4,10 +4,16 @@ package app
 
 import "golang.org/x/time/rate"
 
-// SetRateLimiterForTesting replaces the App's rate limiter with the given one.
+// SetRateLimiterForTesting gives the App's rate limiter the limit and burst of the given one.
 // Only compiled under the integration build tag; used by integration tests in
 // other packages that need to override rate limiting without touching unexported
 // fields directly.
 func SetRateLimiterForTesting(a *App, l *rate.Limiter) {
-	a.rateLimiter = l
+	if a.rateLimiter == nil {
+		a.rateLimiter = l
+		return
+	}
+	// The fetchers hold the App's limiter, so change it in place rather than replacing it
+	a.rateLimiter.SetLimit(l.Limit())
+	a.rateLimiter.SetBurst(l.Burst())
 }
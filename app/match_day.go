@@ -0,0 +1,117 @@
+/* match_day.go
+ * Contains the logic for building the "match day" view: today's live matches, today's finished matches with
+ * scores and the next matches to start. The bot renders this into a message it edits in place.
+ */
+
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+)
+
+// matchDayUpcomingLimit caps how many upcoming matches the match day view lists
+const matchDayUpcomingLimit = 5
+
+// MatchDayLayout is the date layout used to identify a match day
+const MatchDayLayout = "2006-01-02"
+
+// MatchDay is a snapshot of a single day of the current round
+type MatchDay struct {
+	Day      string // local date, formatted with MatchDayLayout
+	Live     []sources.ScheduledMatch
+	Finished []MatchDayResult
+	Upcoming []sources.ScheduledMatch
+}
+
+// MatchDayResult is a finished match with its result, when the result is known
+type MatchDayResult struct {
+	sources.ScheduledMatch
+	Winner string
+	Score  string
+}
+
+// GetMatchDay builds the match day view for the day containing now, in now's location. Upcoming matches are
+// not limited to today so the view always shows when play resumes.
+func (a *App) GetMatchDay(ctx context.Context, now time.Time) (MatchDay, error) {
+	schedule, err := a.Store.FetchMatchSchedule(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return MatchDay{}, err
+	}
+	nodes, _, err := a.MatchNodes(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return MatchDay{}, err
+	}
+	results := make(map[[2]string]sources.MatchNode, len(nodes))
+	for _, n := range nodes {
+		results[teamPair(n.Team1, n.Team2)] = n
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	day := MatchDay{Day: dayStart.Format(MatchDayLayout)}
+
+	for _, m := range schedule {
+		if m.EpochTime <= 0 {
+			continue
+		}
+		start := time.Unix(m.EpochTime, 0)
+		switch {
+		case m.Finished:
+			if start.Before(dayStart) || !start.Before(dayEnd) {
+				continue
+			}
+			result := MatchDayResult{ScheduledMatch: m}
+			if n, ok := results[teamPair(m.Team1, m.Team2)]; ok {
+				result.Winner = n.Winner
+				result.Score = n.Score
+			}
+			day.Finished = append(day.Finished, result)
+		case m.Live || start.Before(now):
+			// Same inference as GetUpcomingMatches for sources without an explicit live flag
+			m.Live = true
+			day.Live = append(day.Live, m)
+		case m.Team1 != "TBD" && m.Team2 != "TBD":
+			day.Upcoming = append(day.Upcoming, m)
+		}
+	}
+
+	byStart := func(x, y sources.ScheduledMatch) int { return cmp.Compare(x.EpochTime, y.EpochTime) }
+	slices.SortFunc(day.Live, byStart)
+	slices.SortFunc(day.Upcoming, byStart)
+	slices.SortFunc(day.Finished, func(x, y MatchDayResult) int { return byStart(x.ScheduledMatch, y.ScheduledMatch) })
+	if len(day.Upcoming) > matchDayUpcomingLimit {
+		day.Upcoming = day.Upcoming[:matchDayUpcomingLimit]
+	}
+	return day, nil
+}
+
+// teamPair returns an order-independent key for a match between two teams
+func teamPair(team1, team2 string) [2]string {
+	if team2 < team1 {
+		team1, team2 = team2, team1
+	}
+	return [2]string{team1, team2}
+}
+
+// GetMatchDayMessages returns the match day message of every guild for the current round.
+func (a *App) GetMatchDayMessages(ctx context.Context) ([]store.MatchDayMessage, error) {
+	return a.Store.FetchMatchDayMessages(ctx)
+}
+
+// SaveMatchDayMessage records the match day message a guild should have edited in place.
+func (a *App) SaveMatchDayMessage(ctx context.Context, message store.MatchDayMessage) error {
+	message.Round = a.Store.GetRound()
+	return a.Store.StoreMatchDayMessage(ctx, message)
+}
+
+// RemoveMatchDayMessage stops updating a guild's match day message.
+func (a *App) RemoveMatchDayMessage(ctx context.Context, guildID string) error {
+	return a.Store.DeleteMatchDayMessage(ctx, guildID)
+}
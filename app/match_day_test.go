@@ -0,0 +1,134 @@
+/* match_day_test.go
+ * Contains unit tests for match_day.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// region GetMatchDay tests
+
+func TestGetMatchDay_SplitsLiveFinishedAndUpcoming(t *testing.T) {
+	now := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "Team B", EpochTime: now.Add(-4 * time.Hour).Unix(), Finished: true},
+		{Team1: "Team Y", Team2: "Team Z", EpochTime: now.Add(-40 * time.Hour).Unix(), Finished: true}, // yesterday
+		{Team1: "Team C", Team2: "Team D", EpochTime: now.Add(-time.Hour).Unix()},                      // live by clock
+		{Team1: "Team E", Team2: "Team F", EpochTime: now.Add(time.Hour).Unix(), Live: true},           // live by flag
+		{Team1: "Team G", Team2: "Team H", EpochTime: now.Add(20 * time.Hour).Unix()},
+		{Team1: "TBD", Team2: "TBD", EpochTime: now.Add(30 * time.Hour).Unix()},
+	})
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team B", Team2: "Team A", Winner: "Team B", Score: "2-1"}}
+	a := &App{Store: mockStore}
+
+	day, err := a.GetMatchDay(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-06-01", day.Day)
+
+	require.Len(t, day.Finished, 1)
+	assert.Equal(t, "Team B", day.Finished[0].Winner)
+	assert.Equal(t, "2-1", day.Finished[0].Score)
+
+	require.Len(t, day.Live, 2)
+	assert.Equal(t, "Team C", day.Live[0].Team1)
+	assert.True(t, day.Live[0].Live)
+
+	require.Len(t, day.Upcoming, 1)
+	assert.Equal(t, "Team G", day.Upcoming[0].Team1)
+}
+
+func TestGetMatchDay_LimitsUpcoming(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	var schedule []sources.ScheduledMatch
+	for i := range 8 {
+		schedule = append(schedule, sources.ScheduledMatch{Team1: "Team A", Team2: "Team B", EpochTime: now.Add(time.Duration(i+1) * time.Hour).Unix()})
+	}
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches(schedule)
+	a := &App{Store: mockStore}
+
+	day, err := a.GetMatchDay(context.Background(), now)
+	require.NoError(t, err)
+	assert.Len(t, day.Upcoming, matchDayUpcomingLimit)
+}
+
+func TestGetMatchDay_NoDocumentsIsEmpty(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchScheduleError = mongo.ErrNoDocuments
+	mockStore.FetchMatchNodesFromDbError = mongo.ErrNoDocuments
+	a := &App{Store: mockStore}
+
+	day, err := a.GetMatchDay(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, day.Live)
+	assert.Empty(t, day.Finished)
+	assert.Empty(t, day.Upcoming)
+}
+
+func TestGetMatchDay_Errors(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchScheduleError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetMatchDay(context.Background(), time.Now())
+	assert.Error(t, err)
+
+	mockStore.FetchMatchScheduleError = nil
+	mockStore.FetchMatchNodesFromDbError = errors.New("db down")
+	_, err = a.GetMatchDay(context.Background(), time.Now())
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region match day message tests
+
+func TestMatchDayMessages_SaveFetchRemove(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.SaveMatchDayMessage(context.Background(), store.MatchDayMessage{GuildID: "guild1", ChannelID: "channel1", MessageID: "msg1"}))
+	messages, err := a.GetMatchDayMessages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "test_round", messages[0].Round)
+
+	require.NoError(t, a.RemoveMatchDayMessage(context.Background(), "guild1"))
+	messages, err = a.GetMatchDayMessages(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+// endregion
+
+func TestGetMatchDay_UsesLocationOfNow(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// 23:30 UTC on 1 June is already 2 June in Warsaw
+	now := time.Date(2026, 6, 1, 23, 30, 0, 0, time.UTC)
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "Team B", EpochTime: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC).Unix(), Finished: true},
+		{Team1: "Team C", Team2: "Team D", EpochTime: time.Date(2026, 6, 1, 22, 15, 0, 0, time.UTC).Unix(), Finished: true},
+	})
+	a := &App{Store: mockStore}
+
+	day, err := a.GetMatchDay(context.Background(), now.In(warsaw))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-06-02", day.Day)
+	require.Len(t, day.Finished, 1)
+	assert.Equal(t, "Team C", day.Finished[0].Team1)
+}
@@ -5,6 +5,8 @@
 
 package app
 
+import "pickems-bot/store"
+
 // ScoreResult represents the outcome of score calculation for a user's predictions
 type ScoreResult struct {
 	Successes int
@@ -22,8 +24,10 @@ type TournamentInfo struct {
 
 // LeaderboardUser represents a single user on the leaderboard
 type LeaderboardUser struct {
+	UserID    string
 	Username  string
 	Rank      int
+	Score     int
 	Successes int
 	Failures  int
 }
@@ -33,3 +37,16 @@ type Team struct {
 	Name       string
 	VRSRanking int
 }
+
+// TeamRanking is a team's latest VRS entry along with its standing in earlier releases of the rankings. A zero
+// standing means the team wasn't ranked in that release, and an empty date that there is no such release.
+type TeamRanking struct {
+	store.VRSEntry
+	// PreviousStanding is the team's standing in the release before the latest one, dated PreviousDate
+	PreviousStanding int
+	PreviousDate     string
+	// StartStanding is the team's standing in the last release on or before the day the tournament started, dated
+	// StartDate
+	StartStanding int
+	StartDate     string
+}
@@ -0,0 +1,157 @@
+/* overrides.go
+ * Contains the app logic for manual result overrides: pinning or clearing the result of a match node, and
+ * reading match nodes with the overrides applied.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+)
+
+// overrideScorePattern matches a series or map score such as "2-1" or "13-10"
+var overrideScorePattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// OverrideErrorKind identifies which check the arguments given to SetResultOverride failed
+type OverrideErrorKind int
+
+const (
+	// OverrideUnknownMatch means MatchID is not a match node in the current round
+	OverrideUnknownMatch OverrideErrorKind = iota
+	// OverrideInvalidWinner means the winner is neither of Teams, the match's two teams
+	OverrideInvalidWinner
+	// OverrideInvalidScore means Score is not of the form 2-1
+	OverrideInvalidScore
+)
+
+// OverrideError is returned by SetResultOverride when the match, winner or score fails validation
+type OverrideError struct {
+	Kind    OverrideErrorKind
+	MatchID string
+	Teams   [2]string
+	Score   string
+}
+
+// Error describes the validation failure in English
+func (e *OverrideError) Error() string {
+	switch e.Kind {
+	case OverrideUnknownMatch:
+		return fmt.Sprintf("no match with ID '%s' in the current round", e.MatchID)
+	case OverrideInvalidWinner:
+		return fmt.Sprintf("winner must be '%s' or '%s'", e.Teams[0], e.Teams[1])
+	case OverrideInvalidScore:
+		return fmt.Sprintf("score must look like 2-1, got '%s'", e.Score)
+	}
+	return "invalid override"
+}
+
+// OverriddenMatch pairs an override with the match node it applies to, as reported by the data source
+type OverriddenMatch struct {
+	Override store.ResultOverride
+	Source   sources.MatchNode // zero value if the source no longer reports the match
+}
+
+// MatchNodes returns the stored match nodes for the current round with any result overrides applied. Use this
+// instead of Store.FetchMatchNodesFromDb for anything users see.
+func (a *App) MatchNodes(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
+	nodes, kind, err := a.Store.FetchMatchNodesFromDb(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	overrides, err := a.Store.FetchResultOverrides(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return store.ApplyResultOverrides(nodes, overrides), kind, nil
+}
+
+// GetResultOverrides returns the current round's overrides along with the source's view of each match.
+func (a *App) GetResultOverrides(ctx context.Context) ([]OverriddenMatch, error) {
+	overrides, err := a.Store.FetchResultOverrides(ctx)
+	if err != nil || len(overrides) == 0 {
+		return nil, err
+	}
+	nodes, _, err := a.Store.FetchMatchNodesFromDb(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]sources.MatchNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+	matches := make([]OverriddenMatch, 0, len(overrides))
+	for _, o := range overrides {
+		matches = append(matches, OverriddenMatch{Override: o, Source: byID[o.MatchID]})
+	}
+	return matches, nil
+}
+
+// SetResultOverride pins the winner, and optionally the score, of a match node until the data source agrees.
+// winner is matched case-insensitively against the node's teams. The stored results and leaderboard are
+// rebuilt immediately. Invalid arguments return an *OverrideError.
+func (a *App) SetResultOverride(ctx context.Context, matchID, winner, score, setBy string) (store.ResultOverride, error) {
+	nodes, _, err := a.Store.FetchMatchNodesFromDb(ctx)
+	if err != nil {
+		return store.ResultOverride{}, err
+	}
+	var node *sources.MatchNode
+	for i := range nodes {
+		if nodes[i].ID == matchID {
+			node = &nodes[i]
+			break
+		}
+	}
+	if node == nil {
+		return store.ResultOverride{}, &OverrideError{Kind: OverrideUnknownMatch, MatchID: matchID}
+	}
+
+	switch {
+	case strings.EqualFold(winner, node.Team1):
+		winner = node.Team1
+	case strings.EqualFold(winner, node.Team2):
+		winner = node.Team2
+	default:
+		return store.ResultOverride{}, &OverrideError{Kind: OverrideInvalidWinner, MatchID: matchID, Teams: [2]string{node.Team1, node.Team2}}
+	}
+	if score != "" && !overrideScorePattern.MatchString(score) {
+		return store.ResultOverride{}, &OverrideError{Kind: OverrideInvalidScore, MatchID: matchID, Score: score}
+	}
+
+	override := store.ResultOverride{
+		MatchID:   matchID,
+		Round:     a.Store.GetRound(),
+		Winner:    winner,
+		Score:     score,
+		SetBy:     setBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := a.Store.StoreResultOverride(ctx, override); err != nil {
+		return store.ResultOverride{}, err
+	}
+	return override, a.rescoreWithOverrides(ctx)
+}
+
+// ClearResultOverride removes the override for a match and rebuilds the results from the source data. Returns
+// store.ErrNotFound when the match has no override.
+func (a *App) ClearResultOverride(ctx context.Context, matchID string) error {
+	if err := a.Store.DeleteResultOverride(ctx, matchID); err != nil {
+		return err
+	}
+	return a.rescoreWithOverrides(ctx)
+}
+
+// rescoreWithOverrides rebuilds the stored results from the stored nodes and overrides, then the leaderboard
+func (a *App) rescoreWithOverrides(ctx context.Context) error {
+	if err := a.Store.RebuildMatchResults(ctx); err != nil {
+		return fmt.Errorf("failed to rebuild match results: %w", err)
+	}
+	return a.GenerateLeaderboard(ctx)
+}
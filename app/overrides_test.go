@@ -0,0 +1,165 @@
+/* overrides_test.go
+ * Contains unit tests for overrides.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// newOverrideStore returns a mock store with two stored match nodes and one prediction, so rescoring succeeds
+func newOverrideStore() *MockStore {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B", Score: "0-2", Section: "Round 1"},
+		{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "Team C", Score: "2-1", Section: "Round 1"},
+	}
+	mockStore.MatchKind = "swiss"
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	return mockStore
+}
+
+// region MatchNodes tests
+
+func TestMatchNodes_AppliesOverrides(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A", Score: "2-0"}
+	a := &App{Store: mockStore}
+
+	nodes, kind, err := a.MatchNodes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "swiss", string(kind))
+	assert.Equal(t, "Team A", nodes[0].Winner)
+	assert.Equal(t, "2-0", nodes[0].Score)
+	assert.Equal(t, "Team C", nodes[1].Winner)
+	assert.Equal(t, "Team B", mockStore.MatchNodes[0].Winner, "stored nodes must stay raw")
+}
+
+func TestMatchNodes_Errors(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.FetchResultOverridesError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, _, err := a.MatchNodes(context.Background())
+	assert.Error(t, err)
+
+	mockStore.FetchResultOverridesError = nil
+	mockStore.FetchMatchNodesFromDbError = errors.New("db down")
+	_, _, err = a.MatchNodes(context.Background())
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region GetResultOverrides tests
+
+func TestGetResultOverrides_IncludesSourceNode(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A"}
+	mockStore.Overrides["gone"] = store.ResultOverride{MatchID: "gone", Winner: "Team Z"}
+	a := &App{Store: mockStore}
+
+	matches, err := a.GetResultOverrides(context.Background())
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	for _, m := range matches {
+		if m.Override.MatchID == "m1" {
+			assert.Equal(t, "Team B", m.Source.Winner)
+		} else {
+			assert.Empty(t, m.Source.ID)
+		}
+	}
+}
+
+func TestGetResultOverrides_NoneSkipsNodeLookup(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.FetchMatchNodesFromDbError = errors.New("should not be called")
+	a := &App{Store: mockStore}
+
+	matches, err := a.GetResultOverrides(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+// endregion
+
+// region SetResultOverride tests
+
+func TestSetResultOverride_Success(t *testing.T) {
+	mockStore := newOverrideStore()
+	a := &App{Store: mockStore}
+
+	override, err := a.SetResultOverride(context.Background(), "m1", "team a", "2-0", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "Team A", override.Winner, "winner is canonicalised to the node's team name")
+	assert.Equal(t, "test_round", override.Round)
+	assert.Equal(t, "admin", override.SetBy)
+	assert.Equal(t, override, mockStore.Overrides["m1"])
+	assert.Equal(t, 1, mockStore.RebuildMatchResultsCallCount)
+	assert.Len(t, mockStore.Leaderboard, 1)
+}
+
+func TestSetResultOverride_Validation(t *testing.T) {
+	cases := map[string]struct {
+		args [3]string
+		kind OverrideErrorKind
+	}{
+		"unknown match":     {[3]string{"m9", "Team A", ""}, OverrideUnknownMatch},
+		"winner not in it":  {[3]string{"m1", "Team C", ""}, OverrideInvalidWinner},
+		"malformed score":   {[3]string{"m1", "Team A", "two-nil"}, OverrideInvalidScore},
+		"score with spaces": {[3]string{"m1", "Team A", "2 - 0"}, OverrideInvalidScore},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockStore := newOverrideStore()
+			a := &App{Store: mockStore}
+
+			_, err := a.SetResultOverride(context.Background(), tc.args[0], tc.args[1], tc.args[2], "admin")
+			var overrideErr *OverrideError
+			require.ErrorAs(t, err, &overrideErr)
+			assert.Equal(t, tc.kind, overrideErr.Kind)
+			assert.Empty(t, mockStore.Overrides)
+			assert.Zero(t, mockStore.RebuildMatchResultsCallCount)
+		})
+	}
+}
+
+func TestSetResultOverride_RebuildError(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.RebuildMatchResultsError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.SetResultOverride(context.Background(), "m1", "Team A", "", "admin")
+	assert.ErrorContains(t, err, "failed to rebuild match results")
+}
+
+// endregion
+
+// region ClearResultOverride tests
+
+func TestClearResultOverride(t *testing.T) {
+	mockStore := newOverrideStore()
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A"}
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.ClearResultOverride(context.Background(), "m1"))
+	assert.Empty(t, mockStore.Overrides)
+	assert.Equal(t, 1, mockStore.RebuildMatchResultsCallCount)
+
+	assert.ErrorIs(t, a.ClearResultOverride(context.Background(), "m1"), mongo.ErrNoDocuments)
+	assert.Equal(t, 1, mockStore.RebuildMatchResultsCallCount)
+}
+
+// endregion
@@ -0,0 +1,52 @@
+/* prediction_errors.go
+ * Contains PredictionError, the validation errors SetUserPrediction returns for the teams a user entered.
+ */
+
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PredictionErrorKind identifies which check the teams given to SetUserPrediction failed
+type PredictionErrorKind int
+
+const (
+	// PredictionWrongCount means the wrong number of teams was given; Expected and Got hold the counts
+	PredictionWrongCount PredictionErrorKind = iota
+	// PredictionInvalidTeams means Teams matched no team in the tournament
+	PredictionInvalidTeams
+	// PredictionDuplicateTeam means Teams[0] was entered more than once
+	PredictionDuplicateTeam
+	// PredictionAmbiguousTeams means the names Teams[0] and Teams[1] both resolved to the team Teams[2]
+	PredictionAmbiguousTeams
+)
+
+// PredictionError is returned by SetUserPrediction when the teams a user entered fail validation. It carries the
+// counts and team names involved so callers can describe the problem in the user's language.
+type PredictionError struct {
+	Kind     PredictionErrorKind
+	Expected int
+	Got      int
+	Teams    []string
+}
+
+// Error describes the validation failure in English
+func (e *PredictionError) Error() string {
+	switch e.Kind {
+	case PredictionWrongCount:
+		return fmt.Sprintf("incorrect number of teams arguments, expected %d but got %d", e.Expected, e.Got)
+	case PredictionInvalidTeams:
+		quoted := make([]string, len(e.Teams))
+		for i, team := range e.Teams {
+			quoted[i] = fmt.Sprintf("'%s'", team)
+		}
+		return "the following team names are invalid: " + strings.Join(quoted, " ")
+	case PredictionDuplicateTeam:
+		return fmt.Sprintf("'%s' entered multiple times, stored prediction was not updated", e.Teams[0])
+	case PredictionAmbiguousTeams:
+		return fmt.Sprintf("'%s' and '%s' both resolved to '%s'. Please enter a more specific name for one of them", e.Teams[0], e.Teams[1], e.Teams[2])
+	}
+	return "invalid prediction"
+}
@@ -0,0 +1,52 @@
+/* recent.go
+ * Contains the logic behind $recent: the current round's matches that finished within a recent window, with
+ * their scores.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+)
+
+// GetRecentResults returns the current round's finished matches that ended at or after since, newest first,
+// with result overrides applied. Matches whose source gave no finish time fall back to their scheduled start;
+// matches with neither are left out.
+func (a *App) GetRecentResults(ctx context.Context, since time.Time) ([]sources.MatchNode, error) {
+	nodes, _, err := a.MatchNodes(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	schedule, err := a.Store.FetchMatchSchedule(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	starts := make(map[[2]string]time.Time, len(schedule))
+	for _, m := range schedule {
+		if m.Finished && m.EpochTime > 0 {
+			starts[teamPair(m.Team1, m.Team2)] = time.Unix(m.EpochTime, 0).UTC()
+		}
+	}
+
+	var recent []sources.MatchNode
+	for _, node := range nodes {
+		if node.Winner == "" || node.Winner == "TBD" {
+			continue
+		}
+		if node.FinishedAt.IsZero() {
+			node.FinishedAt = starts[teamPair(node.Team1, node.Team2)]
+		}
+		if node.FinishedAt.IsZero() || node.FinishedAt.Before(since) {
+			continue
+		}
+		recent = append(recent, node)
+	}
+	slices.SortStableFunc(recent, func(x, y sources.MatchNode) int { return y.FinishedAt.Compare(x.FinishedAt) })
+	return recent, nil
+}
@@ -0,0 +1,75 @@
+/* recent_test.go
+ * Contains unit tests for recent.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region GetRecentResults tests
+
+func TestGetRecentResults_FiltersAndSorts(t *testing.T) {
+	now := time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC)
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team E", Team2: "Team F", EpochTime: now.Add(-3 * time.Hour).Unix(), Finished: true},
+		{Team1: "Team G", Team2: "Team H", EpochTime: now.Add(-2 * time.Hour).Unix()}, // not finished per the schedule
+	})
+	mockStore.MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-0", FinishedAt: now.Add(-5 * time.Hour)},
+		{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "Team D", Score: "1-2", FinishedAt: now.Add(-time.Hour)},
+		{ID: "m3", Team1: "Team F", Team2: "Team E", Winner: "Team E", Score: "0-2"},                 // start time from the schedule
+		{ID: "m4", Team1: "Team G", Team2: "Team H", Winner: "Team G", Score: "2-1"},                 // no time at all
+		{ID: "m5", Team1: "Team I", Team2: "Team J", Winner: "TBD", FinishedAt: now.Add(-time.Hour)}, // unfinished
+		{ID: "m6", Team1: "Team K", Team2: "Team L", Winner: "Team K", Score: "2-0", FinishedAt: now.Add(-30 * time.Hour)},
+	}
+	a := &App{Store: mockStore}
+
+	recent, err := a.GetRecentResults(context.Background(), now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, n := range recent {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"m2", "m3", "m1"}, ids)
+	assert.Equal(t, now.Add(-3*time.Hour), recent[1].FinishedAt)
+}
+
+func TestGetRecentResults_AppliesOverrides(t *testing.T) {
+	now := time.Now()
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-0", FinishedAt: now.Add(-time.Hour)},
+	}
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team B", Score: "0-2"}
+	a := &App{Store: mockStore}
+
+	recent, err := a.GetRecentResults(context.Background(), now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "Team B", recent[0].Winner)
+	assert.Equal(t, "0-2", recent[0].Score)
+}
+
+func TestGetRecentResults_ScheduleError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchScheduleError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetRecentResults(context.Background(), time.Now())
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
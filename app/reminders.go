@@ -0,0 +1,255 @@
+/* reminders.go
+ * Contains the logic for deciding which users should be DM'd a "set your Pick'Ems" reminder before the
+ * current round locks, and which guild reminder channels should get a notice. Sending is the bot's
+ * responsibility.
+ */
+
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+)
+
+// Reminder is a pending pre-lock reminder for a single user
+type Reminder struct {
+	UserID   string
+	GuildID  string // guild the user last used the bot in, empty if unknown
+	Round    string
+	Lead     time.Duration // configured lead time this reminder satisfies
+	LockTime time.Time     // start time of the round's first scheduled match
+}
+
+// ChannelReminder is a pending pre-lock notice for a guild's reminder channel
+type ChannelReminder struct {
+	GuildID      string
+	ChannelID    string
+	Round        string
+	Lead         time.Duration
+	LockTime     time.Time
+	MissingPicks int // number of known users who still haven't set picks
+}
+
+// TrackUser records that the given user has interacted with the bot, making them eligible for reminders.
+func (a *App) TrackUser(ctx context.Context, user models.User) error {
+	return a.Store.TrackUser(ctx, user)
+}
+
+// SetRemindersEnabled opts a user in to or out of reminder DMs.
+func (a *App) SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error {
+	return a.Store.SetRemindersEnabled(ctx, userID, enabled)
+}
+
+// GetRoundLockTime returns the start time of the current round's first scheduled match, which is when
+// predictions should be in. Matches with placeholder (pre-epoch) start times are ignored.
+func (a *App) GetRoundLockTime(ctx context.Context) (time.Time, error) {
+	matches, err := a.Store.FetchMatchSchedule(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return firstMatchTime(matches)
+}
+
+// firstMatchTime returns the earliest real start time in a schedule.
+func firstMatchTime(matches []sources.ScheduledMatch) (time.Time, error) {
+	var first int64
+	for _, m := range matches {
+		if m.EpochTime <= 0 {
+			continue
+		}
+		if first == 0 || m.EpochTime < first {
+			first = m.EpochTime
+		}
+	}
+	if first == 0 {
+		return time.Time{}, fmt.Errorf("no scheduled matches with a start time")
+	}
+	return time.Unix(first, 0), nil
+}
+
+// DueReminders returns the reminders that should be sent at now for the given lead times. A user is due a
+// reminder when they have interacted with the bot or predicted in a previous round, haven't opted out,
+// haven't stored a prediction for the current round and haven't already been reminded for this lead time.
+// When several lead times have elapsed (e.g. the bot was offline) only the closest one is used, so users
+// are never sent a burst of reminders at once.
+func (a *App) DueReminders(ctx context.Context, leadTimes []time.Duration, now time.Time) ([]Reminder, error) {
+	lead, lockTime, ok, err := a.reminderWindow(ctx, leadTimes, now)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	candidates, err := a.usersWithoutPicks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := a.Store.FetchSentReminders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range sent {
+		if r.Lead == lead.String() {
+			delete(candidates, r.UserID)
+		}
+	}
+
+	round := a.Store.GetRound()
+	reminders := make([]Reminder, 0, len(candidates))
+	for userID, guildID := range candidates {
+		reminders = append(reminders, Reminder{UserID: userID, GuildID: guildID, Round: round, Lead: lead, LockTime: lockTime})
+	}
+	slices.SortFunc(reminders, func(x, y Reminder) int {
+		return cmp.Compare(x.UserID, y.UserID)
+	})
+	return reminders, nil
+}
+
+// DueChannelReminders returns the reminder notices that should be posted at now to each guild's configured
+// reminder channel. Each guild gets at most one notice per lead time per round.
+func (a *App) DueChannelReminders(ctx context.Context, leadTimes []time.Duration, now time.Time) ([]ChannelReminder, error) {
+	lead, lockTime, ok, err := a.reminderWindow(ctx, leadTimes, now)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	guilds, err := a.Store.FetchAllGuildSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := a.Store.FetchSentReminders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alreadySent := make(map[string]bool)
+	for _, r := range sent {
+		if r.GuildID != "" && r.Lead == lead.String() {
+			alreadySent[r.GuildID] = true
+		}
+	}
+
+	var due []ChannelReminder
+	for _, g := range guilds {
+		if g.ReminderChannel == "" || alreadySent[g.GuildID] {
+			continue
+		}
+		due = append(due, ChannelReminder{GuildID: g.GuildID, ChannelID: g.ReminderChannel, Round: a.Store.GetRound(), Lead: lead, LockTime: lockTime})
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	missing, err := a.usersWithoutPicks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range due {
+		due[i].MissingPicks = len(missing)
+	}
+	slices.SortFunc(due, func(x, y ChannelReminder) int {
+		return cmp.Compare(x.GuildID, y.GuildID)
+	})
+	return due, nil
+}
+
+// reminderWindow returns the smallest configured lead time whose window has opened at now, along with the
+// round's lock time. ok is false when no lead times are configured, the round has locked or no window has
+// opened yet.
+func (a *App) reminderWindow(ctx context.Context, leadTimes []time.Duration, now time.Time) (lead time.Duration, lockTime time.Time, ok bool, err error) {
+	if len(leadTimes) == 0 {
+		return 0, time.Time{}, false, nil
+	}
+
+	lockTime, err = a.GetRoundLockTime(ctx)
+	if err != nil {
+		return 0, time.Time{}, false, err
+	}
+	if !now.Before(lockTime) {
+		return 0, time.Time{}, false, nil
+	}
+
+	for _, l := range leadTimes {
+		if now.Before(lockTime.Add(-l)) {
+			continue
+		}
+		if lead == 0 || l < lead {
+			lead = l
+		}
+	}
+	return lead, lockTime, lead != 0, nil
+}
+
+// usersWithoutPicks returns the reminder candidates who haven't stored a prediction for the current round
+func (a *App) usersWithoutPicks(ctx context.Context) (map[string]string, error) {
+	candidates, err := a.reminderCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preds, err := a.Store.GetAllUserPredictions(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	for _, p := range preds {
+		delete(candidates, p.UserID)
+	}
+	return candidates, nil
+}
+
+// reminderCandidates returns the IDs of the users that may receive reminders: everyone the bot has seen
+// plus everyone who has predicted in any round, minus users who opted out. Each ID maps to the guild the
+// user last used the bot in, or "" if it isn't known.
+func (a *App) reminderCandidates(ctx context.Context) (map[string]string, error) {
+	profiles, err := a.Store.FetchUserProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pastPredictors, err := a.Store.FetchPredictionUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	optedOut := make(map[string]bool, len(profiles))
+	candidates := make(map[string]string, len(profiles)+len(pastPredictors))
+	for _, p := range profiles {
+		if p.RemindersOff {
+			optedOut[p.UserID] = true
+			continue
+		}
+		candidates[p.UserID] = p.GuildID
+	}
+	for _, id := range pastPredictors {
+		if _, seen := candidates[id]; !seen && !optedOut[id] {
+			candidates[id] = ""
+		}
+	}
+	return candidates, nil
+}
+
+// MarkChannelReminderSent records that a guild's reminder channel notice has been posted so it is not
+// posted again.
+func (a *App) MarkChannelReminderSent(ctx context.Context, r ChannelReminder) error {
+	return a.Store.StoreSentReminder(ctx, store.SentReminder{
+		GuildID: r.GuildID,
+		Round:   r.Round,
+		Lead:    r.Lead.String(),
+		SentAt:  time.Now().UTC(),
+	})
+}
+
+// MarkReminderSent records that a reminder has been delivered so it is not sent again.
+func (a *App) MarkReminderSent(ctx context.Context, r Reminder) error {
+	return a.Store.StoreSentReminder(ctx, store.SentReminder{
+		UserID: r.UserID,
+		Round:  r.Round,
+		Lead:   r.Lead.String(),
+		SentAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,254 @@
+/* reminders_test.go
+ * Contains unit tests for reminders.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReminderStore returns a MockStore whose round starts at lockTime
+func newReminderStore(lockTime time.Time) *MockStore {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "TBD", Team2: "TBD", EpochTime: -62167219200},
+		{Team1: "Team C", Team2: "Team D", EpochTime: lockTime.Add(time.Hour).Unix()},
+		{Team1: "Team A", Team2: "Team B", EpochTime: lockTime.Unix()},
+	})
+	return mockStore
+}
+
+var reminderLeads = []time.Duration{24 * time.Hour, time.Hour}
+
+// region GetRoundLockTime tests
+
+func TestGetRoundLockTime_IgnoresPlaceholderTimes(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	a := &App{Store: newReminderStore(lock)}
+
+	got, err := a.GetRoundLockTime(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, lock, got)
+}
+
+func TestGetRoundLockTime_NoRealTimes(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "TBD", Team2: "TBD", EpochTime: -62167219200}})
+	a := &App{Store: mockStore}
+
+	_, err := a.GetRoundLockTime(context.Background())
+	assert.Error(t, err)
+}
+
+// endregion
+
+// region DueReminders tests
+
+func TestDueReminders_OutsideWindow(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueReminders(context.Background(), reminderLeads, lock.Add(-48*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func TestDueReminders_AfterLock(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueReminders(context.Background(), reminderLeads, lock.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func TestDueReminders_NoLeadTimes(t *testing.T) {
+	a := &App{Store: newReminderStore(time.Now().Add(time.Minute))}
+
+	reminders, err := a.DueReminders(context.Background(), nil, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func TestDueReminders_SelectsCandidates(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.Profiles["seen"] = store.UserProfile{UserID: "seen", GuildID: "guild1"}
+	mockStore.Profiles["optedout"] = store.UserProfile{UserID: "optedout", RemindersOff: true}
+	mockStore.PastPredictorIDs = []string{"veteran", "optedout"}
+	mockStore.Predictions["alreadyset"] = models.Prediction{UserID: "alreadyset", Round: "test_round"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueReminders(context.Background(), reminderLeads, lock.Add(-12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, "seen", reminders[0].UserID)
+	assert.Equal(t, "veteran", reminders[1].UserID)
+	assert.Equal(t, "guild1", reminders[0].GuildID)
+	assert.Empty(t, reminders[1].GuildID)
+	assert.Equal(t, 24*time.Hour, reminders[0].Lead)
+	assert.Equal(t, lock, reminders[0].LockTime)
+	assert.Equal(t, "test_round", reminders[0].Round)
+}
+
+func TestDueReminders_UsesClosestElapsedLead(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueReminders(context.Background(), reminderLeads, lock.Add(-30*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, time.Hour, reminders[0].Lead)
+}
+
+func TestDueReminders_SkipsAlreadySent(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1"}
+	mockStore.Profiles["user2"] = store.UserProfile{UserID: "user2"}
+	mockStore.SentReminders = []store.SentReminder{
+		{UserID: "user1", Round: "test_round", Lead: time.Hour.String()},
+		{UserID: "user2", Round: "test_round", Lead: (24 * time.Hour).String()},
+	}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueReminders(context.Background(), reminderLeads, lock.Add(-30*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "user2", reminders[0].UserID)
+}
+
+func TestDueReminders_StoreErrors(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	now := lock.Add(-30 * time.Minute)
+	boom := errors.New("boom")
+
+	cases := map[string]func(m *MockStore){
+		"schedule":    func(m *MockStore) { m.FetchMatchScheduleError = boom },
+		"profiles":    func(m *MockStore) { m.FetchUserProfilesError = boom },
+		"predictors":  func(m *MockStore) { m.FetchPredictionUserIDsError = boom },
+		"predictions": func(m *MockStore) { m.GetAllUserPredictionsError = boom },
+		"sent":        func(m *MockStore) { m.FetchSentRemindersError = boom },
+	}
+	for name, inject := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockStore := newReminderStore(lock)
+			inject(mockStore)
+			a := &App{Store: mockStore}
+
+			_, err := a.DueReminders(context.Background(), reminderLeads, now)
+			assert.ErrorIs(t, err, boom)
+		})
+	}
+}
+
+// endregion
+
+// region MarkReminderSent / preference tests
+
+func TestMarkReminderSent_StoresLeadAsString(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	err := a.MarkReminderSent(context.Background(), Reminder{UserID: "user1", Round: "test_round", Lead: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, mockStore.SentReminders, 1)
+	assert.Equal(t, "1h0m0s", mockStore.SentReminders[0].Lead)
+}
+
+func TestTrackUser_And_SetRemindersEnabled(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.TrackUser(context.Background(), models.User{UserID: "user1", Username: "alice"}))
+	require.NoError(t, a.SetRemindersEnabled(context.Background(), "user1", false))
+
+	assert.Equal(t, "alice", mockStore.Profiles["user1"].Username)
+	assert.True(t, mockStore.Profiles["user1"].RemindersOff)
+}
+
+// endregion
+
+// region DueChannelReminders tests
+
+func TestDueChannelReminders_PostsToReminderChannels(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", ReminderChannel: "chan1"}
+	mockStore.GuildSettings["guild2"] = store.GuildSettings{GuildID: "guild2"} // no reminder channel
+	mockStore.GuildSettings["guild3"] = store.GuildSettings{GuildID: "guild3", ReminderChannel: "chan3"}
+	mockStore.SentReminders = []store.SentReminder{{GuildID: "guild3", Round: "test_round", Lead: time.Hour.String()}}
+	mockStore.Profiles["forgetful"] = store.UserProfile{UserID: "forgetful"}
+	mockStore.Profiles["diligent"] = store.UserProfile{UserID: "diligent"}
+	mockStore.Predictions["diligent"] = models.Prediction{UserID: "diligent", Round: "test_round"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueChannelReminders(context.Background(), reminderLeads, lock.Add(-30*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "guild1", reminders[0].GuildID)
+	assert.Equal(t, "chan1", reminders[0].ChannelID)
+	assert.Equal(t, time.Hour, reminders[0].Lead)
+	assert.Equal(t, 1, reminders[0].MissingPicks)
+}
+
+func TestDueChannelReminders_OutsideWindow(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	mockStore := newReminderStore(lock)
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", ReminderChannel: "chan1"}
+	a := &App{Store: mockStore}
+
+	reminders, err := a.DueChannelReminders(context.Background(), reminderLeads, lock.Add(-48*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func TestDueChannelReminders_StoreErrors(t *testing.T) {
+	lock := time.Unix(1750359600, 0)
+	boom := errors.New("boom")
+	cases := map[string]func(m *MockStore){
+		"guilds":   func(m *MockStore) { m.FetchAllGuildSettingsError = boom },
+		"sent":     func(m *MockStore) { m.FetchSentRemindersError = boom },
+		"profiles": func(m *MockStore) { m.FetchUserProfilesError = boom },
+	}
+	for name, inject := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockStore := newReminderStore(lock)
+			mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", ReminderChannel: "chan1"}
+			inject(mockStore)
+			a := &App{Store: mockStore}
+
+			_, err := a.DueChannelReminders(context.Background(), reminderLeads, lock.Add(-30*time.Minute))
+			assert.ErrorIs(t, err, boom)
+		})
+	}
+}
+
+func TestMarkChannelReminderSent(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.MarkChannelReminderSent(context.Background(), ChannelReminder{GuildID: "guild1", Round: "test_round", Lead: time.Hour}))
+	require.Len(t, mockStore.SentReminders, 1)
+	assert.Equal(t, "guild1", mockStore.SentReminders[0].GuildID)
+	assert.Empty(t, mockStore.SentReminders[0].UserID)
+}
+
+// endregion
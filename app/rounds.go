@@ -0,0 +1,168 @@
+/* rounds.go
+ * Contains the app logic for looking back at rounds other than the configured one: listing the rounds the
+ * database holds data for, and checking picks, leaderboards and results in a given round.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"pickems-bot/models"
+	"pickems-bot/scoring"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+)
+
+// ErrUnknownRound is returned when a round has no data stored
+var ErrUnknownRound = errors.New("unknown round")
+
+// GetRounds returns every round the database holds data for, sorted by name. The current round is always included.
+func (a *App) GetRounds(ctx context.Context) ([]string, error) {
+	rounds, err := a.Store.FetchRounds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current := a.Store.GetRound(); !slices.Contains(rounds, current) {
+		rounds = append(rounds, current)
+		slices.Sort(rounds)
+	}
+	return rounds, nil
+}
+
+// ResolveRound returns the stored name of round, matched case-insensitively against the known rounds. An empty
+// round resolves to the current round. Returns ErrUnknownRound if no data is stored for it.
+func (a *App) ResolveRound(ctx context.Context, round string) (string, error) {
+	if round == "" {
+		return a.Store.GetRound(), nil
+	}
+	rounds, err := a.GetRounds(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, known := range rounds {
+		if strings.EqualFold(known, round) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownRound, round)
+}
+
+// CheckPredictionForRound scores the given user's picks in the given round against that round's results.
+func (a *App) CheckPredictionForRound(ctx context.Context, user models.User, round string) (tournament.ScoreReport, error) {
+	round, err := a.ResolveRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	if round == a.Store.GetRound() {
+		return a.CheckPrediction(ctx, user)
+	}
+	doc, err := a.Store.GetUserPredictionForRound(ctx, user.UserID, round)
+	if err != nil {
+		return nil, err
+	}
+	return a.scoreInRound(ctx, doc, round)
+}
+
+// CheckPredictionByUsernameForRound looks up picks by username (case-insensitive), like CheckPredictionByUsername, in
+// the given round and scores them against that round's results.
+func (a *App) CheckPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.User, tournament.ScoreReport, error) {
+	round, err := a.ResolveRound(ctx, round)
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	if round == a.Store.GetRound() {
+		return a.CheckPredictionByUsername(ctx, username)
+	}
+	doc, err := a.findPredictionByUsername(ctx, username, round)
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	report, err := a.scoreInRound(ctx, doc, round)
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	return models.User{UserID: doc.UserID, Username: doc.Username}, report, nil
+}
+
+// scoreInRound scores a prediction against the stored results of the given round
+func (a *App) scoreInRound(ctx context.Context, doc models.Prediction, round string) (tournament.ScoreReport, error) {
+	results, err := a.Store.GetMatchResultsForRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scoring.CalculateUserScore(doc, results, aliases)
+}
+
+// GetRoundInfo returns the format and number of picks required in the given round. Other rounds take them from
+// their stored results, since their schedule has usually been replaced by the current round's.
+func (a *App) GetRoundInfo(ctx context.Context, round string) (TournamentInfo, error) {
+	round, err := a.ResolveRound(ctx, round)
+	if err != nil {
+		return TournamentInfo{}, err
+	}
+	if round == a.Store.GetRound() {
+		return a.GetTournamentInfo(ctx)
+	}
+	results, err := a.Store.GetMatchResultsForRound(ctx, round)
+	if err != nil {
+		return TournamentInfo{}, err
+	}
+	f, err := tournament.Get(results.GetType())
+	if err != nil {
+		return TournamentInfo{}, err
+	}
+	return TournamentInfo{
+		TournamentName: a.Store.GetDatabase().Name(),
+		Round:          round,
+		Format:         string(results.GetType()),
+		NumTeams:       f.RequiredPredictions(len(results.GetTeamNames())),
+	}, nil
+}
+
+// GetLeaderboardForRound returns the leaderboard stored for the given round, ranked the same way as GetLeaderboard.
+func (a *App) GetLeaderboardForRound(ctx context.Context, round string) ([]LeaderboardUser, error) {
+	round, err := a.ResolveRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := a.Store.FetchLeaderboardForRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	return rankLeaderboard(a.withCurrentDisplayNames(ctx, entries)), nil
+}
+
+// GetRoundResults returns the finished matches of the given round, in stored order, with that round's result
+// overrides applied.
+func (a *App) GetRoundResults(ctx context.Context, round string) ([]sources.MatchNode, error) {
+	round, err := a.ResolveRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	nodes, _, err := a.Store.FetchMatchNodesForRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := a.Store.FetchResultOverridesForRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	var finished []sources.MatchNode
+	for _, node := range store.ApplyResultOverrides(nodes, overrides) {
+		if node.Winner != "" && node.Winner != "TBD" {
+			finished = append(finished, node)
+		}
+	}
+	return finished, nil
+}
@@ -0,0 +1,157 @@
+/* rounds_test.go
+ * Contains unit tests for rounds.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRoundsStore returns a mock store on "stage_2" that also holds a finished Swiss "stage_1"
+func newRoundsStore() *MockStore {
+	mockStore := NewMockStore(tournament.Swiss, "stage_2")
+	mockStore.PastRounds = map[string]MockRound{
+		"stage_1": {
+			Predictions: map[string]models.Prediction{
+				"u1": {UserID: "u1", Username: "alice", Format: "swiss", Round: "stage_1",
+					Win:     []string{"Team A", "Team B"},
+					Advance: []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+					Lose:    []string{"Team I", "Team J"},
+				},
+			},
+			MatchResults: tournament.SwissResult{Round: "stage_1", Teams: map[string]string{
+				"Team A": "3-0", "Team B": "3-1", "Team C": "3-2", "Team D": "3-1",
+				"Team E": "2-3", "Team F": "3-2", "Team G": "1-3", "Team H": "3-2",
+				"Team I": "0-3", "Team J": "1-3", "Team K": "3-1", "Team L": "0-3",
+				"Team M": "2-3", "Team N": "2-3", "Team O": "1-3", "Team P": "2-3",
+			}},
+			MatchNodes: []sources.MatchNode{
+				{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-0"},
+				{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "TBD"},
+				{ID: "m3", Team1: "Team E", Team2: "Team F", Winner: "Team E"},
+			},
+			MatchKind:   tournament.Swiss,
+			Leaderboard: []store.LeaderboardEntry{{UserID: "u2", Username: "bob", Score: 3}, {UserID: "u1", Username: "alice", Score: 9}},
+			Overrides:   []store.ResultOverride{{MatchID: "m3", Round: "stage_1", Winner: "Team F"}},
+		},
+	}
+	return mockStore
+}
+
+// region GetRounds tests
+
+func TestGetRounds_IncludesCurrentRound(t *testing.T) {
+	mockStore := newRoundsStore()
+	mockStore.Round, mockStore.RoundName = "stage_3", "stage_3"
+	app := NewTestApp(mockStore)
+
+	rounds, err := app.GetRounds(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stage_1", "stage_3"}, rounds)
+}
+
+func TestResolveRound(t *testing.T) {
+	app := NewTestApp(newRoundsStore())
+	ctx := context.Background()
+
+	round, err := app.ResolveRound(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "stage_2", round)
+
+	round, err = app.ResolveRound(ctx, "STAGE_1")
+	require.NoError(t, err)
+	assert.Equal(t, "stage_1", round)
+
+	_, err = app.ResolveRound(ctx, "playoffs")
+	assert.ErrorIs(t, err, ErrUnknownRound)
+}
+
+func TestResolveRound_FetchError(t *testing.T) {
+	mockStore := newRoundsStore()
+	mockStore.FetchRoundsError = errors.New("boom")
+	app := NewTestApp(mockStore)
+
+	_, err := app.ResolveRound(context.Background(), "stage_1")
+	assert.EqualError(t, err, "boom")
+}
+
+// endregion
+
+// region Round lookup tests
+
+func TestCheckPredictionForRound_PastRound(t *testing.T) {
+	app := NewTestApp(newRoundsStore())
+	ctx := context.Background()
+
+	report, err := app.CheckPredictionForRound(ctx, models.User{UserID: "u1"}, "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.GetScore().Successes)
+
+	user, report, err := app.CheckPredictionByUsernameForRound(ctx, "ALICE", "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.UserID)
+	assert.Equal(t, 6, report.GetScore().Successes)
+
+	_, err = app.CheckPredictionForRound(ctx, models.User{UserID: "u2"}, "stage_1")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	_, err = app.CheckPredictionForRound(ctx, models.User{UserID: "u1"}, "playoffs")
+	assert.ErrorIs(t, err, ErrUnknownRound)
+}
+
+func TestCheckPredictionForRound_CurrentRound(t *testing.T) {
+	mockStore := newRoundsStore()
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.SetSwissResults(map[string]string{"Team A": "3-0", "Team B": "0-3"})
+	mockStore.Predictions["u1"] = models.Prediction{UserID: "u1", Username: "alice", Format: "swiss", Round: "stage_2", Win: []string{"Team A"}}
+	app := NewTestApp(mockStore)
+
+	for _, round := range []string{"", "stage_2"} {
+		report, err := app.CheckPredictionForRound(context.Background(), models.User{UserID: "u1"}, round)
+		require.NoError(t, err, round)
+		assert.Equal(t, 1, report.GetScore().Successes, round)
+	}
+}
+
+func TestGetRoundInfo_PastRound(t *testing.T) {
+	app := NewTestApp(newRoundsStore())
+
+	info, err := app.GetRoundInfo(context.Background(), "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, "stage_1", info.Round)
+	assert.Equal(t, "swiss", info.Format)
+	assert.Equal(t, 10, info.NumTeams)
+}
+
+func TestGetLeaderboardForRound(t *testing.T) {
+	app := NewTestApp(newRoundsStore())
+
+	leaderboard, err := app.GetLeaderboardForRound(context.Background(), "stage_1")
+	require.NoError(t, err)
+	require.Len(t, leaderboard, 2)
+	assert.Equal(t, "alice", leaderboard[0].Username)
+	assert.Equal(t, 1, leaderboard[0].Rank)
+	assert.Equal(t, 2, leaderboard[1].Rank)
+}
+
+func TestGetRoundResults_AppliesRoundOverrides(t *testing.T) {
+	app := NewTestApp(newRoundsStore())
+
+	results, err := app.GetRoundResults(context.Background(), "stage_1")
+	require.NoError(t, err)
+	require.Len(t, results, 2, "unfinished matches are left out")
+	assert.Equal(t, "Team A", results[0].Winner)
+	assert.Equal(t, "Team F", results[1].Winner)
+}
+
+// endregion
@@ -0,0 +1,214 @@
+/* stats.go
+ * Contains the app logic behind $stats: aggregating every user's Pick'Ems for the round into per-team pick
+ * counts, hit rates and the crowd's consensus prediction, and exporting the same numbers as Prometheus gauges.
+ */
+
+package app
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"pickems-bot/metrics"
+	"pickems-bot/scoring"
+	"pickems-bot/tournament"
+)
+
+// slotOrder is the display order of pick slots across formats. Slots not listed sort after these.
+var slotOrder = []string{"3-0", "Advance", "0-3", "Champion", "Grand Final", "Semi Final", "Quarter Final", "Best of 16", "Best of 32"}
+
+// TeamStats is how often a team was picked in each slot and how those picks stand
+type TeamStats struct {
+	Team      string
+	Record    string         // current Swiss record, e.g. "2-1"; empty for other formats
+	Picks     map[string]int // slot → number of users who picked the team for it
+	Succeeded int
+	Pending   int
+	Failed    int
+}
+
+// Total returns the number of users who picked the team in any slot
+func (t TeamStats) Total() int {
+	return t.Succeeded + t.Pending + t.Failed
+}
+
+// HitRate returns the share of the team's picks that have hit so far
+func (t TeamStats) HitRate() float64 {
+	if t.Total() == 0 {
+		return 0
+	}
+	return float64(t.Succeeded) / float64(t.Total())
+}
+
+// PickCount is a pick and the number of users who made it
+type PickCount struct {
+	Pick
+	Count int
+}
+
+// PickStats aggregates every user's Pick'Ems for the current round
+type PickStats struct {
+	Predictors int
+	Slots      []string    // slots present in the round's predictions, in display order
+	Teams      []TeamStats // most picked first
+	Consensus  []PickCount // the crowd's prediction: the most popular teams for each slot
+}
+
+// GetPickStats scores every prediction for the current round and aggregates them. The Prometheus pick gauges
+// are updated as a side effect.
+func (a *App) GetPickStats(ctx context.Context) (PickStats, error) {
+	if err := a.Store.EnsureScheduledMatches(ctx); err != nil {
+		return PickStats{}, err
+	}
+	results, err := a.Store.GetMatchResults(ctx)
+	if err != nil {
+		return PickStats{}, err
+	}
+	preds, err := a.Store.GetAllUserPredictions(ctx)
+	if err != nil {
+		return PickStats{}, err
+	}
+
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return PickStats{}, err
+	}
+
+	var reports []tournament.ScoreReport
+	for _, pred := range preds {
+		report, err := scoring.CalculateUserScore(pred, results, aliases)
+		if err != nil {
+			a.logger().Warn("skipping prediction in stats (stale or incompatible format)", "user", pred.Username, "error", err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	stats, err := buildPickStats(reports)
+	if err != nil {
+		return PickStats{}, err
+	}
+	recordPickStats(stats)
+	return stats, nil
+}
+
+// buildPickStats aggregates scored predictions into per-team counts and the consensus prediction
+func buildPickStats(reports []tournament.ScoreReport) (PickStats, error) {
+	stats := PickStats{Predictors: len(reports)}
+	teams := make(map[string]*TeamStats)
+	slotCounts := make(map[string]map[string]*PickCount) // slot → team → count
+	slotSize := make(map[string]int)                     // most picks any one user made for a slot
+
+	for _, report := range reports {
+		picks, err := reportPicks(report)
+		if err != nil {
+			return PickStats{}, err
+		}
+		perSlot := make(map[string]int)
+		for _, p := range picks {
+			team := teams[p.Team]
+			if team == nil {
+				team = &TeamStats{Team: p.Team, Record: p.Record, Picks: make(map[string]int)}
+				teams[p.Team] = team
+			}
+			team.Picks[p.Slot]++
+			switch p.Status {
+			case tournament.StatusSucceeded:
+				team.Succeeded++
+			case tournament.StatusPending:
+				team.Pending++
+			default:
+				team.Failed++
+			}
+
+			if slotCounts[p.Slot] == nil {
+				slotCounts[p.Slot] = make(map[string]*PickCount)
+			}
+			if count := slotCounts[p.Slot][p.Team]; count != nil {
+				count.Count++
+			} else {
+				slotCounts[p.Slot][p.Team] = &PickCount{Pick: p, Count: 1}
+			}
+			perSlot[p.Slot]++
+		}
+		for slot, n := range perSlot {
+			slotSize[slot] = max(slotSize[slot], n)
+		}
+	}
+
+	for slot := range slotCounts {
+		stats.Slots = append(stats.Slots, slot)
+	}
+	sort.Slice(stats.Slots, func(i, j int) bool { return slotLess(stats.Slots[i], stats.Slots[j]) })
+
+	for _, team := range teams {
+		stats.Teams = append(stats.Teams, *team)
+	}
+	sort.Slice(stats.Teams, func(i, j int) bool {
+		if stats.Teams[i].Total() != stats.Teams[j].Total() {
+			return stats.Teams[i].Total() > stats.Teams[j].Total()
+		}
+		return stats.Teams[i].Team < stats.Teams[j].Team
+	})
+
+	// Fill each slot in display order with its most popular teams not already placed in an earlier slot
+	placed := make(map[string]bool)
+	for _, slot := range stats.Slots {
+		var candidates []PickCount
+		for _, count := range slotCounts[slot] {
+			if !placed[count.Team] {
+				candidates = append(candidates, *count)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Count != candidates[j].Count {
+				return candidates[i].Count > candidates[j].Count
+			}
+			return candidates[i].Team < candidates[j].Team
+		})
+		for _, pick := range candidates[:min(slotSize[slot], len(candidates))] {
+			placed[pick.Team] = true
+			stats.Consensus = append(stats.Consensus, pick)
+		}
+	}
+	return stats, nil
+}
+
+// slotLess orders slots by slotOrder, then alphabetically for any slot it doesn't list
+func slotLess(a, b string) bool {
+	ia, ib := slices.Index(slotOrder, a), slices.Index(slotOrder, b)
+	switch {
+	case ia >= 0 && ib >= 0:
+		return ia < ib
+	case ia >= 0 || ib >= 0:
+		return ia >= 0
+	default:
+		return a < b
+	}
+}
+
+// pickGaugesMu stops concurrent leaderboard regenerations interleaving their resets and sets of the pick gauges
+var pickGaugesMu sync.Mutex
+
+// recordPickStats replaces the Prometheus pick gauges with the given stats
+func recordPickStats(stats PickStats) {
+	pickGaugesMu.Lock()
+	defer pickGaugesMu.Unlock()
+	setPickGauges(stats)
+}
+
+// setPickGauges resets the pick gauges, so teams from an earlier round don't linger, and sets them from stats.
+// Callers must hold pickGaugesMu.
+func setPickGauges(stats PickStats) {
+	metrics.PickPredictors.Set(float64(stats.Predictors))
+	metrics.PickPopularity.Reset()
+	metrics.PickHitRate.Reset()
+	for _, team := range stats.Teams {
+		for slot, n := range team.Picks {
+			metrics.PickPopularity.WithLabelValues(team.Team, slot).Set(float64(n))
+		}
+		metrics.PickHitRate.WithLabelValues(team.Team).Set(team.HitRate())
+	}
+}
@@ -0,0 +1,122 @@
+/* stats_test.go
+ * Contains unit tests for stats.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/metrics"
+	"pickems-bot/tournament"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region GetPickStats tests
+
+func TestGetPickStats_Swiss(t *testing.T) {
+	api := &App{Store: newCompareStore()}
+
+	stats, err := api.GetPickStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, stats.Predictors)
+	assert.Equal(t, []string{"3-0", "Advance", "0-3"}, stats.Slots)
+
+	var order []string
+	for _, team := range stats.Teams {
+		order = append(order, team.Team)
+	}
+	assert.Equal(t, []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team I", "Team H", "Team J", "Team K", "Team L"}, order)
+
+	teamA := stats.Teams[0]
+	assert.Equal(t, map[string]int{"3-0": 2}, teamA.Picks)
+	assert.Equal(t, "3-0", teamA.Record)
+	assert.Equal(t, 1.0, teamA.HitRate())
+
+	// Team C was picked once to advance (still pending) and once to go 3-0 (already failed)
+	teamC := stats.Teams[2]
+	assert.Equal(t, map[string]int{"3-0": 1, "Advance": 1}, teamC.Picks)
+	assert.Equal(t, 0, teamC.Succeeded)
+	assert.Equal(t, 1, teamC.Pending)
+	assert.Equal(t, 1, teamC.Failed)
+	assert.Equal(t, 0.0, teamC.HitRate())
+
+	var consensus []string
+	for _, pick := range stats.Consensus {
+		consensus = append(consensus, pick.Slot+" "+pick.Team)
+	}
+	assert.Equal(t, []string{
+		"3-0 Team A", "3-0 Team B",
+		"Advance Team D", "Advance Team E", "Advance Team F", "Advance Team G", "Advance Team C", "Advance Team H",
+		"0-3 Team I", "0-3 Team J",
+	}, consensus)
+	assert.Equal(t, 2, stats.Consensus[0].Count)
+	assert.Equal(t, tournament.StatusSucceeded, stats.Consensus[0].Status)
+}
+
+func TestRecordPickStats(t *testing.T) {
+	api := &App{Store: newCompareStore()}
+	stats, err := api.GetPickStats(context.Background())
+	require.NoError(t, err)
+
+	// Hold the lock so leaderboard regenerations left running by other tests can't reset the gauges mid-test
+	pickGaugesMu.Lock()
+	defer pickGaugesMu.Unlock()
+	metrics.PickPopularity.WithLabelValues("Old Team", "3-0").Set(5)
+	setPickGauges(stats)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.PickPredictors))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.PickPopularity.WithLabelValues("Team A", "3-0")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.PickPopularity.WithLabelValues("Team C", "Advance")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.PickHitRate.WithLabelValues("Team D")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.PickHitRate.WithLabelValues("Team C")))
+	// 12 teams, Team B and Team C each picked in two slots; earlier rounds' series are cleared
+	assert.Equal(t, 14, testutil.CollectAndCount(metrics.PickPopularity))
+}
+
+func TestGetPickStats_SingleElim(t *testing.T) {
+	reports := []tournament.ScoreReport{
+		tournament.SingleElimReport{Predictions: []tournament.ElimPredictionEntry{
+			{Team: "Team A", Round: "Grand Final", ToWin: true, Status: tournament.StatusPending},
+			{Team: "Team B", Round: "Grand Final", Status: tournament.StatusPending},
+			{Team: "Team C", Round: "Semi Final", Status: tournament.StatusSucceeded},
+		}},
+		tournament.SingleElimReport{Predictions: []tournament.ElimPredictionEntry{
+			{Team: "Team B", Round: "Grand Final", ToWin: true, Status: tournament.StatusPending},
+			{Team: "Team A", Round: "Grand Final", Status: tournament.StatusPending},
+			{Team: "Team C", Round: "Semi Final", Status: tournament.StatusSucceeded},
+		}},
+		tournament.SingleElimReport{Predictions: []tournament.ElimPredictionEntry{
+			{Team: "Team A", Round: "Grand Final", ToWin: true, Status: tournament.StatusPending},
+			{Team: "Team C", Round: "Grand Final", Status: tournament.StatusFailed},
+			{Team: "Team D", Round: "Semi Final", Status: tournament.StatusFailed},
+		}},
+	}
+
+	stats, err := buildPickStats(reports)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Champion", "Grand Final", "Semi Final"}, stats.Slots)
+	assert.Equal(t, []PickCount{
+		{Pick: Pick{Team: "Team A", Slot: "Champion", Status: tournament.StatusPending}, Count: 2},
+		{Pick: Pick{Team: "Team B", Slot: "Grand Final", Status: tournament.StatusPending}, Count: 1},
+		{Pick: Pick{Team: "Team C", Slot: "Semi Final", Status: tournament.StatusSucceeded}, Count: 2},
+	}, stats.Consensus)
+}
+
+func TestGetPickStats_NoPredictions(t *testing.T) {
+	mockStore := newCompareStore()
+	mockStore.GetAllUserPredictionsError = errors.New("db down")
+	api := &App{Store: mockStore}
+
+	_, err := api.GetPickStats(context.Background())
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
@@ -0,0 +1,110 @@
+/* team_aliases.go
+ * Contains the app logic for team aliases: the admin-managed names, such as "navi" for "Natus Vincere", that are
+ * resolved before fuzzy matching when users' picks, results and VRS entries are matched up.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+)
+
+// AliasErrorKind identifies which check the arguments given to SetTeamAlias failed
+type AliasErrorKind int
+
+const (
+	// AliasEmpty means the alias or the team name was blank
+	AliasEmpty AliasErrorKind = iota
+	// AliasRedundant means Alias already matches Team without an alias
+	AliasRedundant
+	// AliasChained means Team is itself an alias for Target
+	AliasChained
+)
+
+// AliasError is returned by SetTeamAlias when the alias or team fails validation
+type AliasError struct {
+	Kind   AliasErrorKind
+	Alias  string
+	Team   string
+	Target string
+}
+
+// Error describes the validation failure in English
+func (e *AliasError) Error() string {
+	switch e.Kind {
+	case AliasEmpty:
+		return "alias and team name must not be empty"
+	case AliasRedundant:
+		return fmt.Sprintf("'%s' already matches '%s' without an alias", e.Alias, e.Team)
+	case AliasChained:
+		return fmt.Sprintf("'%s' is itself an alias for '%s', point the alias at '%s' instead", e.Team, e.Target, e.Target)
+	}
+	return "invalid alias"
+}
+
+// TeamAliases returns the alias lookup used when resolving team names
+func (a *App) TeamAliases(ctx context.Context) (sources.TeamAliases, error) {
+	aliases, err := a.Store.FetchTeamAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.AliasMap(aliases), nil
+}
+
+// GetTeamAliases returns every team alias, sorted by alias
+func (a *App) GetTeamAliases(ctx context.Context) ([]store.TeamAlias, error) {
+	return a.Store.FetchTeamAliases(ctx)
+}
+
+// SetTeamAlias makes alias resolve to team, replacing any existing alias of the same name. If team is one of
+// this round's teams in a different case, the round's spelling is stored. Regenerate the leaderboard afterwards
+// so existing picks are rescored with the alias. Invalid arguments return an *AliasError.
+func (a *App) SetTeamAlias(ctx context.Context, alias, team, setBy string) (store.TeamAlias, error) {
+	key := sources.NormalizeTeamName(alias)
+	team = strings.TrimSpace(team)
+	if key == "" || team == "" {
+		return store.TeamAlias{}, &AliasError{Kind: AliasEmpty, Alias: alias, Team: team}
+	}
+	if key == sources.NormalizeTeamName(team) {
+		return store.TeamAlias{}, &AliasError{Kind: AliasRedundant, Alias: alias, Team: team}
+	}
+
+	existing, err := a.TeamAliases(ctx)
+	if err != nil {
+		return store.TeamAlias{}, err
+	}
+	if target, ok := existing[sources.NormalizeTeamName(team)]; ok {
+		return store.TeamAlias{}, &AliasError{Kind: AliasChained, Alias: alias, Team: team, Target: target}
+	}
+
+	if validTeams, _, err := a.Store.GetValidTeams(ctx); err == nil {
+		for _, valid := range validTeams {
+			if strings.EqualFold(valid, team) {
+				team = valid
+				break
+			}
+		}
+	}
+
+	entry := store.TeamAlias{
+		Alias:     key,
+		Team:      team,
+		SetBy:     setBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := a.Store.StoreTeamAlias(ctx, entry); err != nil {
+		return store.TeamAlias{}, err
+	}
+	return entry, nil
+}
+
+// DeleteTeamAlias removes an alias. Returns store.ErrNotFound when there is no such alias.
+func (a *App) DeleteTeamAlias(ctx context.Context, alias string) error {
+	return a.Store.DeleteTeamAlias(ctx, sources.NormalizeTeamName(alias))
+}
@@ -0,0 +1,145 @@
+/* team_aliases_test.go
+ * Contains unit tests for team_aliases.go and alias resolution in the rest of the app
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// region SetTeamAlias tests
+
+func TestSetTeamAlias_NormalisesAliasAndUsesRoundSpelling(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.ValidTeams = []string{"Natus Vincere", "MOUZ"}
+	a := &App{Store: mockStore}
+
+	alias, err := a.SetTeamAlias(context.Background(), "NaVi", "natus vincere", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "navi", alias.Alias)
+	assert.Equal(t, "Natus Vincere", alias.Team)
+	assert.Equal(t, "admin", alias.SetBy)
+	assert.Equal(t, alias, mockStore.TeamAliases["navi"])
+
+	// A team outside this round is stored as given
+	alias, err = a.SetTeamAlias(context.Background(), "vp", "Virtus.pro", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "Virtus.pro", alias.Team)
+}
+
+func TestSetTeamAlias_Invalid(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	a := &App{Store: mockStore}
+
+	tests := []struct {
+		alias, team, want string
+		kind              AliasErrorKind
+	}{
+		{"", "Natus Vincere", "must not be empty", AliasEmpty},
+		{"navi", " ", "must not be empty", AliasEmpty},
+		{"Team Liquid", "Liquid", "already matches", AliasRedundant},
+		{"navy", "NAVI", "is itself an alias for 'Natus Vincere'", AliasChained},
+	}
+	for _, tc := range tests {
+		_, err := a.SetTeamAlias(context.Background(), tc.alias, tc.team, "admin")
+		assert.ErrorContains(t, err, tc.want, tc.alias)
+		var aliasErr *AliasError
+		if assert.ErrorAs(t, err, &aliasErr, tc.alias) {
+			assert.Equal(t, tc.kind, aliasErr.Kind, tc.alias)
+		}
+	}
+	assert.Len(t, mockStore.TeamAliases, 1)
+}
+
+func TestSetTeamAlias_StoreError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.StoreTeamAliasError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.SetTeamAlias(context.Background(), "navi", "Natus Vincere", "admin")
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
+
+// region DeleteTeamAlias tests
+
+func TestDeleteTeamAlias(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.DeleteTeamAlias(context.Background(), "NAVI"))
+	assert.Empty(t, mockStore.TeamAliases)
+	assert.ErrorIs(t, a.DeleteTeamAlias(context.Background(), "navi"), mongo.ErrNoDocuments)
+}
+
+// endregion
+
+// region alias resolution tests
+
+func TestSetUserPrediction_ResolvesAliases(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.ValidTeams[0] = "Natus Vincere"
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	a := &App{Store: mockStore}
+
+	teams := []string{"navi", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
+	prediction, err := a.SetUserPrediction(context.Background(), models.User{UserID: "user1", Username: "one"}, teams, "test_round")
+	require.NoError(t, err)
+	assert.Equal(t, "Natus Vincere", prediction.Win[0])
+}
+
+func TestSetUserPrediction_AliasFetchError(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.FetchTeamAliasesError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	teams := []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J"}
+	_, err := a.SetUserPrediction(context.Background(), models.User{UserID: "user1", Username: "one"}, teams, "test_round")
+	assert.ErrorContains(t, err, "db down")
+	assert.Empty(t, mockStore.Predictions)
+}
+
+func TestGetTeam_ResolvesAliases(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetVRSEntries([]store.VRSEntry{
+		{TeamName: "Natus Vincere", Standing: 3},
+		{TeamName: "NRG", Standing: 40},
+	})
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	a := &App{Store: mockStore}
+
+	entry, err := a.GetTeam(context.Background(), "NAVI")
+	require.NoError(t, err)
+	assert.Equal(t, 3, entry.Standing)
+}
+
+func TestGetTeams_ResolvesAliases(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.ValidTeams = []string{"NAVI"}
+	mockStore.SetVRSEntries([]store.VRSEntry{{TeamName: "Natus Vincere", Standing: 3}})
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	a := &App{Store: mockStore}
+
+	teams, err := a.GetTeams(context.Background())
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, 3, teams[0].VRSRanking)
+}
+
+// endregion
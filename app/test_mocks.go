@@ -8,6 +8,8 @@ package app
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"pickems-bot/models"
@@ -15,7 +17,6 @@ import (
 	"pickems-bot/store"
 	"pickems-bot/tournament"
 
-	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/time/rate"
 )
 
@@ -28,8 +29,9 @@ type MockStore struct {
 	ValidTeams       []string
 	Format           tournament.Kind
 
-	// VRS data
+	// VRS data: VRSEntries is the latest snapshot and VRSHistory holds earlier ones
 	VRSEntries []store.VRSEntry
+	VRSHistory []store.VRSEntry
 
 	// Error injection for testing error paths
 	EnsureScheduledMatchesError      error
@@ -49,6 +51,33 @@ type MockStore struct {
 	FetchAndStoreScheduleError       error
 	FetchMatchNodesFromDbError       error
 	PingError                        error
+	TrackUserError                   error
+	SetRemindersEnabledError         error
+	FetchUserProfilesError           error
+	GetUserProfileError              error
+	SetUserLocaleError               error
+	SetUserTimezoneError             error
+	FetchPredictionUserIDsError      error
+	FetchSentRemindersError          error
+	StoreSentReminderError           error
+	FetchMatchDayMessagesError       error
+	StoreMatchDayMessageError        error
+	DeleteMatchDayMessageError       error
+	GetGuildSettingsError            error
+	FetchAllGuildSettingsError       error
+	StoreGuildSettingsError          error
+	DeleteUserPredictionError        error
+	StoreAuditEntryError             error
+	FetchAuditEntriesError           error
+	FetchResultOverridesError        error
+	StoreResultOverrideError         error
+	DeleteResultOverrideError        error
+	RebuildMatchResultsError         error
+	RebuildMatchResultsCallCount     int
+	FetchTeamAliasesError            error
+	StoreTeamAliasError              error
+	DeleteTeamAliasError             error
+	FetchRoundsError                 error
 
 	MatchNodes []sources.MatchNode
 	MatchKind  tournament.Kind
@@ -61,11 +90,44 @@ type MockStore struct {
 	DatabaseName string
 	RoundName    string
 
+	// Users and reminders
+	Profiles         map[string]store.UserProfile
+	PastPredictorIDs []string
+	SentReminders    []store.SentReminder
+
+	// Match day messages, keyed by guild ID
+	MatchDayMessages map[string]store.MatchDayMessage
+
+	// Guild settings, keyed by guild ID
+	GuildSettings map[string]store.GuildSettings
+
+	// Audit log, in insertion order
+	AuditLog []store.AuditEntry
+
+	// Result overrides, keyed by match ID
+	Overrides map[string]store.ResultOverride
+
+	// Team aliases, keyed by normalised alias
+	TeamAliases map[string]store.TeamAlias
+
+	// Data of rounds other than the current one, keyed by round name
+	PastRounds map[string]MockRound
+
 	// Store fields needed for compatibility
 	Round    string
 	Database interface{ Name() string }
 }
 
+// MockRound holds the stored data of a round other than the current one
+type MockRound struct {
+	Predictions  map[string]models.Prediction // keyed by user ID
+	MatchResults tournament.MatchResult
+	MatchNodes   []sources.MatchNode
+	MatchKind    tournament.Kind
+	Leaderboard  []store.LeaderboardEntry
+	Overrides    []store.ResultOverride
+}
+
 // mockDatabase implements the minimal Database interface needed for tests
 type mockDatabase struct {
 	name string
@@ -79,6 +141,11 @@ func (m *mockDatabase) Name() string {
 func NewMockStore(kind tournament.Kind, round string) *MockStore {
 	return &MockStore{
 		Predictions:      make(map[string]models.Prediction),
+		Profiles:         make(map[string]store.UserProfile),
+		MatchDayMessages: make(map[string]store.MatchDayMessage),
+		GuildSettings:    make(map[string]store.GuildSettings),
+		Overrides:        make(map[string]store.ResultOverride),
+		TeamAliases:      make(map[string]store.TeamAlias),
 		ScheduledMatches: []sources.ScheduledMatch{},
 		ValidTeams:       []string{"Team A", "Team B", "Team C", "Team D", "Team E", "Team F", "Team G", "Team H", "Team I", "Team J", "Team K", "Team L", "Team M", "Team N", "Team O", "Team P"},
 		Format:           kind,
@@ -90,7 +157,7 @@ func NewMockStore(kind tournament.Kind, round string) *MockStore {
 }
 
 // EnsureScheduledMatches mock implementation
-func (m *MockStore) EnsureScheduledMatches() error {
+func (m *MockStore) EnsureScheduledMatches(ctx context.Context) error {
 	if m.EnsureScheduledMatchesError != nil {
 		return m.EnsureScheduledMatchesError
 	}
@@ -101,7 +168,7 @@ func (m *MockStore) EnsureScheduledMatches() error {
 }
 
 // GetValidTeams mock implementation
-func (m *MockStore) GetValidTeams() ([]string, tournament.Kind, error) {
+func (m *MockStore) GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error) {
 	if m.GetValidTeamsError != nil {
 		return nil, "", m.GetValidTeamsError
 	}
@@ -109,7 +176,7 @@ func (m *MockStore) GetValidTeams() ([]string, tournament.Kind, error) {
 }
 
 // StoreUserPrediction mock implementation
-func (m *MockStore) StoreUserPrediction(userID string, prediction models.Prediction) error {
+func (m *MockStore) StoreUserPrediction(ctx context.Context, userID string, prediction models.Prediction) error {
 	if m.StoreUserPredictionError != nil {
 		return m.StoreUserPredictionError
 	}
@@ -118,19 +185,19 @@ func (m *MockStore) StoreUserPrediction(userID string, prediction models.Predict
 }
 
 // GetUserPrediction mock implementation
-func (m *MockStore) GetUserPrediction(userID string) (models.Prediction, error) {
+func (m *MockStore) GetUserPrediction(ctx context.Context, userID string) (models.Prediction, error) {
 	if m.GetUserPredictionError != nil {
 		return models.Prediction{}, m.GetUserPredictionError
 	}
 	pred, ok := m.Predictions[userID]
 	if !ok {
-		return models.Prediction{}, mongo.ErrNoDocuments
+		return models.Prediction{}, store.ErrNotFound
 	}
 	return pred, nil
 }
 
 // GetUserPredictionByUsername mock implementation — case-insensitive search over stored predictions
-func (m *MockStore) GetUserPredictionByUsername(username string) (models.Prediction, error) {
+func (m *MockStore) GetUserPredictionByUsername(ctx context.Context, username string) (models.Prediction, error) {
 	if m.GetUserPredictionByUsernameError != nil {
 		return models.Prediction{}, m.GetUserPredictionByUsernameError
 	}
@@ -140,11 +207,23 @@ func (m *MockStore) GetUserPredictionByUsername(username string) (models.Predict
 			return pred, nil
 		}
 	}
-	return models.Prediction{}, mongo.ErrNoDocuments
+	return models.Prediction{}, store.ErrNotFound
+}
+
+// DeleteUserPrediction mock implementation
+func (m *MockStore) DeleteUserPrediction(ctx context.Context, userID string) error {
+	if m.DeleteUserPredictionError != nil {
+		return m.DeleteUserPredictionError
+	}
+	if _, ok := m.Predictions[userID]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.Predictions, userID)
+	return nil
 }
 
 // GetMatchResults mock implementation
-func (m *MockStore) GetMatchResults() (tournament.MatchResult, error) {
+func (m *MockStore) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
 	if m.GetMatchResultsError != nil {
 		return nil, m.GetMatchResultsError
 	}
@@ -155,7 +234,7 @@ func (m *MockStore) GetMatchResults() (tournament.MatchResult, error) {
 }
 
 // GetAllUserPredictions mock implementation
-func (m *MockStore) GetAllUserPredictions() ([]models.Prediction, error) {
+func (m *MockStore) GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error) {
 	if m.GetAllUserPredictionsError != nil {
 		return nil, m.GetAllUserPredictionsError
 	}
@@ -166,14 +245,14 @@ func (m *MockStore) GetAllUserPredictions() ([]models.Prediction, error) {
 	}
 
 	if len(predictions) == 0 {
-		return nil, mongo.ErrNoDocuments
+		return nil, store.ErrNotFound
 	}
 
 	return predictions, nil
 }
 
 // FetchMatchSchedule mock implementation
-func (m *MockStore) FetchMatchSchedule() ([]sources.ScheduledMatch, error) {
+func (m *MockStore) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
 	if m.FetchMatchScheduleError != nil {
 		return nil, m.FetchMatchScheduleError
 	}
@@ -181,7 +260,7 @@ func (m *MockStore) FetchMatchSchedule() ([]sources.ScheduledMatch, error) {
 }
 
 // StoreMatchSchedule mock implementation
-func (m *MockStore) StoreMatchSchedule(matches []sources.ScheduledMatch) error {
+func (m *MockStore) StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error {
 	m.StoreMatchScheduleCallCount++
 	if m.StoreMatchScheduleError != nil {
 		return m.StoreMatchScheduleError
@@ -235,7 +314,7 @@ func (m *MockStore) GetRound() string {
 }
 
 // FetchAndStoreSchedule mock implementation
-func (m *MockStore) FetchAndStoreSchedule() error {
+func (m *MockStore) FetchAndStoreSchedule(ctx context.Context) error {
 	return m.FetchAndStoreScheduleError
 }
 
@@ -252,7 +331,7 @@ func (m *MockStore) GetClient() interface{ Disconnect(context.Context) error } {
 }
 
 // FetchAndUpdateMatchResults mock implementation
-func (m *MockStore) FetchAndUpdateMatchResults() error {
+func (m *MockStore) FetchAndUpdateMatchResults(ctx context.Context) error {
 	if m.FetchAndUpdateMatchResultsError != nil {
 		return m.FetchAndUpdateMatchResultsError
 	}
@@ -260,7 +339,7 @@ func (m *MockStore) FetchAndUpdateMatchResults() error {
 }
 
 // FetchMatchNodesFromDb mock implementation
-func (m *MockStore) FetchMatchNodesFromDb() ([]sources.MatchNode, tournament.Kind, error) {
+func (m *MockStore) FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
 	if m.FetchMatchNodesFromDbError != nil {
 		return nil, "", m.FetchMatchNodesFromDbError
 	}
@@ -268,7 +347,7 @@ func (m *MockStore) FetchMatchNodesFromDb() ([]sources.MatchNode, tournament.Kin
 }
 
 // StoreLeaderboard mock implementation
-func (m *MockStore) StoreLeaderboard(leaderboard store.Leaderboard) error {
+func (m *MockStore) StoreLeaderboard(ctx context.Context, leaderboard store.Leaderboard) error {
 	if m.StoreLeaderboardError != nil {
 		return m.StoreLeaderboardError
 	}
@@ -283,7 +362,7 @@ func (m *MockStore) StoreLeaderboard(leaderboard store.Leaderboard) error {
 }
 
 // FetchLeaderboardFromDB mock implementation
-func (m *MockStore) FetchLeaderboardFromDB() ([]store.LeaderboardEntry, error) {
+func (m *MockStore) FetchLeaderboardFromDB(ctx context.Context) ([]store.LeaderboardEntry, error) {
 	if m.FetchLeaderboardFromDBError != nil {
 		return nil, m.FetchLeaderboardFromDBError
 	}
@@ -294,18 +373,324 @@ func (m *MockStore) FetchLeaderboardFromDB() ([]store.LeaderboardEntry, error) {
 func (m *MockStore) Ping(ctx context.Context) error { return m.PingError }
 
 // FetchVrsDataFromDB mock implementation
-func (m *MockStore) FetchVrsDataFromDB() ([]store.VRSEntry, error) {
+func (m *MockStore) FetchVrsDataFromDB(ctx context.Context) ([]store.VRSEntry, error) {
 	if m.FetchVrsDataFromDBError != nil {
 		return nil, m.FetchVrsDataFromDBError
 	}
 	return m.VRSEntries, nil
 }
 
+// FetchVrsStandingsDates mock implementation returns the standings dates of VRSEntries and VRSHistory
+func (m *MockStore) FetchVrsStandingsDates(ctx context.Context) ([]string, error) {
+	if m.FetchVrsDataFromDBError != nil {
+		return nil, m.FetchVrsDataFromDBError
+	}
+	var dates []string
+	for _, e := range append(slices.Clone(m.VRSHistory), m.VRSEntries...) {
+		dates = append(dates, e.StandingsDate)
+	}
+	slices.Sort(dates)
+	return slices.Compact(dates), nil
+}
+
+// FetchVrsSnapshot mock implementation returns the entries of VRSEntries and VRSHistory with the given date
+func (m *MockStore) FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]store.VRSEntry, error) {
+	if m.FetchVrsDataFromDBError != nil {
+		return nil, m.FetchVrsDataFromDBError
+	}
+	var entries []store.VRSEntry
+	for _, e := range append(slices.Clone(m.VRSHistory), m.VRSEntries...) {
+		if e.StandingsDate == standingsDate {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
 // SetVRSEntries sets the mock VRS entries
 func (m *MockStore) SetVRSEntries(entries []store.VRSEntry) {
 	m.VRSEntries = entries
 }
 
+// TrackUser mock implementation
+func (m *MockStore) TrackUser(ctx context.Context, user models.User) error {
+	if m.TrackUserError != nil {
+		return m.TrackUserError
+	}
+	profile := m.Profiles[user.UserID]
+	profile.UserID = user.UserID
+	profile.Username = user.Username
+	profile.DisplayName = user.DisplayName
+	if user.GuildID != "" {
+		profile.GuildID = user.GuildID
+	}
+	m.Profiles[user.UserID] = profile
+	return nil
+}
+
+// SetRemindersEnabled mock implementation
+func (m *MockStore) SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error {
+	if m.SetRemindersEnabledError != nil {
+		return m.SetRemindersEnabledError
+	}
+	profile := m.Profiles[userID]
+	profile.UserID = userID
+	profile.RemindersOff = !enabled
+	m.Profiles[userID] = profile
+	return nil
+}
+
+// GetUserProfile mock implementation
+func (m *MockStore) GetUserProfile(ctx context.Context, userID string) (store.UserProfile, error) {
+	if m.GetUserProfileError != nil {
+		return store.UserProfile{}, m.GetUserProfileError
+	}
+	profile, ok := m.Profiles[userID]
+	if !ok {
+		return store.UserProfile{}, store.ErrNotFound
+	}
+	return profile, nil
+}
+
+// GetUserProfileByUsername mock implementation — case-insensitive search over Profiles
+func (m *MockStore) GetUserProfileByUsername(ctx context.Context, username string) (store.UserProfile, error) {
+	if m.GetUserProfileError != nil {
+		return store.UserProfile{}, m.GetUserProfileError
+	}
+	for _, profile := range m.Profiles {
+		if strings.EqualFold(profile.Username, username) {
+			return profile, nil
+		}
+	}
+	return store.UserProfile{}, store.ErrNotFound
+}
+
+// SetUserLocale mock implementation
+func (m *MockStore) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	if m.SetUserLocaleError != nil {
+		return m.SetUserLocaleError
+	}
+	profile := m.Profiles[userID]
+	profile.UserID = userID
+	profile.Locale = locale
+	m.Profiles[userID] = profile
+	return nil
+}
+
+// SetUserTimezone mock implementation
+func (m *MockStore) SetUserTimezone(ctx context.Context, userID string, timezone string) error {
+	if m.SetUserTimezoneError != nil {
+		return m.SetUserTimezoneError
+	}
+	profile := m.Profiles[userID]
+	profile.UserID = userID
+	profile.Timezone = timezone
+	m.Profiles[userID] = profile
+	return nil
+}
+
+// FetchUserProfiles mock implementation
+func (m *MockStore) FetchUserProfiles(ctx context.Context) ([]store.UserProfile, error) {
+	if m.FetchUserProfilesError != nil {
+		return nil, m.FetchUserProfilesError
+	}
+	profiles := make([]store.UserProfile, 0, len(m.Profiles))
+	for _, p := range m.Profiles {
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// FetchPredictionUserIDs mock implementation — returns PastPredictorIDs plus every user with a stored prediction
+func (m *MockStore) FetchPredictionUserIDs(ctx context.Context) ([]string, error) {
+	if m.FetchPredictionUserIDsError != nil {
+		return nil, m.FetchPredictionUserIDsError
+	}
+	ids := append([]string{}, m.PastPredictorIDs...)
+	for id := range m.Predictions {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// FetchSentReminders mock implementation
+func (m *MockStore) FetchSentReminders(ctx context.Context) ([]store.SentReminder, error) {
+	if m.FetchSentRemindersError != nil {
+		return nil, m.FetchSentRemindersError
+	}
+	return m.SentReminders, nil
+}
+
+// StoreSentReminder mock implementation
+func (m *MockStore) StoreSentReminder(ctx context.Context, reminder store.SentReminder) error {
+	if m.StoreSentReminderError != nil {
+		return m.StoreSentReminderError
+	}
+	m.SentReminders = append(m.SentReminders, reminder)
+	return nil
+}
+
+// FetchMatchDayMessages mock implementation
+func (m *MockStore) FetchMatchDayMessages(ctx context.Context) ([]store.MatchDayMessage, error) {
+	if m.FetchMatchDayMessagesError != nil {
+		return nil, m.FetchMatchDayMessagesError
+	}
+	var messages []store.MatchDayMessage
+	for _, msg := range m.MatchDayMessages {
+		if msg.Round == m.Round {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// StoreMatchDayMessage mock implementation
+func (m *MockStore) StoreMatchDayMessage(ctx context.Context, message store.MatchDayMessage) error {
+	if m.StoreMatchDayMessageError != nil {
+		return m.StoreMatchDayMessageError
+	}
+	m.MatchDayMessages[message.GuildID] = message
+	return nil
+}
+
+// DeleteMatchDayMessage mock implementation
+func (m *MockStore) DeleteMatchDayMessage(ctx context.Context, guildID string) error {
+	if m.DeleteMatchDayMessageError != nil {
+		return m.DeleteMatchDayMessageError
+	}
+	delete(m.MatchDayMessages, guildID)
+	return nil
+}
+
+// GetGuildSettings mock implementation
+func (m *MockStore) GetGuildSettings(ctx context.Context, guildID string) (store.GuildSettings, error) {
+	if m.GetGuildSettingsError != nil {
+		return store.GuildSettings{}, m.GetGuildSettingsError
+	}
+	settings, ok := m.GuildSettings[guildID]
+	if !ok {
+		return store.GuildSettings{}, store.ErrNotFound
+	}
+	return settings, nil
+}
+
+// FetchAllGuildSettings mock implementation
+func (m *MockStore) FetchAllGuildSettings(ctx context.Context) ([]store.GuildSettings, error) {
+	if m.FetchAllGuildSettingsError != nil {
+		return nil, m.FetchAllGuildSettingsError
+	}
+	var all []store.GuildSettings
+	for _, settings := range m.GuildSettings {
+		all = append(all, settings)
+	}
+	return all, nil
+}
+
+// StoreGuildSettings mock implementation
+func (m *MockStore) StoreGuildSettings(ctx context.Context, settings store.GuildSettings) error {
+	if m.StoreGuildSettingsError != nil {
+		return m.StoreGuildSettingsError
+	}
+	m.GuildSettings[settings.GuildID] = settings
+	return nil
+}
+
+// StoreAuditEntry mock implementation
+func (m *MockStore) StoreAuditEntry(ctx context.Context, entry store.AuditEntry) error {
+	if m.StoreAuditEntryError != nil {
+		return m.StoreAuditEntryError
+	}
+	m.AuditLog = append(m.AuditLog, entry)
+	return nil
+}
+
+// FetchAuditEntries mock implementation — newest first
+func (m *MockStore) FetchAuditEntries(ctx context.Context, guildID string, limit int) ([]store.AuditEntry, error) {
+	if m.FetchAuditEntriesError != nil {
+		return nil, m.FetchAuditEntriesError
+	}
+	var entries []store.AuditEntry
+	for i := len(m.AuditLog) - 1; i >= 0 && len(entries) < limit; i-- {
+		if m.AuditLog[i].GuildID == guildID || m.AuditLog[i].GuildID == "" {
+			entries = append(entries, m.AuditLog[i])
+		}
+	}
+	return entries, nil
+}
+
+// FetchResultOverrides mock implementation
+func (m *MockStore) FetchResultOverrides(ctx context.Context) ([]store.ResultOverride, error) {
+	if m.FetchResultOverridesError != nil {
+		return nil, m.FetchResultOverridesError
+	}
+	var overrides []store.ResultOverride
+	for _, o := range m.Overrides {
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// StoreResultOverride mock implementation
+func (m *MockStore) StoreResultOverride(ctx context.Context, override store.ResultOverride) error {
+	if m.StoreResultOverrideError != nil {
+		return m.StoreResultOverrideError
+	}
+	m.Overrides[override.MatchID] = override
+	return nil
+}
+
+// DeleteResultOverride mock implementation
+func (m *MockStore) DeleteResultOverride(ctx context.Context, matchID string) error {
+	if m.DeleteResultOverrideError != nil {
+		return m.DeleteResultOverrideError
+	}
+	if _, ok := m.Overrides[matchID]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.Overrides, matchID)
+	return nil
+}
+
+// RebuildMatchResults mock implementation — only records the call
+func (m *MockStore) RebuildMatchResults(ctx context.Context) error {
+	m.RebuildMatchResultsCallCount++
+	return m.RebuildMatchResultsError
+}
+
+// FetchTeamAliases mock implementation — sorted by alias
+func (m *MockStore) FetchTeamAliases(ctx context.Context) ([]store.TeamAlias, error) {
+	if m.FetchTeamAliasesError != nil {
+		return nil, m.FetchTeamAliasesError
+	}
+	var aliases []store.TeamAlias
+	for _, a := range m.TeamAliases {
+		aliases = append(aliases, a)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	return aliases, nil
+}
+
+// StoreTeamAlias mock implementation
+func (m *MockStore) StoreTeamAlias(ctx context.Context, alias store.TeamAlias) error {
+	if m.StoreTeamAliasError != nil {
+		return m.StoreTeamAliasError
+	}
+	m.TeamAliases[alias.Alias] = alias
+	return nil
+}
+
+// DeleteTeamAlias mock implementation
+func (m *MockStore) DeleteTeamAlias(ctx context.Context, alias string) error {
+	if m.DeleteTeamAliasError != nil {
+		return m.DeleteTeamAliasError
+	}
+	if _, ok := m.TeamAliases[alias]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.TeamAliases, alias)
+	return nil
+}
+
 // NewTestApp creates a minimal App for unit tests in other packages that need
 // an App instance with a rate limiter but without a real MongoDB connection.
 // The injected store is used as-is; callers are responsible for configuring it.
@@ -315,3 +700,109 @@ func NewTestApp(s store.Interface) *App {
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
 	}
 }
+
+// FetchRounds mock implementation returns the current round and every round in PastRounds, sorted by name
+func (m *MockStore) FetchRounds(ctx context.Context) ([]string, error) {
+	if m.FetchRoundsError != nil {
+		return nil, m.FetchRoundsError
+	}
+	rounds := []string{m.RoundName}
+	for round := range m.PastRounds {
+		rounds = append(rounds, round)
+	}
+	sort.Strings(rounds)
+	return rounds, nil
+}
+
+// pastRound returns the data of a round other than the current one
+func (m *MockStore) pastRound(round string) (MockRound, error) {
+	past, ok := m.PastRounds[round]
+	if !ok {
+		return MockRound{}, store.ErrNotFound
+	}
+	return past, nil
+}
+
+// GetUserPredictionForRound mock implementation
+func (m *MockStore) GetUserPredictionForRound(ctx context.Context, userID string, round string) (models.Prediction, error) {
+	if round == m.RoundName {
+		return m.GetUserPrediction(ctx, userID)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+	pred, ok := past.Predictions[userID]
+	if !ok {
+		return models.Prediction{}, store.ErrNotFound
+	}
+	return pred, nil
+}
+
+// GetUserPredictionByUsernameForRound mock implementation
+func (m *MockStore) GetUserPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.Prediction, error) {
+	if round == m.RoundName {
+		return m.GetUserPredictionByUsername(ctx, username)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+	for _, pred := range past.Predictions {
+		if strings.EqualFold(pred.Username, username) {
+			return pred, nil
+		}
+	}
+	return models.Prediction{}, store.ErrNotFound
+}
+
+// GetMatchResultsForRound mock implementation
+func (m *MockStore) GetMatchResultsForRound(ctx context.Context, round string) (tournament.MatchResult, error) {
+	if round == m.RoundName {
+		return m.GetMatchResults(ctx)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return nil, err
+	}
+	if past.MatchResults == nil {
+		return nil, store.ErrNotFound
+	}
+	return past.MatchResults, nil
+}
+
+// FetchMatchNodesForRound mock implementation
+func (m *MockStore) FetchMatchNodesForRound(ctx context.Context, round string) ([]sources.MatchNode, tournament.Kind, error) {
+	if round == m.RoundName {
+		return m.FetchMatchNodesFromDb(ctx)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return nil, "", err
+	}
+	return past.MatchNodes, past.MatchKind, nil
+}
+
+// FetchLeaderboardForRound mock implementation
+func (m *MockStore) FetchLeaderboardForRound(ctx context.Context, round string) ([]store.LeaderboardEntry, error) {
+	if round == m.RoundName {
+		return m.FetchLeaderboardFromDB(ctx)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return nil, err
+	}
+	return past.Leaderboard, nil
+}
+
+// FetchResultOverridesForRound mock implementation
+func (m *MockStore) FetchResultOverridesForRound(ctx context.Context, round string) ([]store.ResultOverride, error) {
+	if round == m.RoundName {
+		return m.FetchResultOverrides(ctx)
+	}
+	past, err := m.pastRound(round)
+	if err != nil {
+		return nil, err
+	}
+	return past.Overrides, nil
+}
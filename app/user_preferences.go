@@ -0,0 +1,67 @@
+/* user_preferences.go
+ * Contains the logic for reading and validating a user's own preferences: their locale, which overrides their
+ * guild's locale, and their timezone, used for times written as plain text.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pickems-bot/i18n"
+	"pickems-bot/store"
+)
+
+// GetUserLocale returns the locale the given user has chosen, or an empty string if they haven't chosen one.
+func (a *App) GetUserLocale(ctx context.Context, userID string) (string, error) {
+	profile, err := a.Store.GetUserProfile(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return profile.Locale, nil
+}
+
+// SetUserLocale sets the locale bot responses to the given user are translated into. An empty locale clears the
+// choice so the user follows their guild's locale again.
+func (a *App) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	if locale != "" && !i18n.IsSupported(locale) {
+		return fmt.Errorf("unsupported locale %q, supported locales are: %s", locale, strings.Join(i18n.SupportedLocales, ", "))
+	}
+	return a.Store.SetUserLocale(ctx, userID, locale)
+}
+
+// GetUserTimezone returns the location of the given user's chosen timezone, or nil if they haven't chosen one.
+func (a *App) GetUserTimezone(ctx context.Context, userID string) (*time.Location, error) {
+	profile, err := a.Store.GetUserProfile(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) || (err == nil && profile.Timezone == "") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(profile.Timezone)
+}
+
+// SetUserTimezone sets the IANA timezone plain-text times are shown to the given user in. An empty timezone clears
+// the choice. Returns the location that was set, or nil when it was cleared.
+func (a *App) SetUserTimezone(ctx context.Context, userID string, timezone string) (*time.Location, error) {
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		// "Local" would resolve to the server's zone rather than the user's
+		if loc, err = time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return nil, fmt.Errorf("unknown timezone %q, use an IANA name such as Europe/Berlin", timezone)
+		}
+	}
+	if err := a.Store.SetUserTimezone(ctx, userID, timezone); err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
@@ -0,0 +1,126 @@
+/* user_preferences_test.go
+ * Contains unit tests for user_preferences.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region GetUserLocale tests
+
+func TestGetUserLocale(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1", Locale: "ru"}
+	mockStore.Profiles["user2"] = store.UserProfile{UserID: "user2"}
+	a := &App{Store: mockStore}
+
+	for userID, want := range map[string]string{"user1": "ru", "user2": "", "unknown": ""} {
+		locale, err := a.GetUserLocale(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, want, locale, userID)
+	}
+}
+
+func TestGetUserLocale_Error(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GetUserProfileError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetUserLocale(context.Background(), "user1")
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
+
+// region SetUserLocale tests
+
+func TestSetUserLocale(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.SetUserLocale(context.Background(), "user1", "pl"))
+	assert.Equal(t, "pl", mockStore.Profiles["user1"].Locale)
+
+	require.NoError(t, a.SetUserLocale(context.Background(), "user1", ""))
+	assert.Empty(t, mockStore.Profiles["user1"].Locale)
+}
+
+func TestSetUserLocale_Unsupported(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	err := a.SetUserLocale(context.Background(), "user1", "de")
+	assert.ErrorContains(t, err, `unsupported locale "de"`)
+	assert.NotContains(t, mockStore.Profiles, "user1")
+}
+
+// endregion
+
+// region GetUserTimezone tests
+
+func TestGetUserTimezone(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1", Timezone: "Europe/Warsaw"}
+	mockStore.Profiles["user2"] = store.UserProfile{UserID: "user2"}
+	a := &App{Store: mockStore}
+
+	loc, err := a.GetUserTimezone(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Warsaw", loc.String())
+
+	for _, userID := range []string{"user2", "unknown"} {
+		loc, err := a.GetUserTimezone(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Nil(t, loc, userID)
+	}
+}
+
+func TestGetUserTimezone_Error(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.GetUserProfileError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, err := a.GetUserTimezone(context.Background(), "user1")
+	assert.ErrorContains(t, err, "db down")
+}
+
+// endregion
+
+// region SetUserTimezone tests
+
+func TestSetUserTimezone(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	loc, err := a.SetUserTimezone(context.Background(), "user1", "America/Sao_Paulo")
+	require.NoError(t, err)
+	assert.Equal(t, "America/Sao_Paulo", loc.String())
+	assert.Equal(t, "America/Sao_Paulo", mockStore.Profiles["user1"].Timezone)
+
+	loc, err = a.SetUserTimezone(context.Background(), "user1", "")
+	require.NoError(t, err)
+	assert.Nil(t, loc)
+	assert.Empty(t, mockStore.Profiles["user1"].Timezone)
+}
+
+func TestSetUserTimezone_Invalid(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	a := &App{Store: mockStore}
+
+	for _, tz := range []string{"Mars/Olympus", "Local"} {
+		_, err := a.SetUserTimezone(context.Background(), "user1", tz)
+		assert.ErrorContains(t, err, "unknown timezone", tz)
+	}
+	assert.NotContains(t, mockStore.Profiles, "user1")
+}
+
+// endregion
@@ -0,0 +1,79 @@
+/* usernames.go
+ * Contains the lookups that keep usernames and display names current. Predictions and leaderboards store the
+ * username a user had when they were written; the users collection is refreshed on every interaction, so it is
+ * consulted first.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"pickems-bot/models"
+	"pickems-bot/store"
+)
+
+// findPredictionByUsername returns the prediction of the user going by username (case-insensitive) in the given
+// round. The user's current username is checked before the usernames stored on predictions, so a name someone has
+// since given up doesn't find their picks once another user has taken it.
+func (a *App) findPredictionByUsername(ctx context.Context, username string, round string) (models.Prediction, error) {
+	profile, err := a.Store.GetUserProfileByUsername(ctx, username)
+	switch {
+	case err == nil:
+		prediction, err := a.Store.GetUserPredictionForRound(ctx, profile.UserID, round)
+		if err != nil {
+			return models.Prediction{}, err
+		}
+		prediction.Username = profile.Username
+		return prediction, nil
+	case !errors.Is(err, store.ErrNotFound):
+		return models.Prediction{}, err
+	}
+
+	// Nobody currently goes by it, so fall back to the name the picks were stored under
+	prediction, err := a.Store.GetUserPredictionByUsernameForRound(ctx, username, round)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+	return prediction, nil
+}
+
+// currentDisplayNames returns the current display name of every tracked user, keyed by user ID. Users without a
+// display name are listed under their username.
+func (a *App) currentDisplayNames(ctx context.Context) (map[string]string, error) {
+	profiles, err := a.Store.FetchUserProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(profiles))
+	for _, profile := range profiles {
+		switch {
+		case profile.DisplayName != "":
+			names[profile.UserID] = profile.DisplayName
+		case profile.Username != "":
+			names[profile.UserID] = profile.Username
+		}
+	}
+	return names, nil
+}
+
+// withCurrentDisplayNames replaces the stored username of each leaderboard entry with the user's current display
+// name. Entries of users that were never tracked keep their stored username, as do all entries if the profiles
+// can't be read.
+func (a *App) withCurrentDisplayNames(ctx context.Context, entries []store.LeaderboardEntry) []store.LeaderboardEntry {
+	names, err := a.currentDisplayNames(ctx)
+	if err != nil {
+		a.logger().Warn("failed to fetch current display names", "error", fmt.Errorf("withCurrentDisplayNames: %w", err))
+		return entries
+	}
+	renamed := slices.Clone(entries)
+	for i, entry := range renamed {
+		if name, ok := names[entry.UserID]; ok {
+			renamed[i].Username = name
+		}
+	}
+	return renamed
+}
@@ -0,0 +1,130 @@
+/* usernames_test.go
+ * Contains unit tests for usernames.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRenamedUserStore returns a MockStore where u1 set picks as "oldname" and has since been seen as "newname",
+// and u2 has since taken "oldname" without setting any picks
+func newRenamedUserStore() *MockStore {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.SetSwissResults(map[string]string{"Team A": "3-0"})
+	mockStore.Predictions["u1"] = models.Prediction{
+		UserID: "u1", Username: "oldname", Format: "swiss", Round: "test_round",
+		Win: []string{"Team A", "Team B"}, Advance: []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+		Lose: []string{"Team I", "Team J"},
+	}
+	mockStore.Profiles["u1"] = store.UserProfile{UserID: "u1", Username: "newname"}
+	return mockStore
+}
+
+// region findPredictionByUsername tests
+
+func TestCheckPredictionByUsername_FindsUserByCurrentName(t *testing.T) {
+	a := &App{Store: newRenamedUserStore()}
+
+	user, _, err := a.CheckPredictionByUsername(context.Background(), "NewName")
+	require.NoError(t, err)
+	assert.Equal(t, models.User{UserID: "u1", Username: "newname"}, user)
+}
+
+func TestCheckPredictionByUsername_FallsBackToStoredName(t *testing.T) {
+	a := &App{Store: newRenamedUserStore()}
+
+	user, _, err := a.CheckPredictionByUsername(context.Background(), "oldname")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.UserID)
+}
+
+func TestCheckPredictionByUsername_TakenNameDoesNotFindPreviousOwner(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	mockStore.Profiles["u2"] = store.UserProfile{UserID: "u2", Username: "oldname"}
+	a := &App{Store: mockStore}
+
+	_, _, err := a.CheckPredictionByUsername(context.Background(), "oldname")
+	assert.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestCheckPredictionByUsername_ProfileError(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	mockStore.GetUserProfileError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	_, _, err := a.CheckPredictionByUsername(context.Background(), "newname")
+	assert.EqualError(t, err, "db down")
+}
+
+func TestFindPredictor_ByCurrentName(t *testing.T) {
+	a := &App{Store: newRenamedUserStore()}
+
+	user, err := a.FindPredictor(context.Background(), "newname")
+	require.NoError(t, err)
+	assert.Equal(t, models.User{UserID: "u1", Username: "newname"}, user)
+}
+
+// endregion
+
+// region Leaderboard username tests
+
+func TestGenerateLeaderboard_UsesCurrentUsernames(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	a := &App{Store: mockStore}
+
+	require.NoError(t, a.GenerateLeaderboard(context.Background()))
+	require.Len(t, mockStore.Leaderboard, 1)
+	assert.Equal(t, "newname", mockStore.Leaderboard[0].Username)
+}
+
+func TestGetLeaderboard_RenamesStoredEntries(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	mockStore.Leaderboard = []store.LeaderboardEntry{
+		{UserID: "u1", Username: "oldname", Score: 2},
+		{UserID: "u3", Username: "untracked", Score: 1},
+	}
+	a := &App{Store: mockStore}
+
+	leaderboard, err := a.GetLeaderboard(context.Background())
+	require.NoError(t, err)
+	require.Len(t, leaderboard, 2)
+	assert.Equal(t, "newname", leaderboard[0].Username)
+	assert.Equal(t, "untracked", leaderboard[1].Username)
+	assert.Equal(t, "oldname", mockStore.Leaderboard[0].Username, "the stored leaderboard isn't modified")
+}
+
+func TestGetLeaderboard_PrefersDisplayNames(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	mockStore.Profiles["u1"] = store.UserProfile{UserID: "u1", Username: "newname", DisplayName: "New Name"}
+	mockStore.Leaderboard = []store.LeaderboardEntry{{UserID: "u1", Username: "oldname", Score: 2}}
+	a := &App{Store: mockStore}
+
+	leaderboard, err := a.GetLeaderboard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "New Name", leaderboard[0].Username)
+}
+
+func TestGetLeaderboard_KeepsStoredNamesWhenProfilesFail(t *testing.T) {
+	mockStore := newRenamedUserStore()
+	mockStore.Leaderboard = []store.LeaderboardEntry{{UserID: "u1", Username: "oldname"}}
+	mockStore.FetchUserProfilesError = errors.New("db down")
+	a := &App{Store: mockStore}
+
+	leaderboard, err := a.GetLeaderboard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "oldname", leaderboard[0].Username)
+}
+
+// endregion
@@ -0,0 +1,132 @@
+/* vrs.go
+ * Contains the logic behind $team's ranking history: a team's latest VRS standing compared with the previous release
+ * and with the last release before the tournament started.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+)
+
+// findVRSEntry returns the entry of entries for teamName, matched by alias key. With fuzzy set, a name that matches
+// no team exactly falls back to the closest fuzzy match.
+func findVRSEntry(entries []store.VRSEntry, aliases sources.TeamAliases, teamName string, fuzzyMatch bool) (store.VRSEntry, bool) {
+	norm := aliases.Key(teamName)
+	for _, entry := range entries {
+		if aliases.Key(entry.TeamName) == norm {
+			return entry, true
+		}
+	}
+	if !fuzzyMatch {
+		return store.VRSEntry{}, false
+	}
+
+	// Exact normalised match failed — try fuzzy
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = aliases.Key(e.TeamName)
+	}
+	if matches := fuzzy.RankFind(norm, keys); len(matches) > 0 {
+		for _, entry := range entries {
+			if aliases.Key(entry.TeamName) == matches[0].Target {
+				return entry, true
+			}
+		}
+	}
+	return store.VRSEntry{}, false
+}
+
+// GetTeamRanking returns the latest VRS entry of a team, found like GetTeam, along with its standing in the previous
+// release and in the last release before the tournament started.
+func (a *App) GetTeamRanking(ctx context.Context, teamName string) (TeamRanking, error) {
+	entry, err := a.GetTeam(ctx, teamName)
+	if err != nil {
+		return TeamRanking{}, err
+	}
+	ranking := TeamRanking{VRSEntry: entry}
+
+	dates, err := a.Store.FetchVrsStandingsDates(ctx)
+	if err != nil {
+		return TeamRanking{}, err
+	}
+	aliases, err := a.TeamAliases(ctx)
+	if err != nil {
+		return TeamRanking{}, err
+	}
+	// Earlier releases are matched on the name found in the latest one, so a fuzzy match can't land on another team
+	standingOn := func(date string) (int, error) {
+		snapshot, err := a.Store.FetchVrsSnapshot(ctx, date)
+		if err != nil {
+			return 0, err
+		}
+		previous, _ := findVRSEntry(snapshot, aliases, entry.TeamName, false)
+		return previous.Standing, nil
+	}
+
+	if i := slices.Index(dates, entry.StandingsDate); i > 0 {
+		ranking.PreviousDate = dates[i-1]
+		if ranking.PreviousStanding, err = standingOn(ranking.PreviousDate); err != nil {
+			return TeamRanking{}, err
+		}
+	}
+
+	start, err := a.tournamentStartDate(ctx)
+	if err != nil {
+		return TeamRanking{}, err
+	}
+	if !start.IsZero() {
+		ranking.StartDate = lastStandingsDateBefore(dates, start)
+		if ranking.StartDate != "" {
+			if ranking.StartStanding, err = standingOn(ranking.StartDate); err != nil {
+				return TeamRanking{}, err
+			}
+		}
+	}
+	return ranking, nil
+}
+
+// tournamentStartDate returns the configured tournament start, or else the day of the current round's first
+// scheduled match. It returns the zero time if neither is known.
+func (a *App) tournamentStartDate(ctx context.Context) (time.Time, error) {
+	if !a.tournamentStart.IsZero() {
+		return a.tournamentStart, nil
+	}
+	schedule, err := a.Store.FetchMatchSchedule(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return time.Time{}, fmt.Errorf("failed to fetch schedule: %w", err)
+	}
+	var first int64
+	for _, m := range schedule {
+		if m.EpochTime > 0 && (first == 0 || m.EpochTime < first) {
+			first = m.EpochTime
+		}
+	}
+	if first == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(first, 0).UTC(), nil
+}
+
+// lastStandingsDateBefore returns the latest of dates, sorted oldest first, released on or before the day of start,
+// or "" if there is none
+func lastStandingsDateBefore(dates []string, start time.Time) string {
+	cutoff := start.UTC().Format(store.VRSStandingsDateLayout)
+	latest := ""
+	for _, date := range dates {
+		if date > cutoff {
+			break
+		}
+		latest = date
+	}
+	return latest
+}
@@ -0,0 +1,125 @@
+/* vrs_test.go
+ * Contains unit tests for vrs.go
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVRSHistoryStore returns a MockStore with three releases of the rankings: 2026_05_04, 2026_06_01 and the latest,
+// 2026_06_08
+func newVRSHistoryStore() *MockStore {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.VRSHistory = []store.VRSEntry{
+		{Standing: 9, TeamName: "Team Spirit", StandingsDate: "2026_05_04"},
+		{Standing: 6, TeamName: "Team Spirit", StandingsDate: "2026_06_01"},
+		{Standing: 2, TeamName: "Vitality", StandingsDate: "2026_06_01"},
+	}
+	mockStore.VRSEntries = []store.VRSEntry{
+		{Standing: 1, TeamName: "Vitality", StandingsDate: "2026_06_08"},
+		{Standing: 4, TeamName: "Team Spirit", StandingsDate: "2026_06_08"},
+		{Standing: 12, TeamName: "Legacy", StandingsDate: "2026_06_08"},
+	}
+	return mockStore
+}
+
+// region GetTeamRanking tests
+
+func TestGetTeamRanking_PreviousAndConfiguredStart(t *testing.T) {
+	a := &App{Store: newVRSHistoryStore(), tournamentStart: time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC)}
+
+	ranking, err := a.GetTeamRanking(context.Background(), "spirit")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Team Spirit", ranking.TeamName)
+	assert.Equal(t, 4, ranking.Standing)
+	assert.Equal(t, 6, ranking.PreviousStanding)
+	assert.Equal(t, "2026_06_01", ranking.PreviousDate)
+	assert.Equal(t, 9, ranking.StartStanding)
+	assert.Equal(t, "2026_05_04", ranking.StartDate)
+}
+
+func TestGetTeamRanking_StartFromSchedule(t *testing.T) {
+	mockStore := newVRSHistoryStore()
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Vitality", Team2: "Legacy", EpochTime: time.Date(2026, 6, 3, 12, 0, 0, 0, time.UTC).Unix()},
+		{Team1: "Team Spirit", Team2: "Vitality", EpochTime: time.Date(2026, 6, 1, 15, 0, 0, 0, time.UTC).Unix()},
+	})
+	a := &App{Store: mockStore}
+
+	ranking, err := a.GetTeamRanking(context.Background(), "Vitality")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, ranking.StartStanding, "a release on the day the first match is played counts")
+	assert.Equal(t, "2026_06_01", ranking.StartDate)
+}
+
+func TestGetTeamRanking_NewEntryAndNoStart(t *testing.T) {
+	a := &App{Store: newVRSHistoryStore()}
+
+	ranking, err := a.GetTeamRanking(context.Background(), "Legacy")
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, ranking.Standing)
+	assert.Zero(t, ranking.PreviousStanding, "unranked in the previous release")
+	assert.Equal(t, "2026_06_01", ranking.PreviousDate)
+	assert.Empty(t, ranking.StartDate, "no configured start and no schedule")
+}
+
+func TestGetTeamRanking_SingleRelease(t *testing.T) {
+	mockStore := NewMockStore("swiss", "test_round")
+	mockStore.VRSEntries = []store.VRSEntry{{Standing: 1, TeamName: "Vitality", StandingsDate: "2026_06_08"}}
+	a := &App{Store: mockStore, tournamentStart: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	ranking, err := a.GetTeamRanking(context.Background(), "Vitality")
+	require.NoError(t, err)
+
+	assert.Empty(t, ranking.PreviousDate)
+	assert.Empty(t, ranking.StartDate, "no release before the tournament started")
+}
+
+func TestGetTeamRanking_Errors(t *testing.T) {
+	a := &App{Store: newVRSHistoryStore()}
+	_, err := a.GetTeamRanking(context.Background(), "")
+	assert.Error(t, err)
+
+	mockStore := newVRSHistoryStore()
+	mockStore.FetchVrsDataFromDBError = errors.New("db down")
+	_, err = (&App{Store: mockStore}).GetTeamRanking(context.Background(), "Vitality")
+	assert.EqualError(t, err, "db down")
+}
+
+// endregion
+
+// region lastStandingsDateBefore tests
+
+func TestLastStandingsDateBefore(t *testing.T) {
+	dates := []string{"2026_05_04", "2026_06_01", "2026_06_08"}
+	tests := []struct {
+		name  string
+		start time.Time
+		want  string
+	}{
+		{"between releases", time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC), "2026_06_01"},
+		{"on a release", time.Date(2026, 6, 8, 18, 0, 0, 0, time.UTC), "2026_06_08"},
+		{"before every release", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lastStandingsDateBefore(dates, tt.start))
+		})
+	}
+}
+
+// endregion
@@ -0,0 +1,619 @@
+/* admin.go
+ * Contains the $admin command suite used to operate a live tournament: forcing a data refresh, rescoring,
+ * re-rendering the results image and fixing individual users' picks. Every use is recorded in the audit log.
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-andiamo/splitter"
+)
+
+const (
+	maxDescription      = 4096 // maximum length Discord allows for an embed description
+	defaultAuditEntries = 10
+	maxAuditTextLength  = 100 // audit log args and errors are truncated to this many bytes when displayed
+)
+
+// userMentionPattern matches a user mention (<@id> or <@!id>) or a bare user ID
+var userMentionPattern = regexp.MustCompile(`^(?:<@!?(\d+)>|(\d+))$`)
+
+// adminHandler handles $admin <subcommand>. Every use, including unauthorised attempts and failures, is
+// recorded in the audit log.
+func (b *Bot) adminHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	args := strings.Fields(message.Content)
+	var sub, rest string
+	if len(args) > 1 {
+		sub = args[1]
+		rest = strings.Join(args[2:], " ")
+	}
+
+	if !b.requireAdmin(ctx, session, message, "$admin") {
+		b.recordAdminAction(ctx, message, sub, rest, app.AuditResultDenied)
+		return
+	}
+
+	var err error
+	switch sub {
+	case "refresh":
+		err = b.adminRefresh(ctx, session, message)
+	case "rescore":
+		err = b.adminRescore(ctx, session, message)
+	case "render":
+		err = b.adminRender(ctx, session, message)
+	case "deletepick":
+		err = b.adminDeletePick(ctx, session, message, rest)
+	case "setpick":
+		err = b.adminSetPick(ctx, session, message)
+	case "matches":
+		err = b.adminMatches(ctx, session, message)
+	case "override":
+		err = b.adminOverride(ctx, session, message)
+	case "overrides":
+		err = b.adminOverrides(ctx, session, message)
+	case "alias":
+		err = b.adminAlias(ctx, session, message)
+	case "aliases":
+		err = b.adminAliases(ctx, session, message)
+	case "audit":
+		err = b.adminAudit(ctx, session, message, rest)
+	default:
+		loc := b.locale(ctx, message)
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.usage"))
+		err = fmt.Errorf("unknown subcommand %q", sub)
+	}
+
+	result := app.AuditResultOK
+	if err != nil {
+		result = err.Error()
+	}
+	b.recordAdminAction(ctx, message, sub, rest, result)
+}
+
+// recordAdminAction writes an audit log entry for an $admin invocation. Failures are logged but never shown
+// to the user, since the command itself has already run.
+func (b *Bot) recordAdminAction(ctx context.Context, message *discordgo.MessageCreate, command, args, result string) {
+	entry := store.AuditEntry{
+		GuildID:  message.GuildID,
+		UserID:   message.Author.ID,
+		Username: message.Author.Username,
+		Command:  command,
+		Args:     args,
+		Result:   result,
+	}
+	if err := b.APIPtr.RecordAdminAction(ctx, entry); err != nil {
+		b.logger().Error("failed to record admin action", "command", command, "user", message.Author.Username, "error", fmt.Errorf("recordAdminAction: %w", err))
+	}
+}
+
+// adminRefresh re-fetches the schedule and results from the data source and runs the same follow-up steps as
+// the update pipelines: rescoring, result announcements, match day messages and the results image.
+func (b *Bot) adminRefresh(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	before, snapshotErr := b.APIPtr.SnapshotResults(ctx)
+	if snapshotErr != nil {
+		b.logger().Warn("failed to snapshot results, skipping announcement", "error", fmt.Errorf("adminRefresh: %w", snapshotErr))
+	}
+
+	if err := b.APIPtr.PopulateMatches(ctx, b.ScheduleOnly); err != nil {
+		b.logger().Error("failed to refresh matches", "error", fmt.Errorf("adminRefresh: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.refresh.error"))
+		return err
+	}
+	if err := b.APIPtr.GenerateLeaderboard(ctx); err != nil {
+		b.logger().Error("failed to generate leaderboard", "error", fmt.Errorf("adminRefresh: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.refresh.rescore_error"))
+		return err
+	}
+
+	if snapshotErr == nil {
+		announcement, err := b.APIPtr.ResultsAnnouncement(ctx, before)
+		if err != nil {
+			b.logger().Warn("failed to build results announcement", "error", fmt.Errorf("adminRefresh: %w", err))
+		} else {
+			b.announceResults(ctx, session, announcement)
+		}
+	}
+	b.refreshMatchDay(ctx, session, time.Now())
+
+	description := loc.T("admin.refresh.done")
+	if !b.ScheduleOnly {
+		if err := b.renderResults(ctx); err != nil {
+			b.logger().Error("failed to render results image", "error", fmt.Errorf("adminRefresh: %w", err))
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.refresh.render_error"))
+			return err
+		}
+		description = loc.T("admin.refresh.done_image")
+	}
+	b.sendAdminSuccess(session, message.ChannelID, loc.T("admin.refresh.title"), description)
+	return nil
+}
+
+// adminRescore regenerates the leaderboard from the stored results and predictions
+func (b *Bot) adminRescore(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	if err := b.APIPtr.GenerateLeaderboard(ctx); err != nil {
+		b.logger().Error("failed to generate leaderboard", "error", fmt.Errorf("adminRescore: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.rescore.error"))
+		return err
+	}
+	b.sendAdminSuccess(session, message.ChannelID, loc.T("admin.rescore.title"), loc.T("admin.rescore.done"))
+	return nil
+}
+
+// adminRender regenerates the results image used by $results
+func (b *Bot) adminRender(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	if err := b.renderResults(ctx); err != nil {
+		b.logger().Error("failed to render results image", "error", fmt.Errorf("adminRender: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.render.error"))
+		return err
+	}
+	b.sendAdminSuccess(session, message.ChannelID, loc.T("admin.render.title"), loc.T("admin.render.done"))
+	return nil
+}
+
+// renderResults calls the injected results renderer
+func (b *Bot) renderResults(ctx context.Context) error {
+	if b.RenderResults == nil {
+		return errors.New("results rendering is not configured")
+	}
+	return b.RenderResults(ctx)
+}
+
+// adminDeletePick removes a user's picks for the current round
+func (b *Bot) adminDeletePick(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate, target string) error {
+	loc := b.locale(ctx, message)
+	if target == "" {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.deletepick.usage"))
+		return errors.New("no user given")
+	}
+	user, err := b.resolveAdminTarget(ctx, message, target)
+	if err != nil {
+		return b.sendTargetError(session, message.ChannelID, loc, target, err)
+	}
+
+	if err := b.APIPtr.DeleteUserPrediction(ctx, user.UserID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.deletepick.not_found", user.Username))
+			return err
+		}
+		b.logger().Error("failed to delete user prediction", "user", user.UserID, "error", fmt.Errorf("adminDeletePick: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.deletepick.error", user.Username))
+		return err
+	}
+	b.sendAdminSuccess(session, message.ChannelID, loc.T("admin.deletepick.title"), loc.T("admin.deletepick.done", user.Username))
+	return nil
+}
+
+// adminSetPick sets picks on another user's behalf, with the same validation as $set
+func (b *Bot) adminSetPick(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
+	parts, _ := spaceSplitter.Split(message.Content)
+	if len(parts) < 4 {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.setpick.usage"))
+		return errors.New("no user or teams given")
+	}
+	target := parts[2]
+	user, err := b.resolveAdminTarget(ctx, message, target)
+	if err != nil {
+		return b.sendTargetError(session, message.ChannelID, loc, target, err)
+	}
+
+	prediction, err := b.APIPtr.SetUserPrediction(ctx, user, parts[3:], b.APIPtr.Store.GetRound())
+	if err != nil {
+		b.logger().Warn("failed to set user prediction", "user", user.UserID, "error", fmt.Errorf("adminSetPick: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, predictionErrorMessage(loc, err))
+		return err
+	}
+
+	fields, err := predictionFields(prediction)
+	if err != nil {
+		b.logger().Error("failed to build prediction fields", "user", user.UserID, "error", fmt.Errorf("adminSetPick: %w", err))
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("admin.setpick.title"),
+		Description: loc.T("admin.setpick.done", user.Username, message.Author.Username),
+		Color:       green,
+		Fields:      fields,
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send set-pick embed", "error", fmt.Errorf("adminSetPick: %w", err))
+	}
+	return nil
+}
+
+// resolveAdminTarget turns a mention, user ID or username into a user. Mentioned users are taken from the
+// message itself so picks can be set for someone who hasn't predicted yet; anything else must match a
+// stored prediction.
+func (b *Bot) resolveAdminTarget(ctx context.Context, message *discordgo.MessageCreate, target string) (models.User, error) {
+	if m := userMentionPattern.FindStringSubmatch(target); m != nil {
+		id := m[1] + m[2]
+		for _, u := range message.Mentions {
+			if u.ID == id {
+				return models.User{UserID: u.ID, Username: u.Username}, nil
+			}
+		}
+		return b.APIPtr.FindPredictor(ctx, id)
+	}
+	return b.APIPtr.FindPredictor(ctx, target)
+}
+
+// sendTargetError reports a failed resolveAdminTarget lookup in loc and returns the error for the audit log
+func (b *Bot) sendTargetError(session DiscordSession, channelID string, loc i18n.Locale, target string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		sendLocalizedError(session, channelID, loc, loc.T("admin.target.not_found", target))
+		return err
+	}
+	b.logger().Error("failed to look up user", "target", target, "error", fmt.Errorf("sendTargetError: %w", err))
+	sendLocalizedError(session, channelID, loc, loc.T("user.lookup_error", target))
+	return err
+}
+
+// adminMatches lists the current round's match nodes and their IDs, grouped by section, so admins can find the
+// ID to override
+func (b *Bot) adminMatches(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	nodes, _, err := b.APIPtr.MatchNodes(ctx)
+	if err != nil {
+		b.logger().Error("failed to fetch match nodes", "error", fmt.Errorf("adminMatches: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.matches.error"))
+		return err
+	}
+	overrides, err := b.APIPtr.GetResultOverrides(ctx)
+	if err != nil {
+		b.logger().Error("failed to fetch result overrides", "error", fmt.Errorf("adminMatches: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.overrides.error"))
+		return err
+	}
+	overridden := make(map[string]bool, len(overrides))
+	for _, o := range overrides {
+		overridden[o.Override.MatchID] = true
+	}
+
+	var sections []string
+	lines := make(map[string][]string)
+	for _, node := range nodes {
+		section := node.Section
+		if section == "" {
+			section = loc.T("admin.matches.title")
+		}
+		if _, ok := lines[section]; !ok {
+			sections = append(sections, section)
+		}
+		line := fmt.Sprintf("`%s` %s vs %s — %s", node.ID, node.Team1, node.Team2, nodeResult(loc, node))
+		if overridden[node.ID] {
+			line += " 📌"
+		}
+		lines[section] = append(lines[section], line)
+	}
+
+	embed := &discordgo.MessageEmbed{Title: loc.T("admin.matches.title"), Color: burple}
+	if len(nodes) == 0 {
+		embed.Description = loc.T("admin.matches.none")
+	}
+	for i, section := range sections {
+		if i == maxEmbedFields {
+			embed.Description = loc.T("admin.matches.truncated", maxEmbedFields, len(sections))
+			break
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: section, Value: joinFieldLines(loc, lines[section])})
+	}
+	if len(overrides) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: loc.T("admin.matches.footer")}
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send matches embed", "error", fmt.Errorf("adminMatches: %w", err))
+	}
+	return nil
+}
+
+// adminOverride pins (or with "clear", unpins) the result of a match, then re-renders the results image
+func (b *Bot) adminOverride(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
+	parts, _ := spaceSplitter.Split(message.Content)
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], `"“”`)
+	}
+
+	var title, description string
+	switch {
+	case len(parts) == 4 && parts[2] == "clear":
+		matchID := parts[3]
+		if err := b.APIPtr.ClearResultOverride(ctx, matchID); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.override.not_found", matchID))
+				return err
+			}
+			b.logger().Error("failed to clear result override", "match", matchID, "error", fmt.Errorf("adminOverride: %w", err))
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.override.clear_error"))
+			return err
+		}
+		title, description = loc.T("admin.override.cleared_title"), loc.T("admin.override.cleared", matchID)
+	case len(parts) == 4 || len(parts) == 5:
+		var score string
+		if len(parts) == 5 {
+			score = parts[4]
+		}
+		override, err := b.APIPtr.SetResultOverride(ctx, parts[2], parts[3], score, message.Author.Username)
+		if err != nil {
+			var overrideErr *app.OverrideError
+			if !errors.As(err, &overrideErr) {
+				b.logger().Error("failed to set result override", "match", parts[2], "error", fmt.Errorf("adminOverride: %w", err))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.override.error"))
+				return err
+			}
+			sendLocalizedError(session, message.ChannelID, loc, overrideErrorMessage(loc, overrideErr))
+			return err
+		}
+		result := override.Winner
+		if override.Score != "" {
+			result += " " + override.Score
+		}
+		title, description = loc.T("admin.override.set_title"), loc.T("admin.override.set", override.MatchID, result)
+	default:
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.override.usage"))
+		return errors.New("invalid override arguments")
+	}
+
+	b.refreshMatchDay(ctx, session, time.Now())
+	if !b.ScheduleOnly {
+		if err := b.renderResults(ctx); err != nil {
+			b.logger().Error("failed to render results image", "error", fmt.Errorf("adminOverride: %w", err))
+			description += "\n" + loc.T("admin.override.render_failed")
+		}
+	}
+	b.sendAdminSuccess(session, message.ChannelID, title, description)
+	return nil
+}
+
+// overrideErrorMessage describes a SetResultOverride validation error in the given locale
+func overrideErrorMessage(loc i18n.Locale, err *app.OverrideError) string {
+	switch err.Kind {
+	case app.OverrideUnknownMatch:
+		return loc.T("admin.override.unknown_match", err.MatchID)
+	case app.OverrideInvalidWinner:
+		return loc.T("admin.override.invalid_winner", err.MatchID, err.Teams[0], err.Teams[1])
+	case app.OverrideInvalidScore:
+		return loc.T("admin.override.invalid_score", err.Score)
+	}
+	return loc.T("admin.override.error")
+}
+
+// adminOverrides lists the overrides in effect for the current round
+func (b *Bot) adminOverrides(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	overrides, err := b.APIPtr.GetResultOverrides(ctx)
+	if err != nil {
+		b.logger().Error("failed to fetch result overrides", "error", fmt.Errorf("adminOverrides: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.overrides.error"))
+		return err
+	}
+	embed := overridesEmbed(loc, overrides)
+	if embed == nil {
+		embed = &discordgo.MessageEmbed{Title: loc.T("admin.overrides.title"), Description: loc.T("admin.overrides.none"), Color: burple}
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send overrides embed", "error", fmt.Errorf("adminOverrides: %w", err))
+	}
+	return nil
+}
+
+// overridesEmbed lists result overrides alongside the source's current result, written in loc. Returns nil when
+// there are none.
+func overridesEmbed(loc i18n.Locale, overrides []app.OverriddenMatch) *discordgo.MessageEmbed {
+	if len(overrides) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(overrides))
+	for _, o := range overrides {
+		pinned := o.Override.Winner
+		if o.Override.Score != "" {
+			pinned += " " + o.Override.Score
+		}
+		source := loc.T("admin.overrides.not_reported")
+		if o.Source.ID != "" {
+			source = nodeResult(loc, o.Source)
+		}
+		teams := o.Override.MatchID
+		if o.Source.ID != "" {
+			teams = fmt.Sprintf("%s vs %s", o.Source.Team1, o.Source.Team2)
+		}
+		lines = append(lines, loc.T("admin.overrides.line", o.Override.MatchID, teams, pinned, source, o.Override.SetBy))
+	}
+	return &discordgo.MessageEmbed{
+		Title:       loc.T("admin.overrides.title"),
+		Description: joinLines(loc, lines, maxDescription),
+		Color:       burple,
+		Footer:      &discordgo.MessageEmbedFooter{Text: loc.T("admin.overrides.footer")},
+	}
+}
+
+// nodeResult describes a match node's result in loc, e.g. "Team A 2-1" or "not played"
+func nodeResult(loc i18n.Locale, node sources.MatchNode) string {
+	if node.Winner == "" || node.Winner == "TBD" {
+		return loc.T("admin.matches.not_played")
+	}
+	if node.Score == "" {
+		return node.Winner
+	}
+	return node.Winner + " " + node.Score
+}
+
+// adminAlias adds (or with "remove", deletes) a team alias, then rescores so existing picks pick it up
+func (b *Bot) adminAlias(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
+	parts, _ := spaceSplitter.Split(message.Content)
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], `"“”`)
+	}
+
+	var title, description string
+	switch {
+	case len(parts) == 4 && parts[2] == "remove":
+		alias := parts[3]
+		if err := b.APIPtr.DeleteTeamAlias(ctx, alias); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.alias.not_found", alias))
+				return err
+			}
+			b.logger().Error("failed to delete team alias", "alias", alias, "error", fmt.Errorf("adminAlias: %w", err))
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.alias.remove_error"))
+			return err
+		}
+		title, description = loc.T("admin.alias.removed_title"), loc.T("admin.alias.removed", alias)
+	case len(parts) == 4:
+		alias, err := b.APIPtr.SetTeamAlias(ctx, parts[2], parts[3], message.Author.Username)
+		if err != nil {
+			var aliasErr *app.AliasError
+			if !errors.As(err, &aliasErr) {
+				b.logger().Error("failed to set team alias", "alias", parts[2], "error", fmt.Errorf("adminAlias: %w", err))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.alias.error"))
+				return err
+			}
+			sendLocalizedError(session, message.ChannelID, loc, aliasErrorMessage(loc, aliasErr))
+			return err
+		}
+		title, description = loc.T("admin.alias.set_title"), loc.T("admin.alias.set", alias.Alias, alias.Team)
+	default:
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.alias.usage"))
+		return errors.New("invalid alias arguments")
+	}
+
+	if err := b.APIPtr.GenerateLeaderboard(ctx); err != nil {
+		b.logger().Error("failed to rescore after alias change", "error", fmt.Errorf("adminAlias: %w", err))
+		description += "\n" + loc.T("admin.alias.rescore_failed")
+	}
+	b.sendAdminSuccess(session, message.ChannelID, title, description)
+	return nil
+}
+
+// aliasErrorMessage describes a SetTeamAlias validation error in the given locale
+func aliasErrorMessage(loc i18n.Locale, err *app.AliasError) string {
+	switch err.Kind {
+	case app.AliasEmpty:
+		return loc.T("admin.alias.empty")
+	case app.AliasRedundant:
+		return loc.T("admin.alias.redundant", err.Alias, err.Team)
+	case app.AliasChained:
+		return loc.T("admin.alias.chained", err.Team, err.Target, err.Target)
+	}
+	return loc.T("admin.alias.error")
+}
+
+// adminAliases lists every team alias
+func (b *Bot) adminAliases(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) error {
+	loc := b.locale(ctx, message)
+	aliases, err := b.APIPtr.GetTeamAliases(ctx)
+	if err != nil {
+		b.logger().Error("failed to fetch team aliases", "error", fmt.Errorf("adminAliases: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.aliases.error"))
+		return err
+	}
+	embed := &discordgo.MessageEmbed{Title: loc.T("admin.aliases.title"), Color: burple}
+	if len(aliases) == 0 {
+		embed.Description = loc.T("admin.aliases.none")
+	} else {
+		lines := make([]string, 0, len(aliases))
+		for _, a := range aliases {
+			lines = append(lines, loc.T("admin.aliases.line", a.Alias, a.Team, a.SetBy))
+		}
+		embed.Description = joinLines(loc, lines, maxDescription)
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send aliases embed", "error", fmt.Errorf("adminAliases: %w", err))
+	}
+	return nil
+}
+
+// adminAudit shows the guild's most recent audit log entries
+func (b *Bot) adminAudit(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate, countArg string) error {
+	loc := b.locale(ctx, message)
+	count := defaultAuditEntries
+	if countArg != "" {
+		n, err := strconv.Atoi(countArg)
+		if err != nil || n < 1 {
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.audit.usage"))
+			return fmt.Errorf("invalid count %q", countArg)
+		}
+		count = min(n, maxEmbedFields)
+	}
+
+	entries, err := b.APIPtr.GetAuditLog(ctx, message.GuildID, count)
+	if err != nil {
+		b.logger().Error("failed to fetch audit log", "error", fmt.Errorf("adminAudit: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.audit.error"))
+		return err
+	}
+
+	embed := &discordgo.MessageEmbed{Title: loc.T("admin.audit.title"), Color: burple}
+	if len(entries) == 0 {
+		embed.Description = loc.T("admin.audit.none")
+	}
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, auditLine(loc, e))
+	}
+	if len(lines) > 0 {
+		embed.Description = strings.Join(lines, "\n")
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send audit log embed", "error", fmt.Errorf("adminAudit: %w", err))
+	}
+	return nil
+}
+
+// auditLine formats a single audit log entry in loc
+func auditLine(loc i18n.Locale, e store.AuditEntry) string {
+	command := strings.TrimSpace("$admin " + e.Command)
+	if e.Args != "" {
+		command += " " + truncate(e.Args, maxAuditTextLength)
+	}
+	var status string
+	switch e.Result {
+	case app.AuditResultOK:
+		status = "✅"
+	case app.AuditResultDenied:
+		status = "⛔ " + loc.T("admin.audit.denied")
+	default:
+		status = "❌ " + truncate(e.Result, maxAuditTextLength)
+	}
+	return fmt.Sprintf("<t:%d:R> **%s** `%s` %s", e.Timestamp.Unix(), e.Username, command, status)
+}
+
+// truncate shortens s to at most n bytes, cutting on a rune boundary and marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
+
+// sendAdminSuccess sends a green confirmation embed for a completed admin command
+func (b *Bot) sendAdminSuccess(session DiscordSession, channelID, title, description string) {
+	embed := &discordgo.MessageEmbed{Title: title, Description: description, Color: green}
+	if _, err := session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		b.logger().Error("failed to send admin confirmation embed", "error", fmt.Errorf("sendAdminSuccess: %w", err))
+	}
+}
@@ -0,0 +1,617 @@
+/* admin_test.go
+ * Contains unit tests for the $admin command suite
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createAdminTestBot returns a test bot with a rate limiter (so refreshes are allowed) and a counting renderer
+func createAdminTestBot() (*Bot, *app.MockStore, *int) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	bot.APIPtr = app.NewTestApp(mockStore)
+	renders := 0
+	bot.RenderResults = func(context.Context) error {
+		renders++
+		return nil
+	}
+	return bot, mockStore, &renders
+}
+
+// lastAudit returns the most recent audit log entry
+func lastAudit(t *testing.T, mockStore *app.MockStore) store.AuditEntry {
+	t.Helper()
+	require.NotEmpty(t, mockStore.AuditLog)
+	return mockStore.AuditLog[len(mockStore.AuditLog)-1]
+}
+
+// region permission tests
+
+func TestAdminHandler_DeniedIsAudited(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin render"))
+
+	assert.Zero(t, *renders)
+	assert.Equal(t, "Error", mockSession.GetLastEmbed().Embed.Title)
+	entry := lastAudit(t, mockStore)
+	assert.Equal(t, "render", entry.Command)
+	assert.Equal(t, app.AuditResultDenied, entry.Result)
+	assert.Equal(t, "guild123", entry.GuildID)
+	assert.Equal(t, "user123", entry.UserID)
+}
+
+func TestAdminHandler_DMsRejected(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createMockMessage("$admin render", "user123", "TestUser", "dm123"))
+
+	assert.Zero(t, *renders)
+	assert.Equal(t, app.AuditResultDenied, lastAudit(t, mockStore).Result)
+}
+
+func TestAdminHandler_UnknownSubcommand(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin explode"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage")
+	assert.Contains(t, lastAudit(t, mockStore).Result, "unknown subcommand")
+}
+
+func TestAdminHandler_AuditFailureStillRuns(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockStore.StoreAuditEntryError = errors.New("db down")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin render"))
+
+	assert.Equal(t, 1, *renders)
+	assert.Equal(t, "Render Complete", mockSession.GetLastEmbed().Embed.Title)
+}
+
+// endregion
+
+// region refresh/rescore/render tests
+
+func TestAdminRefresh_Success(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin refresh"))
+
+	assert.Equal(t, "Refresh Complete", mockSession.GetLastEmbed().Embed.Title)
+	assert.Equal(t, 1, *renders)
+	assert.Len(t, mockStore.Leaderboard, 1)
+	assert.Equal(t, app.AuditResultOK, lastAudit(t, mockStore).Result)
+}
+
+func TestAdminRefresh_ScheduleOnlySkipsRender(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	bot.ScheduleOnly = true
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin refresh"))
+
+	assert.Equal(t, "Refresh Complete", mockSession.GetLastEmbed().Embed.Title)
+	assert.Zero(t, *renders)
+}
+
+func TestAdminRefresh_FetchError(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockStore.FetchAndStoreScheduleError = errors.New("api down")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin refresh"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Refresh failed")
+	assert.Zero(t, *renders)
+	assert.Equal(t, "api down", lastAudit(t, mockStore).Result)
+}
+
+func TestAdminRefresh_ErrorNotShown(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.FetchAndStoreScheduleError = errors.New("api down")
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin refresh"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "A atualização falhou. Veja o erro em `$admin audit` ou nos logs.", embed.Description)
+	assert.NotContains(t, embed.Description, "api down")
+}
+
+func TestAdminRescore_Error(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.GetMatchResultsError = errors.New("db down")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin rescore"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Rescoring failed")
+	assert.Equal(t, "db down", lastAudit(t, mockStore).Result)
+}
+
+func TestAdminRescore_Success(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin rescore"))
+
+	assert.Equal(t, "Rescore Complete", mockSession.GetLastEmbed().Embed.Title)
+	assert.Len(t, mockStore.Leaderboard, 1)
+}
+
+func TestAdminRender_NotConfigured(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	bot.RenderResults = nil
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin render"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Rendering failed")
+	assert.Contains(t, lastAudit(t, mockStore).Result, "not configured")
+}
+
+// endregion
+
+// region deletepick/setpick tests
+
+func TestAdminDeletePick_ByMention(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.Predictions["111"] = models.Prediction{UserID: "111", Username: "victim", Format: "swiss", Round: "test_round"}
+	mockStore.Predictions["222"] = models.Prediction{UserID: "222", Username: "other", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+	message := createGuildMessage("$admin deletepick <@111>")
+	message.Mentions = []*discordgo.User{{ID: "111", Username: "victim"}}
+
+	bot.adminHandler(context.Background(), mockSession, message)
+
+	assert.NotContains(t, mockStore.Predictions, "111")
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "victim")
+	entry := lastAudit(t, mockStore)
+	assert.Equal(t, "deletepick", entry.Command)
+	assert.Equal(t, "<@111>", entry.Args)
+	assert.Equal(t, app.AuditResultOK, entry.Result)
+}
+
+func TestAdminDeletePick_ByUsername(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.Predictions["111"] = models.Prediction{UserID: "111", Username: "Victim", Format: "swiss", Round: "test_round"}
+	mockStore.Predictions["222"] = models.Prediction{UserID: "222", Username: "other", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin deletepick victim"))
+
+	assert.NotContains(t, mockStore.Predictions, "111")
+}
+
+func TestAdminDeletePick_NotFound(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin deletepick ghost"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No Pick'Ems found for **ghost**")
+	assert.NotEqual(t, app.AuditResultOK, lastAudit(t, mockStore).Result)
+}
+
+func TestAdminDeletePick_MissingUser(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin deletepick"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage")
+}
+
+func TestAdminSetPick_MentionedUserWithoutPicks(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+	message := createGuildMessage(`$admin setpick <@!111> "Team A" "Team B" "Team C" "Team D" "Team E" "Team F" "Team G" "Team H" "Team I" "Team J"`)
+	message.Mentions = []*discordgo.User{{ID: "111", Username: "newcomer"}}
+	mockStore.LeaderboardStored = make(chan struct{}, 1)
+
+	bot.adminHandler(context.Background(), mockSession, message)
+
+	pred, ok := mockStore.Predictions["111"]
+	require.True(t, ok)
+	assert.Equal(t, "newcomer", pred.Username)
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Pick'Ems Updated", embed.Title)
+	assert.Contains(t, embed.Description, "set by TestUser")
+	assert.Equal(t, app.AuditResultOK, lastAudit(t, mockStore).Result)
+
+	// the async leaderboard regeneration triggered by SetUserPrediction
+	select {
+	case <-mockStore.LeaderboardStored:
+	case <-time.After(time.Second):
+		t.Fatal("leaderboard was not regenerated after setpick")
+	}
+	assert.Len(t, mockStore.Leaderboard, 1)
+}
+
+func TestAdminSetPick_InvalidTeams(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+	message := createGuildMessage("$admin setpick <@111> \"Team A\"")
+	message.Mentions = []*discordgo.User{{ID: "111", Username: "newcomer"}}
+
+	bot.adminHandler(context.Background(), mockSession, message)
+
+	assert.NotContains(t, mockStore.Predictions, "111")
+	assert.Contains(t, lastAudit(t, mockStore).Result, "incorrect number of teams")
+	assert.NotContains(t, mockSession.GetLastEmbed().Embed.Description, "incorrect number of teams")
+}
+
+func TestAdminSetPick_StoreErrorNotShown(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.StoreUserPredictionError = errors.New("mongo: connection refused")
+	mockSession := newAdminSession()
+	message := createGuildMessage(`$admin setpick <@!111> "Team A" "Team B" "Team C" "Team D" "Team E" "Team F" "Team G" "Team H" "Team I" "Team J"`)
+	message.Mentions = []*discordgo.User{{ID: "111", Username: "newcomer"}}
+
+	bot.adminHandler(context.Background(), mockSession, message)
+
+	assert.NotContains(t, mockSession.GetLastEmbed().Embed.Description, "connection refused")
+	assert.Contains(t, lastAudit(t, mockStore).Result, "connection refused")
+}
+
+func TestAdminSetPick_MissingArgs(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin setpick <@111>"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage")
+}
+
+// endregion
+
+// region audit tests
+
+func TestAdminAudit_ShowsRecentEntries(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.AuditLog = []store.AuditEntry{
+		{GuildID: "guild123", Username: "mod", Command: "deletepick", Args: "<@111>", Result: app.AuditResultOK, Timestamp: time.Unix(1700000000, 0)},
+		{GuildID: "other", Username: "elsewhere", Command: "refresh", Result: app.AuditResultOK},
+		{GuildID: "guild123", Username: "intruder", Command: "render", Result: app.AuditResultDenied},
+	}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin audit 5"))
+
+	description := mockSession.GetLastEmbed().Embed.Description
+	assert.Contains(t, description, "**mod** `$admin deletepick <@111>` ✅")
+	assert.Contains(t, description, "**intruder** `$admin render` ⛔ denied")
+	assert.NotContains(t, description, "elsewhere")
+	assert.Equal(t, "audit", lastAudit(t, mockStore).Command)
+}
+
+func TestAdminAudit_InvalidCount(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin audit lots"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage")
+}
+
+func TestAdminAudit_Empty(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin audit"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No admin commands")
+}
+
+func TestAdminAudit_UsesAdminLocale(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin audit"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Журнал действий администраторов", embed.Title)
+	assert.Equal(t, "На этом сервере ещё не использовались команды администратора.", embed.Description)
+	assert.Contains(t, auditLine(i18n.Locale("ru"), store.AuditEntry{Username: "mod", Result: app.AuditResultDenied}), "⛔ отказано")
+}
+
+func TestAuditLine_TruncatesLongErrors(t *testing.T) {
+	long := ""
+	for range 50 {
+		long += "é-"
+	}
+	line := auditLine(i18n.English, store.AuditEntry{Username: "mod", Command: "refresh", Result: long})
+	assert.Contains(t, line, "❌ ")
+	assert.Contains(t, line, "…")
+	assert.Less(t, len(line), 200)
+}
+
+// endregion
+
+// region routing tests
+
+func TestNewMessageHandler_RoutesAdmin(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$admin render"), "bot123")
+
+	assert.Equal(t, 1, *renders)
+	assert.Equal(t, "render", lastAudit(t, mockStore).Command)
+}
+
+// endregion
+
+// region override tests
+
+// withOverrideNodes stores two Round 1 match nodes on the mock store
+func withOverrideNodes(mockStore *app.MockStore) {
+	mockStore.MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "The MongolZ", Winner: "The MongolZ", Score: "0-2", Section: "Round 1"},
+		{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "TBD", Section: "Round 2"},
+	}
+	mockStore.MatchKind = "swiss"
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+}
+
+func TestAdminMatches_ListsNodesBySection(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin matches"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	require.Len(t, embed.Fields, 2)
+	assert.Equal(t, "Round 1", embed.Fields[0].Name)
+	assert.Equal(t, "`m1` Team A vs The MongolZ — Team A 0-2 📌", embed.Fields[0].Value)
+	assert.Equal(t, "`m2` Team C vs Team D — not played", embed.Fields[1].Value)
+	require.NotNil(t, embed.Footer)
+}
+
+func TestAdminOverride_SetQuotedWinner(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage(`$admin override m1 "the mongolz" 2-1`))
+
+	override, ok := mockStore.Overrides["m1"]
+	require.True(t, ok)
+	assert.Equal(t, "The MongolZ", override.Winner)
+	assert.Equal(t, "2-1", override.Score)
+	assert.Equal(t, "TestUser", override.SetBy)
+	assert.Equal(t, 1, *renders)
+	assert.Equal(t, "Override Set", mockSession.GetLastEmbed().Embed.Title)
+	entry := lastAudit(t, mockStore)
+	assert.Equal(t, "override", entry.Command)
+	assert.Equal(t, app.AuditResultOK, entry.Result)
+}
+
+func TestAdminOverride_RenderFailureStillConfirms(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	bot.RenderResults = func(context.Context) error { return errors.New("renderer crashed") }
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override m1 \"Team A\""))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Override Set", embed.Title)
+	assert.Contains(t, embed.Description, "$admin render")
+}
+
+func TestAdminOverride_InvalidWinner(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override m1 \"Team D\""))
+
+	assert.Empty(t, mockStore.Overrides)
+	assert.Zero(t, *renders)
+	assert.Equal(t, "The winner of match `m1` must be **Team A** or **The MongolZ**.", mockSession.GetLastEmbed().Embed.Description)
+	assert.Contains(t, lastAudit(t, mockStore).Result, "winner must be")
+}
+
+func TestAdminOverride_InvalidScoreUsesAdminLocale(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override m1 \"Team A\" two-nil"))
+
+	assert.Empty(t, mockStore.Overrides)
+	assert.Equal(t, "O placar deve ter o formato `2-1`, mas foi informado `two-nil`.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestAdminOverride_StoreErrorNotShown(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockStore.StoreResultOverrideError = errors.New("mongo: connection refused")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override m1 \"Team A\""))
+
+	description := mockSession.GetLastEmbed().Embed.Description
+	assert.Equal(t, "An error occurred setting the override.", description)
+	assert.NotContains(t, description, "connection refused")
+}
+
+func TestAdminOverride_Clear(t *testing.T) {
+	bot, mockStore, renders := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override clear m1"))
+
+	assert.Empty(t, mockStore.Overrides)
+	assert.Equal(t, 1, *renders)
+	assert.Equal(t, "Override Cleared", mockSession.GetLastEmbed().Embed.Title)
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override clear m1"))
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "has no override")
+}
+
+func TestAdminOverride_Usage(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin override m1"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "$admin matches")
+}
+
+func TestAdminOverrides_List(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	withOverrideNodes(mockStore)
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin overrides"))
+	assert.Equal(t, "No overrides in effect.", mockSession.GetLastEmbed().Embed.Description)
+
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A", Score: "2-0", SetBy: "mod"}
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin overrides"))
+	assert.Equal(t, "`m1` Team A vs The MongolZ: **Team A 2-0** (source: The MongolZ 0-2, set by mod)", mockSession.GetLastEmbed().Embed.Description)
+}
+
+// endregion
+
+// region alias tests
+
+func TestAdminAlias_Set(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.ValidTeams[0] = "Natus Vincere"
+	mockStore.Predictions["user1"] = models.Prediction{UserID: "user1", Username: "one", Format: "swiss", Round: "test_round"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage(`$admin alias NaVi "natus vincere"`))
+
+	alias, ok := mockStore.TeamAliases["navi"]
+	require.True(t, ok)
+	assert.Equal(t, "Natus Vincere", alias.Team)
+	assert.Equal(t, "TestUser", alias.SetBy)
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Alias Set", embed.Title)
+	assert.Equal(t, "`navi` now resolves to **Natus Vincere**.", embed.Description)
+	entry := lastAudit(t, mockStore)
+	assert.Equal(t, "alias", entry.Command)
+	assert.Equal(t, app.AuditResultOK, entry.Result)
+	assert.Len(t, mockStore.Leaderboard, 1)
+}
+
+func TestAdminAlias_RescoreFailureStillConfirms(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.GetAllUserPredictionsError = errors.New("db down")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage(`$admin alias navi "Natus Vincere"`))
+
+	assert.Contains(t, mockStore.TeamAliases, "navi")
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Alias Set", embed.Title)
+	assert.Contains(t, embed.Description, "$admin rescore")
+}
+
+func TestAdminAlias_Invalid(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage(`$admin alias "Team Liquid" Liquid`))
+
+	assert.Empty(t, mockStore.TeamAliases)
+	assert.Equal(t, "`Team Liquid` already matches **Liquid** without an alias.", mockSession.GetLastEmbed().Embed.Description)
+	assert.Contains(t, lastAudit(t, mockStore).Result, "already matches")
+}
+
+func TestAdminAlias_ChainedUsesAdminLocale(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pl"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin alias navy NAVI"))
+
+	assert.Len(t, mockStore.TeamAliases, 1)
+	assert.Equal(t, "**NAVI** jest już aliasem dla **Natus Vincere**. Wskaż alias na **Natus Vincere**.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestAdminAlias_StoreErrorNotShown(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.StoreTeamAliasError = errors.New("mongo: connection refused")
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage(`$admin alias navi "Natus Vincere"`))
+
+	description := mockSession.GetLastEmbed().Embed.Description
+	assert.Equal(t, "An error occurred setting the alias.", description)
+	assert.NotContains(t, description, "connection refused")
+}
+
+func TestAdminAlias_Remove(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere"}
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin alias remove NAVI"))
+
+	assert.Empty(t, mockStore.TeamAliases)
+	assert.Equal(t, "Alias Removed", mockSession.GetLastEmbed().Embed.Title)
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin alias remove navi"))
+	assert.Equal(t, "There is no alias `navi`.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestAdminAlias_Usage(t *testing.T) {
+	bot, _, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin alias navi"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage: `$admin alias <alias> <team>`")
+}
+
+func TestAdminAliases_List(t *testing.T) {
+	bot, mockStore, _ := createAdminTestBot()
+	mockSession := newAdminSession()
+
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin aliases"))
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No aliases set")
+
+	mockStore.TeamAliases["navi"] = store.TeamAlias{Alias: "navi", Team: "Natus Vincere", SetBy: "mod"}
+	mockStore.TeamAliases["g2"] = store.TeamAlias{Alias: "g2", Team: "G2 Esports", SetBy: "mod"}
+	bot.adminHandler(context.Background(), mockSession, createGuildMessage("$admin aliases"))
+	assert.Equal(t, "`g2` → **G2 Esports** (set by mod)\n`navi` → **Natus Vincere** (set by mod)", mockSession.GetLastEmbed().Embed.Description)
+}
+
+// endregion
@@ -0,0 +1,124 @@
+/* announcements.go
+ * Contains the methods used to post new match results to the configured announcement channel.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxEmbedFields is the maximum number of fields Discord allows on a single embed
+const maxEmbedFields = 25
+
+// AnnounceResults posts newly decided matches to the global announcement channel and every guild's
+// configured announcement channel. It is safe to call before the bot has connected; the announcement is
+// simply dropped.
+func (b *Bot) AnnounceResults(ctx context.Context, announcement app.ResultsAnnouncement) {
+	session := b.discordSession()
+	if session == nil {
+		return
+	}
+	b.announceResults(ctx, session, announcement)
+}
+
+// announceResults builds the results embed and sends it to each announcement channel, written in the locale of
+// the guild the channel belongs to
+func (b *Bot) announceResults(ctx context.Context, session DiscordSession, announcement app.ResultsAnnouncement) {
+	if len(announcement.Matches) == 0 {
+		return
+	}
+	channels := b.announcementChannels(ctx)
+	if len(channels) == 0 {
+		return
+	}
+
+	embeds := make(map[i18n.Locale]*discordgo.MessageEmbed)
+	for _, channel := range channels {
+		embed, ok := embeds[channel.locale]
+		if !ok {
+			embed = resultsAnnouncementEmbed(channel.locale, announcement)
+			embeds[channel.locale] = embed
+		}
+		if _, err := session.ChannelMessageSendEmbed(channel.id, embed); err != nil {
+			b.logger().Error("failed to send results announcement", "channel", channel.id, "error", fmt.Errorf("announceResults: %w", err))
+		}
+	}
+}
+
+// resultsAnnouncementEmbed builds the embed announcing newly decided matches, written in loc
+func resultsAnnouncementEmbed(loc i18n.Locale, announcement app.ResultsAnnouncement) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: loc.T("announce.title", announcement.Round),
+		Color: green,
+	}
+	for i, m := range announcement.Matches {
+		if i == maxEmbedFields {
+			embed.Description = loc.T("announce.truncated", maxEmbedFields, len(announcement.Matches))
+			break
+		}
+		embed.Fields = append(embed.Fields, matchResultField(loc, m))
+	}
+
+	switch announcement.PicksDecided {
+	case 0:
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: loc.T("announce.none_decided")}
+	case 1:
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: loc.T("announce.one_decided")}
+	default:
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: loc.T("announce.many_decided", announcement.PicksDecided)}
+	}
+	return embed
+}
+
+// announcementChannel is a channel results are announced in and the locale the announcement is written in
+type announcementChannel struct {
+	id     string
+	locale i18n.Locale
+}
+
+// announcementChannels returns the global announcement channel, in the default locale, plus every guild's
+// configured one in that guild's locale, without duplicates
+func (b *Bot) announcementChannels(ctx context.Context) []announcementChannel {
+	var channels []announcementChannel
+	if b.AnnouncementChannel != "" {
+		channels = append(channels, announcementChannel{id: b.AnnouncementChannel, locale: i18n.English})
+	}
+	guilds, err := b.APIPtr.ListGuildSettings(ctx)
+	if err != nil {
+		b.logger().Warn("failed to load guild announcement channels", "error", fmt.Errorf("announcementChannels: %w", err))
+		return channels
+	}
+	for _, g := range guilds {
+		announced := slices.ContainsFunc(channels, func(c announcementChannel) bool { return c.id == g.AnnouncementChannel })
+		if g.AnnouncementChannel != "" && !announced {
+			channels = append(channels, announcementChannel{id: g.AnnouncementChannel, locale: i18n.Locale(g.Locale)})
+		}
+	}
+	return channels
+}
+
+// matchResultField formats a single finished match as an embed field in loc, including Swiss records when known
+func matchResultField(loc i18n.Locale, m app.FinishedMatch) *discordgo.MessageEmbedField {
+	name := fmt.Sprintf("%s vs %s", m.Team1, m.Team2)
+	if m.Section != "" {
+		name = fmt.Sprintf("%s: %s", m.Section, name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(loc.T("announce.wins", m.Winner))
+	if m.Score != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", m.Score))
+	}
+	if r1, r2 := m.Records[m.Team1], m.Records[m.Team2]; r1 != "" && r2 != "" {
+		sb.WriteString("\n" + loc.T("announce.records", m.Team1, r1, m.Team2, r2))
+	}
+	return &discordgo.MessageEmbedField{Name: name, Value: sb.String(), Inline: false}
+}
@@ -0,0 +1,176 @@
+/* announcements_test.go
+ * Contains unit tests for match result announcements
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region announceResults tests
+
+func TestAnnounceResults_SendsEmbedToChannel(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "announce123"
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{
+		Round: "Stage_1",
+		Matches: []app.FinishedMatch{{
+			Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-1", Section: "Round 3",
+			Records: map[string]string{"Team A": "3-0", "Team B": "2-1"},
+		}},
+		PicksDecided: 12,
+	})
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "announce123", embed.ChannelID)
+	assert.Contains(t, embed.Embed.Title, "Stage_1")
+	require.Len(t, embed.Embed.Fields, 1)
+	assert.Equal(t, "Round 3: Team A vs Team B", embed.Embed.Fields[0].Name)
+	assert.Contains(t, embed.Embed.Fields[0].Value, "**Team A** wins (2-1)")
+	assert.Contains(t, embed.Embed.Fields[0].Value, "Team A is now **3-0**")
+	assert.Contains(t, embed.Embed.Footer.Text, "12 users")
+}
+
+func TestAnnounceResults_NoRecordsOrScore(t *testing.T) {
+	bot := createTestBot("single-elimination")
+	bot.AnnouncementChannel = "announce123"
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{
+		Matches:      []app.FinishedMatch{{Team1: "Team A", Team2: "Team B", Winner: "Team B"}},
+		PicksDecided: 1,
+	})
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "Team A vs Team B", embed.Embed.Fields[0].Name)
+	assert.Equal(t, "🏆 **Team B** wins", embed.Embed.Fields[0].Value)
+	assert.Contains(t, embed.Embed.Footer.Text, "1 user just")
+}
+
+func TestAnnounceResults_CapsFields(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "announce123"
+	mockSession := NewMockDiscordSession()
+
+	var matches []app.FinishedMatch
+	for i := range 30 {
+		matches = append(matches, app.FinishedMatch{Team1: fmt.Sprintf("T%d", i), Team2: "X", Winner: "X"})
+	}
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{Matches: matches})
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Len(t, embed.Embed.Fields, maxEmbedFields)
+	assert.Contains(t, embed.Embed.Description, "25 of 30")
+	assert.Contains(t, embed.Embed.Footer.Text, "No Pick'Ems")
+}
+
+func TestAnnounceResults_NoMatchesSendsNothing(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "announce123"
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{})
+
+	assert.Empty(t, mockSession.SentEmbeds)
+}
+
+func TestAnnounceResults_SendErrorIsLogged(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "announce123"
+	mockSession := NewMockDiscordSession()
+	mockSession.ErrorToReturn = errors.New("missing access")
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}}})
+
+	assert.Empty(t, mockSession.SentEmbeds)
+}
+
+func TestAnnounceResults_NotConnectedIsNoop(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "announce123"
+
+	// session is nil before Run; must not panic
+	bot.AnnounceResults(context.Background(), app.ResultsAnnouncement{Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}}})
+}
+
+// endregion
+
+// region guild announcement channel tests
+
+func TestAnnounceResults_GuildChannels(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "global"
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", AnnouncementChannel: "guild1-results"}
+	mockStore.GuildSettings["guild2"] = store.GuildSettings{GuildID: "guild2", AnnouncementChannel: "global"}
+	mockStore.GuildSettings["guild3"] = store.GuildSettings{GuildID: "guild3"}
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}}})
+
+	var channels []string
+	for _, e := range mockSession.SentEmbeds {
+		channels = append(channels, e.ChannelID)
+	}
+	assert.ElementsMatch(t, []string{"global", "guild1-results"}, channels)
+}
+
+func TestAnnounceResults_GuildLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "global"
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild1"] = store.GuildSettings{GuildID: "guild1", AnnouncementChannel: "guild1-results", Locale: "pl"}
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{
+		Round:   "Stage 1",
+		Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}},
+	})
+
+	titles := make(map[string]string)
+	for _, e := range mockSession.SentEmbeds {
+		titles[e.ChannelID] = e.Embed.Title
+	}
+	assert.Equal(t, map[string]string{
+		"global":         "🏁 Match Results — Stage 1",
+		"guild1-results": "🏁 Wyniki meczów — Stage 1",
+	}, titles)
+}
+
+func TestAnnounceResults_NoChannels(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}}})
+
+	assert.Empty(t, mockSession.SentEmbeds)
+}
+
+func TestAnnounceResults_GuildLookupErrorKeepsGlobal(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.AnnouncementChannel = "global"
+	bot.APIPtr.Store.(*app.MockStore).FetchAllGuildSettingsError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.announceResults(context.Background(), mockSession, app.ResultsAnnouncement{Matches: []app.FinishedMatch{{Team1: "A", Team2: "B", Winner: "A"}}})
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "global", mockSession.SentEmbeds[0].ChannelID)
+}
+
+// endregion
@@ -7,10 +7,14 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"pickems-bot/app"
+	"pickems-bot/store"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -19,8 +23,29 @@ import (
 type Bot struct {
 	BotToken string
 	APIPtr   *app.App
-	session  *discordgo.Session
-	log      *slog.Logger
+	// ReminderLeadTimes are how long before a round locks users without picks are DM'd. Empty disables reminders.
+	ReminderLeadTimes []time.Duration
+	// AnnouncementChannel is the channel ID new match results are posted to. Empty disables announcements.
+	AnnouncementChannel string
+	// RenderResults regenerates the $results image. Injected by main so the bot doesn't depend on the web
+	// package; nil makes $admin render fail.
+	RenderResults func(ctx context.Context) error
+	// ScheduleOnly mirrors the upcoming_only config: $admin refresh fetches only the schedule and skips rendering.
+	ScheduleOnly bool
+	// CalendarURL is the public address of the calendar feed, linked from $calendar. Empty when it isn't served.
+	CalendarURL string
+	// CommandTimeout bounds how long a single command or button press may spend on storage and data source
+	// calls. Zero leaves commands bounded only by the bot's lifetime.
+	CommandTimeout  time.Duration
+	runMu           sync.RWMutex    // guards baseCtx and session, set by Run while the update pipelines read them
+	baseCtx         context.Context // cancelled on shutdown; set by Run
+	matchDayMu      sync.Mutex      // serialises match day posts so a day rollover is only posted once per guild
+	settingsMu      sync.RWMutex
+	settingsCache   map[string]store.GuildSettings // guild ID -> settings, loaded on first use
+	settingsRetryAt map[string]time.Time           // guild ID -> when a failed settings lookup may be retried
+	userLocales     map[string]string              // user ID -> chosen locale ("" for none), guarded by settingsMu
+	session         *discordgo.Session
+	log             *slog.Logger
 }
 
 // logger returns the bot's logger, falling back to the global default when none was injected.
@@ -31,6 +56,32 @@ func (b *Bot) logger() *slog.Logger {
 	return b.log
 }
 
+// context returns the context background work should run under, falling back to context.Background() before
+// Run has been called.
+func (b *Bot) context() context.Context {
+	b.runMu.RLock()
+	defer b.runMu.RUnlock()
+	if b.baseCtx == nil {
+		return context.Background()
+	}
+	return b.baseCtx
+}
+
+// discordSession returns the session opened by Run, or nil before the bot has connected
+func (b *Bot) discordSession() *discordgo.Session {
+	b.runMu.RLock()
+	defer b.runMu.RUnlock()
+	return b.session
+}
+
+// commandContext derives the context a single command or interaction runs under, bounded by CommandTimeout.
+func (b *Bot) commandContext() (context.Context, context.CancelFunc) {
+	if b.CommandTimeout <= 0 {
+		return context.WithCancel(b.context())
+	}
+	return context.WithTimeout(b.context(), b.CommandTimeout)
+}
+
 // NewBot creates a new Bot instance with the provided token and API pointer.
 // log may be nil; if so the global slog default is used.
 func NewBot(botToken string, apiPtr *app.App, log *slog.Logger) (*Bot, error) {
@@ -51,7 +102,8 @@ func NewBot(botToken string, apiPtr *app.App, log *slog.Logger) (*Bot, error) {
 
 // IsConnected reports whether the Discord gateway session is open
 func (b *Bot) IsConnected() bool {
-	return b.session != nil && b.session.DataReady
+	session := b.discordSession()
+	return session != nil && session.DataReady
 }
 
 // startsWith is an internal helper to check if a string starts with a given substring
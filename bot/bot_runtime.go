@@ -9,33 +9,40 @@
 package bot
 
 import (
-	"os"
-	"os/signal"
+	"context"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-// Run starts the Discord bot and listens for messages
-func (b *Bot) Run() error {
+// Run starts the Discord bot and listens for messages until ctx is cancelled
+func (b *Bot) Run(ctx context.Context) error {
 	// create a session
 	discord, err := discordgo.New("Bot " + b.BotToken)
 	if err != nil {
 		return err
 	}
 
+	// commands and background loops run under ctx so they are cancelled on shutdown. Both are set before the
+	// session is opened, as the update pipelines may already be reading them from other goroutines.
+	b.runMu.Lock()
+	b.baseCtx = ctx
+	b.session = discord
+	b.runMu.Unlock()
+
 	// add a event handler
 	discord.AddHandler(b.newMessage)
+	discord.AddHandler(b.newInteraction)
 
 	// open session
 	discord.Open()
-	b.session = discord
 	defer discord.Close() // close session, after function termination
 
-	// keep bot running until there is NO os interruption (ctrl + C)
+	// DM users who haven't set picks before the round locks
+	go b.runReminders(ctx, discord, reminderInterval)
+
+	// keep bot running until shutdown is requested
 	b.logger().Info("Pickems Bot started")
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-	<-c
+	<-ctx.Done()
 	return nil
 }
 
@@ -44,3 +51,8 @@ func (b *Bot) Run() error {
 func (b *Bot) newMessage(discord *discordgo.Session, message *discordgo.MessageCreate) {
 	b.newMessageHandler(discord, message, discord.State.User.ID)
 }
+
+// newInteraction delegates to the testable newInteractionHandler
+func (b *Bot) newInteraction(discord *discordgo.Session, interaction *discordgo.InteractionCreate) {
+	b.newInteractionHandler(discord, interaction)
+}
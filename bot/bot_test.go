@@ -6,7 +6,9 @@
 package bot
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,3 +84,35 @@ func TestStartsWith_SpecialCharacters(t *testing.T) {
 	result := startsWith("$check-predictions", "$check")
 	assert.True(t, result)
 }
+
+// TestCommandContext_AppliesCommandTimeout tests that commands get a deadline when CommandTimeout is set
+func TestCommandContext_AppliesCommandTimeout(t *testing.T) {
+	bot := &Bot{CommandTimeout: time.Minute}
+	ctx, cancel := bot.commandContext()
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+// TestCommandContext_NoTimeout tests that commands have no deadline when CommandTimeout is zero
+func TestCommandContext_NoTimeout(t *testing.T) {
+	bot := &Bot{}
+	ctx, cancel := bot.commandContext()
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
+
+// TestCommandContext_CancelledOnShutdown tests that in-flight commands are cancelled with the bot's context
+func TestCommandContext_CancelledOnShutdown(t *testing.T) {
+	base, shutdown := context.WithCancel(context.Background())
+	bot := &Bot{CommandTimeout: time.Minute, baseCtx: base}
+	ctx, cancel := bot.commandContext()
+	defer cancel()
+
+	shutdown()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
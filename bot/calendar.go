@@ -0,0 +1,60 @@
+/* calendar.go
+ * Contains the $calendar command, which sends the current round's schedule as an iCalendar (.ics) file.
+ */
+
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// fileNameUnsafe matches characters that are replaced when a round name is used in a file name
+var fileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// calendarHandler handles the $calendar command with a DiscordSession interface
+func (b *Bot) calendarHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	calendar, err := b.APIPtr.GetCalendar(ctx, time.Now())
+	if err != nil {
+		b.logger().Error("failed to build calendar", "error", fmt.Errorf("calendarHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("calendar.error"))
+		return
+	}
+
+	round := b.APIPtr.Store.GetRound()
+	description := loc.T("calendar.description", round)
+	if b.CalendarURL != "" {
+		description += "\n\n" + loc.T("calendar.subscribe", b.CalendarURL)
+	}
+	data := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{{
+			Title:       loc.T("calendar.title"),
+			Description: description,
+			Color:       green,
+		}},
+		Files: []*discordgo.File{{
+			Name:        calendarFileName(round),
+			ContentType: "text/calendar",
+			Reader:      bytes.NewReader(calendar),
+		}},
+	}
+	if _, err := session.ChannelMessageSendComplex(message.ChannelID, data); err != nil {
+		b.logger().Error("failed to send calendar", "error", fmt.Errorf("calendarHandler: %w", err))
+	}
+}
+
+// calendarFileName returns the attachment name for a round's calendar, e.g. "pickems-Stage_1.ics"
+func calendarFileName(round string) string {
+	name := strings.Trim(fileNameUnsafe.ReplaceAllString(round, "_"), "_")
+	if name == "" {
+		return "pickems.ics"
+	}
+	return "pickems-" + name + ".ics"
+}
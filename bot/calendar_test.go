@@ -0,0 +1,84 @@
+/* calendar_test.go
+ * Contains unit tests for the $calendar command
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region calendar tests
+
+func TestCalendar_SendsFile(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "Team B", BestOf: "3", EpochTime: 1700000000},
+	})
+	mockSession := NewMockDiscordSession()
+
+	bot.calendarHandler(context.Background(), mockSession, createMockMessage("$calendar", "user123", "TestUser", "channel123"))
+
+	require.Len(t, mockSession.SentComplex, 1)
+	sent := mockSession.SentComplex[0]
+	assert.Equal(t, "📅 Match Calendar", sent.Embeds[0].Title)
+	assert.NotContains(t, sent.Embeds[0].Description, "subscribe")
+	require.Len(t, sent.Files, 1)
+	assert.Equal(t, "pickems-test_round.ics", sent.Files[0].Name)
+	assert.Equal(t, "text/calendar", sent.Files[0].ContentType)
+	body, err := io.ReadAll(sent.Files[0].Reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "SUMMARY:Team A vs Team B (Bo3)")
+}
+
+func TestCalendar_SubscribeURL(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.CalendarURL = "https://pickems.example.com/calendar.ics"
+	mockSession := NewMockDiscordSession()
+
+	bot.calendarHandler(context.Background(), mockSession, createMockMessage("$calendar", "user123", "TestUser", "channel123"))
+
+	require.Len(t, mockSession.SentComplex, 1)
+	assert.Contains(t, mockSession.SentComplex[0].Embeds[0].Description, "subscribe to https://pickems.example.com/calendar.ics")
+}
+
+func TestCalendar_Error(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).FetchMatchScheduleError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.calendarHandler(context.Background(), mockSession, createMockMessage("$calendar", "user123", "TestUser", "channel123"))
+
+	assert.Empty(t, mockSession.SentComplex)
+	assert.Equal(t, "An error occurred building the match calendar.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestCalendar_ErrorUsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.FetchMatchScheduleError = errors.New("db down")
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pl"}
+	mockSession := NewMockDiscordSession()
+
+	bot.calendarHandler(context.Background(), mockSession, createMockMessage("$calendar", "user123", "TestUser", "channel123"))
+
+	assert.Equal(t, "Wystąpił błąd podczas tworzenia kalendarza meczów.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestCalendarFileName(t *testing.T) {
+	assert.Equal(t, "pickems-Stage_1.ics", calendarFileName("Stage_1"))
+	assert.Equal(t, "pickems-Playoff_Stage.ics", calendarFileName("Playoff Stage!"))
+	assert.Equal(t, "pickems.ics", calendarFileName(""))
+}
+
+// endregion
@@ -0,0 +1,154 @@
+/* compare.go
+ * Contains the $compare command, a head-to-head of two users' Pick'Ems for the current round.
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-andiamo/splitter"
+)
+
+// compareHandler handles the $compare command with a DiscordSession interface
+func (b *Bot) compareHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
+	parts, err := spaceSplitter.Split(message.Content)
+	if err != nil || len(parts) < 2 {
+		// Split fails on an unclosed quote
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("compare.usage"))
+		return
+	}
+	targets := parts[1:]
+	switch len(targets) {
+	case 1:
+		targets = []string{message.Author.ID, targets[0]}
+	case 2:
+	default:
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("compare.usage"))
+		return
+	}
+
+	users := make([]models.User, len(targets))
+	for i, target := range targets {
+		user, err := b.findComparedUser(ctx, target)
+		if err != nil {
+			if target == message.Author.ID {
+				target = message.Author.Username
+			}
+			if errors.Is(err, store.ErrNotFound) {
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.no_picks_user", target))
+			} else {
+				b.logger().Error("failed to look up user", "target", target, "error", fmt.Errorf("compareHandler: %w", err))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("user.lookup_error", target))
+			}
+			return
+		}
+		users[i] = user
+	}
+	if users[0].UserID == users[1].UserID {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("compare.same_user"))
+		return
+	}
+
+	comparison, err := b.APIPtr.ComparePredictions(ctx, users[0], users[1])
+	if err != nil {
+		b.logger().Error("failed to compare predictions", "userA", users[0].Username, "userB", users[1].Username, "error", fmt.Errorf("compareHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("compare.error", users[0].Username, users[1].Username))
+		return
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, compareEmbed(loc, comparison)); err != nil {
+		b.logger().Error("failed to send compare embed", "error", fmt.Errorf("compareHandler: %w", err))
+	}
+}
+
+// findComparedUser resolves a mention, user ID or username to a user with Pick'Ems stored for this round
+func (b *Bot) findComparedUser(ctx context.Context, target string) (models.User, error) {
+	if m := userMentionPattern.FindStringSubmatch(target); m != nil {
+		target = m[1] + m[2]
+	}
+	return b.APIPtr.FindPredictor(ctx, target)
+}
+
+// compareEmbed builds the $compare embed: both scores, the shared picks, each user's own picks and the teams
+// whose remaining matches decide who finishes ahead, written in loc
+func compareEmbed(loc i18n.Locale, c app.Comparison) *discordgo.MessageEmbed {
+	nameA, nameB := c.UserA.Username, c.UserB.Username
+	pointsA, pointsB := app.Points(c.ScoreA), app.Points(c.ScoreB)
+
+	description := loc.T("compare.score", nameA, c.ScoreA.Successes, c.ScoreA.Pending, pointsA) + "\n" +
+		loc.T("compare.score", nameB, c.ScoreB.Successes, c.ScoreB.Pending, pointsB) + "\n\n"
+	switch {
+	case pointsA > pointsB:
+		description += loc.T("compare.leads", nameA, pointsA-pointsB)
+	case pointsB > pointsA:
+		description += loc.T("compare.leads", nameB, pointsB-pointsA)
+	default:
+		description += loc.T("compare.level")
+	}
+
+	deciding := make([]string, 0, len(c.Deciding))
+	for _, d := range c.Deciding {
+		deciding = append(deciding, fmt.Sprintf("**%s**%s: %s %s · %s %s",
+			d.Team, record(d.PickA, d.PickB), nameA, slotStatus(loc, d.PickA), nameB, slotStatus(loc, d.PickB)))
+	}
+	decidingValue := joinFieldLines(loc, deciding)
+	if len(deciding) == 0 {
+		decidingValue = loc.T("compare.no_deciding")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       loc.T("compare.title", nameA, nameB),
+		Description: description,
+		Color:       burple,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: loc.T("compare.shared", len(c.Shared)), Value: pickLines(loc, c.Shared)},
+			{Name: loc.T("compare.only", nameA), Value: pickLines(loc, c.OnlyA), Inline: true},
+			{Name: loc.T("compare.only", nameB), Value: pickLines(loc, c.OnlyB), Inline: true},
+			{Name: loc.T("compare.deciding"), Value: decidingValue},
+		},
+	}
+}
+
+// pickLines formats picks one per line, e.g. "3-0: **Team A** (2-0) ⏳"
+func pickLines(loc i18n.Locale, picks []app.Pick) string {
+	if len(picks) == 0 {
+		return "—"
+	}
+	lines := make([]string, 0, len(picks))
+	for _, p := range picks {
+		line := fmt.Sprintf("%s: **%s**", p.Slot, p.Team)
+		if p.Record != "" {
+			line += fmt.Sprintf(" (%s)", p.Record)
+		}
+		lines = append(lines, line+" "+p.Status.String())
+	}
+	return joinFieldLines(loc, lines)
+}
+
+// slotStatus describes one user's pick of a deciding team, e.g. "Advance ⏳" or "didn't pick"
+func slotStatus(loc i18n.Locale, p *app.Pick) string {
+	if p == nil {
+		return loc.T("compare.not_picked")
+	}
+	return p.Slot + " " + p.Status.String()
+}
+
+// record returns a deciding team's current Swiss record for display, e.g. " (2-1)", or "" if there isn't one
+func record(picks ...*app.Pick) string {
+	for _, p := range picks {
+		if p != nil && p.Record != "" {
+			return fmt.Sprintf(" (%s)", p.Record)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+/* compare_test.go
+ * Contains unit tests for the $compare command
+ */
+
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCompareTestBot returns a Swiss test bot with Pick'Ems stored for TestUser (user123) and rival (456)
+func createCompareTestBot() *Bot {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.SetSwissResults(map[string]string{
+		"Team A": "3-0", "Team C": "3-2", "Team E": "0-2", "Team F": "0-3", "Team G": "2-1",
+	})
+	mockStore.Predictions["user123"] = models.Prediction{
+		UserID: "user123", Username: "TestUser", Format: "swiss", Round: "test_round",
+		Win: []string{"Team A"}, Advance: []string{"Team C"}, Lose: []string{"Team F"},
+	}
+	mockStore.Predictions["456"] = models.Prediction{
+		UserID: "456", Username: "rival", Format: "swiss", Round: "test_round",
+		Win: []string{"Team A"}, Advance: []string{"Team G"}, Lose: []string{"Team E"},
+	}
+	return bot
+}
+
+// region compare tests
+
+func TestCompare_TwoUsernames(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare TestUser rival", "user789", "Someone", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "TestUser vs rival", embed.Title)
+	assert.Contains(t, embed.Description, "**TestUser**: 3 correct, 0 pending — 9 pts")
+	assert.Contains(t, embed.Description, "**rival**: 1 correct, 2 pending — 5 pts")
+	assert.Contains(t, embed.Description, "TestUser leads by 4.")
+
+	require.Len(t, embed.Fields, 4)
+	assert.Equal(t, "🤝 Shared Picks (1)", embed.Fields[0].Name)
+	assert.Contains(t, embed.Fields[0].Value, "3-0: **Team A** (3-0) ✅")
+	assert.Equal(t, "Only TestUser", embed.Fields[1].Name)
+	assert.Contains(t, embed.Fields[1].Value, "Advance: **Team C** (3-2) ✅")
+	assert.Equal(t, "Only rival", embed.Fields[2].Name)
+	assert.Contains(t, embed.Fields[2].Value, "0-3: **Team E** (0-2) ⏳")
+
+	// Only rival's pending picks are still in play; TestUser's own picks are already settled
+	assert.Equal(t, "**Team E** (0-2): TestUser didn't pick · rival 0-3 ⏳\n**Team G** (2-1): TestUser didn't pick · rival Advance ⏳", embed.Fields[3].Value)
+}
+
+func TestCompare_MentionAgainstAuthor(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+	message := createMockMessage("$compare <@456>", "user123", "TestUser", "channel123")
+	message.Mentions = []*discordgo.User{{ID: "456", Username: "rival"}}
+
+	bot.compareHandler(context.Background(), mockSession, message)
+
+	assert.Equal(t, "TestUser vs rival", mockSession.GetLastEmbed().Embed.Title)
+}
+
+func TestCompare_NoDecidingTeams(t *testing.T) {
+	bot := createCompareTestBot()
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	rival := mockStore.Predictions["user123"]
+	rival.UserID, rival.Username = "456", "rival"
+	mockStore.Predictions["456"] = rival
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare TestUser rival", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Contains(t, embed.Description, "Level on points.")
+	assert.Equal(t, "—", embed.Fields[1].Value)
+	assert.Contains(t, embed.Fields[3].Value, "every pick still in play is shared")
+}
+
+func TestCompare_UsesUserLocale(t *testing.T) {
+	bot := createCompareTestBot()
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	rival := mockStore.Predictions["user123"]
+	rival.UserID, rival.Username = "456", "rival"
+	mockStore.Predictions["456"] = rival
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare TestUser rival", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Contains(t, embed.Description, "Empatados em pontos.")
+	require.Len(t, embed.Fields, 4)
+	assert.Equal(t, "Só TestUser", embed.Fields[1].Name)
+	assert.Equal(t, "⚔️ Times decisivos", embed.Fields[3].Name)
+}
+
+func TestCompare_Usage(t *testing.T) {
+	bot := createCompareTestBot()
+
+	for _, content := range []string{"$compare", "$compare a b c", `$compare "abc`} {
+		mockSession := NewMockDiscordSession()
+		bot.compareHandler(context.Background(), mockSession, createMockMessage(content, "user123", "TestUser", "channel123"))
+		assert.Equal(t, i18n.English.T("compare.usage"), mockSession.GetLastEmbed().Embed.Description, content)
+	}
+}
+
+func TestCompare_UnknownUser(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare TestUser ghost", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No Pick'Ems found for **ghost**.")
+}
+
+func TestCompare_AuthorWithoutPicks(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare rival", "user789", "Lurker", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No Pick'Ems found for **Lurker**.")
+}
+
+func TestCompare_SameUser(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.compareHandler(context.Background(), mockSession, createMockMessage("$compare rival", "456", "rival", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Pick two different users")
+}
+
+// endregion
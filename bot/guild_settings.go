@@ -0,0 +1,186 @@
+/* guild_settings.go
+ * Contains the $config command, the per-guild settings cache and the admin permission check.
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/store"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// settingsRetryInterval is how long a guild whose settings failed to load gets the defaults before the lookup is
+// retried, so a database outage doesn't hold up every message in the guild for the full operation timeout
+const settingsRetryInterval = 30 * time.Second
+
+// guildSettings returns a guild's settings, loading them into the cache on first use. DMs, and guilds whose
+// settings can't be loaded, get the defaults; failed lookups are retried after settingsRetryInterval.
+func (b *Bot) guildSettings(ctx context.Context, guildID string) store.GuildSettings {
+	defaults := store.GuildSettings{GuildID: guildID, Prefix: app.DefaultPrefix, Locale: app.DefaultLocale, Timezone: app.DefaultTimezone}
+	if guildID == "" {
+		return defaults
+	}
+
+	b.settingsMu.RLock()
+	settings, ok := b.settingsCache[guildID]
+	retryAt, failed := b.settingsRetryAt[guildID]
+	b.settingsMu.RUnlock()
+	if ok {
+		return settings
+	}
+	if failed && time.Now().Before(retryAt) {
+		return defaults
+	}
+
+	settings, err := b.APIPtr.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		b.logger().Warn("failed to load guild settings, using defaults", "guild", guildID, "error", fmt.Errorf("guildSettings: %w", err))
+		b.settingsMu.Lock()
+		if b.settingsRetryAt == nil {
+			b.settingsRetryAt = make(map[string]time.Time)
+		}
+		b.settingsRetryAt[guildID] = time.Now().Add(settingsRetryInterval)
+		b.settingsMu.Unlock()
+		return defaults
+	}
+	b.cacheGuildSettings(settings)
+	return settings
+}
+
+// cacheGuildSettings stores a guild's settings in the cache
+func (b *Bot) cacheGuildSettings(settings store.GuildSettings) {
+	b.settingsMu.Lock()
+	defer b.settingsMu.Unlock()
+	if b.settingsCache == nil {
+		b.settingsCache = make(map[string]store.GuildSettings)
+	}
+	b.settingsCache[settings.GuildID] = settings
+	delete(b.settingsRetryAt, settings.GuildID)
+}
+
+// isAdmin reports whether the message author may run admin commands in the message's guild: they hold the
+// configured admin role, or have the Administrator or Manage Server permission. Always false in DMs.
+func (b *Bot) isAdmin(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) bool {
+	if message.GuildID == "" {
+		return false
+	}
+	settings := b.guildSettings(ctx, message.GuildID)
+	if settings.AdminRoleID != "" && message.Member != nil && slices.Contains(message.Member.Roles, settings.AdminRoleID) {
+		return true
+	}
+	perms, err := session.UserChannelPermissions(message.Author.ID, message.ChannelID)
+	if err != nil {
+		b.logger().Warn("failed to check user permissions", "user", message.Author.Username, "error", fmt.Errorf("isAdmin: %w", err))
+		return false
+	}
+	return perms&(discordgo.PermissionAdministrator|discordgo.PermissionManageGuild) != 0
+}
+
+// requireAdmin sends an error and returns false when the message author isn't an admin
+func (b *Bot) requireAdmin(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate, command string) bool {
+	if message.GuildID == "" {
+		loc := b.locale(ctx, message)
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.guild_only", command))
+		return false
+	}
+	if !b.isAdmin(ctx, session, message) {
+		loc := b.locale(ctx, message)
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("admin.denied", command))
+		return false
+	}
+	return true
+}
+
+// configHandler handles $config (show settings), $config set <key> <value> and $config reset <key>
+func (b *Bot) configHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	if !b.requireAdmin(ctx, session, message, "$config") {
+		return
+	}
+	loc := b.locale(ctx, message)
+
+	args := strings.Fields(message.Content)
+	if len(args) == 1 {
+		b.sendGuildSettings(session, message.ChannelID, loc, b.guildSettings(ctx, message.GuildID), loc.T("config.title"))
+		return
+	}
+
+	var key, value string
+	switch {
+	case args[1] == "set" && len(args) >= 4:
+		key, value = args[2], strings.Join(args[3:], " ")
+	case args[1] == "reset" && len(args) == 3:
+		key = args[2]
+	default:
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("config.usage", strings.Join(app.GuildSettingKeys, ", ")))
+		return
+	}
+
+	settings, err := b.APIPtr.SetGuildSetting(ctx, message.GuildID, key, value)
+	if err != nil {
+		var settingErr *app.GuildSettingError
+		if !errors.As(err, &settingErr) {
+			b.logger().Error("failed to update guild setting", "guild", message.GuildID, "key", key, "error", fmt.Errorf("configHandler: %w", err))
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("config.error"))
+			return
+		}
+		sendLocalizedError(session, message.ChannelID, loc, guildSettingErrorMessage(loc, settingErr))
+		return
+	}
+	b.cacheGuildSettings(settings)
+	b.sendGuildSettings(session, message.ChannelID, loc, settings, loc.T("config.updated_title"))
+}
+
+// guildSettingErrorMessage describes a SetGuildSetting validation error in the given locale
+func guildSettingErrorMessage(loc i18n.Locale, err *app.GuildSettingError) string {
+	switch err.Kind {
+	case app.GuildSettingUnknownKey:
+		return loc.T("config.unknown_key", err.Key, strings.Join(app.GuildSettingKeys, ", "))
+	case app.GuildSettingInvalidPrefix:
+		return loc.T("config.invalid_prefix")
+	case app.GuildSettingInvalidChannel:
+		return loc.T("config.invalid_channel", err.Key)
+	case app.GuildSettingInvalidRole:
+		return loc.T("config.invalid_role", err.Key)
+	case app.GuildSettingUnsupportedLocale:
+		return loc.T("config.unsupported_locale", err.Value, strings.Join(i18n.SupportedLocales, ", "))
+	case app.GuildSettingUnknownTimezone:
+		return loc.T("config.unknown_timezone", err.Value)
+	}
+	return loc.T("config.error")
+}
+
+// sendGuildSettings sends an embed listing a guild's settings, written in loc. The setting names are left as they
+// are typed in $config.
+func (b *Bot) sendGuildSettings(session DiscordSession, channelID string, loc i18n.Locale, settings store.GuildSettings, title string) {
+	orNone := func(v, format string) string {
+		if v == "" {
+			return loc.T("config.not_set")
+		}
+		return fmt.Sprintf(format, v)
+	}
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: green,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "prefix", Value: fmt.Sprintf("`%s`", settings.Prefix), Inline: true},
+			{Name: "locale", Value: settings.Locale, Inline: true},
+			{Name: "timezone", Value: settings.Timezone, Inline: true},
+			{Name: "announcement_channel", Value: orNone(settings.AnnouncementChannel, "<#%s>"), Inline: true},
+			{Name: "reminder_channel", Value: orNone(settings.ReminderChannel, "<#%s>"), Inline: true},
+			{Name: "admin_role", Value: orNone(settings.AdminRoleID, "<@&%s>"), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: loc.T("config.footer")},
+	}
+	if _, err := session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		b.logger().Error("failed to send guild settings embed", "error", fmt.Errorf("sendGuildSettings: %w", err))
+	}
+}
@@ -0,0 +1,269 @@
+/* guild_settings_test.go
+ * Contains unit tests for the $config command, guild prefixes and the admin permission check
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAdminSession returns a mock session in which every user has the Administrator permission
+func newAdminSession() *MockDiscordSession {
+	session := NewMockDiscordSession()
+	session.Permissions = discordgo.PermissionAdministrator
+	return session
+}
+
+// createGuildMessage returns a mock message sent in guild123
+func createGuildMessage(content string) *discordgo.MessageCreate {
+	message := createMockMessage(content, "user123", "TestUser", "channel123")
+	message.GuildID = "guild123"
+	return message
+}
+
+// region guildSettings tests
+
+func TestGuildSettings_DefaultsForDMs(t *testing.T) {
+	bot := createTestBot("swiss")
+
+	settings := bot.guildSettings(context.Background(), "")
+
+	assert.Equal(t, app.DefaultPrefix, settings.Prefix)
+	assert.Equal(t, app.DefaultTimezone, settings.Timezone)
+}
+
+func TestGuildSettings_CachesLookups(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "!"}
+
+	assert.Equal(t, "!", bot.guildSettings(context.Background(), "guild123").Prefix)
+
+	// Later store changes are not visible until the cache is updated
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "?"}
+	assert.Equal(t, "!", bot.guildSettings(context.Background(), "guild123").Prefix)
+}
+
+func TestGuildSettings_ErrorFallsBackToDefaults(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).GetGuildSettingsError = errors.New("db down")
+
+	assert.Equal(t, app.DefaultPrefix, bot.guildSettings(context.Background(), "guild123").Prefix)
+}
+
+func TestGuildSettings_ErrorRetriedAfterInterval(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GetGuildSettingsError = errors.New("db down")
+	assert.Equal(t, app.DefaultPrefix, bot.guildSettings(context.Background(), "guild123").Prefix)
+
+	// The store recovers, but the failure is remembered until the retry interval passes
+	mockStore.GetGuildSettingsError = nil
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "!"}
+	assert.Equal(t, app.DefaultPrefix, bot.guildSettings(context.Background(), "guild123").Prefix)
+
+	bot.settingsRetryAt["guild123"] = time.Now().Add(-time.Second)
+	assert.Equal(t, "!", bot.guildSettings(context.Background(), "guild123").Prefix)
+	assert.NotContains(t, bot.settingsRetryAt, "guild123")
+}
+
+// endregion
+
+// region prefix routing tests
+
+func TestNewMessage_CustomPrefix(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "!"}
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("!help"), "bot_id")
+	require.Len(t, mockSession.SentMessages, 1)
+	assert.Contains(t, mockSession.GetLastMessage().Content, "`!` prefix")
+
+	// The default prefix no longer works in this guild
+	bot.newMessageHandler(mockSession, createGuildMessage("$help"), "bot_id")
+	assert.Len(t, mockSession.SentMessages, 1)
+}
+
+func TestNewMessage_DefaultPrefixInDMs(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("$help", "user123", "TestUser", "dm123"), "bot_id")
+
+	require.Len(t, mockSession.SentMessages, 1)
+}
+
+// endregion
+
+// region isAdmin tests
+
+func TestIsAdmin_AdminRole(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", AdminRoleID: "role1"}
+	message := createGuildMessage("$config")
+	message.Member = &discordgo.Member{Roles: []string{"role1"}}
+
+	assert.True(t, bot.isAdmin(context.Background(), NewMockDiscordSession(), message))
+}
+
+func TestIsAdmin_Permissions(t *testing.T) {
+	bot := createTestBot("swiss")
+	session := NewMockDiscordSession()
+
+	assert.False(t, bot.isAdmin(context.Background(), session, createGuildMessage("$config")))
+
+	session.Permissions = discordgo.PermissionManageGuild
+	assert.True(t, bot.isAdmin(context.Background(), session, createGuildMessage("$config")))
+}
+
+func TestIsAdmin_PermissionError(t *testing.T) {
+	bot := createTestBot("swiss")
+	session := newAdminSession()
+	session.PermissionsError = errors.New("unknown member")
+
+	assert.False(t, bot.isAdmin(context.Background(), session, createGuildMessage("$config")))
+}
+
+func TestIsAdmin_NeverInDMs(t *testing.T) {
+	bot := createTestBot("swiss")
+
+	assert.False(t, bot.isAdmin(context.Background(), newAdminSession(), createMockMessage("$config", "user123", "TestUser", "dm123")))
+}
+
+// endregion
+
+// region config tests
+
+func TestConfig_ShowsSettings(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := newAdminSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$config"), "bot_id")
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "Server Settings", embed.Embed.Title)
+	assert.Len(t, embed.Embed.Fields, len(app.GuildSettingKeys))
+}
+
+func TestConfig_ShowsSettingsInUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config"))
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "Configurações do servidor", embed.Embed.Title)
+	assert.Equal(t, "*não definido*", embed.Embed.Fields[3].Value)
+	assert.Contains(t, embed.Embed.Footer.Text, "$config set <configuração> <valor>")
+}
+
+func TestConfig_SetUpdatesStoreAndCache(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set announcement_channel <#555>"))
+
+	assert.Equal(t, "555", mockStore.GuildSettings["guild123"].AnnouncementChannel)
+	assert.Equal(t, "555", bot.guildSettings(context.Background(), "guild123").AnnouncementChannel)
+	assert.Equal(t, "Server Settings Updated", mockSession.GetLastEmbed().Embed.Title)
+}
+
+func TestConfig_Reset(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "!"}
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config reset prefix"))
+
+	assert.Empty(t, mockStore.GuildSettings["guild123"].Prefix)
+	assert.Equal(t, app.DefaultPrefix, bot.guildSettings(context.Background(), "guild123").Prefix)
+}
+
+func TestConfig_InvalidValue(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set timezone Mars/Olympus"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Unknown timezone `Mars/Olympus`")
+}
+
+func TestConfig_InvalidValueUsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set colour red"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Неизвестная настройка `colour`")
+}
+
+func TestConfig_StoreErrorNotShown(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).StoreGuildSettingsError = errors.New("mongo: connection refused")
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set prefix !"))
+
+	content := mockSession.GetLastMessage().Content
+	assert.Contains(t, content, "An error occurred updating the server settings.")
+	assert.NotContains(t, content, "connection refused")
+}
+
+func TestConfig_Usage(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set prefix"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Usage")
+}
+
+func TestConfig_RequiresAdmin(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set prefix !"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Manage Server")
+	assert.NotContains(t, mockStore.GuildSettings, "guild123")
+}
+
+func TestConfig_RequiresAdminUsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pl"}
+	mockSession := NewMockDiscordSession()
+
+	bot.configHandler(context.Background(), mockSession, createGuildMessage("$config set prefix !"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Aby użyć `$config`")
+}
+
+func TestConfig_RequiresGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := newAdminSession()
+
+	bot.configHandler(context.Background(), mockSession, createMockMessage("$config", "user123", "TestUser", "dm123"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "server channel")
+}
+
+// endregion
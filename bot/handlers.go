@@ -7,11 +7,15 @@
 package bot
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
 	"pickems-bot/metrics"
 	"pickems-bot/models"
+	"pickems-bot/store"
 	"pickems-bot/tournament"
 	"sort"
 	"strconv"
@@ -20,66 +24,45 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/go-andiamo/splitter"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // helpMessageHandler handles the $help command with a DiscordSession interface
-func (b *Bot) helpMessageHandler(session DiscordSession, message *discordgo.MessageCreate) {
-	embed := &discordgo.MessageEmbed{
-		Title: "PickEms Bot v3.3",
-		Description: "Manage your tournament predictions and check standings. All commands use the `$` prefix.\n\n" +
-			"*Match Data sourced from the [Liquipedia Counter-Strike API](https://liquipedia.net) and [PandaScore](https://pandascore.co)*\n" +
-			"*VRS Data sourced from the [counter-strike_regional_standings](https://github.com/ValveSoftware/counter-strike_regional_standings) GitHub repo*",
-		Color: burple,
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:   "`$details`",
-				Value:  "Check active tournament info (name, current round, format, and team requirements).",
-				Inline: false,
-			},
-			{
-				Name: "`$set <team1> ... <teamN>`",
-				Value: cleanIndent(`Lock in your tournament predictions.
-			- **Swiss:** 10 teams needed (1-2: 3-0 teams | 3-8: top-8 | 9-10: 0-3 teams).
-			- **Single Elim:** 4 teams needed (1-2: 3rd/4th place | 3: runner-up | 4: winner).
-			- **Tip:** Wrap multi-word names in quotes (e.g., \"The MongolZ\").`),
-				Inline: false,
-			},
-			{
-				Name:   "`$check`",
-				Value:  "View your currently saved Pick'Ems",
-				Inline: false,
-			},
-			{
-				Name:   "`$teams`",
-				Value:  "List all teams alive in the current stage. Use these exact names for the `$set` command if fuzzy matching doesn't work.",
-				Inline: false,
-			},
-			{
-				Name:   "`$team <name>`",
-				Value:  "Look up a team's current VRS world ranking and roster.",
-				Inline: false,
-			},
-			{
-				Name:   "`$leaderboard`",
-				Value:  "See who has the most correct picks this stage. Sorted strictly by total wins (no tiebreakers).",
-				Inline: false,
-			},
-			{
-				Name:   "`$upcoming`",
-				Value:  "Show matches upcoming matches for this round of the tournament.",
-				Inline: false,
-			},
-			{
-				Name: "`$results`",
-				Value: cleanIndent(`Generate a visual bracket image for Swiss or Single Elimination stages.
-			*Note: Third-place matches are hidden in Single Elim brackets.*`),
+func (b *Bot) helpMessageHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	prefix := b.guildSettings(ctx, message.GuildID).Prefix
+	loc := b.locale(ctx, message)
+	commands := []struct{ name, key string }{
+		{"`$details`", "help.details"},
+		{"`$set <team1> ... <teamN>`", "help.set"},
+		{"`$check [user|@mention] [--round <round>]`", "help.check"},
+		{"`$compare <user> [other user]`", "help.compare"},
+		{"`$teams`", "help.teams"},
+		{"`$team <name>`", "help.team"},
+		{"`$leaderboard [round]`", "help.leaderboard"},
+		{"`$rank`", "help.rank"},
+		{"`$stats`", "help.stats"},
+		{"`$upcoming`", "help.upcoming"},
+		{"`$recent [hours]`", "help.recent"},
+		{"`$calendar`", "help.calendar"},
+		{"`$results [round]`", "help.results"},
+		{"`$matchday [off]`", "help.matchday"},
+		{"`$config [set <setting> <value> | reset <setting>]`", "help.config"},
+		{"`$admin <refresh|rescore|render|deletepick|setpick|matches|override|overrides|audit>`", "help.admin"},
+		{"`$remind <on|off>`", "help.remind"},
+		{"`$language [en|pt|ru|pl|reset]`", "help.language"},
+		{"`$timezone [IANA name|reset]`", "help.timezone"},
+	}
+	fields := make([]*discordgo.MessageEmbedField, 0, len(commands))
+	for _, c := range commands {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: c.name, Value: loc.T(c.key), Inline: false})
+	}
 
-				Inline: false,
-			},
-		},
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("help.title"),
+		Description: loc.T("help.description", prefix) + "\n\n" + loc.T("help.sources"),
+		Color:       burple,
+		Fields:      fields,
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Fuzzy matching is active, but keep names as close as possible!",
+			Text: loc.T("help.footer"),
 		},
 	}
 
@@ -89,35 +72,36 @@ func (b *Bot) helpMessageHandler(session DiscordSession, message *discordgo.Mess
 }
 
 // detailsHandler handles the $details command with a DiscordSession interface
-func (b *Bot) detailsHandler(session DiscordSession, message *discordgo.MessageCreate) {
-	info, err := b.APIPtr.GetTournamentInfo()
+func (b *Bot) detailsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	info, err := b.APIPtr.GetTournamentInfo(ctx)
 	if err != nil {
 		b.logger().Error("failed to get tournament info", "error", fmt.Errorf("detailsHandler: %w", err))
-		sendError(session, message.ChannelID, "An unexpected error occurred.")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("error.unexpected"))
 		return
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title: "Match Details",
+		Title: loc.T("details.title"),
 		Color: green,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Tournament Name",
+				Name:   loc.T("details.tournament"),
 				Value:  info.TournamentName,
 				Inline: false,
 			},
 			{
-				Name:   "Round",
+				Name:   loc.T("details.round"),
 				Value:  info.Round,
 				Inline: false,
 			},
 			{
-				Name:   "Format",
+				Name:   loc.T("details.format"),
 				Value:  info.Format,
 				Inline: false,
 			},
 			{
-				Name:   "Number of Required Teams",
+				Name:   loc.T("details.num_teams"),
 				Value:  strconv.Itoa(info.NumTeams),
 				Inline: false,
 			},
@@ -130,30 +114,36 @@ func (b *Bot) detailsHandler(session DiscordSession, message *discordgo.MessageC
 }
 
 // setPredictionsHandler handles the $set command with a DiscordSession interface
-func (b *Bot) setPredictionsHandler(session DiscordSession, message *discordgo.MessageCreate) {
+func (b *Bot) setPredictionsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
 	user := models.User{UserID: message.Author.ID, Username: message.Author.Username}
+	loc := b.locale(ctx, message)
 
 	// Get User Predictions from message
 	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
-	msg, _ := spaceSplitter.Split(message.Content)
+	msg, err := spaceSplitter.Split(message.Content)
+	if err != nil {
+		// Split fails on an unclosed quote
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("set.unclosed_quote"))
+		return
+	}
 	userPreds := msg[1:]
 
-	prediction, err := b.APIPtr.SetUserPrediction(user, userPreds, b.APIPtr.Store.GetRound())
+	prediction, err := b.APIPtr.SetUserPrediction(ctx, user, userPreds, b.APIPtr.Store.GetRound())
 	if err != nil {
 		b.logger().Error("failed to set user prediction", "user", user.Username, "error", fmt.Errorf("setPredictionsHandler: %w", err))
-		sendError(session, message.ChannelID, err.Error())
+		sendLocalizedError(session, message.ChannelID, loc, predictionErrorMessage(loc, err))
 		return
 	}
 
 	fields, err := predictionFields(prediction)
 	if err != nil {
 		b.logger().Error("failed to build prediction fields", "user", user.Username, "error", fmt.Errorf("setPredictionsHandler: %w", err))
-		sendError(session, message.ChannelID, "An error occurred displaying your Pick'Ems.")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("set.display_error"))
 		return
 	}
 	embed := &discordgo.MessageEmbed{
-		Title:       "Pick'Ems Updated",
-		Description: fmt.Sprintf("%s's Pick'Ems have been saved.", user.Username),
+		Title:       loc.T("set.title"),
+		Description: loc.T("set.saved", user.Username),
 		Color:       green,
 		Fields:      fields,
 	}
@@ -162,6 +152,26 @@ func (b *Bot) setPredictionsHandler(session DiscordSession, message *discordgo.M
 	}
 }
 
+// predictionErrorMessage describes an error from SetUserPrediction in the user's language. Validation failures are
+// explained; anything else gets a generic message, as its details are only meant for the log.
+func predictionErrorMessage(loc i18n.Locale, err error) string {
+	var predictionErr *app.PredictionError
+	if !errors.As(err, &predictionErr) {
+		return loc.T("set.error")
+	}
+	switch predictionErr.Kind {
+	case app.PredictionWrongCount:
+		return loc.T("set.wrong_count", predictionErr.Expected, predictionErr.Got)
+	case app.PredictionInvalidTeams:
+		return loc.T("set.invalid_teams", "**"+strings.Join(predictionErr.Teams, "**, **")+"**")
+	case app.PredictionDuplicateTeam:
+		return loc.T("set.duplicate_team", predictionErr.Teams[0])
+	case app.PredictionAmbiguousTeams:
+		return loc.T("set.ambiguous_teams", predictionErr.Teams[0], predictionErr.Teams[1], predictionErr.Teams[2])
+	}
+	return loc.T("set.error")
+}
+
 func predictionFields(p models.Prediction) ([]*discordgo.MessageEmbedField, error) {
 	f, err := tournament.Get(tournament.Kind(p.Format))
 	if err != nil {
@@ -178,34 +188,67 @@ func predictionFields(p models.Prediction) ([]*discordgo.MessageEmbedField, erro
 	return out, nil
 }
 
+// mentionedUser returns the user a `<@id>` mention in target refers to. Their username is taken from the message's
+// mentions, falling back to the ID if Discord didn't resolve it.
+func mentionedUser(message *discordgo.MessageCreate, target string) (models.User, bool) {
+	m := userMentionPattern.FindStringSubmatch(target)
+	if m == nil || m[1] == "" {
+		return models.User{}, false
+	}
+	for _, u := range message.Mentions {
+		if u.ID == m[1] {
+			return models.User{UserID: u.ID, Username: u.Username}, true
+		}
+	}
+	return models.User{UserID: m[1], Username: m[1]}, true
+}
+
 // checkPredictionsHandler handles the $check command with a DiscordSession interface
-func (b *Bot) checkPredictionsHandler(session DiscordSession, message *discordgo.MessageCreate) {
+func (b *Bot) checkPredictionsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
 	var user models.User
 	var report tournament.ScoreReport
+	loc := b.locale(ctx, message)
 
-	target := strings.TrimSpace(strings.TrimPrefix(message.Content, "$check"))
-	if target == "" {
+	target, round, ok := parseRoundFlag(strings.TrimPrefix(message.Content, "$check"))
+	if !ok {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("round.missing", b.knownRounds(ctx)))
+		return
+	}
+	mentioned, isMention := mentionedUser(message, target)
+	if target == "" || isMention {
 		user = models.User{UserID: message.Author.ID, Username: message.Author.Username}
+		if isMention {
+			user = mentioned
+		}
 		var err error
-		report, err = b.APIPtr.CheckPrediction(user)
+		report, err = b.APIPtr.CheckPredictionForRound(ctx, user, round)
 		if err != nil {
-			if errors.Is(err, mongo.ErrNoDocuments) {
-				sendError(session, message.ChannelID, fmt.Sprintf("%s does not have any Pick'Ems stored. Use `$set` to set your predictions.", user.Username))
-			} else {
+			if b.sendRoundError(ctx, session, message.ChannelID, loc, round, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, store.ErrNotFound) && isMention:
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.no_picks_user", user.Username))
+			case errors.Is(err, store.ErrNotFound):
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.no_picks_self", user.Username))
+			default:
 				b.logger().Error("failed to check prediction", "user", user.Username, "error", fmt.Errorf("checkPredictionsHandler: %w", err))
-				sendError(session, message.ChannelID, fmt.Sprintf("An error occurred checking %s's Pick'Ems.", user.Username))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.error", user.Username))
 			}
 			return
 		}
 	} else {
 		var err error
-		user, report, err = b.APIPtr.CheckPredictionByUsername(target)
+		user, report, err = b.APIPtr.CheckPredictionByUsernameForRound(ctx, target, round)
 		if err != nil {
-			if errors.Is(err, mongo.ErrNoDocuments) {
-				sendError(session, message.ChannelID, fmt.Sprintf("No Pick'Ems found for **%s**.", target))
+			if b.sendRoundError(ctx, session, message.ChannelID, loc, round, err) {
+				return
+			}
+			if errors.Is(err, store.ErrNotFound) {
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.no_picks_user", target))
 			} else {
 				b.logger().Error("failed to check prediction by username", "target", target, "error", fmt.Errorf("checkPredictionsHandler: %w", err))
-				sendError(session, message.ChannelID, fmt.Sprintf("An error occurred checking %s's Pick'Ems.", target))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("check.error", target))
 			}
 			return
 		}
@@ -216,23 +259,27 @@ func (b *Bot) checkPredictionsHandler(session DiscordSession, message *discordgo
 
 	switch r := report.(type) {
 	case tournament.SwissReport:
-		fields = append(fields, swissBucketField("**3-0**", r.WinPicks))
-		fields = append(fields, swissBucketField("**Advance**", r.AdvancePicks))
-		fields = append(fields, swissBucketField("**0-3**", r.LosePicks))
+		fields = append(fields, swissBucketField(loc, "**3-0**", r.WinPicks))
+		fields = append(fields, swissBucketField(loc, "**"+loc.T("check.advance")+"**", r.AdvancePicks))
+		fields = append(fields, swissBucketField(loc, "**0-3**", r.LosePicks))
 	case tournament.SingleElimReport:
-		fields = append(fields, singleElimField(r.Predictions))
+		fields = append(fields, singleElimField(loc, r.Predictions))
 	}
 
-	info, err := b.APIPtr.GetTournamentInfo()
+	info, err := b.APIPtr.GetRoundInfo(ctx, round)
 	if err != nil {
 		b.logger().Error("failed to get tournament info", "error", fmt.Errorf("checkPredictionsHandler: %w", err))
-		sendError(session, message.ChannelID, "An unexpected error occurred.")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("error.unexpected"))
 		return
 	}
 
+	title := loc.T("check.title", user.Username)
+	if round != "" {
+		title = loc.T("check.title_round", user.Username, info.Round)
+	}
 	embed := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("%s's Pick'Ems", user.Username),
-		Description: fmt.Sprintf("**%d/%d Correct** (%d Pending)", score.Successes, info.NumTeams, score.Pending),
+		Title:       title,
+		Description: loc.T("check.summary", score.Successes, info.NumTeams, score.Pending),
 		Color:       green,
 		Fields:      fields,
 	}
@@ -242,49 +289,13 @@ func (b *Bot) checkPredictionsHandler(session DiscordSession, message *discordgo
 	}
 }
 
-// leaderboardHandler handles the $leaderboard command with a DiscordSession interface
-func (b *Bot) leaderboardHandler(session DiscordSession, message *discordgo.MessageCreate) {
-	leaderboard, err := b.APIPtr.GetLeaderboard()
-	if err != nil {
-		b.logger().Error("failed to get leaderboard", "error", fmt.Errorf("leaderboardHandler: %w", err))
-		sendError(session, message.ChannelID, "An error occurred getting the leaderboard.")
-		return
-	}
-	if leaderboard == nil {
-		sendError(session, message.ChannelID, "There are currently no rankings. Try again later.")
-		return
-	}
-
-	var sb strings.Builder
-	for _, user := range leaderboard {
-		fmt.Fprintf(&sb, "%d. %s - %d Successes, %d Failures\n",
-			user.Rank,
-			user.Username,
-			user.Successes,
-			user.Failures,
-		)
-	}
-
-	embed := &discordgo.MessageEmbed{
-		Title:       "Leaderboard",
-		Description: sb.String(),
-		Color:       green,
-		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Calculated using (Successes * 3) + (Pending * 1) + (Failed * 0) • No tiebreakers applied",
-		},
-	}
-
-	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
-		b.logger().Error("failed to send leaderboard embed", "error", fmt.Errorf("leaderboardHandler: %w", err))
-	}
-}
-
 // teamsHandler handles the $teams command with a DiscordSession interface
-func (b *Bot) teamsHandler(session DiscordSession, message *discordgo.MessageCreate) {
-	teams, err := b.APIPtr.GetTeams()
+func (b *Bot) teamsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	teams, err := b.APIPtr.GetTeams(ctx)
 	if err != nil {
 		b.logger().Error("failed to get teams", "error", fmt.Errorf("teamsHandler: %w", err))
-		sendError(session, message.ChannelID, "An error occurred getting the teams list.")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("teams.error"))
 		return
 	}
 
@@ -317,14 +328,14 @@ func (b *Bot) teamsHandler(session DiscordSession, message *discordgo.MessageCre
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title: "Teams in this Stage",
+		Title: loc.T("teams.title"),
 		Color: green,
 		Fields: []*discordgo.MessageEmbedField{
 			{Name: "\u200b", Value: left.String(), Inline: true},
 			{Name: "\u200b", Value: right.String(), Inline: true},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("%d teams \u2022 VRS world ranking shown \u2022 Fuzzy matching is active", len(teams)),
+			Text: loc.T("teams.footer", len(teams)),
 		},
 	}
 
@@ -334,19 +345,20 @@ func (b *Bot) teamsHandler(session DiscordSession, message *discordgo.MessageCre
 }
 
 // teamHandler handles the $team <name> command with a DiscordSession interface
-func (b *Bot) teamHandler(session DiscordSession, message *discordgo.MessageCreate) {
+func (b *Bot) teamHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
 	spaceSplitter, _ := splitter.NewSplitter(' ', splitter.DoubleQuotes, splitter.LeftRightDoubleDoubleQuotes)
 	msg, _ := spaceSplitter.Split(message.Content)
 	if len(msg) < 2 {
-		sendError(session, message.ChannelID, "Usage: `$team <team name>`")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("team.usage"))
 		return
 	}
 	teamName := strings.Join(msg[1:], " ")
 
-	entry, err := b.APIPtr.GetTeam(teamName)
+	entry, err := b.APIPtr.GetTeamRanking(ctx, teamName)
 	if err != nil {
 		b.logger().Error("failed to get team", "team", teamName, "error", fmt.Errorf("teamHandler: %w", err))
-		sendError(session, message.ChannelID, fmt.Sprintf("No VRS data found for **%s**.", teamName))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("team.not_found", teamName))
 		return
 	}
 
@@ -355,40 +367,75 @@ func (b *Bot) teamHandler(session DiscordSession, message *discordgo.MessageCrea
 		fmt.Fprintf(&rosterLines, "• %s\n", player)
 	}
 
-	footerText := "VRS Rankings"
-	t, parseErr := time.Parse("2006_01_02", entry.StandingsDate)
-	if parseErr == nil {
-		footerText = "Rankings as of " + t.Format("02 Jan 2006")
+	footerText := loc.T("team.footer")
+	if _, parseErr := time.Parse(store.VRSStandingsDateLayout, entry.StandingsDate); parseErr == nil {
+		footerText = loc.T("team.footer_date", formatStandingsDate(entry.StandingsDate))
+	}
+
+	description := loc.T("team.ranking", entry.Standing, entry.Points)
+	if entry.PreviousDate != "" {
+		since := formatStandingsDate(entry.PreviousDate)
+		var movement string
+		switch {
+		case entry.PreviousStanding == 0:
+			movement = loc.T("team.movement_new", since)
+		case entry.PreviousStanding > entry.Standing:
+			movement = loc.T("team.movement_up", entry.PreviousStanding-entry.Standing, since)
+		case entry.PreviousStanding < entry.Standing:
+			movement = loc.T("team.movement_down", entry.Standing-entry.PreviousStanding, since)
+		default:
+			movement = loc.T("team.movement_none", since)
+		}
+		description += "\n" + movement
 	}
 
 	embed := &discordgo.MessageEmbed{
 		Title:       entry.TeamName,
-		Description: fmt.Sprintf("**#%d** world ranking\n**%d** VRS Points", entry.Standing, entry.Points),
+		Description: description,
 		Color:       green,
 		Fields: []*discordgo.MessageEmbedField{
-			{Name: "Roster", Value: rosterLines.String(), Inline: false},
+			{Name: loc.T("team.roster"), Value: rosterLines.String(), Inline: false},
 		},
 		Footer: &discordgo.MessageEmbedFooter{Text: footerText},
 	}
+	// The ranking at the start is only worth showing once a newer release is out
+	if entry.StartDate != "" && entry.StartDate != entry.StandingsDate {
+		start := loc.T("team.start_unranked", formatStandingsDate(entry.StartDate))
+		if entry.StartStanding > 0 {
+			start = loc.T("team.start_value", entry.StartStanding, formatStandingsDate(entry.StartDate))
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: loc.T("team.start"), Value: start, Inline: false})
+	}
 
 	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
 		b.logger().Error("failed to send team embed", "error", fmt.Errorf("teamHandler: %w", err))
 	}
 }
 
+// formatStandingsDate formats a VRS standings date for display, e.g. "08 Jun 2026". Dates in another layout are
+// returned unchanged.
+func formatStandingsDate(date string) string {
+	t, err := time.Parse(store.VRSStandingsDateLayout, date)
+	if err != nil {
+		return date
+	}
+	return t.Format("02 Jan 2006")
+}
+
 // upcomingMatchesHandler handles the $upcoming command with a DiscordSession interface
-func (b *Bot) upcomingMatchesHandler(session DiscordSession, message *discordgo.MessageCreate) {
-	matches, err := b.APIPtr.GetUpcomingMatches()
+func (b *Bot) upcomingMatchesHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	matches, err := b.APIPtr.GetUpcomingMatches(ctx)
 	if err != nil {
 		b.logger().Error("failed to get upcoming matches", "error", fmt.Errorf("upcomingMatchesHandler: %w", err))
-		sendError(session, message.ChannelID, "An error occurred getting upcoming matches.")
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("upcoming.error"))
 		return
 	}
 
 	if len(matches) == 0 {
 		embed := &discordgo.MessageEmbed{
-			Title:       "Upcoming Matches",
-			Description: "No upcoming matches at this time.",
+			Title:       loc.T("upcoming.title"),
+			Description: loc.T("upcoming.none"),
 			Color:       green,
 		}
 		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
@@ -405,12 +452,12 @@ func (b *Bot) upcomingMatchesHandler(session DiscordSession, message *discordgo.
 		name := fmt.Sprintf("**%s** vs **%s** (Bo%s)", match.Team1, match.Team2, match.BestOf)
 		var value string
 		if match.Live {
-			value = "**LIVE**"
+			value = loc.T("upcoming.live")
 		} else {
 			value = fmt.Sprintf("<t:%d:F> — <t:%d:R>", match.EpochTime, match.EpochTime)
 		}
 		if match.StreamURL != "" {
-			value += fmt.Sprintf("\n📺 [Watch live](%s)", match.StreamURL)
+			value += "\n" + loc.T("upcoming.watch", match.StreamURL)
 		}
 		field := &discordgo.MessageEmbedField{Name: name, Value: value}
 		if match.Live {
@@ -422,20 +469,20 @@ func (b *Bot) upcomingMatchesHandler(session DiscordSession, message *discordgo.
 
 	var fields []*discordgo.MessageEmbedField
 	if len(liveFields) > 0 {
-		fields = append(fields, &discordgo.MessageEmbedField{Name: "🔴  Live Now", Value: "\u200b"})
+		fields = append(fields, &discordgo.MessageEmbedField{Name: loc.T("upcoming.live_now"), Value: "\u200b"})
 		fields = append(fields, liveFields...)
 	}
 	if len(upcomingFields) > 0 {
 		if len(liveFields) > 0 {
-			fields = append(fields, &discordgo.MessageEmbedField{Name: "Upcoming", Value: "\u200b"})
+			fields = append(fields, &discordgo.MessageEmbedField{Name: loc.T("upcoming.upcoming"), Value: "\u200b"})
 		}
 		fields = append(fields, upcomingFields...)
 	}
 
 	if len(fields) == 0 {
 		embed := &discordgo.MessageEmbed{
-			Title:       "Upcoming Matches",
-			Description: "No upcoming matches at this time.",
+			Title:       loc.T("upcoming.title"),
+			Description: loc.T("upcoming.none"),
 			Color:       green,
 		}
 		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
@@ -445,7 +492,7 @@ func (b *Bot) upcomingMatchesHandler(session DiscordSession, message *discordgo.
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:  "Upcoming Matches",
+		Title:  loc.T("upcoming.title"),
 		Color:  green,
 		Fields: fields,
 	}
@@ -458,18 +505,38 @@ func (b *Bot) upcomingMatchesHandler(session DiscordSession, message *discordgo.
 // resultsHandler handles the $results command withing a DiscordSession interface
 // the results image should be stored in <project-root>/resources/result.png.
 // Creating / updating the results image is a slow process and should be handled when we update the match results db via a goroutine
-func (b *Bot) resultsHandler(session DiscordSession, message *discordgo.MessageCreate) {
+// `$results <round>` lists the results of an earlier round as text instead.
+func (b *Bot) resultsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	args := strings.TrimPrefix(strings.TrimPrefix(message.Content, "$results"), "$result")
+	if round := strings.TrimSpace(args); round != "" && !strings.EqualFold(round, b.APIPtr.Store.GetRound()) {
+		b.roundResultsHandler(ctx, session, message, round)
+		return
+	}
+
 	outputPath := "resources/result.png"
 
 	// Load image from disk
 	f, err := os.Open(outputPath)
 	if err != nil {
 		b.logger().Error("failed to open results image", "path", outputPath, "error", fmt.Errorf("resultsHandler: %w", err))
-		sendError(session, message.ChannelID, "An error occurred fetching the match results.")
+		loc := b.locale(ctx, message)
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("results.error"))
 		return
 	}
 
 	session.ChannelFileSend(message.ChannelID, outputPath, f)
+
+	// Flag any results that were set manually rather than reported by the data source
+	overrides, err := b.APIPtr.GetResultOverrides(ctx)
+	if err != nil {
+		b.logger().Warn("failed to fetch result overrides", "error", fmt.Errorf("resultsHandler: %w", err))
+		return
+	}
+	if embed := overridesEmbed(b.locale(ctx, message), overrides); embed != nil {
+		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+			b.logger().Error("failed to send overrides embed", "error", fmt.Errorf("resultsHandler: %w", err))
+		}
+	}
 }
 
 // newMessageHandler routes messages to appropriate handlers with a DiscordSession interface
@@ -480,42 +547,122 @@ func (b *Bot) newMessageHandler(session DiscordSession, message *discordgo.Messa
 		return
 	}
 
+	ctx, cancel := b.commandContext()
+	defer cancel()
+
+	// Commands must use the guild's prefix. Handlers parse the default prefix, so rewrite custom ones.
+	prefix := b.guildSettings(ctx, message.GuildID).Prefix
+	if !strings.HasPrefix(message.Content, prefix) {
+		return
+	}
+	if prefix != app.DefaultPrefix {
+		rewritten := *message.Message
+		rewritten.Content = app.DefaultPrefix + strings.TrimPrefix(message.Content, prefix)
+		message = &discordgo.MessageCreate{Message: &rewritten}
+	}
+
 	// Route to appropriate handler
 	switch {
 	case startsWith(message.Content, "$help"):
 		metrics.DiscordCommandsTotal.WithLabelValues("help").Inc()
-		b.helpMessageHandler(session, message)
+		b.helpMessageHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$details"):
 		metrics.DiscordCommandsTotal.WithLabelValues("details").Inc()
-		b.detailsHandler(session, message)
+		b.detailsHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$set"):
 		metrics.DiscordCommandsTotal.WithLabelValues("set").Inc()
-		b.setPredictionsHandler(session, message)
+		b.setPredictionsHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$check"):
 		metrics.DiscordCommandsTotal.WithLabelValues("check").Inc()
-		b.checkPredictionsHandler(session, message)
+		b.checkPredictionsHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$compare"):
+		metrics.DiscordCommandsTotal.WithLabelValues("compare").Inc()
+		b.compareHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$stats"):
+		metrics.DiscordCommandsTotal.WithLabelValues("stats").Inc()
+		b.statsHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$leaderboard"):
 		metrics.DiscordCommandsTotal.WithLabelValues("leaderboard").Inc()
-		b.leaderboardHandler(session, message)
+		b.leaderboardHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$rank"):
+		metrics.DiscordCommandsTotal.WithLabelValues("rank").Inc()
+		b.rankHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$teams"):
 		metrics.DiscordCommandsTotal.WithLabelValues("teams").Inc()
-		b.teamsHandler(session, message)
+		b.teamsHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$team "):
 		metrics.DiscordCommandsTotal.WithLabelValues("team").Inc()
-		b.teamHandler(session, message)
+		b.teamHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$upcoming"):
 		metrics.DiscordCommandsTotal.WithLabelValues("upcoming").Inc()
-		b.upcomingMatchesHandler(session, message)
+		b.upcomingMatchesHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$recent"):
+		metrics.DiscordCommandsTotal.WithLabelValues("recent").Inc()
+		b.recentHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$calendar"):
+		metrics.DiscordCommandsTotal.WithLabelValues("calendar").Inc()
+		b.calendarHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$timezone"):
+		metrics.DiscordCommandsTotal.WithLabelValues("timezone").Inc()
+		b.timezoneHandler(ctx, session, message)
 
 	case startsWith(message.Content, "$result"):
 		metrics.DiscordCommandsTotal.WithLabelValues("results").Inc()
-		b.resultsHandler(session, message)
+		b.resultsHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$config"):
+		metrics.DiscordCommandsTotal.WithLabelValues("config").Inc()
+		b.configHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$admin"):
+		metrics.DiscordCommandsTotal.WithLabelValues("admin").Inc()
+		b.adminHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$matchday"):
+		metrics.DiscordCommandsTotal.WithLabelValues("matchday").Inc()
+		b.matchDayHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$remind"):
+		metrics.DiscordCommandsTotal.WithLabelValues("remind").Inc()
+		b.remindHandler(ctx, session, message)
+
+	case startsWith(message.Content, "$language"):
+		metrics.DiscordCommandsTotal.WithLabelValues("language").Inc()
+		b.languageHandler(ctx, session, message)
+
+	default:
+		return
+	}
+
+	// Anyone who has used a command is eligible for pre-lock reminders, and their username is kept current
+	b.trackUser(ctx, message.Author, message.Member, message.GuildID)
+}
+
+// newInteractionHandler routes component interactions, such as button presses, to the handler that owns them
+func (b *Bot) newInteractionHandler(session DiscordSession, interaction *discordgo.InteractionCreate) {
+	if interaction.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	ctx, cancel := b.commandContext()
+	defer cancel()
+
+	if strings.HasPrefix(interaction.MessageComponentData().CustomID, leaderboardButtonPrefix) {
+		metrics.DiscordCommandsTotal.WithLabelValues("leaderboard_page").Inc()
+		b.leaderboardButtonHandler(ctx, session, interaction)
 	}
+	b.trackUser(ctx, interactionUser(interaction), interaction.Member, interaction.GuildID)
 }
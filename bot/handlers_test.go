@@ -6,6 +6,7 @@
 package bot
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"time"
 
 	"pickems-bot/app"
+	"pickems-bot/i18n"
 	"pickems-bot/models"
 	"pickems-bot/sources"
 	"pickems-bot/store"
@@ -84,7 +86,7 @@ func TestHelpMessage_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$help", "user123", "TestUser", "channel123")
 
-	bot.helpMessageHandler(mockSession, message)
+	bot.helpMessageHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -104,7 +106,7 @@ func TestDetails_SwissFormat(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$details", "user123", "TestUser", "channel123")
 
-	bot.detailsHandler(mockSession, message)
+	bot.detailsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -118,7 +120,7 @@ func TestDetails_EliminationFormat(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$details", "user123", "TestUser", "channel123")
 
-	bot.detailsHandler(mockSession, message)
+	bot.detailsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -134,7 +136,7 @@ func TestTeams_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$teams", "user123", "TestUser", "channel123")
 
-	bot.teamsHandler(mockSession, message)
+	bot.teamsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -148,7 +150,7 @@ func TestTeams_ShowsVRSRankInFooter(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$teams", "user123", "TestUser", "channel123")
 
-	bot.teamsHandler(mockSession, message)
+	bot.teamsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	embed := mockSession.GetLastEmbed()
@@ -175,7 +177,7 @@ func TestTeam_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$team faze", "user123", "TestUser", "channel123")
 
-	b.teamHandler(mockSession, message)
+	b.teamHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -184,13 +186,55 @@ func TestTeam_Success(t *testing.T) {
 	assert.Contains(t, msg.Content, "karrigan")
 }
 
+func TestTeam_ShowsMovementAndStartRanking(t *testing.T) {
+	mockStore := app.NewMockStore("swiss", "test_round")
+	mockStore.VRSHistory = []store.VRSEntry{
+		{TeamName: "FaZe", Standing: 9, StandingsDate: "2026_05_04"},
+		{TeamName: "FaZe", Standing: 8, StandingsDate: "2026_06_01"},
+	}
+	mockStore.SetVRSEntries([]store.VRSEntry{{TeamName: "FaZe", Standing: 5, StandingsDate: "2026_06_08"}})
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "FaZe", Team2: "NAVI", EpochTime: time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC).Unix()},
+	})
+	b := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
+	mockSession := NewMockDiscordSession()
+	message := createMockMessage("$team faze", "user123", "TestUser", "channel123")
+
+	b.teamHandler(context.Background(), mockSession, message)
+
+	require.Len(t, mockSession.SentMessages, 1)
+	msg := mockSession.GetLastMessage()
+	assert.Contains(t, msg.Content, "▲ 3 since 01 Jun 2026")
+	assert.Contains(t, msg.Content, "At tournament start")
+	assert.Contains(t, msg.Content, "**#9** (04 May 2026)")
+}
+
+func TestTeam_ShowsDropAndNewEntry(t *testing.T) {
+	mockStore := app.NewMockStore("swiss", "test_round")
+	mockStore.VRSHistory = []store.VRSEntry{{TeamName: "FaZe", Standing: 3, StandingsDate: "2026_06_01"}}
+	mockStore.SetVRSEntries([]store.VRSEntry{
+		{TeamName: "FaZe", Standing: 5, StandingsDate: "2026_06_08"},
+		{TeamName: "Legacy", Standing: 30, StandingsDate: "2026_06_08"},
+	})
+	b := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
+
+	mockSession := NewMockDiscordSession()
+	b.teamHandler(context.Background(), mockSession, createMockMessage("$team faze", "user123", "TestUser", "channel123"))
+	assert.Contains(t, mockSession.GetLastMessage().Content, "▼ 2 since 01 Jun 2026")
+	assert.NotContains(t, mockSession.GetLastMessage().Content, "At tournament start")
+
+	mockSession = NewMockDiscordSession()
+	b.teamHandler(context.Background(), mockSession, createMockMessage("$team legacy", "user123", "TestUser", "channel123"))
+	assert.Contains(t, mockSession.GetLastMessage().Content, "New to the rankings since 01 Jun 2026")
+}
+
 func TestTeam_MissingArg(t *testing.T) {
 	mockStore := app.NewMockStore("swiss", "test_round")
 	b := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$team", "user123", "TestUser", "channel123")
 
-	b.teamHandler(mockSession, message)
+	b.teamHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -206,7 +250,7 @@ func TestTeam_NotFound(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$team unknownteamxyz", "user123", "TestUser", "channel123")
 
-	b.teamHandler(mockSession, message)
+	b.teamHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -222,7 +266,7 @@ func TestUpcomingMatches_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$upcoming", "user123", "TestUser", "channel123")
 
-	bot.upcomingMatchesHandler(mockSession, message)
+	bot.upcomingMatchesHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -240,7 +284,7 @@ func TestUpcomingMatches_LiveOnly_HasLiveNowHeader(t *testing.T) {
 	bot := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
 	mockSession := NewMockDiscordSession()
 
-	bot.upcomingMatchesHandler(mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
+	bot.upcomingMatchesHandler(context.Background(), mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	fields := mockSession.GetLastEmbed().Embed.Fields
@@ -258,7 +302,7 @@ func TestUpcomingMatches_UpcomingOnly_NoSectionHeaders(t *testing.T) {
 	bot := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
 	mockSession := NewMockDiscordSession()
 
-	bot.upcomingMatchesHandler(mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
+	bot.upcomingMatchesHandler(context.Background(), mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	fields := mockSession.GetLastEmbed().Embed.Fields
@@ -275,7 +319,7 @@ func TestUpcomingMatches_LiveAndUpcoming_BothSectionHeaders(t *testing.T) {
 	bot := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
 	mockSession := NewMockDiscordSession()
 
-	bot.upcomingMatchesHandler(mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
+	bot.upcomingMatchesHandler(context.Background(), mockSession, createMockMessage("$upcoming", "user123", "TestUser", "channel123"))
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	fields := mockSession.GetLastEmbed().Embed.Fields
@@ -305,7 +349,7 @@ func TestUpcomingMatches_AllTBD_ShowsNoMatchesEmbed(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$upcoming", "user123", "TestUser", "channel123")
 
-	bot.upcomingMatchesHandler(mockSession, message)
+	bot.upcomingMatchesHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	embed := mockSession.GetLastEmbed()
@@ -322,7 +366,7 @@ func TestLeaderboard_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$leaderboard", "user123", "TestUser", "channel123")
 
-	bot.leaderboardHandler(mockSession, message)
+	bot.leaderboardHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -342,7 +386,7 @@ func TestSetPredictions_Swiss_Success(t *testing.T) {
 		"user123", "TestUser", "channel123",
 	)
 
-	bot.setPredictionsHandler(mockSession, message)
+	bot.setPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	embed := mockSession.GetLastEmbed().Embed
@@ -359,7 +403,7 @@ func TestSetPredictions_Swiss_EmbedFieldsMatchPrediction(t *testing.T) {
 		"user123", "TestUser", "channel123",
 	)
 
-	bot.setPredictionsHandler(mockSession, message)
+	bot.setPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	fields := mockSession.GetLastEmbed().Embed.Fields
@@ -397,7 +441,7 @@ func TestSetPredictions_SingleElim_EmbedFieldsMatchPrediction(t *testing.T) {
 		"user123", "TestUser", "channel123",
 	)
 
-	bot.setPredictionsHandler(mockSession, message)
+	bot.setPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentEmbeds, 1)
 	fields := mockSession.GetLastEmbed().Embed.Fields
@@ -447,13 +491,53 @@ func TestSetPredictions_InvalidInput(t *testing.T) {
 	// Too few teams
 	message := createMockMessage("$set Team1 Team2", "user123", "TestUser", "channel123")
 
-	bot.setPredictionsHandler(mockSession, message)
+	bot.setPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
 	assert.Contains(t, strings.ToLower(msg.Content), "error")
 }
 
+func TestSetPredictions_LocalisedValidationError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := NewMockDiscordSession()
+
+	bot.setPredictionsHandler(context.Background(), mockSession, createMockMessage("$set Team1 Team2", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, i18n.Locale("pt").T("set.wrong_count", 10, 2), embed.Description)
+	assert.NotContains(t, embed.Description, "incorrect number of teams")
+}
+
+func TestSetPredictions_UnclosedQuote(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.setPredictionsHandler(context.Background(), mockSession, createMockMessage(`$set "Team A`, "user123", "TestUser", "channel123"))
+
+	assert.Equal(t, i18n.English.T("set.unclosed_quote"), mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestPredictionErrorMessage(t *testing.T) {
+	loc := i18n.English
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"invalid teams", &app.PredictionError{Kind: app.PredictionInvalidTeams, Teams: []string{"Foo", "Bar"}}, "The following team names are invalid: **Foo**, **Bar**"},
+		{"duplicate", &app.PredictionError{Kind: app.PredictionDuplicateTeam, Teams: []string{"Vitality"}}, loc.T("set.duplicate_team", "Vitality")},
+		{"ambiguous", &app.PredictionError{Kind: app.PredictionAmbiguousTeams, Teams: []string{"g2", "G2 Esports", "G2"}}, loc.T("set.ambiguous_teams", "g2", "G2 Esports", "G2")},
+		{"other errors stay in the log", errors.New("connection refused"), loc.T("set.error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, predictionErrorMessage(loc, tt.err))
+		})
+	}
+}
+
 // endregion
 
 // region checkPredictions tests
@@ -463,7 +547,7 @@ func TestCheckPredictions_NoPredictions(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$check", "user123", "TestUser", "channel123")
 
-	bot.checkPredictionsHandler(mockSession, message)
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -488,7 +572,7 @@ func TestCheckPredictions_WithPredictions(t *testing.T) {
 	})
 
 	// Store a prediction for the user
-	mockStore.StoreUserPrediction("user123", models.Prediction{
+	mockStore.StoreUserPrediction(context.Background(), "user123", models.Prediction{
 		UserID:   "user123",
 		Username: "TestUser",
 		Format:   "swiss",
@@ -506,7 +590,7 @@ func TestCheckPredictions_WithPredictions(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$check", "user123", "TestUser", "channel123")
 
-	bot.checkPredictionsHandler(mockSession, message)
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -643,7 +727,7 @@ func TestLeaderboard_APIError(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$leaderboard", "user123", "TestUser", "channel123")
 
-	bot.leaderboardHandler(mockSession, message)
+	bot.leaderboardHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -667,7 +751,7 @@ func TestTeams_APIError(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$teams", "user123", "TestUser", "channel123")
 
-	bot.teamsHandler(mockSession, message)
+	bot.teamsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -689,7 +773,7 @@ func TestUpcomingMatches_APIError(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$upcoming", "user123", "TestUser", "channel123")
 
-	bot.upcomingMatchesHandler(mockSession, message)
+	bot.upcomingMatchesHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -715,7 +799,7 @@ func TestUpcomingMatches_WithConfirmedMatches(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$upcoming", "user123", "TestUser", "channel123")
 
-	bot.upcomingMatchesHandler(mockSession, message)
+	bot.upcomingMatchesHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -735,7 +819,7 @@ func TestDetails_APIError(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$details", "user123", "TestUser", "channel123")
 
-	bot.detailsHandler(mockSession, message)
+	bot.detailsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -751,7 +835,7 @@ func TestCheckPredictions_ByUsername_Found(t *testing.T) {
 		"Team A": "3-0",
 		"Team B": "3-1",
 	})
-	mockStore.StoreUserPrediction("user456", models.Prediction{
+	mockStore.StoreUserPrediction(context.Background(), "user456", models.Prediction{
 		UserID:   "user456",
 		Username: "PickemsBot",
 		Format:   "swiss",
@@ -769,7 +853,7 @@ func TestCheckPredictions_ByUsername_Found(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$check PickemsBot", "user123", "TestUser", "channel123")
 
-	bot.checkPredictionsHandler(mockSession, message)
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	embed := mockSession.GetLastEmbed()
@@ -783,13 +867,69 @@ func TestCheckPredictions_ByUsername_NotFound(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$check ghost", "user123", "TestUser", "channel123")
 
-	bot.checkPredictionsHandler(mockSession, message)
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
 	assert.Contains(t, strings.ToLower(msg.Content), "no pick'ems found")
 }
 
+func TestCheckPredictions_ByMention(t *testing.T) {
+	mockStore := app.NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B"}})
+	mockStore.SetSwissResults(map[string]string{"Team A": "3-0"})
+	mockStore.StoreUserPrediction(context.Background(), "456", models.Prediction{
+		UserID:   "456",
+		Username: "old.name",
+		Format:   "swiss",
+		Round:    "test_round",
+		Win:      []string{"Team A", "Team B"},
+		Advance:  []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+		Lose:     []string{"Team I", "Team J"},
+	})
+	bot := &Bot{BotToken: "test_token", APIPtr: &app.App{Store: mockStore}}
+	mockSession := NewMockDiscordSession()
+	message := createMockMessage("$check <@!456>", "user123", "TestUser", "channel123")
+	message.Mentions = []*discordgo.User{{ID: "456", Username: "new.name"}}
+
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
+
+	require.Len(t, mockSession.SentMessages, 1)
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Contains(t, embed.Embed.Title, "new.name")
+}
+
+func TestCheckPredictions_ByMention_NotFound(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+	message := createMockMessage("$check <@999>", "user123", "TestUser", "channel123")
+	message.Mentions = []*discordgo.User{{ID: "999", Username: "ghost"}}
+
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
+
+	require.Len(t, mockSession.SentMessages, 1)
+	assert.Contains(t, mockSession.GetLastMessage().Content, "No Pick'Ems found for **ghost**")
+}
+
+func TestMentionedUser(t *testing.T) {
+	message := createMockMessage("$check <@1>", "user123", "TestUser", "channel123")
+	message.Mentions = []*discordgo.User{{ID: "1", Username: "alice"}}
+
+	user, ok := mentionedUser(message, "<@1>")
+	assert.True(t, ok)
+	assert.Equal(t, models.User{UserID: "1", Username: "alice"}, user)
+
+	user, ok = mentionedUser(message, "<@!2>")
+	assert.True(t, ok)
+	assert.Equal(t, models.User{UserID: "2", Username: "2"}, user, "unresolved mentions fall back to the ID")
+
+	_, ok = mentionedUser(message, "12345")
+	assert.False(t, ok, "bare IDs are looked up as usernames")
+	_, ok = mentionedUser(message, "alice")
+	assert.False(t, ok)
+}
+
 func TestCheckPredictions_GenericError(t *testing.T) {
 	mockStore := app.NewMockStore("swiss", "test_round")
 	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
@@ -807,7 +947,7 @@ func TestCheckPredictions_GenericError(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$check", "user123", "TestUser", "channel123")
 
-	bot.checkPredictionsHandler(mockSession, message)
+	bot.checkPredictionsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	msg := mockSession.GetLastMessage()
@@ -830,20 +970,41 @@ func TestResults_Success(t *testing.T) {
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$results", "user123", "TestUser", "channel123")
 
-	bot.resultsHandler(mockSession, message)
+	bot.resultsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentFiles, 1)
 	assert.Equal(t, "channel123", mockSession.SentFiles[0].ChannelID)
 	assert.Equal(t, "resources/result.png", mockSession.SentFiles[0].Name)
 }
 
+func TestResults_ShowsOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "resources"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "resources", "result.png"), []byte("dummy png"), 0644))
+	t.Chdir(tmpDir)
+
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B"}}
+	mockStore.Overrides["m1"] = store.ResultOverride{MatchID: "m1", Winner: "Team A", SetBy: "mod"}
+	mockSession := NewMockDiscordSession()
+
+	bot.resultsHandler(context.Background(), mockSession, createMockMessage("$results", "user123", "TestUser", "channel123"))
+
+	require.Len(t, mockSession.SentFiles, 1)
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "📌 Result Overrides", embed.Embed.Title)
+	assert.Contains(t, embed.Embed.Description, "**Team A** (source: Team B, set by mod)")
+}
+
 func TestResults_FileNotFound(t *testing.T) {
 	// No chdir — bot/ has no resources/result.png, so os.Open will fail
 	bot := createTestBot("swiss")
 	mockSession := NewMockDiscordSession()
 	message := createMockMessage("$results", "user123", "TestUser", "channel123")
 
-	bot.resultsHandler(mockSession, message)
+	bot.resultsHandler(context.Background(), mockSession, message)
 
 	require.Len(t, mockSession.SentMessages, 1)
 	assert.Contains(t, strings.ToLower(mockSession.SentMessages[0].Content), "error")
@@ -0,0 +1,220 @@
+/* leaderboard.go
+ * Contains the $leaderboard and $rank commands. The leaderboard is paginated with previous/next buttons so it
+ * stays within Discord's embed limits however many users play. `$leaderboard <round>` shows an earlier round's
+ * leaderboard.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	leaderboardPageSize = 20
+	// leaderboardButtonPrefix prefixes the custom ID of the leaderboard's page buttons; the page index and the ID of
+	// the user who requested the leaderboard follow it, then ":<round>" when the leaderboard is of a round other than
+	// the current one
+	leaderboardButtonPrefix = "leaderboard:"
+)
+
+// leaderboardHandler handles the $leaderboard command with a DiscordSession interface
+func (b *Bot) leaderboardHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	round := strings.TrimSpace(strings.TrimPrefix(message.Content, "$leaderboard"))
+	if round != "" {
+		// Use the stored name so the page buttons find the round whatever case it was typed in
+		resolved, err := b.APIPtr.ResolveRound(ctx, round)
+		if err != nil {
+			if !b.sendRoundError(ctx, session, message.ChannelID, loc, round, err) {
+				b.logger().Error("failed to resolve round", "round", round, "error", fmt.Errorf("leaderboardHandler: %w", err))
+				sendLocalizedError(session, message.ChannelID, loc, loc.T("leaderboard.error"))
+			}
+			return
+		}
+		round = resolved
+	}
+
+	leaderboard, err := b.leaderboard(ctx, round)
+	if err != nil {
+		b.logger().Error("failed to get leaderboard", "error", fmt.Errorf("leaderboardHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("leaderboard.error"))
+		return
+	}
+	if len(leaderboard) == 0 {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("leaderboard.empty"))
+		return
+	}
+
+	embed, components := leaderboardPage(loc, leaderboard, 0, message.Author.ID, round)
+	data := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, Components: components}
+	if _, err := session.ChannelMessageSendComplex(message.ChannelID, data); err != nil {
+		b.logger().Error("failed to send leaderboard embed", "error", fmt.Errorf("leaderboardHandler: %w", err))
+	}
+}
+
+// leaderboardButtonHandler handles a press of the leaderboard's previous/next buttons by editing the message to
+// show the requested page. The message is shared, so it stays highlighted for the user who requested it rather than
+// whoever pressed the button.
+func (b *Bot) leaderboardButtonHandler(ctx context.Context, session DiscordSession, interaction *discordgo.InteractionCreate) {
+	customID := interaction.MessageComponentData().CustomID
+	fields := strings.SplitN(strings.TrimPrefix(customID, leaderboardButtonPrefix), ":", 3)
+	page, err := strconv.Atoi(fields[0])
+	if err != nil || len(fields) < 2 {
+		b.logger().Warn("invalid leaderboard button", "custom_id", customID)
+		return
+	}
+	requesterID, round := fields[1], ""
+	if len(fields) == 3 {
+		round = fields[2]
+	}
+
+	loc := b.localeFor(ctx, requesterID, interaction.GuildID)
+	leaderboard, err := b.leaderboard(ctx, round)
+	if err != nil || len(leaderboard) == 0 {
+		if err != nil {
+			b.logger().Error("failed to get leaderboard", "error", fmt.Errorf("leaderboardButtonHandler: %w", err))
+		}
+		respondError(session, interaction, loc, loc.T("leaderboard.error"))
+		return
+	}
+
+	embed, components := leaderboardPage(loc, leaderboard, page, requesterID, round)
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	}
+	if err := session.InteractionRespond(interaction.Interaction, response); err != nil {
+		b.logger().Error("failed to update leaderboard page", "error", fmt.Errorf("leaderboardButtonHandler: %w", err))
+	}
+}
+
+// leaderboard returns the leaderboard of the given round, or of the current round if round is empty
+func (b *Bot) leaderboard(ctx context.Context, round string) ([]app.LeaderboardUser, error) {
+	if round == "" {
+		return b.APIPtr.GetLeaderboard(ctx)
+	}
+	return b.APIPtr.GetLeaderboardForRound(ctx, round)
+}
+
+// rankHandler handles the $rank command, showing the caller's position with the users directly above and below
+func (b *Bot) rankHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	leaderboard, err := b.APIPtr.GetLeaderboard(ctx)
+	if err != nil {
+		b.logger().Error("failed to get leaderboard", "error", fmt.Errorf("rankHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("leaderboard.error"))
+		return
+	}
+
+	idx := leaderboardIndex(leaderboard, message.Author.ID)
+	if idx < 0 {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("leaderboard.not_ranked"))
+		return
+	}
+
+	lines := make([]string, 0, 3)
+	for i := max(idx-1, 0); i <= min(idx+1, len(leaderboard)-1); i++ {
+		lines = append(lines, leaderboardLine(loc, leaderboard[i], message.Author.ID))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("leaderboard.your_rank"),
+		Description: rankSummary(loc, leaderboard, idx) + "\n\n" + strings.Join(lines, "\n"),
+		Color:       green,
+		Footer:      &discordgo.MessageEmbedFooter{Text: loc.T("leaderboard.scoring")},
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send rank embed", "error", fmt.Errorf("rankHandler: %w", err))
+	}
+}
+
+// leaderboardPage builds the embed and navigation buttons for one page of the leaderboard, written in loc. The page is
+// clamped to the valid range, userID's line is bolded and their rank is pinned in a field below the page. round names
+// the round of an earlier leaderboard, and is empty for the current one.
+func leaderboardPage(loc i18n.Locale, leaderboard []app.LeaderboardUser, page int, userID string, round string) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	pages := (len(leaderboard) + leaderboardPageSize - 1) / leaderboardPageSize
+	page = min(max(page, 0), pages-1)
+
+	start := page * leaderboardPageSize
+	end := min(start+leaderboardPageSize, len(leaderboard))
+	lines := make([]string, 0, end-start)
+	for _, user := range leaderboard[start:end] {
+		lines = append(lines, leaderboardLine(loc, user, userID))
+	}
+
+	yourRank := loc.T("leaderboard.join")
+	if idx := leaderboardIndex(leaderboard, userID); idx >= 0 {
+		yourRank = rankSummary(loc, leaderboard, idx)
+	}
+
+	title, buttonSuffix := loc.T("leaderboard.title"), ":"+userID
+	if round != "" {
+		title, buttonSuffix = loc.T("leaderboard.round_title", round), buttonSuffix+":"+round
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: strings.Join(lines, "\n"),
+		Color:       green,
+		Fields:      []*discordgo.MessageEmbedField{{Name: loc.T("leaderboard.your_rank"), Value: yourRank}},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("leaderboard.page", page+1, pages, loc.T("leaderboard.scoring")),
+		},
+	}
+	if pages == 1 {
+		return embed, nil
+	}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    loc.T("leaderboard.previous"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: leaderboardButtonPrefix + strconv.Itoa(page-1) + buttonSuffix,
+				Disabled: page == 0,
+			},
+			discordgo.Button{
+				Label:    loc.T("leaderboard.next"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: leaderboardButtonPrefix + strconv.Itoa(page+1) + buttonSuffix,
+				Disabled: page == pages-1,
+			},
+		}},
+	}
+	return embed, components
+}
+
+// leaderboardLine formats a leaderboard entry in loc, bolding it when it belongs to userID
+func leaderboardLine(loc i18n.Locale, user app.LeaderboardUser, userID string) string {
+	line := loc.T("leaderboard.line", user.Rank, user.Username, user.Successes, user.Failures)
+	if user.UserID != "" && user.UserID == userID {
+		return "**" + line + "**"
+	}
+	return line
+}
+
+// leaderboardIndex returns the position of userID on the leaderboard, or -1 if they aren't on it
+func leaderboardIndex(leaderboard []app.LeaderboardUser, userID string) int {
+	for i, user := range leaderboard {
+		if user.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// rankSummary describes the rank of the user at idx, e.g. "You are ranked **#3** of 40 with **12** points."
+func rankSummary(loc i18n.Locale, leaderboard []app.LeaderboardUser, idx int) string {
+	user := leaderboard[idx]
+	return loc.T("leaderboard.rank_summary", user.Rank, len(leaderboard), user.Score)
+}
@@ -0,0 +1,279 @@
+/* leaderboard_test.go
+ * Contains unit tests for the paginated $leaderboard, its page buttons and $rank
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLeaderboardTestBot creates a Bot whose leaderboard holds n users, user1 (highest score) to usern
+func createLeaderboardTestBot(n int) *Bot {
+	bot := createTestBot("swiss")
+	entries := make([]store.LeaderboardEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		entries = append(entries, store.LeaderboardEntry{
+			UserID:      fmt.Sprintf("user%d", i),
+			Username:    fmt.Sprintf("User %d", i),
+			Score:       1000 - i,
+			ScoreResult: models.ScoreResult{Successes: n - i},
+		})
+	}
+	bot.APIPtr.Store.(*app.MockStore).Leaderboard = entries
+	return bot
+}
+
+// createButtonInteraction creates a leaderboard button press by userID
+func createButtonInteraction(customID, userID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type:   discordgo.InteractionMessageComponent,
+		Data:   discordgo.MessageComponentInteractionData{CustomID: customID},
+		Member: &discordgo.Member{User: &discordgo.User{ID: userID}},
+	}}
+}
+
+// buttons returns the buttons of the first action row
+func buttons(t *testing.T, components []discordgo.MessageComponent) []discordgo.Button {
+	require.Len(t, components, 1)
+	row, ok := components[0].(discordgo.ActionsRow)
+	require.True(t, ok)
+	var result []discordgo.Button
+	for _, c := range row.Components {
+		result = append(result, c.(discordgo.Button))
+	}
+	return result
+}
+
+// region leaderboard pagination tests
+
+func TestLeaderboard_SinglePageHasNoButtons(t *testing.T) {
+	bot := createLeaderboardTestBot(3)
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard", "user2", "User 2", "channel123"))
+
+	require.Len(t, mockSession.SentComplex, 1)
+	assert.Empty(t, mockSession.SentComplex[0].Components)
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "1. User 1 - 2 Successes, 0 Failures\n**2. User 2 - 1 Successes, 0 Failures**\n3. User 3 - 0 Successes, 0 Failures", embed.Description)
+	assert.Equal(t, "Page 1/1 • "+i18n.English.T("leaderboard.scoring"), embed.Footer.Text)
+	require.Len(t, embed.Fields, 1)
+	assert.Equal(t, "You are ranked **#2** of 3 with **998** points.", embed.Fields[0].Value)
+}
+
+func TestLeaderboard_FirstPageOfMany(t *testing.T) {
+	bot := createLeaderboardTestBot(45)
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard", "user30", "User 30", "channel123"))
+
+	require.Len(t, mockSession.SentComplex, 1)
+	sent := mockSession.SentComplex[0]
+	embed := sent.Embeds[0]
+	assert.Contains(t, embed.Description, "1. User 1 -")
+	assert.Contains(t, embed.Description, "20. User 20 -")
+	assert.NotContains(t, embed.Description, "21. User 21 -")
+	assert.Equal(t, "Page 1/3 • "+i18n.English.T("leaderboard.scoring"), embed.Footer.Text)
+	assert.Equal(t, "You are ranked **#30** of 45 with **970** points.", embed.Fields[0].Value)
+
+	btns := buttons(t, sent.Components)
+	require.Len(t, btns, 2)
+	assert.True(t, btns[0].Disabled)
+	assert.Equal(t, "leaderboard:1:user30", btns[1].CustomID)
+	assert.False(t, btns[1].Disabled)
+}
+
+func TestLeaderboard_CallerNotRanked(t *testing.T) {
+	bot := createLeaderboardTestBot(3)
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard", "stranger", "Stranger", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.NotContains(t, embed.Description, "**")
+	assert.Contains(t, embed.Fields[0].Value, "not on the leaderboard")
+}
+
+func TestLeaderboard_Empty(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard", "user123", "TestUser", "channel123"))
+
+	assert.Empty(t, mockSession.SentComplex)
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "There are currently no rankings")
+}
+
+func TestLeaderboardButton_ShowsRequestedPage(t *testing.T) {
+	bot := createLeaderboardTestBot(45)
+	mockSession := NewMockDiscordSession()
+
+	// Pressed by someone other than the requester, whose line stays highlighted
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:2:user42", "user1"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	response := mockSession.InteractionResponses[0]
+	assert.Equal(t, discordgo.InteractionResponseUpdateMessage, response.Type)
+	embed := response.Data.Embeds[0]
+	assert.Contains(t, embed.Description, "41. User 41 -")
+	assert.Contains(t, embed.Description, "**42. User 42 - 3 Successes, 0 Failures**")
+	assert.Equal(t, "Page 3/3 • "+i18n.English.T("leaderboard.scoring"), embed.Footer.Text)
+
+	btns := buttons(t, response.Data.Components)
+	assert.Contains(t, embed.Fields[0].Value, "**#42**")
+	assert.Equal(t, "leaderboard:1:user42", btns[0].CustomID)
+	assert.False(t, btns[0].Disabled)
+	assert.True(t, btns[1].Disabled)
+}
+
+func TestLeaderboardButton_TracksUser(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+	mockSession := NewMockDiscordSession()
+	interaction := createButtonInteraction("leaderboard:0:user3", "user3")
+	interaction.Member.User.Username = "renamed"
+
+	bot.newInteractionHandler(mockSession, interaction)
+
+	assert.Equal(t, "renamed", bot.APIPtr.Store.(*app.MockStore).Profiles["user3"].Username)
+}
+
+func TestLeaderboardButton_ClampsPage(t *testing.T) {
+	bot := createLeaderboardTestBot(25)
+	mockSession := NewMockDiscordSession()
+
+	// The leaderboard shrank since the buttons were rendered
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:5:user1", "user1"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	assert.Equal(t, "Page 2/2 • "+i18n.English.T("leaderboard.scoring"), mockSession.InteractionResponses[0].Data.Embeds[0].Footer.Text)
+}
+
+func TestLeaderboardButton_Error(t *testing.T) {
+	bot := createLeaderboardTestBot(25)
+	bot.APIPtr.Store.(*app.MockStore).FetchLeaderboardFromDBError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:1:user1", "user1"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	response := mockSession.InteractionResponses[0]
+	assert.Equal(t, discordgo.MessageFlagsEphemeral, response.Data.Flags)
+	assert.Equal(t, "An error occurred getting the leaderboard.", response.Data.Embeds[0].Description)
+}
+
+func TestLeaderboardButton_ErrorUsesRequesterLocale(t *testing.T) {
+	bot := createLeaderboardTestBot(25)
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.FetchLeaderboardFromDBError = errors.New("db down")
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1", Locale: "pt"}
+	mockSession := NewMockDiscordSession()
+
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:1:user1", "user1"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	embed := mockSession.InteractionResponses[0].Data.Embeds[0]
+	assert.Equal(t, "Erro", embed.Title)
+	assert.Equal(t, "Ocorreu um erro ao obter a classificação.", embed.Description)
+}
+
+func TestNewInteraction_IgnoresUnknownComponents(t *testing.T) {
+	bot := createLeaderboardTestBot(25)
+	mockSession := NewMockDiscordSession()
+
+	bot.newInteractionHandler(mockSession, createButtonInteraction("something:1", "user1"))
+	bot.newInteractionHandler(mockSession, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Type: discordgo.InteractionPing}})
+
+	assert.Empty(t, mockSession.InteractionResponses)
+}
+
+func TestInteractionUserID(t *testing.T) {
+	assert.Equal(t, "guild", interactionUserID(&discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Member: &discordgo.Member{User: &discordgo.User{ID: "guild"}}}}))
+	assert.Equal(t, "dm", interactionUserID(&discordgo.InteractionCreate{Interaction: &discordgo.Interaction{User: &discordgo.User{ID: "dm"}}}))
+	assert.Empty(t, interactionUserID(&discordgo.InteractionCreate{Interaction: &discordgo.Interaction{}}))
+}
+
+// endregion
+
+// region rank tests
+
+func TestRank_ShowsNeighbours(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("$rank", "user3", "User 3", "channel123"), "bot_id")
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Your Rank", embed.Title)
+	assert.Equal(t, "You are ranked **#3** of 5 with **997** points.\n\n"+
+		"2. User 2 - 3 Successes, 0 Failures\n"+
+		"**3. User 3 - 2 Successes, 0 Failures**\n"+
+		"4. User 4 - 1 Successes, 0 Failures", embed.Description)
+}
+
+func TestRank_TopAndBottom(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+
+	mockSession := NewMockDiscordSession()
+	bot.rankHandler(context.Background(), mockSession, createMockMessage("$rank", "user1", "User 1", "channel123"))
+	assert.Equal(t, "You are ranked **#1** of 5 with **999** points.\n\n"+
+		"**1. User 1 - 4 Successes, 0 Failures**\n"+
+		"2. User 2 - 3 Successes, 0 Failures", mockSession.GetLastEmbed().Embed.Description)
+
+	mockSession = NewMockDiscordSession()
+	bot.rankHandler(context.Background(), mockSession, createMockMessage("$rank", "user5", "User 5", "channel123"))
+	assert.Equal(t, "You are ranked **#5** of 5 with **995** points.\n\n"+
+		"4. User 4 - 1 Successes, 0 Failures\n"+
+		"**5. User 5 - 0 Successes, 0 Failures**", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestRank_UsesUserLocale(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user3"] = store.UserProfile{UserID: "user3", Locale: "pl"}
+	mockSession := NewMockDiscordSession()
+
+	bot.rankHandler(context.Background(), mockSession, createMockMessage("$rank", "user3", "User 3", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Twoje miejsce", embed.Title)
+	assert.Equal(t, "Zajmujesz miejsce **#3** z 5 z wynikiem **997** pkt.\n\n"+
+		"2. User 2 - trafione: 3, nietrafione: 0\n"+
+		"**3. User 3 - trafione: 2, nietrafione: 0**\n"+
+		"4. User 4 - trafione: 1, nietrafione: 0", embed.Description)
+	assert.Equal(t, i18n.Locale("pl").T("leaderboard.scoring"), embed.Footer.Text)
+}
+
+func TestRank_NotRanked(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+	mockSession := NewMockDiscordSession()
+
+	bot.rankHandler(context.Background(), mockSession, createMockMessage("$rank", "stranger", "Stranger", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Error", embed.Title)
+	assert.Contains(t, embed.Description, "You're not on the leaderboard yet")
+}
+
+func TestRank_Error(t *testing.T) {
+	bot := createLeaderboardTestBot(5)
+	bot.APIPtr.Store.(*app.MockStore).FetchLeaderboardFromDBError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.rankHandler(context.Background(), mockSession, createMockMessage("$rank", "user1", "User 1", "channel123"))
+
+	assert.Equal(t, "An error occurred getting the leaderboard.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+// endregion
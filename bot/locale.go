@@ -0,0 +1,113 @@
+/* locale.go
+ * Contains the $language command and the lookup of the locale a response should be translated into: the user's
+ * own choice when they have made one, otherwise their guild's locale.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/i18n"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// locale returns the locale responses to message should be translated into
+func (b *Bot) locale(ctx context.Context, message *discordgo.MessageCreate) i18n.Locale {
+	return b.localeFor(ctx, message.Author.ID, message.GuildID)
+}
+
+// localeFor returns the locale a response meant for userID in guildID should be translated into
+func (b *Bot) localeFor(ctx context.Context, userID, guildID string) i18n.Locale {
+	if locale := b.userLocale(ctx, userID); locale != "" {
+		return i18n.Locale(locale)
+	}
+	return b.guildLocale(ctx, guildID)
+}
+
+// guildLocale returns the locale messages posted to guildID's channels for everyone, such as announcements, should
+// be translated into
+func (b *Bot) guildLocale(ctx context.Context, guildID string) i18n.Locale {
+	return i18n.Locale(b.guildSettings(ctx, guildID).Locale)
+}
+
+// userLocale returns the locale a user has chosen, or "" if they haven't, loading it into the cache on first use.
+// Failed lookups are not cached so they are retried on the next message.
+func (b *Bot) userLocale(ctx context.Context, userID string) string {
+	b.settingsMu.RLock()
+	locale, ok := b.userLocales[userID]
+	b.settingsMu.RUnlock()
+	if ok {
+		return locale
+	}
+
+	locale, err := b.APIPtr.GetUserLocale(ctx, userID)
+	if err != nil {
+		b.logger().Warn("failed to load user locale, using the guild's", "user_id", userID, "error", fmt.Errorf("userLocale: %w", err))
+		return ""
+	}
+	b.cacheUserLocale(userID, locale)
+	return locale
+}
+
+// cacheUserLocale stores a user's chosen locale in the cache
+func (b *Bot) cacheUserLocale(userID, locale string) {
+	b.settingsMu.Lock()
+	defer b.settingsMu.Unlock()
+	if b.userLocales == nil {
+		b.userLocales = make(map[string]string)
+	}
+	b.userLocales[userID] = locale
+}
+
+// languageHandler handles $language (show your language), $language <code> and $language reset
+func (b *Bot) languageHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	usage := loc.T("language.usage", strings.Join(i18n.SupportedLocales, "|"))
+
+	args := strings.Fields(message.Content)
+	if len(args) == 1 {
+		embed := &discordgo.MessageEmbed{
+			Title:       loc.T("language.current_title"),
+			Description: loc.T("language.current", loc.T("language.name")) + "\n" + usage,
+			Color:       green,
+		}
+		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+			b.logger().Error("failed to send language embed", "error", fmt.Errorf("languageHandler: %w", err))
+		}
+		return
+	}
+
+	code := strings.ToLower(args[1])
+	if code == "reset" {
+		code = ""
+	}
+	if len(args) > 2 || (code != "" && !i18n.IsSupported(code)) {
+		sendLocalizedError(session, message.ChannelID, loc, usage)
+		return
+	}
+
+	if err := b.APIPtr.SetUserLocale(ctx, message.Author.ID, code); err != nil {
+		b.logger().Error("failed to update user locale", "user", message.Author.Username, "error", fmt.Errorf("languageHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("language.error"))
+		return
+	}
+	b.cacheUserLocale(message.Author.ID, code)
+
+	// Confirm in the language the user will now see
+	loc = b.locale(ctx, message)
+	description := loc.T("language.updated", loc.T("language.name"))
+	if code == "" {
+		description = loc.T("language.reset", loc.T("language.name"))
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("language.title"),
+		Description: description,
+		Color:       green,
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send language embed", "error", fmt.Errorf("languageHandler: %w", err))
+	}
+}
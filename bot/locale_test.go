@@ -0,0 +1,145 @@
+/* locale_test.go
+ * Contains unit tests for the $language command and locale selection
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region locale selection tests
+
+func TestLocale_DefaultsToEnglish(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$details"), "bot_id")
+
+	assert.Equal(t, "Match Details", mockSession.GetLastEmbed().Embed.Title)
+}
+
+func TestLocale_UsesGuildLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "pt"}
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$details"), "bot_id")
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Detalhes do Torneio", embed.Title)
+	assert.Equal(t, "Nome do Torneio", embed.Fields[0].Name)
+}
+
+func TestLocale_UserOverridesGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "pt"}
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := NewMockDiscordSession()
+
+	bot.teamHandler(context.Background(), mockSession, createGuildMessage("$team"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Ошибка", embed.Title)
+	assert.Equal(t, "Использование: `$team <название команды>`", embed.Description)
+}
+
+func TestLocale_LookupErrorFallsBackToGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "pl"}
+	mockStore.GetUserProfileError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.helpMessageHandler(context.Background(), mockSession, createGuildMessage("$help"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Contains(t, embed.Description, "Typuj wyniki turnieju")
+	assert.Equal(t, "Dopasowanie przybliżone jest włączone, ale wpisuj nazwy jak najdokładniej!", embed.Footer.Text)
+}
+
+// endregion
+
+// region language tests
+
+func TestLanguage_SetUpdatesLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$language PL"), "bot_id")
+
+	assert.Equal(t, "pl", mockStore.Profiles["user123"].Locale)
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Język zaktualizowany", embed.Title)
+	assert.Equal(t, "Od teraz bot będzie ci odpowiadał w języku: **Polski**.", embed.Description)
+
+	// Later responses use the new locale without another store lookup
+	mockStore.GetUserProfileError = errors.New("db down")
+	bot.newMessageHandler(mockSession, createGuildMessage("$details"), "bot_id")
+	assert.Equal(t, "Szczegóły turnieju", mockSession.GetLastEmbed().Embed.Title)
+}
+
+func TestLanguage_ResetFollowsGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "pt"}
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := NewMockDiscordSession()
+
+	bot.languageHandler(context.Background(), mockSession, createGuildMessage("$language reset"))
+
+	assert.Empty(t, mockStore.Profiles["user123"].Locale)
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Idioma Atualizado", embed.Title)
+	assert.Equal(t, "Seu idioma foi removido. O bot seguirá o idioma deste servidor (**Português**).", embed.Description)
+}
+
+func TestLanguage_ShowsCurrent(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.languageHandler(context.Background(), mockSession, createGuildMessage("$language"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Your Language", embed.Title)
+	assert.Equal(t, "Bot responses to you are in **English**.\n"+
+		"Usage: `$language <en|pt|ru|pl>` to choose your language, or `$language reset` to use this server's language.", embed.Description)
+}
+
+func TestLanguage_Unsupported(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	for _, content := range []string{"$language de", "$language pt br"} {
+		bot.languageHandler(context.Background(), mockSession, createGuildMessage(content))
+		embed := mockSession.GetLastEmbed().Embed
+		assert.Equal(t, "Error", embed.Title, content)
+		assert.Contains(t, embed.Description, "Usage: `$language <en|pt|ru|pl>`", content)
+	}
+	assert.NotContains(t, mockStore.Profiles, "user123")
+}
+
+func TestLanguage_StoreError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).SetUserLocaleError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.languageHandler(context.Background(), mockSession, createGuildMessage("$language ru"))
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "An error occurred updating your language.", mockSession.GetLastEmbed().Embed.Description)
+	assert.Empty(t, bot.userLocale(context.Background(), "user123"))
+}
+
+// endregion
@@ -0,0 +1,209 @@
+/* match_day.go
+ * Contains the $matchday command and the logic that keeps each guild's match day message up to date by
+ * editing it in place as matches go live and finish.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/store"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxFieldValue is the maximum length Discord allows for an embed field value
+const maxFieldValue = 1024
+
+// matchDayHandler handles $matchday (post a match day message in this channel) and $matchday off
+func (b *Bot) matchDayHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	if !b.requireAdmin(ctx, session, message, "$matchday") {
+		return
+	}
+	loc := b.locale(ctx, message)
+
+	args := strings.Fields(message.Content)
+	if len(args) > 1 && args[1] == "off" {
+		if err := b.APIPtr.RemoveMatchDayMessage(ctx, message.GuildID); err != nil {
+			b.logger().Error("failed to remove match day message", "guild", message.GuildID, "error", fmt.Errorf("matchDayHandler: %w", err))
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("matchday.off_error"))
+			return
+		}
+		embed := &discordgo.MessageEmbed{
+			Title:       loc.T("matchday.disabled_title"),
+			Description: loc.T("matchday.disabled"),
+			Color:       green,
+		}
+		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+			b.logger().Error("failed to send match day embed", "error", fmt.Errorf("matchDayHandler: %w", err))
+		}
+		return
+	}
+
+	b.matchDayMu.Lock()
+	defer b.matchDayMu.Unlock()
+
+	tz := app.GuildLocation(b.guildSettings(ctx, message.GuildID))
+	day, err := b.APIPtr.GetMatchDay(ctx, time.Now().In(tz))
+	if err != nil {
+		b.logger().Error("failed to build match day", "error", fmt.Errorf("matchDayHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("matchday.error"))
+		return
+	}
+	// The message is shared by the whole server, so it is written in the server's locale rather than the caller's
+	tracked := store.MatchDayMessage{GuildID: message.GuildID, ChannelID: message.ChannelID, Day: day.Day}
+	if err := b.postMatchDay(ctx, session, tracked, b.matchDayEmbed(b.guildLocale(ctx, message.GuildID), day, time.Now())); err != nil {
+		b.logger().Error("failed to post match day message", "guild", message.GuildID, "error", fmt.Errorf("matchDayHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("matchday.post_error"))
+	}
+}
+
+// RefreshMatchDay edits every guild's match day message to reflect the latest schedule and results. It is
+// called by the update pipelines and is a no-op before the bot has connected.
+func (b *Bot) RefreshMatchDay(ctx context.Context) {
+	session := b.discordSession()
+	if session == nil {
+		return
+	}
+	b.refreshMatchDay(ctx, session, time.Now())
+}
+
+// refreshMatchDay updates each tracked match day message in place. When the day has rolled over, or the
+// message can no longer be edited (e.g. it was deleted), a fresh message is posted and tracked instead.
+func (b *Bot) refreshMatchDay(ctx context.Context, session DiscordSession, now time.Time) {
+	b.matchDayMu.Lock()
+	defer b.matchDayMu.Unlock()
+
+	messages, err := b.APIPtr.GetMatchDayMessages(ctx)
+	if err != nil {
+		b.logger().Error("failed to fetch match day messages", "error", fmt.Errorf("refreshMatchDay: %w", err))
+		return
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	// Each guild's day follows its own timezone; build each distinct day once
+	days := make(map[string]app.MatchDay)
+	for _, msg := range messages {
+		settings := b.guildSettings(ctx, msg.GuildID)
+		tz := app.GuildLocation(settings)
+		day, ok := days[tz.String()]
+		if !ok {
+			var err error
+			if day, err = b.APIPtr.GetMatchDay(ctx, now.In(tz)); err != nil {
+				b.logger().Error("failed to build match day", "error", fmt.Errorf("refreshMatchDay: %w", err))
+				return
+			}
+			days[tz.String()] = day
+		}
+		embed := b.matchDayEmbed(i18n.Locale(settings.Locale), day, now)
+
+		if msg.Day == day.Day {
+			_, err := session.ChannelMessageEditEmbed(msg.ChannelID, msg.MessageID, embed)
+			if err == nil {
+				continue
+			}
+			b.logger().Warn("failed to edit match day message, reposting", "guild", msg.GuildID, "error", fmt.Errorf("refreshMatchDay: %w", err))
+		}
+		msg.Day = day.Day
+		if err := b.postMatchDay(ctx, session, msg, embed); err != nil {
+			b.logger().Error("failed to post match day message", "guild", msg.GuildID, "error", fmt.Errorf("refreshMatchDay: %w", err))
+		}
+	}
+}
+
+// postMatchDay sends embed as a new match day message to tracked.ChannelID and records it as the guild's message.
+// Callers must hold matchDayMu.
+func (b *Bot) postMatchDay(ctx context.Context, session DiscordSession, tracked store.MatchDayMessage, embed *discordgo.MessageEmbed) error {
+	sent, err := session.ChannelMessageSendEmbed(tracked.ChannelID, embed)
+	if err != nil {
+		return fmt.Errorf("failed to send match day embed: %w", err)
+	}
+	tracked.MessageID = sent.ID
+	if err := b.APIPtr.SaveMatchDayMessage(ctx, tracked); err != nil {
+		return fmt.Errorf("failed to save match day message: %w", err)
+	}
+	return nil
+}
+
+// matchDayEmbed renders a match day as an embed with Live, Finished and Up Next sections, written in loc
+func (b *Bot) matchDayEmbed(loc i18n.Locale, day app.MatchDay, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:     loc.T("matchday.title", day.Day),
+		Color:     burple,
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Footer:    &discordgo.MessageEmbedFooter{Text: loc.T("matchday.footer", b.APIPtr.Store.GetRound())},
+	}
+
+	var live, finished, upcoming []string
+	for _, m := range day.Live {
+		line := fmt.Sprintf("**%s** vs **%s** (Bo%s)", m.Team1, m.Team2, m.BestOf)
+		if m.StreamURL != "" {
+			line += loc.T("matchday.watch", m.StreamURL)
+		}
+		live = append(live, line)
+	}
+	for _, m := range day.Finished {
+		finished = append(finished, finishedLine(loc, m))
+	}
+	for _, m := range day.Upcoming {
+		upcoming = append(upcoming, fmt.Sprintf("**%s** vs **%s** (Bo%s) — <t:%d:t>, <t:%d:R>", m.Team1, m.Team2, m.BestOf, m.EpochTime, m.EpochTime))
+	}
+
+	if len(live) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: loc.T("matchday.live"), Value: joinFieldLines(loc, live)})
+	}
+	if len(finished) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: loc.T("matchday.finished"), Value: joinFieldLines(loc, finished)})
+	}
+	if len(upcoming) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: loc.T("matchday.upcoming"), Value: joinFieldLines(loc, upcoming)})
+	}
+	if len(embed.Fields) == 0 {
+		embed.Description = loc.T("matchday.none")
+	}
+	return embed
+}
+
+// finishedLine formats a finished match in loc, putting the winner first when the result is known. The sources mark
+// a result that isn't in yet with "TBD" as well as "".
+func finishedLine(loc i18n.Locale, m app.MatchDayResult) string {
+	if m.Winner == "" || m.Winner == "TBD" {
+		return loc.T("matchday.pending", m.Team1, m.Team2)
+	}
+	loser := m.Team1
+	if m.Winner == m.Team1 {
+		loser = m.Team2
+	}
+	if m.Score == "" {
+		return loc.T("matchday.defeated", m.Winner, loser)
+	}
+	return fmt.Sprintf("**%s** %s %s", m.Winner, m.Score, loser)
+}
+
+// joinFieldLines joins lines into a single field value, truncating with a count of omitted lines, written in loc, so
+// the value stays within Discord's field limit
+func joinFieldLines(loc i18n.Locale, lines []string) string {
+	return joinLines(loc, lines, maxFieldValue)
+}
+
+// joinLines joins lines with newlines, truncating with a count of omitted lines, written in loc, so the result stays
+// within limit
+func joinLines(loc i18n.Locale, lines []string, limit int) string {
+	var sb strings.Builder
+	for i, line := range lines {
+		more := loc.T("list.more", len(lines)-i)
+		if sb.Len()+len(line)+1+len(more) > limit {
+			sb.WriteString(more)
+			break
+		}
+		sb.WriteString(line + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,247 @@
+/* match_day_test.go
+ * Contains unit tests for the $matchday command and match day message refreshes
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region matchDay command tests
+
+func TestMatchDay_PostsAndTracksMessage(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := newAdminSession()
+	message := createMockMessage("$matchday", "user123", "TestUser", "channel123")
+	message.GuildID = "guild123"
+
+	bot.newMessageHandler(mockSession, message, "bot_id")
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Contains(t, embed.Embed.Title, "Match Day")
+	tracked, ok := mockStore.MatchDayMessages["guild123"]
+	require.True(t, ok)
+	assert.Equal(t, "channel123", tracked.ChannelID)
+	assert.Equal(t, "mock_message_id", tracked.MessageID)
+	assert.Equal(t, "test_round", tracked.Round)
+	assert.Equal(t, time.Now().UTC().Format(app.MatchDayLayout), tracked.Day)
+}
+
+func TestMatchDay_PostsInGuildLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "ru"}
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := newAdminSession()
+	message := createMockMessage("$matchday", "user123", "TestUser", "channel123")
+	message.GuildID = "guild123"
+
+	bot.matchDayHandler(context.Background(), mockSession, message)
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Contains(t, embed.Embed.Title, "Игровой день")
+}
+
+func TestMatchDay_Off(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.MatchDayMessages["guild123"] = store.MatchDayMessage{GuildID: "guild123", Round: "test_round"}
+	mockSession := newAdminSession()
+	message := createMockMessage("$matchday off", "user123", "TestUser", "channel123")
+	message.GuildID = "guild123"
+
+	bot.matchDayHandler(context.Background(), mockSession, message)
+
+	assert.NotContains(t, mockStore.MatchDayMessages, "guild123")
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Disabled")
+}
+
+func TestMatchDay_RequiresGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := newAdminSession()
+
+	bot.matchDayHandler(context.Background(), mockSession, createMockMessage("$matchday", "user123", "TestUser", "dm123"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "server channel")
+}
+
+func TestMatchDay_Errors(t *testing.T) {
+	cases := map[string]func(m *app.MockStore){
+		"schedule": func(m *app.MockStore) { m.FetchMatchScheduleError = errors.New("db down") },
+		"save":     func(m *app.MockStore) { m.StoreMatchDayMessageError = errors.New("db down") },
+	}
+	for name, inject := range cases {
+		t.Run(name, func(t *testing.T) {
+			bot := createTestBot("swiss")
+			inject(bot.APIPtr.Store.(*app.MockStore))
+			mockSession := newAdminSession()
+			message := createMockMessage("$matchday", "user123", "TestUser", "channel123")
+			message.GuildID = "guild123"
+
+			bot.matchDayHandler(context.Background(), mockSession, message)
+
+			assert.Contains(t, mockSession.GetLastMessage().Content, "error occurred")
+		})
+	}
+}
+
+func TestMatchDay_OffError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).DeleteMatchDayMessageError = errors.New("db down")
+	mockSession := newAdminSession()
+	message := createMockMessage("$matchday off", "user123", "TestUser", "channel123")
+	message.GuildID = "guild123"
+
+	bot.matchDayHandler(context.Background(), mockSession, message)
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "error occurred")
+}
+
+// endregion
+
+// region refreshMatchDay tests
+
+func TestRefreshMatchDay_UsesGuildLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	now := time.Unix(1700000000, 0)
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Locale: "pl"}
+	mockStore.MatchDayMessages["guild123"] = store.MatchDayMessage{GuildID: "guild123", ChannelID: "channel123", MessageID: "msg1", Round: "test_round", Day: now.UTC().Format(app.MatchDayLayout)}
+	mockSession := NewMockDiscordSession()
+
+	bot.refreshMatchDay(context.Background(), mockSession, now.Add(time.Minute))
+
+	require.Len(t, mockSession.EditedEmbeds, 1)
+	require.NotEmpty(t, mockSession.EditedEmbeds[0].Embed.Fields)
+	assert.Equal(t, "🔴  Na żywo", mockSession.EditedEmbeds[0].Embed.Fields[0].Name)
+}
+
+func TestRefreshMatchDay_EditsInPlace(t *testing.T) {
+	bot := createTestBot("swiss")
+	now := time.Unix(1700000000, 0)
+	today := now.UTC().Format(app.MatchDayLayout)
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.MatchDayMessages["guild123"] = store.MatchDayMessage{GuildID: "guild123", ChannelID: "channel123", MessageID: "msg1", Round: "test_round", Day: today}
+	mockSession := NewMockDiscordSession()
+
+	bot.refreshMatchDay(context.Background(), mockSession, now.Add(time.Minute))
+
+	require.Len(t, mockSession.EditedEmbeds, 1)
+	edited := mockSession.EditedEmbeds[0]
+	assert.Equal(t, "msg1", edited.MessageID)
+	require.NotEmpty(t, edited.Embed.Fields)
+	assert.Contains(t, edited.Embed.Fields[0].Name, "Live Now")
+	assert.Empty(t, mockSession.SentEmbeds)
+}
+
+func TestRefreshMatchDay_NewDayPostsNewMessage(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.MatchDayMessages["guild123"] = store.MatchDayMessage{GuildID: "guild123", ChannelID: "channel123", MessageID: "old", Round: "test_round", Day: "2000-01-01"}
+	mockSession := NewMockDiscordSession()
+
+	bot.refreshMatchDay(context.Background(), mockSession, time.Unix(1700000000, 0))
+
+	assert.Empty(t, mockSession.EditedEmbeds)
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "mock_message_id", mockStore.MatchDayMessages["guild123"].MessageID)
+	assert.NotEqual(t, "2000-01-01", mockStore.MatchDayMessages["guild123"].Day)
+}
+
+func TestRefreshMatchDay_EditFailureReposts(t *testing.T) {
+	bot := createTestBot("swiss")
+	now := time.Unix(1700000000, 0)
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.MatchDayMessages["guild123"] = store.MatchDayMessage{GuildID: "guild123", ChannelID: "channel123", MessageID: "deleted", Round: "test_round", Day: now.UTC().Format(app.MatchDayLayout)}
+	mockSession := NewMockDiscordSession()
+	mockSession.EditErrorToReturn = errors.New("unknown message")
+
+	bot.refreshMatchDay(context.Background(), mockSession, now)
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "mock_message_id", mockStore.MatchDayMessages["guild123"].MessageID)
+}
+
+func TestRefreshMatchDay_NoTrackedMessages(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.refreshMatchDay(context.Background(), mockSession, time.Now())
+
+	assert.Empty(t, mockSession.EditedEmbeds)
+	assert.Empty(t, mockSession.SentEmbeds)
+}
+
+func TestRefreshMatchDay_NotConnectedIsNoop(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.RefreshMatchDay(context.Background())
+}
+
+// endregion
+
+// region matchDayEmbed tests
+
+func TestMatchDayEmbed_Sections(t *testing.T) {
+	bot := createTestBot("swiss")
+	day := app.MatchDay{
+		Day:  "2026-06-01",
+		Live: []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", BestOf: "3", StreamURL: "https://twitch.tv/x"}},
+		Finished: []app.MatchDayResult{
+			{ScheduledMatch: sources.ScheduledMatch{Team1: "Team C", Team2: "Team D"}, Winner: "Team D", Score: "2-0"},
+			{ScheduledMatch: sources.ScheduledMatch{Team1: "Team E", Team2: "Team F"}, Winner: "Team E"},
+			{ScheduledMatch: sources.ScheduledMatch{Team1: "Team G", Team2: "Team H"}},
+			{ScheduledMatch: sources.ScheduledMatch{Team1: "Team K", Team2: "Team L"}, Winner: "TBD"},
+		},
+		Upcoming: []sources.ScheduledMatch{{Team1: "Team I", Team2: "Team J", BestOf: "1", EpochTime: 1750000000}},
+	}
+
+	embed := bot.matchDayEmbed(i18n.English, day, time.Now())
+
+	require.Len(t, embed.Fields, 3)
+	assert.Contains(t, embed.Fields[0].Value, "[Watch](https://twitch.tv/x)")
+	assert.Contains(t, embed.Fields[1].Value, "**Team D** 2-0 Team C")
+	assert.Contains(t, embed.Fields[1].Value, "**Team E** def. Team F")
+	assert.Contains(t, embed.Fields[1].Value, "Team G vs Team H — result pending")
+	assert.Contains(t, embed.Fields[1].Value, "Team K vs Team L — result pending")
+	assert.NotContains(t, embed.Fields[1].Value, "**TBD**")
+	assert.Contains(t, embed.Fields[2].Value, "<t:1750000000:R>")
+}
+
+func TestMatchDayEmbed_Empty(t *testing.T) {
+	bot := createTestBot("swiss")
+
+	embed := bot.matchDayEmbed(i18n.English, app.MatchDay{Day: "2026-06-01"}, time.Now())
+
+	assert.Empty(t, embed.Fields)
+	assert.Equal(t, "No matches scheduled.", embed.Description)
+}
+
+func TestJoinFieldLines_Truncates(t *testing.T) {
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 40)
+	}
+
+	value := joinFieldLines(i18n.English, lines)
+
+	assert.LessOrEqual(t, len(value), maxFieldValue)
+	assert.Contains(t, value, "more")
+}
+
+// endregion
@@ -20,6 +20,20 @@ type MockDiscordSession struct {
 	SentEmbeds []MockEmbedMessage
 	// SentFiles stores all files sent during tests
 	SentFiles []MockFileMessage
+	// EditedEmbeds stores all embeds sent via ChannelMessageEditEmbed
+	EditedEmbeds []MockEditedEmbed
+	// EditErrorToReturn allows tests to simulate edit failures independently of sends
+	EditErrorToReturn error
+	// Permissions is returned by UserChannelPermissions for every user and channel
+	Permissions int64
+	// PermissionsError allows tests to simulate permission lookup failures
+	PermissionsError error
+	// DMChannels stores the recipient IDs of every DM channel opened via UserChannelCreate
+	DMChannels []string
+	// SentComplex stores all messages sent via ChannelMessageSendComplex
+	SentComplex []*discordgo.MessageSend
+	// InteractionResponses stores all responses sent via InteractionRespond
+	InteractionResponses []*discordgo.InteractionResponse
 	// ErrorToReturn allows tests to simulate errors
 	ErrorToReturn error
 }
@@ -42,6 +56,13 @@ type MockEmbedMessage struct {
 	Embed     *discordgo.MessageEmbed
 }
 
+// MockEditedEmbed represents an embed edited into an existing message
+type MockEditedEmbed struct {
+	ChannelID string
+	MessageID string
+	Embed     *discordgo.MessageEmbed
+}
+
 // ChannelFileSend implements DiscordSession.ChannelFileSend
 func (m *MockDiscordSession) ChannelFileSend(channelID string, name string, r io.Reader, options ...discordgo.RequestOption) (*discordgo.Message, error) {
 	if m.ErrorToReturn != nil {
@@ -96,6 +117,57 @@ func (m *MockDiscordSession) ChannelMessageSendEmbed(channelID string, embed *di
 	return &discordgo.Message{ID: "mock_message_id", ChannelID: channelID}, nil
 }
 
+// ChannelMessageSendComplex implements DiscordSession.ChannelMessageSendComplex. The message is stored in
+// SentComplex and its first embed is recorded as if sent via ChannelMessageSendEmbed.
+func (m *MockDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.SentComplex = append(m.SentComplex, data)
+	if len(data.Embeds) > 0 {
+		return m.ChannelMessageSendEmbed(channelID, data.Embeds[0])
+	}
+	return m.ChannelMessageSend(channelID, data.Content)
+}
+
+// ChannelMessageEditEmbed implements DiscordSession.ChannelMessageEditEmbed
+func (m *MockDiscordSession) ChannelMessageEditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if m.EditErrorToReturn != nil {
+		return nil, m.EditErrorToReturn
+	}
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.EditedEmbeds = append(m.EditedEmbeds, MockEditedEmbed{ChannelID: channelID, MessageID: messageID, Embed: embed})
+	return &discordgo.Message{ID: messageID, ChannelID: channelID}, nil
+}
+
+// UserChannelPermissions implements DiscordSession.UserChannelPermissions
+func (m *MockDiscordSession) UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error) {
+	if m.PermissionsError != nil {
+		return 0, m.PermissionsError
+	}
+	return m.Permissions, nil
+}
+
+// UserChannelCreate implements DiscordSession.UserChannelCreate. The returned channel ID is "dm_" + recipientID.
+func (m *MockDiscordSession) UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.DMChannels = append(m.DMChannels, recipientID)
+	return &discordgo.Channel{ID: "dm_" + recipientID, Type: discordgo.ChannelTypeDM}, nil
+}
+
+// InteractionRespond implements DiscordSession.InteractionRespond
+func (m *MockDiscordSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	if m.ErrorToReturn != nil {
+		return m.ErrorToReturn
+	}
+	m.InteractionResponses = append(m.InteractionResponses, resp)
+	return nil
+}
+
 // GetLastEmbed returns the last embed sent, or nil if none
 func (m *MockDiscordSession) GetLastEmbed() *MockEmbedMessage {
 	if len(m.SentEmbeds) == 0 {
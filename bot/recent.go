@@ -0,0 +1,88 @@
+/* recent.go
+ * Contains the $recent command, which lists the current round's matches that finished in the last few hours.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/sources"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	defaultRecentHours = 24
+	maxRecentHours     = 168 // a week
+)
+
+// recentHandler handles the $recent command with a DiscordSession interface
+func (b *Bot) recentHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	hours := defaultRecentHours
+	if arg := strings.TrimSpace(strings.TrimPrefix(message.Content, "$recent")); arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > maxRecentHours {
+			sendLocalizedError(session, message.ChannelID, loc, loc.T("recent.usage", maxRecentHours))
+			return
+		}
+		hours = n
+	}
+
+	results, err := b.APIPtr.GetRecentResults(ctx, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if err != nil {
+		b.logger().Error("failed to get recent results", "error", fmt.Errorf("recentHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("recent.error"))
+		return
+	}
+
+	title, none := loc.T("recent.title_hours", hours), loc.T("recent.none_hours", hours)
+	if hours == 1 {
+		title, none = loc.T("recent.title_hour"), loc.T("recent.none_hour")
+	}
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: green,
+	}
+	if len(results) == 0 {
+		embed.Description = none
+	} else {
+		lines := make([]string, 0, len(results))
+		for _, node := range results {
+			lines = append(lines, recentResultLine(node))
+		}
+		embed.Description = joinLines(loc, lines, maxDescription)
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send recent results embed", "error", fmt.Errorf("recentHandler: %w", err))
+	}
+}
+
+// recentResultLine formats a finished match with the winner in bold, e.g. "**Team A** 2-1 Team B · Round 3 · 2 hours ago"
+func recentResultLine(node sources.MatchNode) string {
+	return matchResultLine(node) + fmt.Sprintf(" · <t:%d:R>", node.FinishedAt.Unix())
+}
+
+// matchResultLine formats a finished match with the winner in bold, e.g. "**Team A** 2-1 Team B · Round 3"
+func matchResultLine(node sources.MatchNode) string {
+	team1, team2 := node.Team1, node.Team2
+	if node.Winner == team1 {
+		team1 = "**" + team1 + "**"
+	} else if node.Winner == team2 {
+		team2 = "**" + team2 + "**"
+	}
+	score := node.Score
+	if score == "" {
+		score = "vs"
+	}
+	line := fmt.Sprintf("%s %s %s", team1, score, team2)
+	if node.Section != "" {
+		line += " · " + node.Section
+	}
+	return line
+}
@@ -0,0 +1,101 @@
+/* recent_test.go
+ * Contains unit tests for the $recent command
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// region recent tests
+
+func TestRecent_DefaultWindow(t *testing.T) {
+	bot := createTestBot("swiss")
+	finished := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	bot.APIPtr.Store.(*app.MockStore).MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B", Score: "1-2", Section: "Round 3", FinishedAt: finished},
+		{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "Team C", Score: "2-0", FinishedAt: time.Now().Add(-30 * time.Hour)},
+	}
+	mockSession := NewMockDiscordSession()
+
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Results from the last 24 hours", embed.Title)
+	assert.Equal(t, fmt.Sprintf("Team A 1-2 **Team B** · Round 3 · <t:%d:R>", finished.Unix()), embed.Description)
+}
+
+func TestRecent_CustomWindow(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).MatchNodes = []sources.MatchNode{
+		{ID: "m1", Team1: "Team C", Team2: "Team D", Winner: "Team C", Score: "2-0", FinishedAt: time.Now().Add(-30 * time.Hour)},
+	}
+	mockSession := NewMockDiscordSession()
+
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent 48", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Results from the last 48 hours", embed.Title)
+	assert.Contains(t, embed.Description, "**Team C** 2-0 Team D")
+}
+
+func TestRecent_NoResults(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent 1", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Results from the last hour", embed.Title)
+	assert.Equal(t, "No matches finished in the last hour.", embed.Description)
+}
+
+func TestRecent_UsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+
+	mockSession := NewMockDiscordSession()
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent 48", "user123", "TestUser", "channel123"))
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Resultados das últimas 48 horas", embed.Title)
+	assert.Equal(t, "Nenhuma partida terminou nas últimas 48 horas.", embed.Description)
+
+	mockSession = NewMockDiscordSession()
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent 0", "user123", "TestUser", "channel123"))
+	embed = mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Erro", embed.Title)
+	assert.Equal(t, "Uso: `$recent [horas]`, com horas entre 1 e 168.", embed.Description)
+}
+
+func TestRecent_InvalidHours(t *testing.T) {
+	bot := createTestBot("swiss")
+
+	for _, content := range []string{"$recent abc", "$recent 0", "$recent 169"} {
+		mockSession := NewMockDiscordSession()
+		bot.recentHandler(context.Background(), mockSession, createMockMessage(content, "user123", "TestUser", "channel123"))
+		assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage: `$recent [hours]`", content)
+	}
+}
+
+func TestRecent_Error(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).FetchMatchNodesFromDbError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.recentHandler(context.Background(), mockSession, createMockMessage("$recent", "user123", "TestUser", "channel123"))
+
+	assert.Equal(t, "An error occurred getting recent results.", mockSession.GetLastEmbed().Embed.Description)
+}
+
+// endregion
@@ -0,0 +1,160 @@
+/* reminders.go
+ * Contains the $remind command and the background loop that DMs users who haven't set their Pick'Ems
+ * before the current round locks.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/metrics"
+	"pickems-bot/models"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// reminderInterval is how often the reminder loop checks for due reminders
+const reminderInterval = time.Minute
+
+// remindHandler handles the $remind on|off command, toggling whether the user receives reminder DMs
+func (b *Bot) remindHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	args := strings.Fields(message.Content)
+	if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("remind.usage"))
+		return
+	}
+
+	enabled := args[1] == "on"
+	if err := b.APIPtr.SetRemindersEnabled(ctx, message.Author.ID, enabled); err != nil {
+		b.logger().Error("failed to update reminder preference", "user", message.Author.Username, "error", fmt.Errorf("remindHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("remind.error"))
+		return
+	}
+
+	description := loc.T("remind.off")
+	if enabled {
+		description = loc.T("remind.on")
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("remind.title"),
+		Description: description,
+		Color:       green,
+	}
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send remind embed", "error", fmt.Errorf("remindHandler: %w", err))
+	}
+}
+
+// trackUser records the author of a command or button press as someone who has interacted with the bot, so they are
+// eligible for reminders, their current username is looked up and their display name is shown. member is nil in
+// DMs. guildID is where they used it, and decides the prefix and language of their reminder DMs. Failures are
+// logged but never surfaced to the user.
+func (b *Bot) trackUser(ctx context.Context, author *discordgo.User, member *discordgo.Member, guildID string) {
+	if author == nil || author.Username == "" {
+		return
+	}
+	user := models.User{UserID: author.ID, Username: author.Username, DisplayName: displayName(author, member), GuildID: guildID}
+	if err := b.APIPtr.TrackUser(ctx, user); err != nil {
+		b.logger().Warn("failed to track user", "user", user.Username, "error", fmt.Errorf("trackUser: %w", err))
+	}
+}
+
+// runReminders checks for due reminders every interval until ctx is cancelled. It does nothing when no lead
+// times are configured.
+func (b *Bot) runReminders(ctx context.Context, session DiscordSession, interval time.Duration) {
+	if len(b.ReminderLeadTimes) == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	b.sendDueReminders(ctx, session, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			b.sendDueReminders(ctx, session, now)
+		}
+	}
+}
+
+// sendDueReminders DMs every user who is due a reminder at now and records each successful delivery.
+// A user whose DM fails (e.g. DMs disabled) is still marked as reminded so they aren't retried every tick.
+func (b *Bot) sendDueReminders(ctx context.Context, session DiscordSession, now time.Time) {
+	reminders, err := b.APIPtr.DueReminders(ctx, b.ReminderLeadTimes, now)
+	if err != nil {
+		b.logger().Error("failed to compute due reminders", "error", fmt.Errorf("sendDueReminders: %w", err))
+		return
+	}
+
+	for _, r := range reminders {
+		loc := b.localeFor(ctx, r.UserID, r.GuildID)
+		prefix := b.guildSettings(ctx, r.GuildID).Prefix
+		if err := sendReminder(session, r, loc, prefix, b.userTimezone(ctx, r.UserID)); err != nil {
+			b.logger().Warn("failed to send reminder DM", "user_id", r.UserID, "error", fmt.Errorf("sendDueReminders: %w", err))
+		} else {
+			metrics.RemindersSentTotal.Inc()
+		}
+		if err := b.APIPtr.MarkReminderSent(ctx, r); err != nil {
+			b.logger().Error("failed to record sent reminder", "user_id", r.UserID, "error", fmt.Errorf("sendDueReminders: %w", err))
+		}
+	}
+	if len(reminders) > 0 {
+		b.logger().Info("reminders processed", "count", len(reminders), "lead", reminders[0].Lead.String())
+	}
+
+	b.sendChannelReminders(ctx, session, now)
+}
+
+// sendChannelReminders posts a pre-lock notice, in the guild's locale, to every guild reminder channel that is due one
+func (b *Bot) sendChannelReminders(ctx context.Context, session DiscordSession, now time.Time) {
+	reminders, err := b.APIPtr.DueChannelReminders(ctx, b.ReminderLeadTimes, now)
+	if err != nil {
+		b.logger().Error("failed to compute due channel reminders", "error", fmt.Errorf("sendChannelReminders: %w", err))
+		return
+	}
+
+	for _, r := range reminders {
+		settings := b.guildSettings(ctx, r.GuildID)
+		loc := i18n.Locale(settings.Locale)
+		embed := &discordgo.MessageEmbed{
+			Title: loc.T("reminder.title"),
+			Description: loc.T("reminder.channel", r.Round, r.LockTime.Unix(), settings.Prefix) + "\n" +
+				loc.T("reminder.channel_missing", r.MissingPicks),
+			Color: burple,
+		}
+		if _, err := session.ChannelMessageSendEmbed(r.ChannelID, embed); err != nil {
+			b.logger().Warn("failed to send channel reminder", "guild", r.GuildID, "error", fmt.Errorf("sendChannelReminders: %w", err))
+			continue
+		}
+		if err := b.APIPtr.MarkChannelReminderSent(ctx, r); err != nil {
+			b.logger().Error("failed to record channel reminder", "guild", r.GuildID, "error", fmt.Errorf("sendChannelReminders: %w", err))
+		}
+	}
+}
+
+// sendReminder opens a DM channel with the user and sends the reminder embed in loc, with commands written with
+// prefix. The lock time is also written out in tz, since DM notifications show Discord timestamps raw.
+func sendReminder(session DiscordSession, r app.Reminder, loc i18n.Locale, prefix string, tz *time.Location) error {
+	channel, err := session.UserChannelCreate(r.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM channel: %w", err)
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("reminder.title"),
+		Description: loc.T("reminder.dm", r.Round, r.LockTime.Unix(), r.LockTime.In(tz).Format(plainTimeFormat), prefix),
+		Color:       burple,
+		Footer:      &discordgo.MessageEmbedFooter{Text: loc.T("reminder.dm_footer", prefix, prefix)},
+	}
+	if _, err := session.ChannelMessageSendEmbed(channel.ID, embed); err != nil {
+		return fmt.Errorf("failed to send reminder embed: %w", err)
+	}
+	return nil
+}
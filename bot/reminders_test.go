@@ -0,0 +1,304 @@
+/* reminders_test.go
+ * Contains unit tests for the $remind command and reminder DMs
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/models"
+	"pickems-bot/store"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reminderLock is the first match time used by createTestBot's schedule
+var reminderLock = time.Unix(1700000000, 0)
+
+// region remind tests
+
+func TestRemind_Off(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("$remind off", "user123", "TestUser", "channel123"), "bot_id")
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "Reminders Updated", mockSession.SentEmbeds[0].Embed.Title)
+	assert.True(t, mockStore.Profiles["user123"].RemindersOff)
+}
+
+func TestRemind_On(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", RemindersOff: true}
+	mockSession := NewMockDiscordSession()
+
+	bot.remindHandler(context.Background(), mockSession, createMockMessage("$remind on", "user123", "TestUser", "channel123"))
+
+	assert.False(t, mockStore.Profiles["user123"].RemindersOff)
+	assert.Contains(t, mockSession.GetLastMessage().Content, "DM'd")
+}
+
+func TestRemind_UsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := NewMockDiscordSession()
+
+	bot.remindHandler(context.Background(), mockSession, createMockMessage("$remind off", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Напоминания обновлены", embed.Title)
+	assert.Contains(t, embed.Description, "`$remind on`")
+}
+
+func TestRemind_InvalidArgument(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.remindHandler(context.Background(), mockSession, createMockMessage("$remind maybe", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "Usage")
+}
+
+func TestRemind_StoreError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).SetRemindersEnabledError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.remindHandler(context.Background(), mockSession, createMockMessage("$remind off", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastMessage().Content, "error occurred")
+}
+
+// endregion
+
+// region user tracking tests
+
+func TestNewMessage_TracksCommandUsers(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("$help", "user123", "TestUser", "channel123"), "bot_id")
+
+	assert.Equal(t, "TestUser", mockStore.Profiles["user123"].Username)
+	assert.Equal(t, "TestUser", mockStore.Profiles["user123"].DisplayName)
+}
+
+func TestNewMessage_TracksDisplayNames(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	dm := createMockMessage("$help", "user123", "testuser", "dm123")
+	dm.Author.GlobalName = "Test User"
+	bot.newMessageHandler(mockSession, dm, "bot_id")
+	assert.Equal(t, "testuser", mockStore.Profiles["user123"].Username)
+	assert.Equal(t, "Test User", mockStore.Profiles["user123"].DisplayName)
+
+	guild := createGuildMessage("$help")
+	guild.Author.GlobalName = "Test User"
+	guild.Member = &discordgo.Member{Nick: "Tester"}
+	bot.newMessageHandler(mockSession, guild, "bot_id")
+	assert.Equal(t, "Tester", mockStore.Profiles["user123"].DisplayName)
+}
+
+func TestNewMessage_TracksCommandGuild(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createGuildMessage("$help"), "bot_id")
+	bot.newMessageHandler(mockSession, createMockMessage("$help", "user123", "TestUser", "dm123"), "bot_id")
+
+	assert.Equal(t, "guild123", mockStore.Profiles["user123"].GuildID)
+}
+
+func TestNewMessage_DoesNotTrackNonCommands(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("hello world", "user123", "TestUser", "channel123"), "bot_id")
+
+	assert.NotContains(t, mockStore.Profiles, "user123")
+}
+
+func TestNewMessage_TrackUserErrorIsSilent(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).TrackUserError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.newMessageHandler(mockSession, createMockMessage("$help", "user123", "TestUser", "channel123"), "bot_id")
+
+	require.Len(t, mockSession.SentMessages, 1)
+	assert.Contains(t, mockSession.GetLastMessage().Content, "PickEms Bot")
+}
+
+// endregion
+
+// region sendDueReminders tests
+
+func TestSendDueReminders_DMsUsersWithoutPicks(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{24 * time.Hour, time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["forgetful"] = store.UserProfile{UserID: "forgetful"}
+	mockStore.Profiles["diligent"] = store.UserProfile{UserID: "diligent"}
+	mockStore.Predictions["diligent"] = models.Prediction{UserID: "diligent", Round: "test_round"}
+	mockSession := NewMockDiscordSession()
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	assert.Equal(t, []string{"forgetful"}, mockSession.DMChannels)
+	require.Len(t, mockSession.SentEmbeds, 1)
+	assert.Equal(t, "dm_forgetful", mockSession.SentEmbeds[0].ChannelID)
+	assert.Contains(t, mockSession.SentEmbeds[0].Embed.Description, "<t:1700000000:R>")
+	require.Len(t, mockStore.SentReminders, 1)
+	assert.Equal(t, "1h0m0s", mockStore.SentReminders[0].Lead)
+
+	// A second tick in the same window must not DM again
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-20*time.Minute))
+	assert.Len(t, mockSession.SentEmbeds, 1)
+}
+
+func TestSendDueReminders_UsesGuildPrefixAndLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", Prefix: "!", Locale: "pt"}
+	mockStore.Profiles["forgetful"] = store.UserProfile{UserID: "forgetful", GuildID: "guild123"}
+	mockSession := NewMockDiscordSession()
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	embed := mockSession.SentEmbeds[0].Embed
+	assert.Equal(t, "⏰ Os Pick'Ems fecham em breve", embed.Title)
+	assert.Contains(t, embed.Description, "Use `!set` no servidor")
+	assert.Contains(t, embed.Footer.Text, "!remind off")
+}
+
+func TestSendDueReminders_DMFailureStillMarksSent(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["closeddms"] = store.UserProfile{UserID: "closeddms"}
+	mockSession := NewMockDiscordSession()
+	mockSession.ErrorToReturn = errors.New("cannot send messages to this user")
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	assert.Len(t, mockStore.SentReminders, 1)
+}
+
+func TestSendDueReminders_StoreError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	bot.APIPtr.Store.(*app.MockStore).FetchUserProfilesError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	assert.Empty(t, mockSession.DMChannels)
+}
+
+func TestRunReminders_DisabledReturnsImmediately(t *testing.T) {
+	bot := createTestBot("swiss")
+	done := make(chan struct{})
+
+	go func() {
+		bot.runReminders(context.Background(), NewMockDiscordSession(), time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runReminders did not return with no lead times configured")
+	}
+}
+
+func TestRunReminders_StopsOnCancel(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		bot.runReminders(ctx, NewMockDiscordSession(), time.Millisecond)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runReminders did not stop")
+	}
+}
+
+// endregion
+
+// region sendChannelReminders tests
+
+func TestSendDueReminders_PostsChannelReminders(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", ReminderChannel: "reminders123", Prefix: "!"}
+	mockSession := NewMockDiscordSession()
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, "reminders123", embed.ChannelID)
+	assert.Contains(t, embed.Embed.Description, "`!set`")
+	require.Len(t, mockStore.SentReminders, 1)
+	assert.Equal(t, "guild123", mockStore.SentReminders[0].GuildID)
+
+	// Not posted twice for the same lead time
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-20*time.Minute))
+	assert.Len(t, mockSession.SentEmbeds, 1)
+}
+
+func TestSendChannelReminders_UsesGuildLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", ReminderChannel: "reminders123", Locale: "pt"}
+	mockSession := NewMockDiscordSession()
+
+	bot.sendChannelReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	embed := mockSession.GetLastEmbed()
+	require.NotNil(t, embed)
+	assert.Equal(t, i18n.Locale("pt").T("reminder.title"), embed.Embed.Title)
+	assert.Contains(t, embed.Embed.Description, "Use `$set` para registrar seus palpites.")
+}
+
+func TestSendChannelReminders_SendFailureNotMarked(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.GuildSettings["guild123"] = store.GuildSettings{GuildID: "guild123", ReminderChannel: "reminders123"}
+	mockSession := NewMockDiscordSession()
+	mockSession.ErrorToReturn = errors.New("missing access")
+
+	bot.sendChannelReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	assert.Empty(t, mockStore.SentReminders)
+}
+
+// endregion
@@ -0,0 +1,103 @@
+/* rounds.go
+ * Contains the helpers commands use to look back at rounds other than the configured one: parsing a round
+ * argument, reporting unknown rounds and listing a round's results.
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// roundFlag is the option that selects the round a command looks at, e.g. `$check --round stage_1`
+const roundFlag = "--round"
+
+// parseRoundFlag removes a "--round <round>" (or "--round=<round>") option from a command's arguments, returning the
+// remaining arguments and the round. ok is false when the option is given without a round.
+func parseRoundFlag(args string) (rest string, round string, ok bool) {
+	fields := strings.Fields(args)
+	remaining := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case fields[i] == roundFlag:
+			if i+1 >= len(fields) {
+				return strings.Join(remaining, " "), "", false
+			}
+			round = fields[i+1]
+			i++
+		case strings.HasPrefix(fields[i], roundFlag+"="):
+			round = strings.TrimPrefix(fields[i], roundFlag+"=")
+			if round == "" {
+				return strings.Join(remaining, " "), "", false
+			}
+		default:
+			remaining = append(remaining, fields[i])
+		}
+	}
+	return strings.Join(remaining, " "), round, true
+}
+
+// knownRounds lists the rounds with stored data for an error message, e.g. "`stage_1`, `stage_2`"
+func (b *Bot) knownRounds(ctx context.Context) string {
+	rounds, err := b.APIPtr.GetRounds(ctx)
+	if err != nil {
+		b.logger().Warn("failed to list rounds", "error", fmt.Errorf("knownRounds: %w", err))
+		return "—"
+	}
+	quoted := make([]string, len(rounds))
+	for i, round := range rounds {
+		quoted[i] = "`" + round + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// sendRoundError reports err if it is app.ErrUnknownRound, listing the rounds that do have data. Returns false,
+// without sending anything, for any other error.
+func (b *Bot) sendRoundError(ctx context.Context, session DiscordSession, channelID string, loc i18n.Locale, round string, err error) bool {
+	if !errors.Is(err, app.ErrUnknownRound) {
+		return false
+	}
+	sendLocalizedError(session, channelID, loc, loc.T("round.unknown", round, b.knownRounds(ctx)))
+	return true
+}
+
+// roundResultsHandler handles `$results <round>` for a round other than the current one. The results image is only
+// rendered for the current round, so earlier rounds are listed as text instead.
+func (b *Bot) roundResultsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate, round string) {
+	loc := b.locale(ctx, message)
+	results, err := b.APIPtr.GetRoundResults(ctx, round)
+	if err != nil {
+		if b.sendRoundError(ctx, session, message.ChannelID, loc, round, err) {
+			return
+		}
+		b.logger().Error("failed to get round results", "round", round, "error", fmt.Errorf("roundResultsHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("results.error"))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: loc.T("results.round_title", round),
+		Color: green,
+	}
+	if len(results) == 0 {
+		embed.Description = loc.T("results.round_none", round)
+	} else {
+		lines := make([]string, 0, len(results))
+		for _, node := range results {
+			lines = append(lines, matchResultLine(node))
+		}
+		embed.Description = joinLines(loc, lines, maxDescription)
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, embed); err != nil {
+		b.logger().Error("failed to send round results embed", "round", round, "error", fmt.Errorf("roundResultsHandler: %w", err))
+	}
+}
@@ -0,0 +1,174 @@
+/* rounds_test.go
+ * Contains unit tests for looking back at earlier rounds with $check --round, $leaderboard <round> and $results <round>
+ */
+
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/store"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRoundsTestBot creates a Bot on "test_round" that also holds a finished Swiss "stage_1"
+func createRoundsTestBot() *Bot {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).PastRounds = map[string]app.MockRound{
+		"stage_1": {
+			Predictions: map[string]models.Prediction{
+				"user123": {UserID: "user123", Username: "TestUser", Format: "swiss", Round: "stage_1",
+					Win:     []string{"Team A", "Team B"},
+					Advance: []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+					Lose:    []string{"Team I", "Team J"},
+				},
+			},
+			MatchResults: tournament.SwissResult{Round: "stage_1", Teams: map[string]string{
+				"Team A": "3-0", "Team B": "3-1", "Team C": "3-2", "Team D": "3-1",
+				"Team I": "0-3", "Team J": "0-3",
+			}},
+			MatchNodes: []sources.MatchNode{
+				{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-1"},
+				{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "TBD"},
+			},
+			MatchKind: tournament.Swiss,
+			Leaderboard: []store.LeaderboardEntry{
+				{UserID: "user123", Username: "TestUser", Score: 5, ScoreResult: models.ScoreResult{Successes: 5}},
+			},
+		},
+	}
+	return bot
+}
+
+// region parseRoundFlag tests
+
+func TestParseRoundFlag(t *testing.T) {
+	tests := []struct {
+		args  string
+		rest  string
+		round string
+		ok    bool
+	}{
+		{"", "", "", true},
+		{" alice", "alice", "", true},
+		{" --round stage_1", "", "stage_1", true},
+		{" alice --round stage_1", "alice", "stage_1", true},
+		{" --round=stage_1 alice", "alice", "stage_1", true},
+		{" alice --round", "alice", "", false},
+		{" --round=", "", "", false},
+	}
+	for _, tt := range tests {
+		rest, round, ok := parseRoundFlag(tt.args)
+		assert.Equal(t, tt.rest, rest, tt.args)
+		assert.Equal(t, tt.round, round, tt.args)
+		assert.Equal(t, tt.ok, ok, tt.args)
+	}
+}
+
+// endregion
+
+// region round command tests
+
+func TestCheck_PastRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.checkPredictionsHandler(context.Background(), mockSession, createMockMessage("$check --round STAGE_1", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "TestUser's Pick'Ems (stage_1)", embed.Title)
+	require.Len(t, embed.Fields, 3)
+}
+
+func TestCheck_UnknownRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.checkPredictionsHandler(context.Background(), mockSession, createMockMessage("$check --round playoffs", "user123", "TestUser", "channel123"))
+
+	assert.Equal(t, "No data is stored for round **playoffs**. Known rounds: `stage_1`, `test_round`", mockSession.GetLastEmbed().Embed.Description)
+}
+
+func TestCheck_RoundFlagWithoutRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.checkPredictionsHandler(context.Background(), mockSession, createMockMessage("$check --round", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Give a round name after `--round`")
+}
+
+func TestLeaderboard_PastRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard stage_1", "user123", "TestUser", "channel123"))
+
+	require.Len(t, mockSession.SentComplex, 1)
+	embed := mockSession.SentComplex[0].Embeds[0]
+	assert.Equal(t, "Leaderboard: stage_1", embed.Title)
+	assert.Equal(t, "**1. TestUser - 5 Successes, 0 Failures**", embed.Description)
+}
+
+func TestLeaderboardButton_PastRoundInRequesterLocale(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["user1"] = store.UserProfile{UserID: "user1", Locale: "pt"}
+	past := mockStore.PastRounds["stage_1"]
+	past.Leaderboard = createLeaderboardTestBot(45).APIPtr.Store.(*app.MockStore).Leaderboard
+	mockStore.PastRounds["stage_1"] = past
+	mockSession := NewMockDiscordSession()
+
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:1:user1:stage_1", "user2"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	assert.Equal(t, "Classificação: stage_1", mockSession.InteractionResponses[0].Data.Embeds[0].Title)
+}
+
+func TestLeaderboardButton_PastRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	past := mockStore.PastRounds["stage_1"]
+	past.Leaderboard = createLeaderboardTestBot(45).APIPtr.Store.(*app.MockStore).Leaderboard
+	mockStore.PastRounds["stage_1"] = past
+	mockSession := NewMockDiscordSession()
+
+	bot.newInteractionHandler(mockSession, createButtonInteraction("leaderboard:1:user1:stage_1", "user1"))
+
+	require.Len(t, mockSession.InteractionResponses, 1)
+	response := mockSession.InteractionResponses[0]
+	assert.Equal(t, "Leaderboard: stage_1", response.Data.Embeds[0].Title)
+	btns := buttons(t, response.Data.Components)
+	assert.Equal(t, "leaderboard:0:user1:stage_1", btns[0].CustomID)
+	assert.Equal(t, "leaderboard:2:user1:stage_1", btns[1].CustomID)
+}
+
+func TestLeaderboard_UnknownRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.leaderboardHandler(context.Background(), mockSession, createMockMessage("$leaderboard playoffs", "user123", "TestUser", "channel123"))
+
+	assert.Empty(t, mockSession.SentComplex)
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "No data is stored for round **playoffs**")
+}
+
+func TestResults_PastRound(t *testing.T) {
+	bot := createRoundsTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.resultsHandler(context.Background(), mockSession, createMockMessage("$results stage_1", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "stage_1 results", embed.Title)
+	assert.Equal(t, "**Team A** 2-1 Team B", embed.Description)
+}
+
+// endregion
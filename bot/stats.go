@@ -0,0 +1,84 @@
+/* stats.go
+ * Contains the $stats command: how popular each team is across everyone's Pick'Ems, how those picks are doing
+ * and the crowd's consensus prediction.
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pickems-bot/app"
+	"pickems-bot/i18n"
+	"pickems-bot/store"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// statsHandler handles the $stats command with a DiscordSession interface
+func (b *Bot) statsHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	stats, err := b.APIPtr.GetPickStats(ctx)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		b.logger().Error("failed to get pick stats", "error", fmt.Errorf("statsHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("stats.error"))
+		return
+	}
+	if stats.Predictors == 0 {
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("stats.none"))
+		return
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, statsEmbed(loc, stats)); err != nil {
+		b.logger().Error("failed to send stats embed", "error", fmt.Errorf("statsHandler: %w", err))
+	}
+}
+
+// statsEmbed builds the $stats embed: a line per team with its pick counts by slot and hit rate, and the
+// consensus Pick'Ems with each pick's share of users and status, written in loc
+func statsEmbed(loc i18n.Locale, stats app.PickStats) *discordgo.MessageEmbed {
+	header := loc.T("stats.header", stats.Predictors) + "\n\n"
+
+	teams := make([]string, 0, len(stats.Teams))
+	for _, team := range stats.Teams {
+		var slots []string
+		for _, slot := range stats.Slots {
+			if n := team.Picks[slot]; n > 0 {
+				slots = append(slots, fmt.Sprintf("%s %d", slot, n))
+			}
+		}
+		name := fmt.Sprintf("**%s**", team.Team)
+		if team.Record != "" {
+			name += fmt.Sprintf(" (%s)", team.Record)
+		}
+		teams = append(teams, loc.T("stats.team", name, strings.Join(slots, " · "), percent(team.Succeeded, team.Total())))
+	}
+
+	consensus := make([]string, 0, len(stats.Consensus))
+	for _, pick := range stats.Consensus {
+		consensus = append(consensus, fmt.Sprintf("%s: **%s** — %d (%s) %s",
+			pick.Slot, pick.Team, pick.Count, percent(pick.Count, stats.Predictors), pick.Status))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       loc.T("stats.title"),
+		Description: header + joinLines(loc, teams, maxDescription-len(header)),
+		Color:       burple,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: loc.T("stats.consensus"), Value: joinFieldLines(loc, consensus)},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("stats.footer"),
+		},
+	}
+}
+
+// percent formats n/total as a whole percentage, e.g. "60%"
+func percent(n, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%d%%", n*100/total)
+}
@@ -0,0 +1,82 @@
+/* stats_test.go
+ * Contains unit tests for the $stats command
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/app"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region stats tests
+
+func TestStats_Success(t *testing.T) {
+	bot := createCompareTestBot()
+	mockSession := NewMockDiscordSession()
+
+	bot.statsHandler(context.Background(), mockSession, createMockMessage("$stats", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Pick'Ems Stats", embed.Title)
+	assert.Equal(t, "Based on **2** users' Pick'Ems.\n\n"+
+		"**Team A** (3-0): 3-0 2 — 100% hit\n"+
+		"**Team C** (3-2): Advance 1 — 100% hit\n"+
+		"**Team E** (0-2): 0-3 1 — 0% hit\n"+
+		"**Team F** (0-3): 0-3 1 — 100% hit\n"+
+		"**Team G** (2-1): Advance 1 — 0% hit", embed.Description)
+
+	require.Len(t, embed.Fields, 1)
+	assert.Equal(t, "3-0: **Team A** — 2 (100%) ✅\n"+
+		"Advance: **Team C** — 1 (50%) ✅\n"+
+		"0-3: **Team E** — 1 (50%) ⏳", embed.Fields[0].Value)
+}
+
+func TestStats_UsesUserLocale(t *testing.T) {
+	bot := createCompareTestBot()
+	bot.APIPtr.Store.(*app.MockStore).Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "ru"}
+	mockSession := NewMockDiscordSession()
+
+	bot.statsHandler(context.Background(), mockSession, createMockMessage("$stats", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Статистика Pick'Ems", embed.Title)
+	assert.Contains(t, embed.Description, "На основе Pick'Ems пользователей: **2**.\n\n")
+	assert.Contains(t, embed.Description, "**Team A** (3-0): 3-0 2 — попадание 100%")
+	require.Len(t, embed.Fields, 1)
+	assert.Equal(t, "🗳️ Pick'Ems большинства", embed.Fields[0].Name)
+}
+
+func TestStats_NoPredictions(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.statsHandler(context.Background(), mockSession, createMockMessage("$stats", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Nobody has set their Pick'Ems")
+}
+
+func TestStats_Error(t *testing.T) {
+	bot := createCompareTestBot()
+	bot.APIPtr.Store.(*app.MockStore).GetAllUserPredictionsError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.statsHandler(context.Background(), mockSession, createMockMessage("$stats", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "An error occurred getting the Pick'Ems stats.")
+}
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, "0%", percent(0, 0))
+	assert.Equal(t, "33%", percent(1, 3))
+	assert.Equal(t, "100%", percent(4, 4))
+}
+
+// endregion
@@ -0,0 +1,74 @@
+/* timezone.go
+ * Contains the $timezone command and the lookup of the timezone times are written in for plain-text contexts
+ * such as DMs, where Discord's <t:...> timestamps may be shown raw.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// plainTimeFormat is how times are written when they can't rely on Discord timestamps, e.g. "Sat 14 Jun 18:00 CEST"
+const plainTimeFormat = "Mon 2 Jan 15:04 MST"
+
+// userTimezone returns the location a user has chosen, or UTC if they haven't chosen one or it can't be loaded
+func (b *Bot) userTimezone(ctx context.Context, userID string) *time.Location {
+	loc, err := b.APIPtr.GetUserTimezone(ctx, userID)
+	if err != nil {
+		b.logger().Warn("failed to load user timezone, using UTC", "user_id", userID, "error", fmt.Errorf("userTimezone: %w", err))
+		return time.UTC
+	}
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// timezoneHandler handles $timezone (show your timezone), $timezone <IANA name> and $timezone reset
+func (b *Bot) timezoneHandler(ctx context.Context, session DiscordSession, message *discordgo.MessageCreate) {
+	loc := b.locale(ctx, message)
+	usage := loc.T("timezone.usage")
+
+	args := strings.Fields(message.Content)
+	if len(args) > 2 {
+		sendLocalizedError(session, message.ChannelID, loc, usage)
+		return
+	}
+	if len(args) == 1 {
+		tz := b.userTimezone(ctx, message.Author.ID)
+		b.sendTimezone(session, message.ChannelID, loc.T("timezone.current_title"),
+			loc.T("timezone.current", tz, time.Now().In(tz).Format(plainTimeFormat))+"\n"+usage)
+		return
+	}
+
+	timezone := args[1]
+	if strings.EqualFold(timezone, "reset") {
+		timezone = ""
+	}
+	tz, err := b.APIPtr.SetUserTimezone(ctx, message.Author.ID, timezone)
+	if err != nil {
+		b.logger().Warn("failed to update user timezone", "user", message.Author.Username, "timezone", timezone, "error", fmt.Errorf("timezoneHandler: %w", err))
+		sendLocalizedError(session, message.ChannelID, loc, loc.T("timezone.error", timezone)+" "+usage)
+		return
+	}
+
+	description := loc.T("timezone.reset")
+	if tz != nil {
+		description = loc.T("timezone.updated", tz, time.Now().In(tz).Format(plainTimeFormat))
+	}
+	b.sendTimezone(session, message.ChannelID, loc.T("timezone.title"), description)
+}
+
+// sendTimezone sends a timezone embed to the given channel
+func (b *Bot) sendTimezone(session DiscordSession, channelID, title, description string) {
+	embed := &discordgo.MessageEmbed{Title: title, Description: description, Color: green}
+	if _, err := session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		b.logger().Error("failed to send timezone embed", "error", fmt.Errorf("sendTimezone: %w", err))
+	}
+}
@@ -0,0 +1,111 @@
+/* timezone_test.go
+ * Contains unit tests for the $timezone command and timezone-aware reminders
+ */
+
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/app"
+	"pickems-bot/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region timezone tests
+
+func TestTimezone_ShowDefault(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockSession := NewMockDiscordSession()
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Your Timezone", embed.Title)
+	assert.Contains(t, embed.Description, "shown in **UTC**")
+}
+
+func TestTimezone_Set(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockSession := NewMockDiscordSession()
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone Europe/Warsaw", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Timezone Updated", embed.Title)
+	assert.Contains(t, embed.Description, "now shown in **Europe/Warsaw**")
+	assert.Equal(t, "Europe/Warsaw", mockStore.Profiles["user123"].Timezone)
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone", "user123", "TestUser", "channel123"))
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "shown in **Europe/Warsaw**")
+}
+
+func TestTimezone_UsesUserLocale(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Locale: "pt"}
+	mockSession := NewMockDiscordSession()
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone Europe/Warsaw", "user123", "TestUser", "channel123"))
+
+	embed := mockSession.GetLastEmbed().Embed
+	assert.Equal(t, "Fuso horário atualizado", embed.Title)
+	assert.Contains(t, embed.Description, "**Europe/Warsaw**")
+}
+
+func TestTimezone_Reset(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["user123"] = store.UserProfile{UserID: "user123", Timezone: "Asia/Tokyo"}
+	mockSession := NewMockDiscordSession()
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone reset", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Your timezone has been cleared")
+	assert.Empty(t, mockStore.Profiles["user123"].Timezone)
+}
+
+func TestTimezone_Invalid(t *testing.T) {
+	bot := createTestBot("swiss")
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+
+	for _, content := range []string{"$timezone Mars/Olympus", "$timezone Europe Warsaw"} {
+		mockSession := NewMockDiscordSession()
+		bot.timezoneHandler(context.Background(), mockSession, createMockMessage(content, "user123", "TestUser", "channel123"))
+		assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Usage: `$timezone <IANA name>`", content)
+	}
+	assert.NotContains(t, mockStore.Profiles, "user123")
+}
+
+func TestTimezone_StoreError(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.APIPtr.Store.(*app.MockStore).SetUserTimezoneError = errors.New("db down")
+	mockSession := NewMockDiscordSession()
+
+	bot.timezoneHandler(context.Background(), mockSession, createMockMessage("$timezone Europe/Warsaw", "user123", "TestUser", "channel123"))
+
+	assert.Contains(t, mockSession.GetLastEmbed().Embed.Description, "Couldn't set your timezone to `Europe/Warsaw`")
+}
+
+func TestSendDueReminders_UsesUserTimezone(t *testing.T) {
+	bot := createTestBot("swiss")
+	bot.ReminderLeadTimes = []time.Duration{time.Hour}
+	mockStore := bot.APIPtr.Store.(*app.MockStore)
+	mockStore.Profiles["tokyo"] = store.UserProfile{UserID: "tokyo", Timezone: "Asia/Tokyo"}
+	mockSession := NewMockDiscordSession()
+
+	bot.sendDueReminders(context.Background(), mockSession, reminderLock.Add(-30*time.Minute))
+
+	require.Len(t, mockSession.SentEmbeds, 1)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	assert.Contains(t, mockSession.SentEmbeds[0].Embed.Description,
+		"<t:1700000000:R> ("+time.Unix(1700000000, 0).In(tokyo).Format(plainTimeFormat)+")")
+}
+
+// endregion
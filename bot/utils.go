@@ -3,8 +3,8 @@ package bot
 import (
 	"fmt"
 	"log/slog"
+	"pickems-bot/i18n"
 	format "pickems-bot/tournament"
-	"regexp"
 	"sort"
 	"strings"
 
@@ -15,16 +15,9 @@ const burple = 0x7289DA
 const green = 0x57F287
 const red = 0xED4245
 
-// CleanIndent removes structural code indentation from raw multiline strings
-func cleanIndent(s string) string {
-	// Matches any leading whitespace characters at the start of a line
-	re := regexp.MustCompile(`(?m)^[ \t]+`)
-	return re.ReplaceAllString(s, "")
-}
-
 // singleElimField formats a single-elimination predictions list as an embed field,
 // ordered Champion → Runner-up → 3rd/4th → … .
-func singleElimField(entries []format.ElimPredictionEntry) *discordgo.MessageEmbedField {
+func singleElimField(loc i18n.Locale, entries []format.ElimPredictionEntry) *discordgo.MessageEmbedField {
 	sorted := make([]format.ElimPredictionEntry, len(entries))
 	copy(sorted, entries)
 	sort.Slice(sorted, func(i, j int) bool {
@@ -42,12 +35,12 @@ func singleElimField(entries []format.ElimPredictionEntry) *discordgo.MessageEmb
 
 	var sb strings.Builder
 	for _, e := range sorted {
-		sb.WriteString(fmt.Sprintf("%s: **%s** %s\n", elimPositionLabel(e), e.Team, e.Status))
+		sb.WriteString(fmt.Sprintf("%s: **%s** %s\n", elimPositionLabel(loc, e), e.Team, e.Status))
 	}
 	if sb.Len() == 0 {
 		sb.WriteString("—")
 	}
-	return &discordgo.MessageEmbedField{Name: "**Predictions**", Value: sb.String(), Inline: false}
+	return &discordgo.MessageEmbedField{Name: "**" + loc.T("check.predictions") + "**", Value: sb.String(), Inline: false}
 }
 
 // elimRoundOrder defines the sort priority for each single-elim round.
@@ -62,29 +55,29 @@ var elimRoundOrder = map[string]int{
 
 // elimPositionLabel returns the human-readable position label for an entry,
 // prefixed with a medal/trophy emoji for the Discord embed.
-func elimPositionLabel(e format.ElimPredictionEntry) string {
+func elimPositionLabel(loc i18n.Locale, e format.ElimPredictionEntry) string {
 	if e.ToWin {
-		return "🏆 Champion"
+		return loc.T("check.champion")
 	}
 	switch e.Round {
 	case "Grand Final":
-		return "🥈 Runner-up"
+		return loc.T("check.runner_up")
 	case "Semi Final":
-		return "🥉 3rd / 4th"
+		return loc.T("check.semi_final")
 	case "Quarter Final":
-		return "🎖️ Top 8"
+		return loc.T("check.quarter_final")
 	default:
 		return e.Round
 	}
 }
 
 // swissBucketField formats one Swiss prediction bucket (e.g. "3-0") as an embed field.
-func swissBucketField(label string, entries []format.BucketEntry) *discordgo.MessageEmbedField {
+func swissBucketField(loc i18n.Locale, label string, entries []format.BucketEntry) *discordgo.MessageEmbedField {
 	var sb strings.Builder
 	for _, e := range entries {
 		score := e.Score
 		if score == "" {
-			score = "N/A"
+			score = loc.T("check.no_score")
 		}
 		sb.WriteString(fmt.Sprintf("**%s**: %s %s\n", e.Team, score, e.Status))
 	}
@@ -94,14 +87,56 @@ func swissBucketField(label string, entries []format.BucketEntry) *discordgo.Mes
 	return &discordgo.MessageEmbedField{Name: label, Value: sb.String(), Inline: false}
 }
 
-// sendError sends a red error embed to the given channel.
-func sendError(session DiscordSession, channelID string, msg string) {
+// sendLocalizedError sends a red error embed, titled in the given locale, to the given channel. msg should
+// already be translated.
+func sendLocalizedError(session DiscordSession, channelID string, loc i18n.Locale, msg string) {
 	embed := &discordgo.MessageEmbed{
-		Title:       "Error",
+		Title:       loc.T("error.title"),
 		Description: msg,
 		Color:       red,
 	}
 	if _, err := session.ChannelMessageSendEmbed(channelID, embed); err != nil {
-		slog.Error("failed to send error embed", "error", fmt.Errorf("sendError: %w", err))
+		slog.Error("failed to send error embed", "error", fmt.Errorf("sendLocalizedError: %w", err))
+	}
+}
+
+// respondError answers an interaction with a red error embed, titled in the given locale, that only the user who
+// triggered it can see. msg should already be translated.
+func respondError(session DiscordSession, interaction *discordgo.InteractionCreate, loc i18n.Locale, msg string) {
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{Title: loc.T("error.title"), Description: msg, Color: red}},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	}
+	if err := session.InteractionRespond(interaction.Interaction, response); err != nil {
+		slog.Error("failed to send error response", "error", fmt.Errorf("respondError: %w", err))
+	}
+}
+
+// interactionUser returns the user who triggered an interaction, or nil if it carries none. Guild interactions carry
+// the user on the member; DM interactions carry it directly.
+func interactionUser(interaction *discordgo.InteractionCreate) *discordgo.User {
+	if interaction.Member != nil && interaction.Member.User != nil {
+		return interaction.Member.User
+	}
+	return interaction.User
+}
+
+// displayName returns the name user is shown under in Discord: their server nickname from member (nil in DMs), else
+// their global display name, else their username
+func displayName(user *discordgo.User, member *discordgo.Member) string {
+	if member != nil && member.Nick != "" {
+		return member.Nick
+	}
+	return user.DisplayName()
+}
+
+// interactionUserID returns the ID of the user who triggered an interaction
+func interactionUserID(interaction *discordgo.InteractionCreate) string {
+	if user := interactionUser(interaction); user != nil {
+		return user.ID
 	}
+	return ""
 }
@@ -1,6 +1,6 @@
 /* utils_test.go
  * Unit tests for bot utility functions (singleElimField, elimPositionLabel,
- * swissBucketField empty path, sendError error path).
+ * swissBucketField empty path, sendLocalizedError error path).
  */
 
 package bot
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"pickems-bot/i18n"
 	format "pickems-bot/tournament"
 
 	"github.com/stretchr/testify/assert"
@@ -19,27 +20,27 @@ import (
 
 func TestElimPositionLabel_Champion(t *testing.T) {
 	e := format.ElimPredictionEntry{Team: "Alpha", Round: "Grand Final", ToWin: true}
-	assert.Equal(t, "🏆 Champion", elimPositionLabel(e))
+	assert.Equal(t, "🏆 Champion", elimPositionLabel(i18n.English, e))
 }
 
 func TestElimPositionLabel_RunnerUp(t *testing.T) {
 	e := format.ElimPredictionEntry{Team: "Beta", Round: "Grand Final", ToWin: false}
-	assert.Equal(t, "🥈 Runner-up", elimPositionLabel(e))
+	assert.Equal(t, "🥈 Runner-up", elimPositionLabel(i18n.English, e))
 }
 
 func TestElimPositionLabel_ThirdFourth(t *testing.T) {
 	e := format.ElimPredictionEntry{Team: "Gamma", Round: "Semi Final", ToWin: false}
-	assert.Equal(t, "🥉 3rd / 4th", elimPositionLabel(e))
+	assert.Equal(t, "🥉 3rd / 4th", elimPositionLabel(i18n.English, e))
 }
 
 func TestElimPositionLabel_Top8(t *testing.T) {
 	e := format.ElimPredictionEntry{Team: "Delta", Round: "Quarter Final", ToWin: false}
-	assert.Equal(t, "🎖️ Top 8", elimPositionLabel(e))
+	assert.Equal(t, "🎖️ Top 8", elimPositionLabel(i18n.English, e))
 }
 
 func TestElimPositionLabel_DefaultRound(t *testing.T) {
 	e := format.ElimPredictionEntry{Team: "Epsilon", Round: "Best of 32", ToWin: false}
-	assert.Equal(t, "Best of 32", elimPositionLabel(e))
+	assert.Equal(t, "Best of 32", elimPositionLabel(i18n.English, e))
 }
 
 // endregion
@@ -53,7 +54,7 @@ func TestSingleElimField_SortsCorrectly(t *testing.T) {
 		{Team: "Gamma", Round: "Quarter Final", ToWin: false, Status: format.StatusPending},
 	}
 
-	field := singleElimField(entries)
+	field := singleElimField(i18n.English, entries)
 	require.NotNil(t, field)
 	assert.Equal(t, "**Predictions**", field.Name)
 	// Champion (Grand Final winner) should appear first in the value string
@@ -62,7 +63,7 @@ func TestSingleElimField_SortsCorrectly(t *testing.T) {
 }
 
 func TestSingleElimField_EmptyEntries_ShowsDash(t *testing.T) {
-	field := singleElimField(nil)
+	field := singleElimField(i18n.English, nil)
 	require.NotNil(t, field)
 	assert.Equal(t, "—", field.Value)
 }
@@ -73,7 +74,7 @@ func TestSingleElimField_WithinSameRound_WinnerFirst(t *testing.T) {
 		{Team: "RunnerUp", Round: "Grand Final", ToWin: false, Status: format.StatusFailed},
 		{Team: "Champion", Round: "Grand Final", ToWin: true, Status: format.StatusSucceeded},
 	}
-	field := singleElimField(entries)
+	field := singleElimField(i18n.English, entries)
 	require.NotNil(t, field)
 	// Champion (ToWin=true) must appear before Runner-up in the output
 	champIdx := strings.Index(field.Value, "🏆 Champion")
@@ -87,8 +88,13 @@ func TestSingleElimField_WithinSameRound_WinnerFirst(t *testing.T) {
 
 // region swissBucketField empty path test
 
+func TestSwissBucketField_TranslatesMissingScore(t *testing.T) {
+	field := swissBucketField(i18n.Locale("pt"), "**3-0**", []format.BucketEntry{{Team: "Team A"}})
+	assert.Contains(t, field.Value, "**Team A**: N/D")
+}
+
 func TestSwissBucketField_EmptyEntries_ShowsDash(t *testing.T) {
-	field := swissBucketField("3-0 Picks", nil)
+	field := swissBucketField(i18n.English, "3-0 Picks", nil)
 	require.NotNil(t, field)
 	assert.Equal(t, "3-0 Picks", field.Name)
 	assert.Equal(t, "—", field.Value)
@@ -96,25 +102,33 @@ func TestSwissBucketField_EmptyEntries_ShowsDash(t *testing.T) {
 
 // endregion
 
-// region sendError error path test
+// region sendLocalizedError error path test
 
-func TestSendError_LogsOnSessionError(t *testing.T) {
+func TestSendLocalizedError_LogsOnSessionError(t *testing.T) {
 	// When the session returns an error from ChannelMessageSendEmbed,
-	// sendError should not panic and should log the failure (no return value to assert).
+	// sendLocalizedError should not panic and should log the failure (no return value to assert).
 	session := &MockDiscordSession{ErrorToReturn: assert.AnError}
 	// Should not panic even when session.ChannelMessageSendEmbed returns an error
 	assert.NotPanics(t, func() {
-		sendError(session, "channel-123", "something went wrong")
+		sendLocalizedError(session, "channel-123", i18n.English, "something went wrong")
 	})
 }
 
-func TestSendError_Success(t *testing.T) {
+func TestSendLocalizedError_Success(t *testing.T) {
 	session := NewMockDiscordSession()
-	sendError(session, "channel-123", "test error message")
+	sendLocalizedError(session, "channel-123", i18n.English, "test error message")
 	require.Len(t, session.SentEmbeds, 1)
 	assert.Equal(t, "Error", session.SentEmbeds[0].Embed.Title)
 	assert.Equal(t, "test error message", session.SentEmbeds[0].Embed.Description)
 	assert.Equal(t, red, session.SentEmbeds[0].Embed.Color)
 }
 
+func TestSendLocalizedError_TranslatesTitle(t *testing.T) {
+	session := NewMockDiscordSession()
+	sendLocalizedError(session, "channel-123", i18n.Locale("pl"), "coś poszło nie tak")
+	require.Len(t, session.SentEmbeds, 1)
+	assert.Equal(t, "Błąd", session.SentEmbeds[0].Embed.Title)
+	assert.Equal(t, "coś poszło nie tak", session.SentEmbeds[0].Embed.Description)
+}
+
 // endregion
@@ -7,6 +7,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -28,6 +29,14 @@ type Config struct {
 
 	Liquipedia LiquipediaConfig `toml:"liquipedia"`
 	PandaScore PandaScoreConfig `toml:"pandascore"`
+	Reminders  RemindersConfig  `toml:"reminders"`
+
+	Announcements AnnouncementsConfig `toml:"announcements"`
+	Calendar      CalendarConfig      `toml:"calendar"`
+	Timeouts      TimeoutsConfig      `toml:"timeouts"`
+	Storage       StorageConfig       `toml:"storage"`
+	Cache         CacheConfig         `toml:"cache"`
+	VRS           VRSConfig           `toml:"vrs"`
 }
 
 // LiquipediaConfig holds Liquipedia-specific configuration fields.
@@ -40,6 +49,8 @@ type LiquipediaConfig struct {
 	// Set explicitly (e.g. "single-elimination") when the Liquipedia page
 	// contains multiple stages and auto-detection would pick the wrong one.
 	Format string `toml:"format"`
+	// MaxPages caps the pages of 100 matches fetched per request. Zero uses the default of 20.
+	MaxPages int `toml:"max_pages"`
 }
 
 // PandaScoreConfig holds PandaScore-specific configuration fields.
@@ -47,8 +58,112 @@ type PandaScoreConfig struct {
 	APIURL       string `toml:"api_url"`
 	SeriesID     int    `toml:"series_id"`     // filters by serie_id; covers all stages in a major
 	TournamentID int    `toml:"tournament_id"` // optional; narrows to a single stage within the series
+	MaxPages     int    `toml:"max_pages"`     // caps the pages of 100 matches fetched per request; zero uses the default of 20
+}
+
+// RemindersConfig controls the "you haven't set your Pick'Ems" DMs sent before a round locks.
+type RemindersConfig struct {
+	Enabled bool `toml:"enabled"`
+	// LeadTimes are how long before the round's first scheduled match each reminder is sent,
+	// written as Go durations (e.g. ["24h", "1h"]). Defaults to DefaultReminderLeadTimes when unset.
+	LeadTimes []string `toml:"lead_times"`
+
+	// LeadDurations holds the parsed LeadTimes. Populated by Load.
+	LeadDurations []time.Duration `toml:"-"`
+}
+
+// AnnouncementsConfig controls where new match results are posted.
+type AnnouncementsConfig struct {
+	// ChannelID is the Discord channel results are announced in. Leave empty to disable announcements.
+	ChannelID string `toml:"channel_id"`
+}
+
+// CalendarConfig controls the HTTP endpoint that serves the round's schedule as an iCalendar feed.
+type CalendarConfig struct {
+	// Addr is the address the calendar server listens on, e.g. ":8082". Leave empty to not serve the feed.
+	Addr string `toml:"addr"`
+	// PublicURL is where users can subscribe to the feed, e.g. "https://pickems.example.com/calendar.ics".
+	// $calendar links to it when set.
+	PublicURL string `toml:"public_url"`
+}
+
+// TimeoutsConfig bounds how long the bot waits on its dependencies, written as Go durations (e.g. "10s").
+// Unset values fall back to DefaultTimeouts.
+type TimeoutsConfig struct {
+	// Database bounds each individual database operation.
+	Database string `toml:"database"`
+	// DataSource bounds each request to Liquipedia or PandaScore.
+	DataSource string `toml:"data_source"`
+	// Command bounds everything a single Discord command or button press does, across all its operations.
+	Command string `toml:"command"`
+
+	// DatabaseDuration, DataSourceDuration and CommandDuration hold the parsed values. Populated by Load.
+	DatabaseDuration   time.Duration `toml:"-"`
+	DataSourceDuration time.Duration `toml:"-"`
+	CommandDuration    time.Duration `toml:"-"`
 }
 
+// StorageConfig selects where tournament data is stored.
+type StorageConfig struct {
+	// Backend is "mongo" (the default), "sqlite", "postgres" or "memory". The memory backend needs no database but
+	// loses everything on restart, so it is meant for local development and tests.
+	Backend string `toml:"backend"`
+	// DSN is the SQLite file path or PostgreSQL connection URL. Required for postgres; sqlite defaults to
+	// "<tournament_name>.db". Ignored by the mongo and memory backends.
+	DSN string `toml:"dsn"`
+}
+
+// CacheConfig controls how long hot store reads are cached, written as Go durations (e.g. "5m"). "0s" turns caching
+// of that data off. Unset values fall back to DefaultCache.
+type CacheConfig struct {
+	// VRS is how long the VRS rankings are cached. They are synced into the database outside the bot, so this is the
+	// longest a ranking update can take to show.
+	VRS string `toml:"vrs"`
+	// Results is how long the round's match results, valid teams and match nodes are cached. The update pipelines
+	// drop them whenever they store new results.
+	Results string `toml:"results"`
+	// Schedule is how long the round's schedule is cached. It is dropped whenever a new schedule is stored.
+	Schedule string `toml:"schedule"`
+
+	// VRSDuration, ResultsDuration and ScheduleDuration hold the parsed values. Populated by Load.
+	VRSDuration      time.Duration `toml:"-"`
+	ResultsDuration  time.Duration `toml:"-"`
+	ScheduleDuration time.Duration `toml:"-"`
+}
+
+// VRSConfig selects where the VRS world rankings are read from. The rankings are synced into MongoDB by an external
+// job, so this only applies to the mongo backend, except for TournamentStart.
+type VRSConfig struct {
+	// Database is the MongoDB database the rankings are synced to. Defaults to "VRS_RANKINGS".
+	Database string `toml:"database"`
+	// Collections are the collections of Database to read rankings from. Leave empty to follow the sync job, which
+	// writes a collection per year: last year's and this year's are read, so nothing changes at new year.
+	Collections []string `toml:"collections"`
+	// TournamentStart is the date, as "2006-01-02", $team shows each team's ranking as of. Leave empty to use the
+	// date of the round's first scheduled match.
+	TournamentStart string `toml:"tournament_start"`
+
+	// TournamentStartDate holds the parsed TournamentStart, or the zero time if unset. Populated by Load.
+	TournamentStartDate time.Time `toml:"-"`
+}
+
+// Storage backends accepted in StorageConfig.Backend
+const (
+	StorageMongo    = "mongo"
+	StorageSQLite   = "sqlite"
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// DefaultTimeouts is used for any timeout left unset in config.toml.
+var DefaultTimeouts = TimeoutsConfig{Database: "10s", DataSource: "30s", Command: "30s"}
+
+// DefaultCache is used for any cache TTL left unset in config.toml.
+var DefaultCache = CacheConfig{VRS: "1h", Results: "5m", Schedule: "5m"}
+
+// DefaultReminderLeadTimes is used when reminders are enabled but no lead_times are configured.
+var DefaultReminderLeadTimes = []string{"24h", "1h"}
+
 // Load reads and validates a config.toml file at path.
 func Load(path string) (Config, error) {
 	var c Config
@@ -79,5 +194,100 @@ func Load(path string) (Config, error) {
 		return Config{}, fmt.Errorf("unsupported datasource in %s, allowed values are 'liquipedia' and 'pandascore'", path)
 	}
 
+	if c.Liquipedia.MaxPages < 0 {
+		return Config{}, fmt.Errorf("liquipedia.max_pages must not be negative in %s", path)
+	}
+	if c.PandaScore.MaxPages < 0 {
+		return Config{}, fmt.Errorf("pandascore.max_pages must not be negative in %s", path)
+	}
+
+	if c.Reminders.Enabled {
+		if len(c.Reminders.LeadTimes) == 0 {
+			c.Reminders.LeadTimes = DefaultReminderLeadTimes
+		}
+		for _, raw := range c.Reminders.LeadTimes {
+			d, err := time.ParseDuration(raw)
+			if err != nil || d <= 0 {
+				return Config{}, fmt.Errorf("reminders.lead_times entry %q is not a positive duration in %s", raw, path)
+			}
+			c.Reminders.LeadDurations = append(c.Reminders.LeadDurations, d)
+		}
+	}
+
+	if c.Calendar.PublicURL != "" && c.Calendar.Addr == "" {
+		return Config{}, fmt.Errorf("calendar.public_url is set but calendar.addr is empty in %s", path)
+	}
+
+	switch c.Storage.Backend {
+	case "":
+		c.Storage.Backend = StorageMongo
+	case StorageMongo, StorageMemory:
+	case StorageSQLite:
+		if c.Storage.DSN == "" {
+			c.Storage.DSN = c.TournamentName + ".db"
+		}
+	case StoragePostgres:
+		if c.Storage.DSN == "" {
+			return Config{}, fmt.Errorf("storage.dsn is required for the postgres backend in %s", path)
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported storage.backend %q in %s, allowed values are 'mongo', 'sqlite', 'postgres' and 'memory'", c.Storage.Backend, path)
+	}
+
+	timeouts := []struct {
+		name     string
+		raw      *string
+		fallback string
+		parsed   *time.Duration
+	}{
+		{"database", &c.Timeouts.Database, DefaultTimeouts.Database, &c.Timeouts.DatabaseDuration},
+		{"data_source", &c.Timeouts.DataSource, DefaultTimeouts.DataSource, &c.Timeouts.DataSourceDuration},
+		{"command", &c.Timeouts.Command, DefaultTimeouts.Command, &c.Timeouts.CommandDuration},
+	}
+	for _, timeout := range timeouts {
+		if *timeout.raw == "" {
+			*timeout.raw = timeout.fallback
+		}
+		d, err := time.ParseDuration(*timeout.raw)
+		if err != nil || d <= 0 {
+			return Config{}, fmt.Errorf("timeouts.%s %q is not a positive duration in %s", timeout.name, *timeout.raw, path)
+		}
+		*timeout.parsed = d
+	}
+
+	ttls := []struct {
+		name     string
+		raw      *string
+		fallback string
+		parsed   *time.Duration
+	}{
+		{"vrs", &c.Cache.VRS, DefaultCache.VRS, &c.Cache.VRSDuration},
+		{"results", &c.Cache.Results, DefaultCache.Results, &c.Cache.ResultsDuration},
+		{"schedule", &c.Cache.Schedule, DefaultCache.Schedule, &c.Cache.ScheduleDuration},
+	}
+	for _, ttl := range ttls {
+		if *ttl.raw == "" {
+			*ttl.raw = ttl.fallback
+		}
+		d, err := time.ParseDuration(*ttl.raw)
+		if err != nil || d < 0 {
+			return Config{}, fmt.Errorf("cache.%s %q is not a duration of zero or more in %s", ttl.name, *ttl.raw, path)
+		}
+		*ttl.parsed = d
+	}
+
+	for _, collection := range c.VRS.Collections {
+		if collection == "" {
+			return Config{}, fmt.Errorf("vrs.collections contains an empty collection name in %s", path)
+		}
+	}
+	if c.VRS.TournamentStart != "" {
+		start, err := time.Parse(time.DateOnly, c.VRS.TournamentStart)
+		if err != nil {
+			return Config{}, fmt.Errorf("vrs.tournament_start %q is not a date like 2006-01-02 in %s", c.VRS.TournamentStart, path)
+		}
+		c.VRS.TournamentStartDate = start
+	}
+
 	return c, nil
 }
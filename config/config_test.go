@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -197,3 +198,467 @@ func TestLoad_InvalidTOML(t *testing.T) {
 	_, err := Load(path)
 	assert.Error(t, err)
 }
+
+func TestLoad_Reminders_DefaultLeadTimes(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[reminders]
+enabled = true
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour, time.Hour}, cfg.Reminders.LeadDurations)
+}
+
+func TestLoad_Reminders_CustomLeadTimes(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[reminders]
+enabled = true
+lead_times = ["6h", "30m"]
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{6 * time.Hour, 30 * time.Minute}, cfg.Reminders.LeadDurations)
+}
+
+func TestLoad_Reminders_InvalidLeadTime(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[reminders]
+enabled = true
+lead_times = ["tomorrow"]
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "lead_times")
+}
+
+func TestLoad_Reminders_DisabledSkipsParsing(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[reminders]
+lead_times = ["tomorrow"]
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Reminders.LeadDurations)
+}
+
+func TestLoad_Announcements_ChannelID(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[announcements]
+channel_id = "123456789"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789", cfg.Announcements.ChannelID)
+}
+
+func TestLoad_Calendar(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[calendar]
+addr = ":8082"
+public_url = "https://pickems.example.com/calendar.ics"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ":8082", cfg.Calendar.Addr)
+	assert.Equal(t, "https://pickems.example.com/calendar.ics", cfg.Calendar.PublicURL)
+}
+
+func TestLoad_Calendar_PublicURLWithoutAddr(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[calendar]
+public_url = "https://pickems.example.com/calendar.ics"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "calendar.addr")
+}
+
+func TestLoad_Timeouts_Defaults(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, cfg.Timeouts.DatabaseDuration)
+	assert.Equal(t, 30*time.Second, cfg.Timeouts.DataSourceDuration)
+	assert.Equal(t, 30*time.Second, cfg.Timeouts.CommandDuration)
+}
+
+func TestLoad_Timeouts_Custom(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[timeouts]
+database = "2s"
+data_source = "1m"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, cfg.Timeouts.DatabaseDuration)
+	assert.Equal(t, time.Minute, cfg.Timeouts.DataSourceDuration)
+	assert.Equal(t, 30*time.Second, cfg.Timeouts.CommandDuration)
+}
+
+func TestLoad_Timeouts_Invalid(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[timeouts]
+command = "-5s"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timeouts.command")
+}
+
+func TestLoad_Cache_Defaults(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, cfg.Cache.VRSDuration)
+	assert.Equal(t, 5*time.Minute, cfg.Cache.ResultsDuration)
+	assert.Equal(t, 5*time.Minute, cfg.Cache.ScheduleDuration)
+}
+
+func TestLoad_Cache_CustomAndDisabled(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[cache]
+vrs = "10m"
+results = "0s"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, cfg.Cache.VRSDuration)
+	assert.Zero(t, cfg.Cache.ResultsDuration)
+	assert.Equal(t, 5*time.Minute, cfg.Cache.ScheduleDuration)
+}
+
+func TestLoad_Cache_Invalid(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[cache]
+schedule = "soon"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cache.schedule")
+}
+
+func TestLoad_VRS_Defaults(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.VRS.Database)
+	assert.Empty(t, cfg.VRS.Collections)
+	assert.True(t, cfg.VRS.TournamentStartDate.IsZero())
+}
+
+func TestLoad_VRS_Custom(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[vrs]
+database = "RANKINGS"
+collections = ["2025", "2026"]
+tournament_start = "2026-06-02"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "RANKINGS", cfg.VRS.Database)
+	assert.Equal(t, []string{"2025", "2026"}, cfg.VRS.Collections)
+	assert.Equal(t, time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), cfg.VRS.TournamentStartDate)
+}
+
+func TestLoad_VRS_InvalidTournamentStart(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[vrs]
+tournament_start = "02/06/2026"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "vrs.tournament_start")
+}
+
+func TestLoad_Storage_DefaultsToMongo(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, StorageMongo, cfg.Storage.Backend)
+}
+
+func TestLoad_Storage_Memory(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[storage]
+backend = "memory"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, StorageMemory, cfg.Storage.Backend)
+}
+
+func TestLoad_Storage_Unsupported(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[storage]
+backend = "redis"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.backend")
+}
+
+func TestLoad_Storage_SQLiteDefaultsDSN(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[storage]
+backend = "sqlite"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, StorageSQLite, cfg.Storage.Backend)
+	assert.Equal(t, "X.db", cfg.Storage.DSN)
+}
+
+func TestLoad_Storage_Postgres(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[storage]
+backend = "postgres"
+dsn = "postgres://pickems@localhost:5432/pickems"
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, StoragePostgres, cfg.Storage.Backend)
+	assert.Equal(t, "postgres://pickems@localhost:5432/pickems", cfg.Storage.DSN)
+}
+
+func TestLoad_Storage_PostgresRequiresDSN(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+
+[storage]
+backend = "postgres"
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.dsn")
+}
+
+func TestLoad_MaxPages(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "pandascore"
+round = "Z"
+
+[pandascore]
+api_url = "https://api.pandascore.co/csgo/matches"
+series_id = 1
+max_pages = 5
+`)
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cfg.PandaScore.MaxPages)
+	assert.Zero(t, cfg.Liquipedia.MaxPages)
+}
+
+func TestLoad_MaxPages_Negative(t *testing.T) {
+	path := writeTemp(t, `
+tournament_name = "X"
+data_source = "liquipedia"
+round = "Z"
+
+[liquipedia]
+api_url = "https://api.liquipedia.net/api/v3/match"
+page = "Y/Z"
+max_pages = -1
+`)
+
+	_, err := Load(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "liquipedia.max_pages")
+}
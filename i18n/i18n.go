@@ -0,0 +1,88 @@
+/* i18n.go
+ * Contains the message catalogue used to localise bot responses. Translations are JSON files in locales/, one per
+ * locale, mapping message keys to fmt format strings. They are embedded into the binary and loaded at start up.
+ */
+
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultLocale is the locale used when none has been chosen, and the fallback for missing translations
+const DefaultLocale = "en"
+
+// SupportedLocales lists the locale codes a guild or user may select. Each must have a file in locales/.
+var SupportedLocales = []string{"en", "pt", "ru", "pl"}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogue holds the embedded translations, keyed by locale then message key
+var catalogue = mustLoad(localeFiles)
+
+// Catalogue maps a locale code to its messages, keyed by message key
+type Catalogue map[string]map[string]string
+
+// Load reads every <locale>.json file in the locales directory of fsys into a Catalogue.
+func Load(fsys fs.FS) (Catalogue, error) {
+	files, err := fs.Glob(fsys, "locales/*.json")
+	if err != nil {
+		return nil, err
+	}
+	c := make(Catalogue, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		c[strings.TrimSuffix(path.Base(file), ".json")] = messages
+	}
+	return c, nil
+}
+
+// mustLoad loads the embedded catalogue, panicking if a translation file is malformed
+func mustLoad(fsys fs.FS) Catalogue {
+	c, err := Load(fsys)
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+	return c
+}
+
+// Locale is a locale code that bot responses are translated into
+type Locale string
+
+// English is the default locale
+const English Locale = DefaultLocale
+
+// T returns the message for key in this locale, formatted with args. Messages missing from the locale fall back to
+// English, and keys missing from English are returned as-is so a gap is visible rather than blank.
+func (l Locale) T(key string, args ...any) string {
+	msg, ok := catalogue[string(l)][key]
+	if !ok {
+		msg, ok = catalogue[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// IsSupported reports whether code is one of SupportedLocales
+func IsSupported(code string) bool {
+	return slices.Contains(SupportedLocales, code)
+}
@@ -0,0 +1,107 @@
+/* i18n_test.go
+ * Contains unit tests for i18n.go and checks that every translation file matches the English catalogue
+ */
+
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verbPattern matches fmt verbs, including explicit argument indexes such as %[2]s
+var verbPattern = regexp.MustCompile(`%(?:\[\d+\])?[a-z]`)
+
+// region catalogue tests
+
+func TestCatalogue_HasEverySupportedLocale(t *testing.T) {
+	for _, code := range SupportedLocales {
+		assert.Contains(t, catalogue, code)
+	}
+	assert.Len(t, catalogue, len(SupportedLocales))
+}
+
+func TestCatalogue_TranslationsMatchEnglish(t *testing.T) {
+	english := catalogue[DefaultLocale]
+	for _, code := range SupportedLocales {
+		messages := catalogue[code]
+		for key, want := range english {
+			got, ok := messages[key]
+			if !assert.True(t, ok, "%s is missing %q", code, key) {
+				continue
+			}
+			assert.NotEmpty(t, got, "%s has an empty %q", code, key)
+			assert.ElementsMatch(t, verbs(want), verbs(got), "%s %q has different placeholders to English", code, key)
+		}
+		for key := range messages {
+			assert.Contains(t, english, key, "%s has %q, which English doesn't", code, key)
+		}
+	}
+}
+
+// verbs returns the fmt verbs in msg, sorted
+func verbs(msg string) []string {
+	found := verbPattern.FindAllString(msg, -1)
+	slices.Sort(found)
+	return found
+}
+
+// endregion
+
+// region Load tests
+
+func TestLoad_ParsesLocaleFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":   {Data: []byte(`{"greeting": "Hello %s"}`)},
+		"locales/pl.json":   {Data: []byte(`{"greeting": "Cześć %s"}`)},
+		"other/ignored.txt": {Data: []byte("not a locale")},
+	}
+
+	c, err := Load(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, Catalogue{"en": {"greeting": "Hello %s"}, "pl": {"greeting": "Cześć %s"}}, c)
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	fsys := fstest.MapFS{"locales/en.json": {Data: []byte(`{"greeting": `)}}
+
+	_, err := Load(fsys)
+	assert.ErrorContains(t, err, "failed to parse locales/en.json")
+}
+
+// endregion
+
+// region T tests
+
+func TestT_Translates(t *testing.T) {
+	assert.Equal(t, "Error", English.T("error.title"))
+	assert.Equal(t, "Ошибка", Locale("ru").T("error.title"))
+	assert.Equal(t, "Os Pick'Ems de alice foram salvos.", Locale("pt").T("set.saved", "alice"))
+}
+
+func TestT_FallsBack(t *testing.T) {
+	// Unknown locales use English; unknown keys are returned as-is
+	assert.Equal(t, "Error", Locale("xx").T("error.title"))
+	assert.Equal(t, "no.such.key", Locale("pl").T("no.such.key"))
+}
+
+func TestT_LeavesPercentWithoutArgs(t *testing.T) {
+	c := catalogue
+	t.Cleanup(func() { catalogue = c })
+	catalogue = Catalogue{"en": {"rate": "100%"}}
+
+	assert.Equal(t, "100%", English.T("rate"))
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported("pl"))
+	assert.False(t, IsSupported("de"))
+	assert.False(t, IsSupported(""))
+}
+
+// endregion
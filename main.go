@@ -14,7 +14,10 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
+	_ "time/tzdata" // guild timezones must resolve even on images without tzdata installed
 
 	"pickems-bot/app"
 	bot "pickems-bot/bot"
@@ -53,25 +56,31 @@ func main() {
 	logger := slog.New(handler)
 	slog.SetDefault(logger)
 
-	apiInstance, err := app.NewApp(cfg, os.Getenv("MONGO_PROD_URI"), logger)
+	// Cancelled on ctrl + C or SIGTERM; everything below runs under it so in-flight work stops on shutdown.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	apiInstance, err := app.NewApp(ctx, cfg, os.Getenv("MONGO_PROD_URI"), logger)
 	if err != nil {
 		logger.Error("failed to initialize app", "error", err)
 		os.Exit(1)
 	}
 	defer func() {
-		if err = apiInstance.Store.GetClient().Disconnect(context.TODO()); err != nil {
+		disconnectCtx, cancel := context.WithTimeout(context.Background(), cfg.Timeouts.DatabaseDuration)
+		defer cancel()
+		if err = apiInstance.Store.GetClient().Disconnect(disconnectCtx); err != nil {
 			panic(err)
 		}
 	}()
 
 	logger.Debug("populating matches from data source", "source", cfg.DataSource, "schedule_only", cfg.UpcomingOnly)
-	if err := apiInstance.PopulateMatches(cfg.UpcomingOnly); err != nil {
+	if err := apiInstance.PopulateMatches(ctx, cfg.UpcomingOnly); err != nil {
 		logger.Error("failed to populate matches", "error", err)
 		os.Exit(1)
 	}
 	logger.Debug("match data populated")
 
-	if err := apiInstance.GenerateLeaderboard(); err != nil {
+	if err := apiInstance.GenerateLeaderboard(ctx); err != nil {
 		logger.Error("failed to generate leaderboard", "error", err)
 		os.Exit(1)
 	}
@@ -83,7 +92,7 @@ func main() {
 	// Fatal in full mode — $results will be broken if this fails. In upcoming_only
 	// mode there are no match results yet so this is expected to fail; skip it.
 	if !cfg.UpcomingOnly {
-		if err := web.RenderResultsImage(apiInstance); err != nil {
+		if err := web.RenderResultsImage(ctx, apiInstance); err != nil {
 			logger.Error("could not render results image on startup", "error", err)
 			os.Exit(1)
 		}
@@ -101,9 +110,18 @@ func main() {
 		logger.Error("failed to initialize bot", "error", err)
 		os.Exit(1)
 	}
+	if cfg.Reminders.Enabled {
+		botInstance.ReminderLeadTimes = cfg.Reminders.LeadDurations
+		logger.Info("pre-lock reminders enabled", "lead_times", cfg.Reminders.LeadTimes)
+	}
+	botInstance.AnnouncementChannel = cfg.Announcements.ChannelID
+	botInstance.ScheduleOnly = cfg.UpcomingOnly
+	botInstance.RenderResults = func(ctx context.Context) error { return web.RenderResultsImage(ctx, apiInstance) }
+	botInstance.CalendarURL = cfg.Calendar.PublicURL
+	botInstance.CommandTimeout = cfg.Timeouts.CommandDuration
 
 	go func() {
-		if err := web.StartTelemetryServer(web.TelemetryConfig{
+		if err := web.StartTelemetryServer(ctx, web.TelemetryConfig{
 			Addr:      ":9090",
 			App:       apiInstance,
 			Discord:   botInstance,
@@ -115,14 +133,26 @@ func main() {
 		}
 	}()
 
+	if cfg.Calendar.Addr != "" {
+		go func() {
+			if err := web.StartCalendarServer(ctx, web.CalendarConfig{Addr: cfg.Calendar.Addr, App: apiInstance, Logger: logger}); err != nil {
+				logger.Error("calendar server exited", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	switch cfg.DataSource {
 	case "pandascore":
 		poller := web.NewPoller(apiInstance, cfg.PandaScore.SeriesID, cfg.PandaScore.TournamentID, os.Getenv("PANDASCORE_API_KEY"), cfg.PandaScore.APIURL, logger)
-		go poller.Start()
+		poller.SetAnnouncer(botInstance)
+		poller.SetFetchTimeout(cfg.Timeouts.DataSourceDuration)
+		poller.SetMaxPages(cfg.PandaScore.MaxPages)
+		go poller.Start(ctx)
 		logger.Info("PandaScore poller started")
 	case "liquipedia":
 		go func() {
-			if err := web.Start(web.Config{Addr: ":8080", API: apiInstance, Page: cfg.Liquipedia.Page, Logger: logger}); err != nil {
+			if err := web.Start(ctx, web.Config{Addr: ":8080", API: apiInstance, Page: cfg.Liquipedia.Page, Logger: logger, Announcer: botInstance}); err != nil {
 				logger.Error("web server exited", "error", err)
 				os.Exit(1)
 			}
@@ -133,7 +163,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := botInstance.Run(); err != nil {
+	if err := botInstance.Run(ctx); err != nil {
 		logger.Error("unrecoverable error running the bot", "error", fmt.Errorf("bot.Run: %w", err))
 		os.Exit(1)
 	}
@@ -14,9 +14,30 @@ var PollerErrorsTotal = newCounter("poller_errors_total", "Total number of error
 // MatchUpdatesTotal counts match result updates successfully written to MongoDB.
 var MatchUpdatesTotal = newCounter("match_updates_total", "Total number of match updates recieved")
 
+// RemindersSentTotal counts pre-lock reminder DMs successfully delivered.
+var RemindersSentTotal = newCounter("reminders_sent_total", "Total number of pre-lock reminder DMs sent")
+
 // MongoOpsTotal counts MongoDB operations, labelled by operation type (read or write).
 var MongoOpsTotal = newCounterVec("mongodb_operations_total", "Total number of calls made to mongodb", "operation")
 
+// SQLOpsTotal counts SQLite or PostgreSQL operations, labelled by operation type (read or write).
+var SQLOpsTotal = newCounterVec("sql_operations_total", "Total number of calls made to the SQL database", "operation")
+
+// CacheHitsTotal counts store reads served from the read cache, labelled by the cached read.
+var CacheHitsTotal = newCounterVec("store_cache_hits_total", "Total number of store reads served from the cache", "read")
+
+// CacheMissesTotal counts store reads that missed the read cache and went to the database, labelled by the cached read.
+var CacheMissesTotal = newCounterVec("store_cache_misses_total", "Total number of store reads that missed the cache", "read")
+
+// PickPredictors is the number of users with scoreable Pick'Ems for the current round.
+var PickPredictors = newGauge("pick_predictors", "Number of users with Pick'Ems for the current round")
+
+// PickPopularity is the number of users who picked a team for a slot (e.g. 3-0, Advance, Champion), labelled by team and slot.
+var PickPopularity = newGaugeVec("pick_popularity", "Number of users who picked a team for a slot in the current round", "team", "slot")
+
+// PickHitRate is the share of a team's picks that have hit so far, labelled by team.
+var PickHitRate = newGaugeVec("pick_hit_rate", "Share of users' picks on a team that have hit so far in the current round", "team")
+
 // LeaderboardDuration measures time taken to regenerate the leaderboard.
 var LeaderboardDuration = prometheus.NewHistogram(
 	prometheus.HistogramOpts{
@@ -35,6 +56,19 @@ var ImageRenderDuration = prometheus.NewHistogram(
 	},
 )
 
+// DataSourceRequestsTotal counts the requests sent to Liquipedia and PandaScore, retries included
+var DataSourceRequestsTotal = newCounterVec("data_source_requests_total", "Total number of requests sent to the data sources, labelled by source and response status", "source", "status")
+
+// DataSourceRequestDuration tracks how long each data source request takes
+var DataSourceRequestDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "data_source_request_duration_seconds",
+		Help:    "Time taken by each request to a data source",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"source"},
+)
+
 // init registers the prometheus methods
 func init() {
 	prometheus.MustRegister(
@@ -45,6 +79,15 @@ func init() {
 		LeaderboardDuration,
 		ImageRenderDuration,
 		MongoOpsTotal,
+		SQLOpsTotal,
+		CacheHitsTotal,
+		CacheMissesTotal,
+		RemindersSentTotal,
+		PickPredictors,
+		PickPopularity,
+		PickHitRate,
+		DataSourceRequestsTotal,
+		DataSourceRequestDuration,
 	)
 }
 
@@ -60,3 +103,13 @@ func newCounter(name, help string) prometheus.Counter {
 func newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
 	return prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
 }
+
+// newGauge is a wrapper for prometheus.NewGauge that reduces the inline boilerplate
+func newGauge(name, help string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help})
+}
+
+// newGaugeVec is a wrapper for prometheus.GaugeVec that reduces the inline boilerplate
+func newGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+}
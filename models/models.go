@@ -9,8 +9,10 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 // User represents a user with their Discord ID and username
 type User struct {
-	UserID   string
-	Username string
+	UserID      string
+	Username    string
+	DisplayName string // server nickname, else global display name, else Username
+	GuildID     string // guild the interaction came from, empty for DMs
 }
 
 // TeamProgress represents a team's progress through tournament rounds
@@ -16,9 +16,10 @@ import (
 )
 
 // CheckTeamNames processes team names from user input and checks if they are valid.
-// Preconditions: Receives two string slices; one containing the user's predictions and another that is a list of valid team names
+// Preconditions: Receives two string slices; one containing the user's predictions and another that is a list of valid team names,
+// and the team aliases (which may be nil)
 // Postconditions: Returns two string slices, a slice of correctly formatted team names and slice of strings containing the invalid team names
-func CheckTeamNames(predictionTeams []string, validTeams []string) ([]string, []string) {
+func CheckTeamNames(predictionTeams []string, validTeams []string, aliases sources.TeamAliases) ([]string, []string) {
 	var formattedTeamNames []string
 	var invalidTeams []string
 
@@ -31,9 +32,30 @@ func CheckTeamNames(predictionTeams []string, validTeams []string) ([]string, []
 		validTeamsLower = append(validTeamsLower, lower)
 	}
 
+	// Aliases are checked before fuzzy matching. Keys shared by two valid teams are dropped so they can't
+	// pick one arbitrarily.
+	aliasLookup := make(map[string]string)
+	if len(aliases) > 0 {
+		ambiguous := make(map[string]bool)
+		for _, name := range validTeams {
+			key := aliases.Key(name)
+			if _, ok := aliasLookup[key]; ok {
+				ambiguous[key] = true
+			}
+			aliasLookup[key] = name
+		}
+		for key := range ambiguous {
+			delete(aliasLookup, key)
+		}
+	}
+
 	// Match team names
 	for _, team := range predictionTeams {
-		lowerTeam := strings.ToLower(team)
+		if name, ok := aliasLookup[aliases.Key(team)]; ok {
+			formattedTeamNames = append(formattedTeamNames, name)
+			continue
+		}
+		lowerTeam := strings.ToLower(aliases.Resolve(team))
 		fuzzyResults := fuzzy.RankFind(lowerTeam, validTeamsLower)
 		// If there is no valid team name, add it to the invalid teams list
 		if len(fuzzyResults) == 0 {
@@ -62,31 +84,32 @@ func CheckTeamNames(predictionTeams []string, validTeams []string) ([]string, []
 // dispatching through the format registry — the per-format scoring lives in
 // the tournament package.
 // Team names in the prediction are resolved against the result's team names before
-// scoring, handling mismatches between data sources (e.g. "FaZe Clan" vs "FaZe").
-func CalculateUserScore(userPrediction models.Prediction, results tournament.MatchResult) (tournament.ScoreReport, error) {
+// scoring, handling mismatches between data sources (e.g. "FaZe Clan" vs "FaZe") with the help of aliases,
+// which may be nil.
+func CalculateUserScore(userPrediction models.Prediction, results tournament.MatchResult, aliases sources.TeamAliases) (tournament.ScoreReport, error) {
 	f, err := tournament.Get(tournament.Kind(results.GetType()))
 	if err != nil {
 		return nil, err
 	}
-	userPrediction = resolveNamesInPrediction(userPrediction, results.GetTeamNames())
+	userPrediction = resolveNamesInPrediction(userPrediction, results.GetTeamNames(), aliases)
 	return f.CalculateScore(userPrediction, results)
 }
 
 // resolveNamesInPrediction normalises prediction team names to match the canonical
-// names in the result, handling cross-source mismatches. Normalisation runs first;
+// names in the result, handling cross-source mismatches. Normalisation (with aliases applied) runs first;
 // fuzzy matching is the fallback for cases normalisation cannot resolve (e.g. abbreviations).
 // The original stored prediction is never modified.
-func resolveNamesInPrediction(p models.Prediction, validTeams []string) models.Prediction {
+func resolveNamesInPrediction(p models.Prediction, validTeams []string, aliases sources.TeamAliases) models.Prediction {
 	normToOriginal := make(map[string]string, len(validTeams))
 	normKeys := make([]string, 0, len(validTeams))
 	for _, vt := range validTeams {
-		k := sources.NormalizeTeamName(vt)
+		k := aliases.Key(vt)
 		normToOriginal[k] = vt
 		normKeys = append(normKeys, k)
 	}
 
 	resolve := func(name string) string {
-		norm := sources.NormalizeTeamName(name)
+		norm := aliases.Key(name)
 		if original, ok := normToOriginal[norm]; ok {
 			return original
 		}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"pickems-bot/models"
+	"pickems-bot/sources"
 	"pickems-bot/tournament"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestCheckTeamNames_ExactMatches(t *testing.T) {
 	validTeams := []string{"Team A", "Team B", "Team C"}
 	predictionTeams := []string{"Team A", "Team B", "Team C"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Equal(t, []string{"Team A", "Team B", "Team C"}, formatted)
 	assert.Empty(t, invalid)
@@ -30,7 +31,7 @@ func TestCheckTeamNames_CaseInsensitive(t *testing.T) {
 	validTeams := []string{"FaZe Clan", "Natus Vincere", "G2 Esports"}
 	predictionTeams := []string{"faze clan", "NATUS VINCERE", "g2 EsPoRtS"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Equal(t, []string{"FaZe Clan", "Natus Vincere", "G2 Esports"}, formatted)
 	assert.Empty(t, invalid)
@@ -41,7 +42,7 @@ func TestCheckTeamNames_FuzzyMatching(t *testing.T) {
 	validTeams := []string{"FaZe Clan", "Natus Vincere", "G2 Esports"}
 	predictionTeams := []string{"FaZe", "Natus", "G2"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Len(t, formatted, 3)
 	assert.Empty(t, invalid)
@@ -52,7 +53,7 @@ func TestCheckTeamNames_InvalidTeams(t *testing.T) {
 	validTeams := []string{"Team A", "Team B", "Team C"}
 	predictionTeams := []string{"Team A", "InvalidTeam", "Team B", "AnotherInvalid"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Equal(t, []string{"Team A", "Team B"}, formatted)
 	assert.Equal(t, []string{"InvalidTeam", "AnotherInvalid"}, invalid)
@@ -63,7 +64,7 @@ func TestCheckTeamNames_MultipleMatches(t *testing.T) {
 	validTeams := []string{"Cloud9", "Cloud9 Blue", "Cloud9 White"}
 	predictionTeams := []string{"Cloud9"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	// Should return the exact match or best ranked match
 	assert.Len(t, formatted, 1)
@@ -76,7 +77,7 @@ func TestCheckTeamNames_EmptyInput(t *testing.T) {
 	validTeams := []string{"Team A", "Team B"}
 	predictionTeams := []string{}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Empty(t, formatted)
 	assert.Empty(t, invalid)
@@ -87,12 +88,57 @@ func TestCheckTeamNames_AllInvalid(t *testing.T) {
 	validTeams := []string{"Team A", "Team B"}
 	predictionTeams := []string{"XYZ", "ABC", "DEF"}
 
-	formatted, invalid := CheckTeamNames(predictionTeams, validTeams)
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, nil)
 
 	assert.Empty(t, formatted)
 	assert.Len(t, invalid, 3)
 }
 
+// TestCheckTeamNames_Aliases tests that aliases are resolved before fuzzy matching
+func TestCheckTeamNames_Aliases(t *testing.T) {
+	validTeams := []string{"Natus Vincere", "G2 Esports", "MOUZ"}
+	predictionTeams := []string{"navi", "G2", "mousesports"}
+	aliases := sources.TeamAliases{"navi": "Natus Vincere", "mousesports": "mouz"}
+
+	formatted, invalid := CheckTeamNames(predictionTeams, validTeams, aliases)
+
+	assert.Equal(t, []string{"Natus Vincere", "G2 Esports", "MOUZ"}, formatted)
+	assert.Empty(t, invalid)
+}
+
+// TestCheckTeamNames_AliasToOtherSourceName tests an alias whose target is spelt differently to the valid team
+func TestCheckTeamNames_AliasToOtherSourceName(t *testing.T) {
+	validTeams := []string{"NAVI", "Team B"}
+	aliases := sources.TeamAliases{"natus vincere": "NAVI"}
+
+	formatted, invalid := CheckTeamNames([]string{"Natus Vincere"}, validTeams, aliases)
+
+	assert.Equal(t, []string{"NAVI"}, formatted)
+	assert.Empty(t, invalid)
+
+	// Without the alias the name can't be matched
+	_, invalid = CheckTeamNames([]string{"Natus Vincere"}, validTeams, nil)
+	assert.Equal(t, []string{"Natus Vincere"}, invalid)
+}
+
+// TestCalculateUserScore_Aliases tests that aliases resolve picks stored under another source's name
+func TestCalculateUserScore_Aliases(t *testing.T) {
+	prediction := models.Prediction{
+		Format:  "swiss",
+		Win:     []string{"Natus Vincere", "Team B"},
+		Advance: []string{"Team C", "Team D", "Team E", "Team F", "Team G", "Team H"},
+		Lose:    []string{"Team I", "Team J"},
+	}
+	results := tournament.SwissResult{
+		Teams: map[string]string{"NAVI": "3-0"},
+	}
+
+	report, err := CalculateUserScore(prediction, results, sources.TeamAliases{"natus vincere": "NAVI"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.GetScore().Successes)
+}
+
 // TestCalculateUserScore_SwissSuccess tests Swiss score calculation with successful predictions
 func TestCalculateUserScore_SwissSuccess(t *testing.T) {
 	prediction := models.Prediction{
@@ -114,7 +160,7 @@ func TestCalculateUserScore_SwissSuccess(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 7, report.GetScore().Successes)
@@ -143,7 +189,7 @@ func TestCalculateUserScore_SwissPending(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, report.GetScore().Successes)
@@ -168,7 +214,7 @@ func TestCalculateUserScore_SwissFailed(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, report.GetScore().Successes)
@@ -196,7 +242,7 @@ func TestCalculateUserScore_SwissMixedResults(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 3, report.GetScore().Successes)
@@ -223,7 +269,7 @@ func TestCalculateUserScore_EliminationSuccess(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 3, report.GetScore().Successes)
@@ -259,7 +305,7 @@ func TestCalculateUserScore_EliminationPending(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, report.GetScore().Successes)
@@ -284,7 +330,7 @@ func TestCalculateUserScore_EliminationFailed(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, report.GetScore().Successes)
@@ -307,7 +353,7 @@ func TestCalculateUserScore_EliminationTeamNotInResults(t *testing.T) {
 		},
 	}
 
-	report, err := CalculateUserScore(prediction, results)
+	report, err := CalculateUserScore(prediction, results, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, report.GetScore().Successes)
@@ -330,7 +376,7 @@ func TestCalculateUserScore_UnknownType(t *testing.T) {
 
 	results := UnknownResult{}
 
-	_, err := CalculateUserScore(prediction, results)
+	_, err := CalculateUserScore(prediction, results, nil)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unknown format")
@@ -0,0 +1,179 @@
+//go:build !test
+
+// archive exports a tournament database to a versioned JSON archive, or imports an archive into a database.
+// Archives hold every round's predictions, match results, match nodes, schedule and leaderboard, and can be moved
+// between backends.
+//
+// Usage:
+//
+//	go run ./scripts/archive export -db <tournament> [-out archive.json]
+//	go run ./scripts/archive import -db <tournament> -in archive.json [-overwrite]
+//
+// The MongoDB URI is read from -uri, or else MONGO_PROD_URI. Use -backend sqlite or postgres with -dsn for the SQL
+// backends.
+//
+// Examples:
+//
+//	go run ./scripts/archive export -db IEM_Cologne_2026 -out cologne.json
+//	go run ./scripts/archive import -db IEM_Cologne_2026_staging -in cologne.json
+//	go run ./scripts/archive import -backend sqlite -dsn cologne.db -db IEM_Cologne_2026 -in cologne.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"pickems-bot/config"
+	"pickems-bot/store"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+	if len(os.Args) < 2 || (os.Args[1] != "export" && os.Args[1] != "import") {
+		fmt.Fprintf(os.Stderr, "Usage: archive <export|import> -db <tournament> [flags]\n")
+		os.Exit(1)
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	backend := flags.String("backend", config.StorageMongo, `Storage backend: "mongo", "sqlite" or "postgres"`)
+	dbName := flags.String("db", "", "Tournament database name (tournament_name in config.toml)")
+	uri := flags.String("uri", "", "MongoDB URI, defaults to MONGO_PROD_URI (mongo backend only)")
+	dsn := flags.String("dsn", "", "SQLite file path or PostgreSQL URL (sql backends only)")
+	out := flags.String("out", "-", `Archive file to write, "-" for stdout (export only)`)
+	in := flags.String("in", "-", `Archive file to read, "-" for stdin (import only)`)
+	overwrite := flags.Bool("overwrite", false, "Replace rounds that already have data (import only)")
+	_ = flags.Parse(os.Args[2:])
+
+	if *dbName == "" {
+		log.Fatal("missing required flag: -db")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s, closeStore, err := openStore(ctx, *backend, *dbName, *uri, *dsn)
+	if err != nil {
+		log.Fatalf("open store: %v", err)
+	}
+	defer closeStore()
+
+	switch command {
+	case "export":
+		err = runExport(ctx, s, *out)
+	case "import":
+		err = runImport(ctx, s, *in, *overwrite)
+	}
+	if err != nil {
+		closeStore()
+		log.Fatalf("%s: %v", command, err)
+	}
+}
+
+// openStore connects to the given backend. The round is left empty, as the archive covers every round.
+func openStore(ctx context.Context, backend, dbName, uri, dsn string) (store.Archiver, func(), error) {
+	switch backend {
+	case config.StorageSQLite, config.StoragePostgres:
+		dialect := store.SQLite
+		if backend == config.StoragePostgres {
+			dialect = store.Postgres
+		}
+		if dsn == "" {
+			return nil, nil, fmt.Errorf("missing required flag: -dsn")
+		}
+		s, err := store.NewSQLStore(ctx, dialect, dsn, dbName, "", nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s, func() { _ = s.GetClient().Disconnect(context.Background()) }, nil
+
+	case config.StorageMongo:
+		if uri == "" {
+			uri = os.Getenv("MONGO_PROD_URI")
+		}
+		if uri == "" {
+			return nil, nil, fmt.Errorf("missing -uri and MONGO_PROD_URI is not set")
+		}
+		s, err := store.NewStore(dbName, uri, "", nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		closeStore := func() { _ = s.Client.Disconnect(context.Background()) }
+		// Bring an older or fresh database up to the layout the archive is read and written with
+		if err := s.Migrate(ctx); err != nil {
+			closeStore()
+			return nil, nil, fmt.Errorf("migrate: %w", err)
+		}
+		if err := s.EnsureIndexes(ctx); err != nil {
+			closeStore()
+			return nil, nil, fmt.Errorf("create indexes: %w", err)
+		}
+		return s, closeStore, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown -backend %q, use \"mongo\", \"sqlite\" or \"postgres\"", backend)
+	}
+}
+
+// runExport writes the archive of s to path
+func runExport(ctx context.Context, s store.Archiver, path string) error {
+	archive, err := store.ExportArchive(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+
+	for _, r := range archive.Rounds {
+		log.Printf("exported round %s: %d predictions, %d match nodes, %d scheduled matches, %d leaderboard entries",
+			r.Round, len(r.Predictions), len(r.MatchNodes), len(r.Schedule), len(r.Leaderboard))
+	}
+	return nil
+}
+
+// runImport reads the archive at path into s
+func runImport(ctx context.Context, s store.Archiver, path string, overwrite bool) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var archive store.Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+
+	if err := store.ImportArchive(ctx, s, archive, overwrite); err != nil {
+		return err
+	}
+	log.Printf("imported %d rounds of %s (exported %s) into %s", len(archive.Rounds), archive.Tournament,
+		archive.ExportedAt.Format("2006-01-02 15:04 MST"), s.GetDatabase().Name())
+	return nil
+}
@@ -16,6 +16,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"pickems-bot/sources"
 )
 
 const liquipediaBase = "https://liquipedia.net/counterstrike/"
@@ -46,10 +48,9 @@ func fetchWikitext(path string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("create request: %w", err)
 	}
-	req.Header.Set("User-Agent", "LiquipediaDataFetcher/1.0")
 	req.Header.Set("Accept-Encoding", "gzip")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := sources.DefaultClient.Do(sources.SourceLiquipedia, req)
 	if err != nil {
 		return "", fmt.Errorf("fetch %s: %w", path, err)
 	}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -64,15 +65,16 @@ func main() {
 		log.Fatalf("unknown source %q — use 'liquipedia' or 'pandascore'", source)
 	}
 
+	ctx := context.Background()
 	fmt.Printf("=== FetchMatchData (round=%q) ===\n", round)
-	_, matchNodes, err := fetcher.FetchMatchData(round)
+	_, matchNodes, err := fetcher.FetchMatchData(ctx, round)
 	if err != nil {
 		log.Fatalf("FetchMatchData error: %v", err)
 	}
 	printMatchNodes(matchNodes)
 
 	fmt.Printf("\n=== FetchSchedule ===\n")
-	schedule, err := fetcher.FetchSchedule()
+	schedule, err := fetcher.FetchSchedule(ctx)
 	if err != nil {
 		log.Fatalf("FetchSchedule error: %v", err)
 	}
@@ -0,0 +1,170 @@
+/* client.go
+ * Contains Client, the HTTP client shared by every request to Liquipedia and PandaScore. It bounds each attempt with a
+ * timeout, takes a rate limiter token before each attempt, retries 5xx and 429 responses with jittered exponential
+ * backoff, and records per-source metrics.
+ */
+
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pickems-bot/metrics"
+)
+
+// UserAgent identifies the bot on every request. Liquipedia's API terms require a User-Agent naming the project.
+const UserAgent = "pickems-bot/1.0 (https://github.com/zacharyab24/pickems-bot)"
+
+// Source labels for the request metrics
+const (
+	SourceLiquipedia = "liquipedia"
+	SourcePandaScore = "pandascore"
+)
+
+// ErrRateLimited is returned when the client's Limiter doesn't hand out a token before the request's context is done
+var ErrRateLimited = errors.New("data source rate limit reached")
+
+// Limiter bounds how often a data source is requested. *rate.Limiter implements it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Client sends requests to the data sources. The zero value is not usable; use NewClient.
+type Client struct {
+	HTTP *http.Client
+	// Limiter, if set, is waited on before every attempt, so retries count against the data source's limit too
+	Limiter Limiter
+	// MaxAttempts is the number of times a request is sent before the last response or error is returned
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry. It doubles on each retry, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxRetryAfter is the longest Retry-After the client will wait out. Longer waits return the response instead.
+	MaxRetryAfter time.Duration
+}
+
+// NewClient returns a Client whose attempts each time out after timeout
+func NewClient(timeout time.Duration) *Client {
+	return &Client{
+		HTTP:          &http.Client{Timeout: timeout},
+		MaxAttempts:   3,
+		BaseDelay:     500 * time.Millisecond,
+		MaxDelay:      5 * time.Second,
+		MaxRetryAfter: 30 * time.Second,
+	}
+}
+
+// DefaultClient is the Client used by the fetch functions of this package
+var DefaultClient = NewClient(20 * time.Second)
+
+// WithLimiter returns a copy of c that waits on limiter before every attempt
+func (c *Client) WithLimiter(limiter Limiter) *Client {
+	limited := *c
+	limited.Limiter = limiter
+	return &limited
+}
+
+// Do sends request, labelled with source in the metrics, and returns the first response that shouldn't be retried.
+// Network errors, 5xx and 429 responses are retried until MaxAttempts is reached or the request's context is done;
+// the final response is returned as is, so callers check its status as they would with http.Client.Do. Every attempt
+// takes a token from Limiter first, and ErrRateLimited is returned if none is handed out in time. The request must not
+// have a body.
+func (c *Client) Do(source string, request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	if request.Header.Get("User-Agent") == "" {
+		request.Header.Set("User-Agent", UserAgent)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrRateLimited, err)
+			}
+		}
+
+		start := time.Now()
+		response, err := c.HTTP.Do(request.Clone(ctx))
+		metrics.DataSourceRequestDuration.WithLabelValues(source).Observe(time.Since(start).Seconds())
+
+		status := "error"
+		if err == nil {
+			status = strconv.Itoa(response.StatusCode)
+		}
+		metrics.DataSourceRequestsTotal.WithLabelValues(source, status).Inc()
+
+		if attempt >= c.MaxAttempts || ctx.Err() != nil {
+			return response, err
+		}
+		delay := c.backoff(attempt)
+		if err == nil {
+			if !retryable(response.StatusCode) {
+				return response, nil
+			}
+			if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > c.MaxRetryAfter {
+					return response, nil
+				}
+				delay = max(delay, retryAfter)
+			}
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the wait before retry number attempt: BaseDelay doubled per earlier retry and capped at MaxDelay,
+// with up to half of it taken off at random so clients that failed together don't retry together
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.BaseDelay
+	for i := 1; i < attempt && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, c.MaxDelay)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryable reports whether a response with the given status is worth sending again
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date, into the wait from now
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, returning early with the context's error if ctx is done first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
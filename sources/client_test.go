@@ -0,0 +1,233 @@
+/* client_test.go
+ * Contains unit tests for client.go
+ */
+
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// TestMain shortens DefaultClient's backoff so the fetch function tests don't wait out real retries
+func TestMain(m *testing.M) {
+	DefaultClient.BaseDelay = time.Millisecond
+	DefaultClient.MaxDelay = 2 * time.Millisecond
+	os.Exit(m.Run())
+}
+
+// newTestClient returns a Client with millisecond backoff and the given attempt timeout
+func newTestClient(timeout time.Duration) *Client {
+	client := NewClient(timeout)
+	client.BaseDelay = time.Millisecond
+	client.MaxDelay = 2 * time.Millisecond
+	return client
+}
+
+// get sends a GET request to url through client
+func get(t *testing.T, ctx context.Context, client *Client, url string) (*http.Response, error) {
+	t.Helper()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	return client.Do(SourceLiquipedia, request)
+}
+
+// region Client.Do tests
+
+func TestClientDo_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(time.Second), srv.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClientDo_ReturnsLastResponseWhenAttemptsRunOut(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(time.Second), srv.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClientDo_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(time.Second), srv.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClientDo_HonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var first time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.GreaterOrEqual(t, time.Since(first), time.Second, "retried before Retry-After passed")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(5*time.Second), srv.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClientDo_GivesUpOnLongRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(time.Second), srv.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClientDo_SetsUserAgent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, UserAgent, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	response, err := get(t, context.Background(), newTestClient(time.Second), srv.URL)
+	require.NoError(t, err)
+	response.Body.Close()
+}
+
+func TestClientDo_TimesOutHungRequests(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := newTestClient(50 * time.Millisecond)
+	client.MaxAttempts = 2
+	start := time.Now()
+	_, err := get(t, context.Background(), client, srv.URL)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestClientDo_StopsWhenContextIsDone(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := get(t, ctx, newTestClient(time.Second), srv.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// endregion
+
+// region parseRetryAfter tests
+
+func TestClientDo_TakesALimiterTokenPerAttempt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	// Two tokens and no refill: the third attempt finds the limiter empty
+	client := newTestClient(time.Second).WithLimiter(rate.NewLimiter(rate.Every(time.Hour), 2))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := get(t, ctx, client, srv.URL)
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClientWithLimiter_LeavesOriginalUnlimited(t *testing.T) {
+	client := newTestClient(time.Second)
+	limited := client.WithLimiter(rate.NewLimiter(rate.Inf, 1))
+
+	assert.Nil(t, client.Limiter)
+	assert.NotNil(t, limited.Limiter)
+	assert.Same(t, client.HTTP, limited.HTTP)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"seconds", "120", 2 * time.Minute, true},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"missing", "", 0, false},
+		{"invalid", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// endregion
@@ -6,12 +6,19 @@
 package sources
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 // region GetPandaScoreMatches tests
@@ -27,7 +34,7 @@ func TestGetPandaScoreMatches_Success(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	body, err := GetPandaScoreMatches(srv.URL, "test-key", 99001, 0)
+	body, err := GetPandaScoreMatches(context.Background(), srv.URL, "test-key", 99001, 0, 0)
 	require.NoError(t, err)
 	assert.Contains(t, body, "not_started")
 }
@@ -38,7 +45,7 @@ func TestGetPandaScoreMatches_Unauthorized(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetPandaScoreMatches(srv.URL, "bad-key", 99001, 0)
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "bad-key", 99001, 0, 0)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrUnrecoverable)
 }
@@ -49,7 +56,7 @@ func TestGetPandaScoreMatches_Forbidden(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetPandaScoreMatches(srv.URL, "key", 99001, 0)
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 0)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrUnrecoverable)
 }
@@ -60,7 +67,7 @@ func TestGetPandaScoreMatches_NotFound(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetPandaScoreMatches(srv.URL, "key", 99001, 0)
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 0)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrUnrecoverable)
 }
@@ -71,14 +78,14 @@ func TestGetPandaScoreMatches_UnexpectedStatus(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetPandaScoreMatches(srv.URL, "key", 99001, 0)
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 0)
 	require.Error(t, err)
 	assert.NotErrorIs(t, err, ErrUnrecoverable) // 500 is retriable, not unrecoverable
 	assert.Contains(t, err.Error(), "unexpected status code")
 }
 
 func TestGetPandaScoreMatches_InvalidURL(t *testing.T) {
-	_, err := GetPandaScoreMatches("://invalid-url", "key", 1, 0)
+	_, err := GetPandaScoreMatches(context.Background(), "://invalid-url", "key", 1, 0, 0)
 	require.Error(t, err)
 }
 
@@ -96,7 +103,7 @@ func TestGetLiquipediaMatchDataByPage_Success(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	body, err := GetLiquipediaMatchDataByPage(srv.URL, "test-key", "Test/Page")
+	body, err := GetLiquipediaMatchDataByPage(context.Background(), srv.URL, "test-key", "Test/Page", 0)
 	require.NoError(t, err)
 	assert.Contains(t, body, "result")
 }
@@ -107,13 +114,13 @@ func TestGetLiquipediaMatchDataByPage_NonOK(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetLiquipediaMatchDataByPage(srv.URL, "key", "Test/Page")
+	_, err := GetLiquipediaMatchDataByPage(context.Background(), srv.URL, "key", "Test/Page", 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "429")
 }
 
 func TestGetLiquipediaMatchDataByPage_InvalidURL(t *testing.T) {
-	_, err := GetLiquipediaMatchDataByPage("://bad-url", "key", "page")
+	_, err := GetLiquipediaMatchDataByPage(context.Background(), "://bad-url", "key", "page", 0)
 	require.Error(t, err)
 }
 
@@ -133,7 +140,7 @@ func TestGetLiquipediaMatchData_Success(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	body, err := GetLiquipediaMatchData(srv.URL, "test-key", []string{"ID1", "ID2"})
+	body, err := GetLiquipediaMatchData(context.Background(), srv.URL, "test-key", []string{"ID1", "ID2"}, 0)
 	require.NoError(t, err)
 	assert.Contains(t, body, "result")
 }
@@ -144,14 +151,193 @@ func TestGetLiquipediaMatchData_NonOK(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := GetLiquipediaMatchData(srv.URL, "key", []string{"ID1"})
+	_, err := GetLiquipediaMatchData(context.Background(), srv.URL, "key", []string{"ID1"}, 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unexpected status code")
 }
 
 func TestGetLiquipediaMatchData_InvalidURL(t *testing.T) {
-	_, err := GetLiquipediaMatchData("://bad-url", "key", []string{"ID1"})
+	_, err := GetLiquipediaMatchData(context.Background(), "://bad-url", "key", []string{"ID1"}, 0)
 	require.Error(t, err)
 }
 
 // endregion
+
+// region pagination tests
+
+// liquipediaPage returns a LiquipediaDB response body of n matches numbered from first
+func liquipediaPage(first, n int) string {
+	results := make([]string, n)
+	for i := range results {
+		results[i] = fmt.Sprintf(`{"match2id": "M%d"}`, first+i)
+	}
+	return `{"result": [` + strings.Join(results, ",") + `]}`
+}
+
+func TestGetLiquipediaMatchDataByPage_FollowsOffsets(t *testing.T) {
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		start, _ := strconv.Atoi(offset)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(liquipediaPage(start, min(250-start, 100))))
+	}))
+	defer srv.Close()
+
+	body, err := GetLiquipediaMatchDataByPage(context.Background(), srv.URL, "key", "Test/Page", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "100", "200"}, offsets)
+
+	var merged struct {
+		Result []map[string]string `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &merged))
+	require.Len(t, merged.Result, 250)
+	assert.Equal(t, "M0", merged.Result[0]["match2id"])
+	assert.Equal(t, "M249", merged.Result[249]["match2id"])
+}
+
+func TestGetLiquipediaMatchDataByPage_SinglePageUntouched(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result": [], "warning": ["none"]}`))
+	}))
+	defer srv.Close()
+
+	body, err := GetLiquipediaMatchDataByPage(context.Background(), srv.URL, "key", "Test/Page", 0)
+	require.NoError(t, err)
+	assert.Equal(t, `{"result": [], "warning": ["none"]}`, body)
+}
+
+func TestGetLiquipediaMatchData_PageCap(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(liquipediaPage(0, 100)))
+	}))
+	defer srv.Close()
+
+	_, err := GetLiquipediaMatchData(context.Background(), srv.URL, "key", []string{"ID1"}, 2)
+	assert.ErrorIs(t, err, ErrTooManyPages)
+	// Both allowed pages, then the probe that finds more
+	assert.Equal(t, 3, calls)
+}
+
+func TestGetLiquipediaMatchData_ExactlyFillsPageCap(t *testing.T) {
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		w.WriteHeader(http.StatusOK)
+		if offset == "0" {
+			_, _ = w.Write([]byte(liquipediaPage(0, 100)))
+			return
+		}
+		_, _ = w.Write([]byte(liquipediaPage(100, 0)))
+	}))
+	defer srv.Close()
+
+	body, err := GetLiquipediaMatchData(context.Background(), srv.URL, "key", []string{"ID1"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "100"}, offsets)
+
+	var merged struct {
+		Result []map[string]string `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &merged))
+	assert.Len(t, merged.Result, 100)
+}
+
+func TestGetPandaScoreMatches_FollowsLinkHeader(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s/?page=2>; rel="next", <%s/?page=2>; rel="last"`, srv.URL, srv.URL))
+			_, _ = w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		case "2":
+			w.Header().Set("Link", `</?page=1>; rel="first", </?page=1>; rel="prev"`)
+			_, _ = w.Write([]byte(`[{"id": 3}]`))
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer srv.Close()
+
+	body, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 0)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": 1}, {"id": 2}, {"id": 3}]`, body)
+}
+
+func TestGetPandaScoreMatches_PageCap(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Link", fmt.Sprintf(`</?page=%d>; rel="next"`, calls+1))
+		_, _ = w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer srv.Close()
+
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 3)
+	assert.ErrorIs(t, err, ErrTooManyPages)
+	assert.Equal(t, 4, calls)
+}
+
+func TestGetPandaScoreMatches_ExactlyFillsPageCap(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.Header().Set("Link", `</?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer srv.Close()
+
+	body, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": 1}]`, body)
+}
+
+func TestClientGetPandaScoreMatches_TakesALimiterTokenPerPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</?page=2>; rel="next"`)
+		case "2":
+			w.Header().Set("Link", `</?page=3>; rel="next"`)
+		}
+		_, _ = w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer srv.Close()
+
+	// Two tokens and no refill: the third page finds the limiter empty
+	client := DefaultClient.WithLimiter(rate.NewLimiter(rate.Every(time.Hour), 2))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.GetPandaScoreMatches(ctx, srv.URL, "key", 99001, 0, 0)
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestGetPandaScoreMatches_LaterPageError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Link", `</?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer srv.Close()
+
+	_, err := GetPandaScoreMatches(context.Background(), srv.URL, "key", 99001, 0, 0)
+	assert.ErrorIs(t, err, ErrUnrecoverable)
+}
+
+// endregion
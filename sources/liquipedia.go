@@ -6,6 +6,7 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,9 @@ import (
 	"time"
 )
 
+// liquipediaPageSize is the number of matches requested per page of the LiquipediaDB /match endpoint
+const liquipediaPageSize = 100
+
 // GetLiquipediaMatchDataByPage fetches all match data for a tournament page from
 // the LiquipediaDB /match endpoint using a [[pagename::X]] condition. This
 // replaces the older bracket-ID extraction approach: instead of scraping
@@ -24,50 +28,37 @@ import (
 // stored under that page are returned in one query.
 //
 // Preconditions: valid API key, pagename is a slash-separated Liquipedia path
-// Postconditions: returns raw JSON string or an error
-func GetLiquipediaMatchDataByPage(apiURL string, apiKey string, pagename string) (string, error) {
+// Postconditions: returns raw JSON string of up to maxPages pages of matches (zero means DefaultMaxPages) or an error
+func GetLiquipediaMatchDataByPage(ctx context.Context, apiURL string, apiKey string, pagename string, maxPages int) (string, error) {
+	return DefaultClient.GetLiquipediaMatchDataByPage(ctx, apiURL, apiKey, pagename, maxPages)
+}
+
+// GetLiquipediaMatchDataByPage is GetLiquipediaMatchDataByPage sending its requests through c
+func (c *Client) GetLiquipediaMatchDataByPage(ctx context.Context, apiURL string, apiKey string, pagename string, maxPages int) (string, error) {
 	parsedURL, err := url.Parse(apiURL)
 	if err != nil {
 		return "", fmt.Errorf("invalid api url: %w", err)
 	}
 
 	params := parsedURL.Query()
-	params.Set("limit", "100")
 	params.Set("wiki", "counterstrike")
 	params.Set("conditions", fmt.Sprintf("[[pagename::%s]]", pagename))
 	params.Set("rawstreams", "false")
 	params.Set("streamurls", "true")
 	parsedURL.RawQuery = params.Encode()
 
-	client := &http.Client{}
-	request, err := http.NewRequest("GET", parsedURL.String(), nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	request.Header.Set("Authorization", fmt.Sprintf("Apikey %s", apiKey))
-
-	response, err := client.Do(request)
-	if err != nil {
-		return "", err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("liquipedia api returned status %d", response.StatusCode)
-	}
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	return string(body), nil
+	return c.getLiquipediaMatchPages(ctx, parsedURL, apiKey, maxPages)
 }
 
 // GetLiquipediaMatchData fetches match data from the LiquipediaDB API filtered by match2bracketid.
-// Each match2bracketid corresponds to a bracket table on a tournament page.
-func GetLiquipediaMatchData(apiURL string, apiKey string, bracketIds []string) (string, error) {
+// Each match2bracketid corresponds to a bracket table on a tournament page. Up to maxPages pages are fetched, zero
+// meaning DefaultMaxPages.
+func GetLiquipediaMatchData(ctx context.Context, apiURL string, apiKey string, bracketIds []string, maxPages int) (string, error) {
+	return DefaultClient.GetLiquipediaMatchData(ctx, apiURL, apiKey, bracketIds, maxPages)
+}
+
+// GetLiquipediaMatchData is GetLiquipediaMatchData sending its requests through c
+func (c *Client) GetLiquipediaMatchData(ctx context.Context, apiURL string, apiKey string, bracketIds []string, maxPages int) (string, error) {
 	var conditions []string
 	for _, id := range bracketIds {
 		conditions = append(conditions, fmt.Sprintf("[[match2bracketid::%s]]", id))
@@ -79,37 +70,92 @@ func GetLiquipediaMatchData(apiURL string, apiKey string, bracketIds []string) (
 	}
 
 	params := parsedURL.Query()
-	params.Set("limit", "100")
 	params.Set("wiki", "counterstrike")
 	params.Set("conditions", strings.Join(conditions, " OR "))
 	params.Set("rawstreams", "false")
 	params.Set("streamurls", "true")
 	parsedURL.RawQuery = params.Encode()
 
-	client := &http.Client{}
-	request, err := http.NewRequest("GET", parsedURL.String(), nil)
+	return c.getLiquipediaMatchPages(ctx, parsedURL, apiKey, maxPages)
+}
+
+// getLiquipediaMatchPages requests matchURL a page at a time, moving the offset on until a page comes back short,
+// and returns the results of every page merged into one response. A response that fits on the first page is
+// returned untouched. When the last allowed page is full, one more page is requested to tell whether there are more
+// matches; only a probe that finds some fails with ErrTooManyPages.
+func (c *Client) getLiquipediaMatchPages(ctx context.Context, matchURL *url.URL, apiKey string, maxPages int) (string, error) {
+	maxPages = maxPagesOrDefault(maxPages)
+	var results []json.RawMessage
+	for page := 0; ; page++ {
+		pageURL := *matchURL
+		params := pageURL.Query()
+		params.Set("limit", strconv.Itoa(liquipediaPageSize))
+		params.Set("offset", strconv.Itoa(page*liquipediaPageSize))
+		pageURL.RawQuery = params.Encode()
+
+		body, err := c.getLiquipediaPage(ctx, pageURL.String(), apiKey)
+		if err != nil {
+			return "", err
+		}
+
+		var response struct {
+			Result []json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			if page == 0 {
+				// Leave reporting the malformed response to the parser
+				return string(body), nil
+			}
+			return "", fmt.Errorf("error parsing page %d: %w", page+1, err)
+		}
+		if page == 0 && len(response.Result) < liquipediaPageSize {
+			return string(body), nil
+		}
+		if len(response.Result) == 0 {
+			break
+		}
+		if page == maxPages {
+			return "", fmt.Errorf("%w: liquipedia returned more than %d pages", ErrTooManyPages, maxPages)
+		}
+
+		results = append(results, response.Result...)
+		if len(response.Result) < liquipediaPageSize {
+			break
+		}
+	}
+
+	merged, err := json.Marshal(map[string][]json.RawMessage{"result": results})
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to merge pages: %w", err)
+	}
+	return string(merged), nil
+}
+
+// getLiquipediaPage requests a single page of the LiquipediaDB API and returns its body
+func (c *Client) getLiquipediaPage(ctx context.Context, pageURL string, apiKey string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	request.Header.Set("Authorization", fmt.Sprintf("Apikey %s", apiKey))
 
-	response, err := client.Do(request)
+	response, err := c.Do(SourceLiquipedia, request)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", response.StatusCode)
+		return nil, fmt.Errorf("liquipedia api returned unexpected status code: %d", response.StatusCode)
 	}
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // ParseLiquipediaMatches parses a LiquipediaDB match JSON response into a slice of MatchNodes.
@@ -229,16 +275,47 @@ func parseLiquipediaMatch(result interface{}) (*MatchNode, error) {
 
 	section, _ := match["section"].(string)
 
+	var finishedAt time.Time
+	if isFinished {
+		finishedAt = liquipediaFinishTime(match)
+	}
+
 	return &MatchNode{
-		ID:      matchIDStr,
-		Team1:   teams[0],
-		Team2:   teams[1],
-		Winner:  winner,
-		Score:   score,
-		Section: section,
+		ID:         matchIDStr,
+		Team1:      teams[0],
+		Team2:      teams[1],
+		Winner:     winner,
+		Score:      score,
+		Section:    section,
+		FinishedAt: finishedAt,
 	}, nil
 }
 
+// liquipediaFinishTime estimates when a finished match ended. LiquipediaDB has no end time, so this is the
+// date of the last map played, falling back to the match date. Either can be the series start time when
+// editors haven't dated each map. Returns the zero time if no date parses.
+func liquipediaFinishTime(match map[string]interface{}) time.Time {
+	dates := []interface{}{match["date"]}
+	if games, ok := match["match2games"].([]interface{}); ok {
+		for _, g := range games {
+			game, ok := g.(map[string]interface{})
+			if !ok || game["status"] == "notplayed" {
+				continue
+			}
+			dates = append(dates, game["date"])
+		}
+	}
+
+	// Liquipedia dates are in GMT
+	for i := len(dates) - 1; i >= 0; i-- {
+		date, _ := dates[i].(string)
+		if parsed, err := time.Parse("2006-01-02 15:04:05", date); err == nil && parsed.Year() > 1 {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
 // ParseLiquipediaSchedule parses a LiquipediaDB match JSON response into a slice of ScheduledMatches.
 func ParseLiquipediaSchedule(matchData string) ([]ScheduledMatch, error) {
 	var root map[string]interface{}
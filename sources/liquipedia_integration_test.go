@@ -3,6 +3,7 @@
 package sources_test
 
 import (
+	"context"
 	"testing"
 
 	"pickems-bot/sources"
@@ -23,7 +24,7 @@ const (
 func TestLiquipedia_GetMatches_Ongoing(t *testing.T) {
 	testhelpers.SetState(t, "liquipedia", "ongoing")
 
-	raw, err := sources.GetLiquipediaMatchDataByPage(liqURL, liqTestKey, liqPage)
+	raw, err := sources.GetLiquipediaMatchDataByPage(context.Background(), liqURL, liqTestKey, liqPage, 0)
 	require.NoError(t, err)
 
 	nodes, err := sources.ParseLiquipediaMatches(raw)
@@ -42,7 +43,7 @@ func TestLiquipedia_GetMatches_Ongoing(t *testing.T) {
 func TestLiquipedia_GetMatches_NotStarted(t *testing.T) {
 	testhelpers.SetState(t, "liquipedia", "not_started")
 
-	raw, err := sources.GetLiquipediaMatchDataByPage(liqURL, liqTestKey, liqPage)
+	raw, err := sources.GetLiquipediaMatchDataByPage(context.Background(), liqURL, liqTestKey, liqPage, 0)
 	require.NoError(t, err)
 
 	nodes, err := sources.ParseLiquipediaMatches(raw)
@@ -55,6 +56,6 @@ func TestLiquipedia_GetMatches_NotStarted(t *testing.T) {
 func TestLiquipedia_GetMatches_WrongKey(t *testing.T) {
 	testhelpers.SetState(t, "liquipedia", "ongoing")
 
-	_, err := sources.GetLiquipediaMatchDataByPage(liqURL, "wrong-key", liqPage)
+	_, err := sources.GetLiquipediaMatchDataByPage(context.Background(), liqURL, "wrong-key", liqPage, 0)
 	require.Error(t, err, "expected error for bad API key")
 }
@@ -12,8 +12,15 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 )
 
+// liquipediaDate parses a LiquipediaDB date, which is in GMT
+func liquipediaDate(date string) time.Time {
+	parsed, _ := time.Parse("2006-01-02 15:04:05", date)
+	return parsed
+}
+
 // region ParseLiquipediaMatches tests
 
 func TestParseLiquipediaMatches(t *testing.T) {
@@ -21,33 +28,33 @@ func TestParseLiquipediaMatches(t *testing.T) {
 		{ID: "AmF15pUfHd_0001", Team1: "Aurora Gaming", Team2: "SAW", Winner: "TBD", Score: "", Section: "Round 4"},
 		{ID: "AmF15pUfHd_0002", Team1: "Team Liquid", Team2: "FlyQuest", Winner: "TBD", Score: "", Section: "Round 4"},
 		{ID: "AmF15pUfHd_0003", Team1: "B8", Team2: "Legacy", Winner: "TBD", Score: "", Section: "Round 4"},
-		{ID: "IykJinz1G8_0001", Team1: "GamerLegion", Team2: "SAW", Winner: "SAW", Score: "1-2", Section: "Round 3"},
-		{ID: "IykJinz1G8_0002", Team1: "Team Liquid", Team2: "BetBoom Team", Winner: "Team Liquid", Score: "2-1", Section: "Round 3"},
-		{ID: "IykJinz1G8_0003", Team1: "3DMAX", Team2: "FlyQuest", Winner: "FlyQuest", Score: "1-2", Section: "Round 3"},
-		{ID: "IykJinz1G8_0004", Team1: "Astralis", Team2: "Legacy", Winner: "Legacy", Score: "0-2", Section: "Round 3"},
-		{ID: "U7JeCe3nrs_0001", Team1: "GamerLegion", Team2: "PaiN Gaming", Winner: "PaiN Gaming", Score: "1-2", Section: "Round 2"},
-		{ID: "U7JeCe3nrs_0002", Team1: "HEROIC", Team2: "BetBoom Team", Winner: "HEROIC", Score: "2-0", Section: "Round 2"},
-		{ID: "U7JeCe3nrs_0003", Team1: "Aurora Gaming", Team2: "Team Liquid", Winner: "Aurora Gaming", Score: "2-1", Section: "Round 2"},
-		{ID: "U7JeCe3nrs_0004", Team1: "3DMAX", Team2: "B8", Winner: "B8", Score: "0-2", Section: "Round 2"},
-		{ID: "VKTHpS7s0x_0001", Team1: "Aurora Gaming", Team2: "HEROIC", Winner: "HEROIC", Score: "0-2", Section: "Round 3"},
-		{ID: "VKTHpS7s0x_0002", Team1: "B8", Team2: "PaiN Gaming", Winner: "PaiN Gaming", Score: "0-2", Section: "Round 3"},
-		{ID: "ayB546T4zZ_0001", Team1: "PaiN Gaming", Team2: "Gentle Mates", Winner: "PaiN Gaming", Score: "2-0", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0002", Team1: "Legacy", Team2: "Team Liquid", Winner: "Team Liquid", Score: "1-2", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0003", Team1: "HEROIC", Team2: "Ninjas in Pyjamas", Winner: "HEROIC", Score: "2-0", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0004", Team1: "GamerLegion", Team2: "FlyQuest", Winner: "GamerLegion", Score: "2-0", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0005", Team1: "3DMAX", Team2: "SAW", Winner: "3DMAX", Score: "2-0", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0006", Team1: "BetBoom Team", Team2: "MIBR", Winner: "BetBoom Team", Score: "2-1", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0007", Team1: "Aurora Gaming", Team2: "Fnatic", Winner: "Aurora Gaming", Score: "2-0", Section: "Round 1"},
-		{ID: "ayB546T4zZ_0008", Team1: "Astralis", Team2: "B8", Winner: "B8", Score: "0-2", Section: "Round 1"},
+		{ID: "IykJinz1G8_0001", Team1: "GamerLegion", Team2: "SAW", Winner: "SAW", Score: "1-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 08:00:00")},
+		{ID: "IykJinz1G8_0002", Team1: "Team Liquid", Team2: "BetBoom Team", Winner: "Team Liquid", Score: "2-1", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 08:00:00")},
+		{ID: "IykJinz1G8_0003", Team1: "3DMAX", Team2: "FlyQuest", Winner: "FlyQuest", Score: "1-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 12:05:00")},
+		{ID: "IykJinz1G8_0004", Team1: "Astralis", Team2: "Legacy", Winner: "Legacy", Score: "0-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 10:35:00")},
+		{ID: "U7JeCe3nrs_0001", Team1: "GamerLegion", Team2: "PaiN Gaming", Winner: "PaiN Gaming", Score: "1-2", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 08:00:00")},
+		{ID: "U7JeCe3nrs_0002", Team1: "HEROIC", Team2: "BetBoom Team", Winner: "HEROIC", Score: "2-0", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 11:20:00")},
+		{ID: "U7JeCe3nrs_0003", Team1: "Aurora Gaming", Team2: "Team Liquid", Winner: "Aurora Gaming", Score: "2-1", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 13:45:00")},
+		{ID: "U7JeCe3nrs_0004", Team1: "3DMAX", Team2: "B8", Winner: "B8", Score: "0-2", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 17:05:00")},
+		{ID: "VKTHpS7s0x_0001", Team1: "Aurora Gaming", Team2: "HEROIC", Winner: "HEROIC", Score: "0-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 12:55:00")},
+		{ID: "VKTHpS7s0x_0002", Team1: "B8", Team2: "PaiN Gaming", Winner: "PaiN Gaming", Score: "0-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 15:00:00")},
+		{ID: "ayB546T4zZ_0001", Team1: "PaiN Gaming", Team2: "Gentle Mates", Winner: "PaiN Gaming", Score: "2-0", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 08:05:00")},
+		{ID: "ayB546T4zZ_0002", Team1: "Legacy", Team2: "Team Liquid", Winner: "Team Liquid", Score: "1-2", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 08:00:00")},
+		{ID: "ayB546T4zZ_0003", Team1: "HEROIC", Team2: "Ninjas in Pyjamas", Winner: "HEROIC", Score: "2-0", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 11:00:00")},
+		{ID: "ayB546T4zZ_0004", Team1: "GamerLegion", Team2: "FlyQuest", Winner: "GamerLegion", Score: "2-0", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 11:15:00")},
+		{ID: "ayB546T4zZ_0005", Team1: "3DMAX", Team2: "SAW", Winner: "3DMAX", Score: "2-0", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 14:20:00")},
+		{ID: "ayB546T4zZ_0006", Team1: "BetBoom Team", Team2: "MIBR", Winner: "BetBoom Team", Score: "2-1", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 12:55:00")},
+		{ID: "ayB546T4zZ_0007", Team1: "Aurora Gaming", Team2: "Fnatic", Winner: "Aurora Gaming", Score: "2-0", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 16:20:00")},
+		{ID: "ayB546T4zZ_0008", Team1: "Astralis", Team2: "B8", Winner: "B8", Score: "0-2", Section: "Round 1", FinishedAt: liquipediaDate("2025-10-26 16:05:00")},
 		{ID: "f3Ubb66fCx_0001", Team1: "3DMAX", Team2: "Astralis", Winner: "TBD", Score: "", Section: "Round 4"},
 		{ID: "f3Ubb66fCx_0002", Team1: "BetBoom Team", Team2: "Gentle Mates", Winner: "TBD", Score: "", Section: "Round 4"},
 		{ID: "f3Ubb66fCx_0003", Team1: "GamerLegion", Team2: "Fnatic", Winner: "TBD", Score: "", Section: "Round 4"},
-		{ID: "ilPVE8BYF6_0001", Team1: "Ninjas in Pyjamas", Team2: "Gentle Mates", Winner: "Gentle Mates", Score: "1-2", Section: "Round 3"},
-		{ID: "ilPVE8BYF6_0002", Team1: "Fnatic", Team2: "MIBR", Winner: "Fnatic", Score: "2-0", Section: "Round 3"},
-		{ID: "vINHUV3all_0001", Team1: "Legacy", Team2: "Gentle Mates", Winner: "Legacy", Score: "2-0", Section: "Round 2"},
-		{ID: "vINHUV3all_0002", Team1: "SAW", Team2: "Ninjas in Pyjamas", Winner: "SAW", Score: "2-1", Section: "Round 2"},
-		{ID: "vINHUV3all_0003", Team1: "Fnatic", Team2: "FlyQuest", Winner: "FlyQuest", Score: "0-2", Section: "Round 2"},
-		{ID: "vINHUV3all_0004", Team1: "Astralis", Team2: "MIBR", Winner: "Astralis", Score: "2-0", Section: "Round 2"},
+		{ID: "ilPVE8BYF6_0001", Team1: "Ninjas in Pyjamas", Team2: "Gentle Mates", Winner: "Gentle Mates", Score: "1-2", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 15:10:00")},
+		{ID: "ilPVE8BYF6_0002", Team1: "Fnatic", Team2: "MIBR", Winner: "Fnatic", Score: "2-0", Section: "Round 3", FinishedAt: liquipediaDate("2025-10-28 18:25:00")},
+		{ID: "vINHUV3all_0001", Team1: "Legacy", Team2: "Gentle Mates", Winner: "Legacy", Score: "2-0", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 08:00:00")},
+		{ID: "vINHUV3all_0002", Team1: "SAW", Team2: "Ninjas in Pyjamas", Winner: "SAW", Score: "2-1", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 10:15:00")},
+		{ID: "vINHUV3all_0003", Team1: "Fnatic", Team2: "FlyQuest", Winner: "FlyQuest", Score: "0-2", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 13:20:00")},
+		{ID: "vINHUV3all_0004", Team1: "Astralis", Team2: "MIBR", Winner: "Astralis", Score: "2-0", Section: "Round 2", FinishedAt: liquipediaDate("2025-10-27 15:45:00")},
 		{ID: "zIiQwLgw83_0001", Team1: "TBD", Team2: "TBD", Winner: "TBD", Score: "", Section: "Round 5"},
 		{ID: "zIiQwLgw83_0002", Team1: "TBD", Team2: "TBD", Winner: "TBD", Score: "", Section: "Round 5"},
 		{ID: "zIiQwLgw83_0003", Team1: "TBD", Team2: "TBD", Winner: "TBD", Score: "", Section: "Round 5"},
@@ -132,6 +139,33 @@ func TestParseLiquipediaMatches_InvalidData(t *testing.T) {
 	}
 }
 
+func TestLiquipediaFinishTime_LastPlayedMap(t *testing.T) {
+	match := map[string]interface{}{
+		"date": "2025-10-28 08:00:00",
+		"match2games": []interface{}{
+			map[string]interface{}{"date": "2025-10-28 08:00:00"},
+			map[string]interface{}{"date": "2025-10-28 09:10:00"},
+			map[string]interface{}{"date": "2025-10-28 10:30:00", "status": "notplayed"},
+		},
+	}
+	if got := liquipediaFinishTime(match); !got.Equal(liquipediaDate("2025-10-28 09:10:00")) {
+		t.Fatalf("expected the last played map's date, got %s", got)
+	}
+}
+
+func TestLiquipediaFinishTime_FallsBackToMatchDate(t *testing.T) {
+	match := map[string]interface{}{
+		"date":        "2025-10-28 08:00:00",
+		"match2games": []interface{}{map[string]interface{}{"date": "0000-01-01 00:00:00"}},
+	}
+	if got := liquipediaFinishTime(match); !got.Equal(liquipediaDate("2025-10-28 08:00:00")) {
+		t.Fatalf("expected the match date, got %s", got)
+	}
+	if got := liquipediaFinishTime(map[string]interface{}{"date": "0000-01-01 00:00:00"}); !got.IsZero() {
+		t.Fatalf("expected the zero time for an undated match, got %s", got)
+	}
+}
+
 // endregion
 
 // region ParseLiquipediaSchedule tests
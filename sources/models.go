@@ -5,6 +5,8 @@
 
 package sources
 
+import "time"
+
 // MatchNode represents a single match in a tournament bracket
 type MatchNode struct {
 	ID      string `bson:"id"`
@@ -14,6 +16,8 @@ type MatchNode struct {
 	Score   string `bson:"score"`   // series score ("2-1") for BoX, map score ("13-10") for BO1; "" if unfinished
 	Section string `bson:"section"` // round label from Liquipedia (e.g. "Round 1", "Upper Bracket Round 2")
 	Status  string // source-specific status string (e.g. "finished", "running", "not_started" for PandaScore)
+	// FinishedAt is when the match finished, as reported by the source; zero if unfinished or unknown
+	FinishedAt time.Time `bson:"finished_at,omitempty"`
 }
 
 // ScheduledMatch represents a scheduled match with timing and streaming information
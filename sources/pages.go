@@ -0,0 +1,58 @@
+/* pages.go
+ * Contains the helpers shared by the paginated match fetches: the page cap and the parsing of Link headers.
+ */
+
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxPages is the page cap used when a fetch is given a cap of zero
+const DefaultMaxPages = 20
+
+// ErrTooManyPages signals that a source had more pages of matches than the fetch was allowed to request. The
+// matches are not returned, as a partial list would silently drop matches and teams.
+var ErrTooManyPages = errors.New("too many pages of matches")
+
+// maxPagesOrDefault returns maxPages, or DefaultMaxPages if it is zero or less
+func maxPagesOrDefault(maxPages int) int {
+	if maxPages <= 0 {
+		return DefaultMaxPages
+	}
+	return maxPages
+}
+
+// nextLink returns the URL of the rel="next" link of an RFC 8288 Link header, resolved against base, or "" if there
+// is none. Links to another host are refused, since following them would send the API key there.
+func nextLink(header string, base *url.URL) (string, error) {
+	for _, link := range strings.Split(header, ",") {
+		target, params, found := strings.Cut(strings.TrimSpace(link), ";")
+		if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		isNext := false
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "rel") && strings.EqualFold(strings.Trim(value, `"`), "next") {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+
+		next, err := base.Parse(strings.Trim(target, "<>"))
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link: %w", err)
+		}
+		if next.Host != base.Host {
+			return "", fmt.Errorf("next page link points to another host: %s", next.Host)
+		}
+		return next.String(), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,46 @@
+/* pages_test.go
+ * Contains unit tests for pages.go
+ */
+
+package sources
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// region nextLink tests
+
+func TestNextLink(t *testing.T) {
+	base, err := url.Parse("https://api.pandascore.co/csgo/matches?page=1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{"absolute", `<https://api.pandascore.co/csgo/matches?page=2>; rel="next", <https://api.pandascore.co/csgo/matches?page=9>; rel="last"`, "https://api.pandascore.co/csgo/matches?page=2", false},
+		{"relative and unquoted", `</csgo/matches?page=3>; rel=next`, "https://api.pandascore.co/csgo/matches?page=3", false},
+		{"last page", `<https://api.pandascore.co/csgo/matches?page=1>; rel="first", <https://api.pandascore.co/csgo/matches?page=1>; rel="prev"`, "", false},
+		{"no header", "", "", false},
+		{"another host", `<https://example.com/matches?page=2>; rel="next"`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextLink(tt.header, base)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// endregion
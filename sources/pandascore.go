@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,12 +17,22 @@ import (
 // (e.g. bad API key, unknown series ID). The poller should stop when it sees this.
 var ErrUnrecoverable = errors.New("unrecoverable api error")
 
+// pandaScorePageSize is the number of matches requested per page, the most PandaScore allows
+const pandaScorePageSize = 100
+
 // GetPandaScoreMatches fetches matches from the PandaScore API.
 // When tournamentID is non-zero it is used as the server-side filter; the caller is also
 // responsible for client-side filtering since PandaScore's filter[tournament_id] is
 // unreliable for finished matches. Falls back to filter[serie_id] when tournamentID is zero.
-// Returns the raw JSON response body as a string.
-func GetPandaScoreMatches(apiURL string, apiKey string, seriesID int, tournamentID int) (string, error) {
+// Follows the next links of up to maxPages pages (zero means DefaultMaxPages) and
+// returns the matches of every page as a single raw JSON array. When the last allowed page links to another, that page
+// is requested too, and only fails with ErrTooManyPages if it holds matches.
+func GetPandaScoreMatches(ctx context.Context, apiURL string, apiKey string, seriesID int, tournamentID int, maxPages int) (string, error) {
+	return DefaultClient.GetPandaScoreMatches(ctx, apiURL, apiKey, seriesID, tournamentID, maxPages)
+}
+
+// GetPandaScoreMatches is GetPandaScoreMatches sending its requests through c
+func (c *Client) GetPandaScoreMatches(ctx context.Context, apiURL string, apiKey string, seriesID int, tournamentID int, maxPages int) (string, error) {
 	parsedURL, err := url.Parse(apiURL)
 	if err != nil {
 		return "", fmt.Errorf("invalid url: %w", err)
@@ -34,38 +45,77 @@ func GetPandaScoreMatches(apiURL string, apiKey string, seriesID int, tournament
 		params.Set("filter[serie_id]", strconv.Itoa(seriesID))
 	}
 	params.Set("filter[status]", "finished,running,not_started")
-	params.Set("per_page", "50")
+	params.Set("per_page", strconv.Itoa(pandaScorePageSize))
 	parsedURL.RawQuery = params.Encode()
 
-	client := &http.Client{}
-	request, err := http.NewRequest("GET", parsedURL.String(), nil)
+	maxPages = maxPagesOrDefault(maxPages)
+	var matches []json.RawMessage
+	pageURL := parsedURL.String()
+	for page := 0; pageURL != ""; page++ {
+		body, next, err := c.getPandaScorePage(ctx, pageURL, apiKey)
+		if err != nil {
+			return "", err
+		}
+		if page == 0 && next == "" {
+			return string(body), nil
+		}
+
+		var pageMatches []json.RawMessage
+		if err := json.Unmarshal(body, &pageMatches); err != nil {
+			return "", fmt.Errorf("error parsing page %d: %w", page+1, err)
+		}
+		if len(pageMatches) == 0 {
+			break
+		}
+		if page == maxPages {
+			return "", fmt.Errorf("%w: pandascore returned more than %d pages", ErrTooManyPages, maxPages)
+		}
+		matches = append(matches, pageMatches...)
+		pageURL = next
+	}
+
+	merged, err := json.Marshal(matches)
+	if err != nil {
+		return "", fmt.Errorf("failed to merge pages: %w", err)
+	}
+	return string(merged), nil
+}
+
+// getPandaScorePage requests a single page of the PandaScore API and returns its body along with the URL of the
+// next page, which is "" on the last one
+func (c *Client) getPandaScorePage(ctx context.Context, pageURL string, apiKey string) ([]byte, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 
-	response, err := client.Do(request)
+	response, err := c.Do(SourcePandaScore, request)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return nil, "", fmt.Errorf("request failed: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode == http.StatusUnauthorized ||
 		response.StatusCode == http.StatusForbidden ||
 		response.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("%w: status %d", ErrUnrecoverable, response.StatusCode)
+		return nil, "", fmt.Errorf("%w: status %d", ErrUnrecoverable, response.StatusCode)
 	}
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", response.StatusCode)
+		return nil, "", fmt.Errorf("unexpected status code: %d", response.StatusCode)
 	}
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(body), nil
+	next, err := nextLink(response.Header.Get("Link"), request.URL)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, next, nil
 }
 
 // filterByTournament removes raw match objects whose tournament_id does not match the given ID.
@@ -190,14 +240,23 @@ func parsePandaScoreMatch(result interface{}) (*MatchNode, error) {
 		}
 	}
 
+	// end_at is null until the match finishes; a malformed value is treated as unknown rather than an error
+	var finishedAt time.Time
+	if isFinished {
+		if endAt, ok := match["end_at"].(string); ok {
+			finishedAt, _ = time.Parse(time.RFC3339, endAt)
+		}
+	}
+
 	return &MatchNode{
-		ID:      id,
-		Team1:   teams[0],
-		Team2:   teams[1],
-		Winner:  winner,
-		Score:   score,
-		Section: section,
-		Status:  status,
+		ID:         id,
+		Team1:      teams[0],
+		Team2:      teams[1],
+		Winner:     winner,
+		Score:      score,
+		Section:    section,
+		Status:     status,
+		FinishedAt: finishedAt,
 	}, nil
 }
 
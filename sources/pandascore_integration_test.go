@@ -3,6 +3,7 @@
 package sources_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -24,7 +25,7 @@ const (
 func TestPandaScore_GetMatches_Ongoing(t *testing.T) {
 	testhelpers.SetState(t, "pandascore", "ongoing")
 
-	raw, err := sources.GetPandaScoreMatches(psURL, psTestKey, psSeriesID, 0)
+	raw, err := sources.GetPandaScoreMatches(context.Background(), psURL, psTestKey, psSeriesID, 0, 0)
 	require.NoError(t, err)
 
 	nodes, err := sources.ParsePandaScoreMatches(raw, 0)
@@ -43,7 +44,7 @@ func TestPandaScore_GetMatches_Ongoing(t *testing.T) {
 func TestPandaScore_GetMatches_NotStarted(t *testing.T) {
 	testhelpers.SetState(t, "pandascore", "not_started")
 
-	raw, err := sources.GetPandaScoreMatches(psURL, psTestKey, psSeriesID, 0)
+	raw, err := sources.GetPandaScoreMatches(context.Background(), psURL, psTestKey, psSeriesID, 0, 0)
 	require.NoError(t, err)
 
 	nodes, err := sources.ParsePandaScoreMatches(raw, 0)
@@ -56,7 +57,7 @@ func TestPandaScore_GetMatches_NotStarted(t *testing.T) {
 func TestPandaScore_GetMatches_WrongKey(t *testing.T) {
 	testhelpers.SetState(t, "pandascore", "ongoing")
 
-	_, err := sources.GetPandaScoreMatches(psURL, "wrong-key", psSeriesID, 0)
+	_, err := sources.GetPandaScoreMatches(context.Background(), psURL, "wrong-key", psSeriesID, 0, 0)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, sources.ErrUnrecoverable),
 		"expected ErrUnrecoverable for bad API key, got: %v", err)
@@ -67,7 +68,7 @@ func TestPandaScore_GetMatches_WrongKey(t *testing.T) {
 func TestPandaScore_GetMatches_NotFound(t *testing.T) {
 	// The test server returns 404 when filter[serie_id] is present but not "99001".
 	// Use any other value to trigger this.
-	_, err := sources.GetPandaScoreMatches(psURL, psTestKey, 12345, 0)
+	_, err := sources.GetPandaScoreMatches(context.Background(), psURL, psTestKey, 12345, 0, 0)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, sources.ErrUnrecoverable),
 		"expected ErrUnrecoverable for unknown series ID, got: %v", err)
@@ -77,13 +78,13 @@ func TestPandaScore_GetMatches_NotFound(t *testing.T) {
 // at least one more finished match than the ongoing state.
 func TestPandaScore_Complete_HasMoreFinished(t *testing.T) {
 	testhelpers.SetState(t, "pandascore", "ongoing")
-	rawOngoing, err := sources.GetPandaScoreMatches(psURL, psTestKey, psSeriesID, 0)
+	rawOngoing, err := sources.GetPandaScoreMatches(context.Background(), psURL, psTestKey, psSeriesID, 0, 0)
 	require.NoError(t, err)
 	ongoingNodes, err := sources.ParsePandaScoreMatches(rawOngoing, 0)
 	require.NoError(t, err)
 
 	testhelpers.SetState(t, "pandascore", "complete")
-	rawComplete, err := sources.GetPandaScoreMatches(psURL, psTestKey, psSeriesID, 0)
+	rawComplete, err := sources.GetPandaScoreMatches(context.Background(), psURL, psTestKey, psSeriesID, 0, 0)
 	require.NoError(t, err)
 	completeNodes, err := sources.ParsePandaScoreMatches(rawComplete, 0)
 	require.NoError(t, err)
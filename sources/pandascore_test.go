@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +61,33 @@ func TestParsePandaScoreMatch_FinishedStatus(t *testing.T) {
 	assert.Equal(t, "2-0", node.Score)
 }
 
+func TestParsePandaScoreMatch_FinishedAt(t *testing.T) {
+	match := map[string]interface{}{
+		"id":     float64(12345),
+		"status": "finished",
+		"end_at": "2025-10-28T10:42:11Z",
+		"winner": map[string]interface{}{"name": "Team A"},
+	}
+
+	node, err := parsePandaScoreMatch(match)
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 28, 10, 42, 11, 0, time.UTC), node.FinishedAt)
+}
+
+func TestParsePandaScoreMatch_FinishedAtUnknown(t *testing.T) {
+	for _, match := range []map[string]interface{}{
+		{"id": float64(1), "status": "running", "end_at": "2025-10-28T10:42:11Z"},
+		{"id": float64(2), "status": "finished", "end_at": nil},
+		{"id": float64(3), "status": "finished", "end_at": "yesterday"},
+	} {
+		node, err := parsePandaScoreMatch(match)
+
+		require.NoError(t, err)
+		assert.True(t, node.FinishedAt.IsZero(), "match %v", match["id"])
+	}
+}
+
 // TestParsePandaScoreMatch_BO1GamesScore verifies that when games[0].results is
 // present it is preferred over match.results so BO1 CS matches show round counts
 // (e.g. 13-5) instead of series wins (1-0).
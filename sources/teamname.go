@@ -15,3 +15,21 @@ func NormalizeTeamName(name string) string {
 	s = strings.ReplaceAll(s, ".", "")
 	return strings.TrimSpace(s)
 }
+
+// TeamAliases maps normalised alias keys (see NormalizeTeamName) to the team name they stand for, e.g.
+// "navi" → "Natus Vincere". A nil TeamAliases has no aliases.
+type TeamAliases map[string]string
+
+// Resolve returns the team name an alias stands for, or name unchanged if it isn't an alias.
+func (a TeamAliases) Resolve(name string) string {
+	if team, ok := a[NormalizeTeamName(name)]; ok {
+		return team
+	}
+	return name
+}
+
+// Key returns the comparison key for a team name with aliases applied, so an alias and the team it stands for
+// (or two source spellings aliased to the same team) share a key.
+func (a TeamAliases) Key(name string) string {
+	return NormalizeTeamName(a.Resolve(name))
+}
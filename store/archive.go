@@ -0,0 +1,228 @@
+/* archive.go
+ * Contains the export and import of a tournament database as a versioned JSON archive: every round's predictions,
+ * match results, match nodes, schedule and leaderboard. Used by scripts/archive to move deployments between
+ * servers, keep the records of dropped databases and seed staging environments.
+ */
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ArchiveVersion is the layout version of the archives ExportArchive writes. Bump it whenever the layout changes;
+// ImportArchive refuses archives of any other version.
+const ArchiveVersion = 1
+
+// Archive is the exported contents of a tournament database
+type Archive struct {
+	Version    int            `json:"version"`
+	Tournament string         `json:"tournament"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Rounds     []ArchiveRound `json:"rounds"`
+}
+
+// ArchiveRound is the exported data of a single round. MatchResults is the stored document, including its `type`
+// discriminator, as relaxed extended JSON; it is empty if the round has no match results.
+type ArchiveRound struct {
+	Round        string                   `json:"round"`
+	Predictions  []models.Prediction      `json:"predictions,omitempty"`
+	MatchResults json.RawMessage          `json:"match_results,omitempty"`
+	MatchFormat  tournament.Kind          `json:"match_format,omitempty"`
+	MatchNodes   []sources.MatchNode      `json:"match_nodes,omitempty"`
+	Schedule     []sources.ScheduledMatch `json:"schedule,omitempty"`
+	Leaderboard  []LeaderboardEntry       `json:"leaderboard,omitempty"`
+}
+
+// Archiver is a store backend that can be exported to and imported from an Archive. It is implemented by the
+// MongoDB and SQL backends; the in-memory backend has nothing worth keeping.
+type Archiver interface {
+	GetDatabase() interface{ Name() string }
+	FetchRounds(ctx context.Context) ([]string, error)
+	// inRound returns a view of the store scoped to the given round
+	inRound(round string) archiveRoundStore
+}
+
+// archiveRoundStore reads and writes the archived data of the round a store is scoped to
+type archiveRoundStore interface {
+	GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error)
+	StoreUserPrediction(ctx context.Context, userID string, prediction models.Prediction) error
+	GetMatchResults(ctx context.Context) (tournament.MatchResult, error)
+	StoreMatchResults(ctx context.Context, matchResult tournament.MatchResult) error
+	FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error)
+	StoreMatchNodes(ctx context.Context, nodes []sources.MatchNode, kind tournament.Kind) error
+	FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error)
+	StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error
+	FetchLeaderboardFromDB(ctx context.Context) ([]LeaderboardEntry, error)
+	StoreLeaderboard(ctx context.Context, leaderboard Leaderboard) error
+}
+
+// inRound returns a copy of the store scoped to the given round
+func (s *Store) inRound(round string) archiveRoundStore {
+	scoped := *s
+	scoped.Round = round
+	return &scoped
+}
+
+// inRound returns a copy of the store scoped to the given round
+func (s *SQLStore) inRound(round string) archiveRoundStore {
+	scoped := *s
+	scoped.Round = round
+	return &scoped
+}
+
+var _ Archiver = (*Store)(nil)
+var _ Archiver = (*SQLStore)(nil)
+
+// ExportArchive reads every round stored in s into an Archive. Data a round doesn't have, such as the leaderboard of
+// a round nobody predicted, is left out of it.
+func ExportArchive(ctx context.Context, s Archiver) (Archive, error) {
+	rounds, err := s.FetchRounds(ctx)
+	if err != nil {
+		return Archive{}, err
+	}
+	archive := Archive{
+		Version:    ArchiveVersion,
+		Tournament: s.GetDatabase().Name(),
+		ExportedAt: time.Now().UTC(),
+		Rounds:     make([]ArchiveRound, 0, len(rounds)),
+	}
+	for _, round := range rounds {
+		exported, err := exportRound(ctx, s.inRound(round), round)
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to export round %s: %w", round, err)
+		}
+		archive.Rounds = append(archive.Rounds, exported)
+	}
+	return archive, nil
+}
+
+// exportRound reads the archived data of a single round
+func exportRound(ctx context.Context, s archiveRoundStore, round string) (ArchiveRound, error) {
+	exported := ArchiveRound{Round: round}
+
+	predictions, err := s.GetAllUserPredictions(ctx)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return exported, err
+	}
+	for _, p := range predictions {
+		p.ID = primitive.NilObjectID
+		exported.Predictions = append(exported.Predictions, p)
+	}
+
+	results, err := s.GetMatchResults(ctx)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return exported, err
+	default:
+		doc, err := encodeMatchResult(results)
+		if err != nil {
+			return exported, err
+		}
+		if exported.MatchResults, err = bson.MarshalExtJSON(doc, false, false); err != nil {
+			return exported, fmt.Errorf("failed to marshal match results: %w", err)
+		}
+	}
+
+	if exported.MatchNodes, exported.MatchFormat, err = s.FetchMatchNodesFromDb(ctx); err != nil && !errors.Is(err, ErrNotFound) {
+		return exported, err
+	}
+	if exported.Schedule, err = s.FetchMatchSchedule(ctx); err != nil && !errors.Is(err, ErrNotFound) {
+		return exported, err
+	}
+	if exported.Leaderboard, err = s.FetchLeaderboardFromDB(ctx); err != nil && !errors.Is(err, ErrNotFound) {
+		return exported, err
+	}
+	return exported, nil
+}
+
+// ImportArchive writes every round of archive into s. It refuses to import into rounds that already have data
+// unless overwrite is set, in which case the archived data replaces what is stored; predictions of users missing from
+// the archive are kept.
+func ImportArchive(ctx context.Context, s Archiver, archive Archive, overwrite bool) error {
+	if archive.Version != ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d, expected %d", archive.Version, ArchiveVersion)
+	}
+	if !overwrite {
+		existing, err := s.FetchRounds(ctx)
+		if err != nil {
+			return err
+		}
+		var conflicts []string
+		for _, r := range archive.Rounds {
+			for _, round := range existing {
+				if r.Round == round {
+					conflicts = append(conflicts, round)
+				}
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("rounds already have data: %s", strings.Join(conflicts, ", "))
+		}
+	}
+
+	for _, r := range archive.Rounds {
+		if err := importRound(ctx, s.inRound(r.Round), r, archive.ExportedAt); err != nil {
+			return fmt.Errorf("failed to import round %s: %w", r.Round, err)
+		}
+	}
+	return nil
+}
+
+// importRound writes the archived data of a single round. The leaderboard is stamped with the time of the export.
+func importRound(ctx context.Context, s archiveRoundStore, r ArchiveRound, exportedAt time.Time) error {
+	if r.Round == "" {
+		return fmt.Errorf("round has no name")
+	}
+	for _, p := range r.Predictions {
+		p.ID = primitive.NilObjectID
+		p.Round = r.Round
+		if err := s.StoreUserPrediction(ctx, p.UserID, p); err != nil {
+			return err
+		}
+	}
+
+	if len(r.MatchResults) > 0 {
+		var raw bson.M
+		if err := bson.UnmarshalExtJSON(r.MatchResults, false, &raw); err != nil {
+			return fmt.Errorf("failed to decode match results: %w", err)
+		}
+		results, err := decodeMatchResult(raw)
+		if err != nil {
+			return err
+		}
+		if err := s.StoreMatchResults(ctx, results); err != nil {
+			return err
+		}
+	}
+	if len(r.MatchNodes) > 0 || r.MatchFormat != "" {
+		if err := s.StoreMatchNodes(ctx, r.MatchNodes, r.MatchFormat); err != nil {
+			return err
+		}
+	}
+	if len(r.Schedule) > 0 {
+		if err := s.StoreMatchSchedule(ctx, r.Schedule); err != nil {
+			return err
+		}
+	}
+	if len(r.Leaderboard) > 0 {
+		leaderboard := Leaderboard{Round: r.Round, UpdatedAt: exportedAt, Entries: r.Leaderboard}
+		if err := s.StoreLeaderboard(ctx, leaderboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/* archive_test.go
+ * Contains unit tests for archive.go, run against in-memory SQLite databases
+ */
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedArchiveStore stores a finished swiss stage_1 and a stage_2 that only has predictions
+func seedArchiveStore(t *testing.T, s *SQLStore) {
+	t.Helper()
+	ctx := context.Background()
+	stage1 := s.inRound("stage_1")
+	require.NoError(t, stage1.StoreUserPrediction(ctx, "u1", models.Prediction{
+		UserID: "u1", Username: "Alice", Format: "swiss", Round: "stage_1",
+		Win: []string{"Alpha", "Bravo"}, Lose: []string{"Charlie", "Delta"},
+	}))
+	require.NoError(t, stage1.StoreMatchResults(ctx, tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "3-0", "Charlie": "0-3"}}))
+	require.NoError(t, stage1.StoreMatchNodes(ctx, []sources.MatchNode{{ID: "m1", Team1: "Alpha", Team2: "Charlie", Winner: "Alpha", Score: "2-0"}}, tournament.Swiss))
+	require.NoError(t, stage1.StoreMatchSchedule(ctx, []sources.ScheduledMatch{{Team1: "Alpha", Team2: "Charlie", EpochTime: 1700000000, BestOf: "3", Finished: true}}))
+	require.NoError(t, stage1.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", Entries: []LeaderboardEntry{
+		{UserID: "u1", Username: "Alice", Score: 6, ScoreResult: models.ScoreResult{Successes: 2}},
+	}}))
+
+	require.NoError(t, s.inRound("stage_2").StoreUserPrediction(ctx, "u2", models.Prediction{
+		UserID: "u2", Username: "Bob", Format: "single-elimination", Round: "stage_2",
+		Progression: map[string]models.TeamProgress{"Alpha": {Round: "final", Status: "advanced"}},
+	}))
+}
+
+// region Export tests
+
+func TestExportArchive(t *testing.T) {
+	s := newSQLiteTestStore(t, nil)
+	seedArchiveStore(t, s)
+
+	archive, err := ExportArchive(context.Background(), s)
+	require.NoError(t, err)
+
+	assert.Equal(t, ArchiveVersion, archive.Version)
+	assert.Equal(t, "test_db", archive.Tournament)
+	require.Len(t, archive.Rounds, 2)
+
+	stage1 := archive.Rounds[0]
+	assert.Equal(t, "stage_1", stage1.Round)
+	require.Len(t, stage1.Predictions, 1)
+	assert.True(t, stage1.Predictions[0].ID.IsZero(), "database IDs are not exported")
+	assert.Equal(t, tournament.Swiss, stage1.MatchFormat)
+	assert.Len(t, stage1.MatchNodes, 1)
+	assert.Len(t, stage1.Schedule, 1)
+	assert.Len(t, stage1.Leaderboard, 1)
+
+	var results map[string]any
+	require.NoError(t, json.Unmarshal(stage1.MatchResults, &results))
+	assert.Equal(t, "swiss", results["type"])
+
+	stage2 := archive.Rounds[1]
+	assert.Len(t, stage2.Predictions, 1)
+	assert.Empty(t, stage2.MatchResults)
+	assert.Empty(t, stage2.Leaderboard)
+}
+
+// endregion
+
+// region Import tests
+
+func TestImportArchive_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := newSQLiteTestStore(t, nil)
+	seedArchiveStore(t, src)
+	archive, err := ExportArchive(ctx, src)
+	require.NoError(t, err)
+
+	// Go through JSON, as the archive script does
+	data, err := json.Marshal(archive)
+	require.NoError(t, err)
+	var decoded Archive
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	dst := newSQLiteTestStore(t, nil)
+	require.NoError(t, ImportArchive(ctx, dst, decoded, false))
+
+	reexported, err := ExportArchive(ctx, dst)
+	require.NoError(t, err)
+	require.Len(t, reexported.Rounds, 2)
+	// Map keys in match results aren't written in a fixed order
+	assert.JSONEq(t, string(archive.Rounds[0].MatchResults), string(reexported.Rounds[0].MatchResults))
+	archive.Rounds[0].MatchResults, reexported.Rounds[0].MatchResults = nil, nil
+	assert.Equal(t, archive.Rounds, reexported.Rounds)
+
+	results, err := dst.GetMatchResultsForRound(ctx, "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, "3-0", results.(tournament.SwissResult).Teams["Alpha"])
+}
+
+func TestImportArchive_RefusesExistingRounds(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteTestStore(t, nil)
+	seedArchiveStore(t, s)
+	archive := Archive{Version: ArchiveVersion, ExportedAt: time.Now(), Rounds: []ArchiveRound{
+		{Round: "stage_1", Schedule: []sources.ScheduledMatch{{Team1: "Echo", Team2: "Foxtrot"}}},
+	}}
+
+	err := ImportArchive(ctx, s, archive, false)
+	assert.ErrorContains(t, err, "rounds already have data: stage_1")
+
+	require.NoError(t, ImportArchive(ctx, s, archive, true))
+	schedule, err := s.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Echo", schedule[0].Team1)
+}
+
+func TestImportArchive_UnsupportedVersion(t *testing.T) {
+	s := newSQLiteTestStore(t, nil)
+
+	err := ImportArchive(context.Background(), s, Archive{Version: ArchiveVersion + 1}, false)
+	assert.ErrorContains(t, err, "unsupported archive version")
+}
+
+// endregion
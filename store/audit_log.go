@@ -0,0 +1,62 @@
+/* audit_log.go
+ * Contains the methods for interacting with the audit_log collection, which records every use of the admin
+ * commands. Entries apply across rounds.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pickems-bot/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditEntry records a single admin command invocation
+type AuditEntry struct {
+	GuildID   string    `bson:"guild_id"` // empty for entries that apply to every guild
+	UserID    string    `bson:"userid"`
+	Username  string    `bson:"username"`
+	Command   string    `bson:"command"`
+	Args      string    `bson:"args,omitempty"`
+	Round     string    `bson:"round"`
+	Result    string    `bson:"result"` // "ok", "denied" or the error message
+	Timestamp time.Time `bson:"timestamp"`
+}
+
+// StoreAuditEntry appends an entry to the audit log.
+func (s *Store) StoreAuditEntry(ctx context.Context, entry AuditEntry) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	doc, err := versioned(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Collections.AuditLog.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("failed to store audit entry: %w", err)
+	}
+	return nil
+}
+
+// FetchAuditEntries returns up to limit of the most recent audit log entries for a guild, newest first. Entries
+// not tied to a guild (e.g. overrides cleared by the data source) are included for every guild.
+func (s *Store) FetchAuditEntries(ctx context.Context, guildID string, limit int) ([]AuditEntry, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := s.Collections.AuditLog.Find(ctx, bson.M{"guild_id": bson.M{"$in": bson.A{guildID, ""}}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching audit log from db: %w", err)
+	}
+	var results []AuditEntry
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of audit entries: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,90 @@
+/* audit_log_test.go
+ * Contains unit tests for audit_log.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region StoreAuditEntry tests
+
+func TestStoreAuditEntry_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("inserts the entry", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{AuditLog: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := store.StoreAuditEntry(context.Background(), AuditEntry{GuildID: "guild1", UserID: "user1", Command: "refresh", Result: "ok", Timestamp: time.Now()})
+		assert.NoError(t, err)
+	})
+}
+
+func TestStoreAuditEntry_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when insert fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{AuditLog: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.StoreAuditEntry(context.Background(), AuditEntry{GuildID: "guild1", Command: "refresh"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store audit entry")
+	})
+}
+
+// endregion
+
+// region FetchAuditEntries tests
+
+func TestFetchAuditEntries_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns the guild's entries", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{AuditLog: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.audit_log", mtest.FirstBatch,
+			bson.D{
+				{Key: "guild_id", Value: "guild1"},
+				{Key: "userid", Value: "user1"},
+				{Key: "username", Value: "admin"},
+				{Key: "command", Value: "deletepick"},
+				{Key: "args", Value: "<@user2>"},
+				{Key: "result", Value: "ok"},
+				{Key: "timestamp", Value: time.Now()},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.audit_log", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		entries, err := store.FetchAuditEntries(context.Background(), "guild1", 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "deletepick", entries[0].Command)
+		assert.Equal(t, "<@user2>", entries[0].Args)
+		assert.Equal(t, "ok", entries[0].Result)
+	})
+}
+
+func TestFetchAuditEntries_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{AuditLog: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchAuditEntries(context.Background(), "guild1", 10)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching audit log")
+	})
+}
+
+// endregion
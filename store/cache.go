@@ -0,0 +1,237 @@
+/* cache.go
+ * Contains CachedStore, a read-through cache in front of any store backend. Command bursts on match days read the
+ * same VRS rankings, match results and schedule over and over; CachedStore serves them from memory for a TTL and
+ * drops them as soon as the update pipelines write new data.
+ */
+
+package store
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+)
+
+// CacheGroup names a set of cached reads that expire and are invalidated together
+type CacheGroup string
+
+// Cached read groups
+const (
+	// CacheVRS holds the VRS rankings, which are synced into the database outside the bot
+	CacheVRS CacheGroup = "vrs"
+	// CacheResults holds the current round's match results, valid teams and match nodes
+	CacheResults CacheGroup = "results"
+	// CacheSchedule holds the current round's match schedule
+	CacheSchedule CacheGroup = "schedule"
+)
+
+// CacheTTLs is how long each group of reads is cached for. A zero TTL disables caching of that group.
+type CacheTTLs struct {
+	VRS      time.Duration
+	Results  time.Duration
+	Schedule time.Duration
+}
+
+// cacheKey identifies a single cached read. name is the label its hits and misses are counted under, and arg tells
+// apart the reads of a method that takes one, such as the standings date of a VRS snapshot.
+type cacheKey struct {
+	group CacheGroup
+	name  string
+	arg   string
+}
+
+// cacheEntry is a cached read and when it expires
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// CachedStore wraps a store backend, caching the reads commands make most often. Every other method, and every write,
+// goes straight to the wrapped store; writes that change cached data invalidate it. Cached values are shared between
+// callers, which must not modify them. Create one with NewCachedStore.
+type CachedStore struct {
+	Interface
+	ttls map[CacheGroup]time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	// generations counts the invalidations of each group, so a read that started before an invalidation doesn't
+	// cache what it loaded afterwards
+	generations map[CacheGroup]uint64
+}
+
+var _ Interface = (*CachedStore)(nil)
+
+// NewCachedStore returns a CachedStore in front of inner
+func NewCachedStore(inner Interface, ttls CacheTTLs) *CachedStore {
+	return &CachedStore{
+		Interface: inner,
+		ttls: map[CacheGroup]time.Duration{
+			CacheVRS:      ttls.VRS,
+			CacheResults:  ttls.Results,
+			CacheSchedule: ttls.Schedule,
+		},
+		now:         time.Now,
+		entries:     make(map[cacheKey]cacheEntry),
+		generations: make(map[CacheGroup]uint64),
+	}
+}
+
+// Invalidate drops the cached reads of the given groups, or of every group if none are given
+func (c *CachedStore) Invalidate(groups ...CacheGroup) {
+	if len(groups) == 0 {
+		groups = []CacheGroup{CacheVRS, CacheResults, CacheSchedule}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, group := range groups {
+		c.generations[group]++
+		for key := range c.entries {
+			if key.group == group {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// cached returns the cached value of key, or loads and caches it. Errors are never cached.
+func cached[T any](ctx context.Context, c *CachedStore, key cacheKey, load func(ctx context.Context) (T, error)) (T, error) {
+	ttl := c.ttls[key.group]
+	if ttl <= 0 {
+		return load(ctx)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generations[key.group]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		metrics.CacheHitsTotal.WithLabelValues(key.name).Inc()
+		return entry.value.(T), nil
+	}
+	metrics.CacheMissesTotal.WithLabelValues(key.name).Inc()
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.mu.Lock()
+	if c.generations[key.group] == generation {
+		c.entries[key] = cacheEntry{value: value, expires: c.now().Add(ttl)}
+	}
+	c.mu.Unlock()
+	return value, nil
+}
+
+// region Cached reads
+
+// FetchVrsDataFromDB returns the latest VRS rankings, cached for the VRS TTL
+func (c *CachedStore) FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error) {
+	entries, err := cached(ctx, c, cacheKey{CacheVRS, "vrs", ""}, c.Interface.FetchVrsDataFromDB)
+	return slices.Clone(entries), err
+}
+
+// FetchVrsStandingsDates returns the standings dates of the VRS snapshots, cached for the VRS TTL
+func (c *CachedStore) FetchVrsStandingsDates(ctx context.Context) ([]string, error) {
+	dates, err := cached(ctx, c, cacheKey{CacheVRS, "vrs_dates", ""}, c.Interface.FetchVrsStandingsDates)
+	return slices.Clone(dates), err
+}
+
+// FetchVrsSnapshot returns the VRS rankings as of the given standings date, cached for the VRS TTL
+func (c *CachedStore) FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]VRSEntry, error) {
+	entries, err := cached(ctx, c, cacheKey{CacheVRS, "vrs_snapshot", standingsDate}, func(ctx context.Context) ([]VRSEntry, error) {
+		return c.Interface.FetchVrsSnapshot(ctx, standingsDate)
+	})
+	return slices.Clone(entries), err
+}
+
+// GetMatchResults returns the current round's match results, cached for the results TTL
+func (c *CachedStore) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
+	return cached(ctx, c, cacheKey{CacheResults, "match_results", ""}, c.Interface.GetMatchResults)
+}
+
+// validTeams is a cached GetValidTeams
+type validTeams struct {
+	teams []string
+	kind  tournament.Kind
+}
+
+// GetValidTeams returns the current round's valid teams and format, cached for the results TTL
+func (c *CachedStore) GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error) {
+	v, err := cached(ctx, c, cacheKey{CacheResults, "valid_teams", ""}, func(ctx context.Context) (validTeams, error) {
+		teams, kind, err := c.Interface.GetValidTeams(ctx)
+		return validTeams{teams, kind}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return slices.Clone(v.teams), v.kind, nil
+}
+
+// matchNodes is a cached FetchMatchNodesFromDb
+type matchNodes struct {
+	nodes []sources.MatchNode
+	kind  tournament.Kind
+}
+
+// FetchMatchNodesFromDb returns the current round's raw match nodes and format, cached for the results TTL
+func (c *CachedStore) FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
+	v, err := cached(ctx, c, cacheKey{CacheResults, "match_nodes", ""}, func(ctx context.Context) (matchNodes, error) {
+		nodes, kind, err := c.Interface.FetchMatchNodesFromDb(ctx)
+		return matchNodes{nodes, kind}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return slices.Clone(v.nodes), v.kind, nil
+}
+
+// FetchMatchSchedule returns the current round's schedule, cached for the schedule TTL
+func (c *CachedStore) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	matches, err := cached(ctx, c, cacheKey{CacheSchedule, "schedule", ""}, c.Interface.FetchMatchSchedule)
+	return slices.Clone(matches), err
+}
+
+// EnsureScheduledMatches checks the current round has a schedule. A successful check is cached for the schedule TTL.
+func (c *CachedStore) EnsureScheduledMatches(ctx context.Context) error {
+	_, err := cached(ctx, c, cacheKey{CacheSchedule, "schedule_check", ""}, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.Interface.EnsureScheduledMatches(ctx)
+	})
+	return err
+}
+
+// endregion
+
+// region Invalidating writes
+
+// StoreMatchSchedule stores the current round's schedule and drops the cached schedule
+func (c *CachedStore) StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error {
+	defer c.Invalidate(CacheSchedule)
+	return c.Interface.StoreMatchSchedule(ctx, matches)
+}
+
+// FetchAndStoreSchedule refreshes the current round's schedule from the data source and drops the cached schedule
+func (c *CachedStore) FetchAndStoreSchedule(ctx context.Context) error {
+	defer c.Invalidate(CacheSchedule)
+	return c.Interface.FetchAndStoreSchedule(ctx)
+}
+
+// FetchAndUpdateMatchResults refreshes the current round's results from the data source and drops the cached results
+func (c *CachedStore) FetchAndUpdateMatchResults(ctx context.Context) error {
+	defer c.Invalidate(CacheResults)
+	return c.Interface.FetchAndUpdateMatchResults(ctx)
+}
+
+// RebuildMatchResults rebuilds the current round's results from its stored match nodes and drops the cached results
+func (c *CachedStore) RebuildMatchResults(ctx context.Context) error {
+	defer c.Invalidate(CacheResults)
+	return c.Interface.RebuildMatchResults(ctx)
+}
+
+// endregion
@@ -0,0 +1,216 @@
+/* cache_test.go
+ * Contains unit tests for cache.go
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore is a MemoryStore that counts the reads CachedStore caches
+type countingStore struct {
+	*MemoryStore
+	vrsReads      int
+	resultReads   int
+	scheduleReads int
+	scheduleErr   error
+}
+
+func (s *countingStore) FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error) {
+	s.vrsReads++
+	return s.MemoryStore.FetchVrsDataFromDB(ctx)
+}
+
+func (s *countingStore) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
+	s.resultReads++
+	return s.MemoryStore.GetMatchResults(ctx)
+}
+
+func (s *countingStore) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	s.scheduleReads++
+	if s.scheduleErr != nil {
+		return nil, s.scheduleErr
+	}
+	return s.MemoryStore.FetchMatchSchedule(ctx)
+}
+
+// newCachedTestStore returns a CachedStore in front of a countingStore holding a schedule, results and VRS data,
+// with a clock the test controls
+func newCachedTestStore(t *testing.T, fetcher DataSourceFetcher) (*CachedStore, *countingStore, *time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	inner := &countingStore{MemoryStore: NewMemoryStore("test_db", "stage_1", fetcher, nil)}
+	require.NoError(t, inner.StoreMatchSchedule(ctx, []sources.ScheduledMatch{{Team1: "Alpha", Team2: "Bravo"}}))
+	require.NoError(t, inner.StoreMatchResults(ctx, tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "1-0"}}))
+	require.NoError(t, inner.SetVRSData(ctx, []VRSEntry{{Standing: 1, TeamName: "Alpha"}}))
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCachedStore(inner, CacheTTLs{VRS: time.Hour, Results: time.Minute, Schedule: time.Minute})
+	c.now = func() time.Time { return now }
+	return c, inner, &now
+}
+
+// region Cached read tests
+
+func TestCachedStore_ServesRepeatReadsFromCache(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+	hits := testutil.ToFloat64(metrics.CacheHitsTotal.WithLabelValues("vrs"))
+	misses := testutil.ToFloat64(metrics.CacheMissesTotal.WithLabelValues("vrs"))
+
+	for range 3 {
+		entries, err := c.FetchVrsDataFromDB(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Alpha", entries[0].TeamName)
+	}
+
+	assert.Equal(t, 1, inner.vrsReads)
+	assert.Equal(t, hits+2, testutil.ToFloat64(metrics.CacheHitsTotal.WithLabelValues("vrs")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(metrics.CacheMissesTotal.WithLabelValues("vrs")))
+}
+
+func TestCachedStore_ExpiresAfterTTL(t *testing.T) {
+	c, inner, now := newCachedTestStore(t, nil)
+	ctx := context.Background()
+
+	_, err := c.GetMatchResults(ctx)
+	require.NoError(t, err)
+	*now = now.Add(59 * time.Second)
+	_, err = c.GetMatchResults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.resultReads)
+
+	*now = now.Add(time.Second)
+	_, err = c.GetMatchResults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.resultReads)
+}
+
+func TestCachedStore_DoesNotCacheErrors(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+	inner.scheduleErr = errors.New("db down")
+
+	_, err := c.FetchMatchSchedule(ctx)
+	assert.EqualError(t, err, "db down")
+
+	inner.scheduleErr = nil
+	schedule, err := c.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	assert.Len(t, schedule, 1)
+	assert.Equal(t, 2, inner.scheduleReads)
+}
+
+func TestCachedStore_CachesSnapshotsPerDate(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+	require.NoError(t, inner.SetVRSData(ctx, []VRSEntry{{Standing: 4, TeamName: "Alpha", StandingsDate: "2026_05_04"}}))
+
+	older, err := c.FetchVrsSnapshot(ctx, "2026_05_04")
+	require.NoError(t, err)
+	latest, err := c.FetchVrsSnapshot(ctx, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, older[0].Standing)
+	assert.Equal(t, 1, latest[0].Standing)
+}
+
+func TestCachedStore_ZeroTTLDisablesCaching(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	c.ttls[CacheVRS] = 0
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := c.FetchVrsDataFromDB(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, inner.vrsReads)
+}
+
+func TestCachedStore_ReturnsCopiesOfSlices(t *testing.T) {
+	c, _, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+
+	schedule, err := c.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	schedule[0].Team1 = "Changed"
+
+	schedule, err = c.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Alpha", schedule[0].Team1)
+}
+
+// endregion
+
+// region Invalidation tests
+
+func TestCachedStore_Invalidate(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+	_, _ = c.FetchVrsDataFromDB(ctx)
+	_, _ = c.GetMatchResults(ctx)
+
+	c.Invalidate(CacheResults)
+	_, _ = c.FetchVrsDataFromDB(ctx)
+	_, _ = c.GetMatchResults(ctx)
+	assert.Equal(t, 1, inner.vrsReads, "other groups stay cached")
+	assert.Equal(t, 2, inner.resultReads)
+
+	c.Invalidate()
+	_, _ = c.FetchVrsDataFromDB(ctx)
+	assert.Equal(t, 2, inner.vrsReads)
+}
+
+func TestCachedStore_WritesInvalidate(t *testing.T) {
+	fetcher := stubFetcher{
+		result:   tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "2-0"}},
+		schedule: []sources.ScheduledMatch{{Team1: "Charlie", Team2: "Delta"}},
+	}
+	c, _, _ := newCachedTestStore(t, fetcher)
+	ctx := context.Background()
+
+	_, err := c.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.EnsureScheduledMatches(ctx))
+	require.NoError(t, c.FetchAndStoreSchedule(ctx))
+	schedule, err := c.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Charlie", schedule[0].Team1)
+
+	_, err = c.GetMatchResults(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.FetchAndUpdateMatchResults(ctx))
+	results, err := c.GetMatchResults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2-0", results.(tournament.SwissResult).Teams["Alpha"])
+}
+
+func TestCachedStore_InvalidationDuringLoadIsNotCached(t *testing.T) {
+	c, inner, _ := newCachedTestStore(t, nil)
+	ctx := context.Background()
+
+	_, err := cached(ctx, c, cacheKey{CacheVRS, "vrs", ""}, func(ctx context.Context) ([]VRSEntry, error) {
+		entries, err := inner.FetchVrsDataFromDB(ctx)
+		c.Invalidate(CacheVRS) // new rankings were synced while this read was in flight
+		return entries, err
+	})
+	require.NoError(t, err)
+
+	_, err = c.FetchVrsDataFromDB(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.vrsReads)
+}
+
+// endregion
@@ -1,21 +1,24 @@
 package store
 
 import (
+	"context"
 	"pickems-bot/sources"
 	"pickems-bot/tournament"
 )
 
 // DataSourceFetcher interface — two implementations: Liquipedia, PandaScore
 type DataSourceFetcher interface {
-	FetchMatchData(round string) (tournament.MatchResult, []sources.MatchNode, error)
-	FetchSchedule() ([]sources.ScheduledMatch, error)
+	FetchMatchData(ctx context.Context, round string) (tournament.MatchResult, []sources.MatchNode, error)
+	FetchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error)
 }
 
 // LiquipediaFetcher implements the DataSourceFetcher interface
 type LiquipediaFetcher struct {
-	apiURL string
-	apiKey string
-	page   string
+	apiURL   string
+	apiKey   string
+	page     string
+	maxPages int
+	limiter  sources.Limiter
 }
 
 // PandaScoreFetcher implements the DataSourceFetcher interface
@@ -24,6 +27,8 @@ type PandaScoreFetcher struct {
 	apiKey       string
 	seriesID     int
 	tournamentID int
+	maxPages     int
+	limiter      sources.Limiter
 }
 
 // NewLiquipediaFetcher creates a LiquipediaFetcher with the given API URL, API key and page path.
@@ -31,9 +36,21 @@ func NewLiquipediaFetcher(apiURL, apiKey, page string) LiquipediaFetcher {
 	return LiquipediaFetcher{apiURL: apiURL, apiKey: apiKey, page: page}
 }
 
+// WithMaxPages returns a copy of f that fetches at most maxPages pages of matches. Zero uses sources.DefaultMaxPages.
+func (f LiquipediaFetcher) WithMaxPages(maxPages int) LiquipediaFetcher {
+	f.maxPages = maxPages
+	return f
+}
+
+// WithLimiter returns a copy of f that takes a token from limiter before every request, pages and retries included
+func (f LiquipediaFetcher) WithLimiter(limiter sources.Limiter) LiquipediaFetcher {
+	f.limiter = limiter
+	return f
+}
+
 // FetchMatchData fetches match data using liquipedia as a datasource, filtered to the current round of a tournament
-func (f LiquipediaFetcher) FetchMatchData(round string) (tournament.MatchResult, []sources.MatchNode, error) {
-	matchData, err := sources.GetLiquipediaMatchDataByPage(f.apiURL, f.apiKey, f.page)
+func (f LiquipediaFetcher) FetchMatchData(ctx context.Context, round string) (tournament.MatchResult, []sources.MatchNode, error) {
+	matchData, err := sources.DefaultClient.WithLimiter(f.limiter).GetLiquipediaMatchDataByPage(ctx, f.apiURL, f.apiKey, f.page, f.maxPages)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,8 +82,8 @@ func (f LiquipediaFetcher) FetchMatchData(round string) (tournament.MatchResult,
 
 // FetchSchedule fetches the scheduled matches for the tournament using Liquipedia as a datasource.
 // Doesn't do any filtering, callers are responsible for filtering by round / time / etc
-func (f LiquipediaFetcher) FetchSchedule() ([]sources.ScheduledMatch, error) {
-	matchData, err := sources.GetLiquipediaMatchDataByPage(f.apiURL, f.apiKey, f.page)
+func (f LiquipediaFetcher) FetchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	matchData, err := sources.DefaultClient.WithLimiter(f.limiter).GetLiquipediaMatchDataByPage(ctx, f.apiURL, f.apiKey, f.page, f.maxPages)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +95,21 @@ func NewPandaScoreFetcher(apiURL string, apiKey string, seriesID int, tournament
 	return PandaScoreFetcher{apiURL: apiURL, apiKey: apiKey, seriesID: seriesID, tournamentID: tournamentID}
 }
 
+// WithMaxPages returns a copy of f that fetches at most maxPages pages of matches. Zero uses sources.DefaultMaxPages.
+func (f PandaScoreFetcher) WithMaxPages(maxPages int) PandaScoreFetcher {
+	f.maxPages = maxPages
+	return f
+}
+
+// WithLimiter returns a copy of f that takes a token from limiter before every request, pages and retries included
+func (f PandaScoreFetcher) WithLimiter(limiter sources.Limiter) PandaScoreFetcher {
+	f.limiter = limiter
+	return f
+}
+
 // FetchMatchData fetches match data using PandaSource as a datasource, filtered to the current round of a tournament
-func (f PandaScoreFetcher) FetchMatchData(round string) (tournament.MatchResult, []sources.MatchNode, error) {
-	matchData, err := sources.GetPandaScoreMatches(f.apiURL, f.apiKey, f.seriesID, f.tournamentID)
+func (f PandaScoreFetcher) FetchMatchData(ctx context.Context, round string) (tournament.MatchResult, []sources.MatchNode, error) {
+	matchData, err := sources.DefaultClient.WithLimiter(f.limiter).GetPandaScoreMatches(ctx, f.apiURL, f.apiKey, f.seriesID, f.tournamentID, f.maxPages)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -112,8 +141,8 @@ func (f PandaScoreFetcher) FetchMatchData(round string) (tournament.MatchResult,
 
 // FetchSchedule fetches the scheduled matches for the tournament using PandaScore as a datasource.
 // Doesn't do any filtering, callers are responsible for filtering by round / time / etc
-func (f PandaScoreFetcher) FetchSchedule() ([]sources.ScheduledMatch, error) {
-	matchData, err := sources.GetPandaScoreMatches(f.apiURL, f.apiKey, f.seriesID, f.tournamentID)
+func (f PandaScoreFetcher) FetchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	matchData, err := sources.DefaultClient.WithLimiter(f.limiter).GetPandaScoreMatches(ctx, f.apiURL, f.apiKey, f.seriesID, f.tournamentID, f.maxPages)
 	if err != nil {
 		return nil, err
 	}
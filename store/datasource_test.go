@@ -5,12 +5,18 @@
 package store
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"pickems-bot/sources"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 // Minimal Liquipedia JSON for a single Swiss-format match node.
@@ -91,7 +97,7 @@ func TestLiquipediaFetcher_FetchMatchData_Success(t *testing.T) {
 	defer srv.Close()
 
 	f := NewLiquipediaFetcher(srv.URL, "test-key", "Test/Page")
-	result, nodes, err := f.FetchMatchData("Round 1")
+	result, nodes, err := f.FetchMatchData(context.Background(), "Round 1")
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.NotEmpty(t, nodes)
@@ -104,8 +110,79 @@ func TestLiquipediaFetcher_FetchMatchData_HTTPError(t *testing.T) {
 	defer srv.Close()
 
 	f := NewLiquipediaFetcher(srv.URL, "key", "Test/Page")
-	_, _, err := f.FetchMatchData("Round 1")
+	_, _, err := f.FetchMatchData(context.Background(), "Round 1")
+	require.Error(t, err)
+}
+
+func TestLiquipediaFetcher_FetchMatchData_MaxPages(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		results := strings.TrimSuffix(strings.Repeat(`{"match2id": "M"},`, 100), ",")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result": [` + results + `]}`))
+	}))
+	defer srv.Close()
+
+	f := NewLiquipediaFetcher(srv.URL, "key", "Test/Page").WithMaxPages(1)
+	_, _, err := f.FetchMatchData(context.Background(), "Round 1")
+	assert.ErrorIs(t, err, sources.ErrTooManyPages)
+	// The allowed page, then the probe that finds more
+	assert.Equal(t, 2, calls)
+}
+
+func TestLiquipediaFetcher_FetchMatchData_Limiter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		results := strings.TrimSuffix(strings.Repeat(`{"match2id": "M"},`, 100), ",")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result": [` + results + `]}`))
+	}))
+	defer srv.Close()
+
+	// One token and no refill: the second page finds the limiter empty
+	f := NewLiquipediaFetcher(srv.URL, "key", "Test/Page").WithLimiter(rate.NewLimiter(rate.Every(time.Hour), 1))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, _, err := f.FetchMatchData(ctx, "Round 1")
+	assert.ErrorIs(t, err, sources.ErrRateLimited)
+	assert.Equal(t, 1, calls)
+}
+
+func TestLiquipediaFetcher_FetchMatchData_Cancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f := NewLiquipediaFetcher(srv.URL, "key", "Test/Page")
+	_, _, err := f.FetchMatchData(ctx, "Round 1")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFetchAndUpdateMatchResults_FetchTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	s := &Store{
+		Round:        "Round 1",
+		Fetcher:      NewLiquipediaFetcher(srv.URL, "key", "Test/Page"),
+		FetchTimeout: 20 * time.Millisecond,
+	}
+	err := s.FetchAndUpdateMatchResults(context.Background())
 	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
 func TestLiquipediaFetcher_FetchSchedule_Success(t *testing.T) {
@@ -116,7 +193,7 @@ func TestLiquipediaFetcher_FetchSchedule_Success(t *testing.T) {
 	defer srv.Close()
 
 	f := NewLiquipediaFetcher(srv.URL, "test-key", "Test/Page")
-	matches, err := f.FetchSchedule()
+	matches, err := f.FetchSchedule(context.Background())
 	require.NoError(t, err)
 	require.Len(t, matches, 1)
 	assert.Equal(t, "Alpha", matches[0].Team1)
@@ -130,7 +207,7 @@ func TestLiquipediaFetcher_FetchSchedule_HTTPError(t *testing.T) {
 	defer srv.Close()
 
 	f := NewLiquipediaFetcher(srv.URL, "key", "Test/Page")
-	_, err := f.FetchSchedule()
+	_, err := f.FetchSchedule(context.Background())
 	require.Error(t, err)
 }
 
@@ -146,7 +223,7 @@ func TestPandaScoreFetcher_FetchMatchData_Success(t *testing.T) {
 	defer srv.Close()
 
 	f := NewPandaScoreFetcher(srv.URL, "test-key", 99001, 0)
-	result, nodes, err := f.FetchMatchData("Round 1")
+	result, nodes, err := f.FetchMatchData(context.Background(), "Round 1")
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.NotEmpty(t, nodes)
@@ -159,7 +236,7 @@ func TestPandaScoreFetcher_FetchMatchData_Unauthorized(t *testing.T) {
 	defer srv.Close()
 
 	f := NewPandaScoreFetcher(srv.URL, "key", 99001, 0)
-	_, _, err := f.FetchMatchData("Round 1")
+	_, _, err := f.FetchMatchData(context.Background(), "Round 1")
 	require.Error(t, err)
 }
 
@@ -171,7 +248,7 @@ func TestPandaScoreFetcher_FetchSchedule_Success(t *testing.T) {
 	defer srv.Close()
 
 	f := NewPandaScoreFetcher(srv.URL, "test-key", 99001, 0)
-	matches, err := f.FetchSchedule()
+	matches, err := f.FetchSchedule(context.Background())
 	require.NoError(t, err)
 	require.Len(t, matches, 1)
 	assert.Equal(t, "Alpha", matches[0].Team1)
@@ -184,7 +261,7 @@ func TestPandaScoreFetcher_FetchSchedule_Forbidden(t *testing.T) {
 	defer srv.Close()
 
 	f := NewPandaScoreFetcher(srv.URL, "key", 99001, 0)
-	_, err := f.FetchSchedule()
+	_, err := f.FetchSchedule(context.Background())
 	require.Error(t, err)
 }
 
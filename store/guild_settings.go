@@ -0,0 +1,78 @@
+/* guild_settings.go
+ * Contains the methods for interacting with the guild_settings collection, which holds each Discord server's
+ * configuration (command prefix, channels, admin role, locale and timezone). Settings apply across rounds.
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pickems-bot/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GuildSettings holds a single guild's configuration. Empty fields mean "use the default".
+type GuildSettings struct {
+	GuildID             string `bson:"guild_id"`
+	Prefix              string `bson:"prefix,omitempty"`
+	AnnouncementChannel string `bson:"announcement_channel,omitempty"`
+	ReminderChannel     string `bson:"reminder_channel,omitempty"`
+	AdminRoleID         string `bson:"admin_role_id,omitempty"`
+	Locale              string `bson:"locale,omitempty"`
+	Timezone            string `bson:"timezone,omitempty"`
+}
+
+// GetGuildSettings returns the settings stored for a guild. Returns ErrNotFound when the guild
+// has never been configured.
+func (s *Store) GetGuildSettings(ctx context.Context, guildID string) (GuildSettings, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	var result GuildSettings
+	err := s.Collections.GuildSettings.FindOne(ctx, bson.M{"guild_id": guildID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return GuildSettings{}, err
+		}
+		return GuildSettings{}, fmt.Errorf("error fetching guild settings from db: %w", err)
+	}
+	return result, nil
+}
+
+// FetchAllGuildSettings returns the settings of every configured guild.
+func (s *Store) FetchAllGuildSettings(ctx context.Context) ([]GuildSettings, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.GuildSettings.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching guild settings from db: %w", err)
+	}
+	var results []GuildSettings
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of guild settings: %w", err)
+	}
+	return results, nil
+}
+
+// StoreGuildSettings stores a guild's settings, replacing any previous settings for the guild.
+func (s *Store) StoreGuildSettings(ctx context.Context, settings GuildSettings) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"guild_id": settings.GuildID}
+	doc, err := versioned(settings)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Collections.GuildSettings.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to store guild settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/* guild_settings_test.go
+ * Contains unit tests for guild_settings.go
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region GetGuildSettings tests
+
+func TestGetGuildSettings_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns the guild's settings", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.guild_settings", mtest.FirstBatch,
+			bson.D{
+				{Key: "guild_id", Value: "guild1"},
+				{Key: "prefix", Value: "!"},
+				{Key: "timezone", Value: "Europe/Warsaw"},
+			},
+		))
+
+		settings, err := store.GetGuildSettings(context.Background(), "guild1")
+		require.NoError(t, err)
+		assert.Equal(t, "!", settings.Prefix)
+		assert.Equal(t, "Europe/Warsaw", settings.Timezone)
+	})
+}
+
+func TestGetGuildSettings_NotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns ErrNoDocuments for an unconfigured guild", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.guild_settings", mtest.FirstBatch))
+
+		_, err := store.GetGuildSettings(context.Background(), "guild1")
+		assert.True(t, errors.Is(err, mongo.ErrNoDocuments))
+	})
+}
+
+func TestGetGuildSettings_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("wraps other errors", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.GetGuildSettings(context.Background(), "guild1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching guild settings")
+	})
+}
+
+// endregion
+
+// region FetchAllGuildSettings tests
+
+func TestFetchAllGuildSettings_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns every guild", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.guild_settings", mtest.FirstBatch,
+			bson.D{{Key: "guild_id", Value: "guild1"}, {Key: "announcement_channel", Value: "chan1"}},
+			bson.D{{Key: "guild_id", Value: "guild2"}},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.guild_settings", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		settings, err := store.FetchAllGuildSettings(context.Background())
+		require.NoError(t, err)
+		require.Len(t, settings, 2)
+		assert.Equal(t, "chan1", settings[0].AnnouncementChannel)
+	})
+}
+
+func TestFetchAllGuildSettings_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchAllGuildSettings(context.Background())
+		require.Error(t, err)
+	})
+}
+
+// endregion
+
+// region StoreGuildSettings tests
+
+func TestStoreGuildSettings_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts the settings", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		assert.NoError(t, store.StoreGuildSettings(context.Background(), GuildSettings{GuildID: "guild1", Prefix: "!"}))
+	})
+}
+
+func TestStoreGuildSettings_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when replace fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{GuildSettings: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.StoreGuildSettings(context.Background(), GuildSettings{GuildID: "guild1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store guild settings")
+	})
+}
+
+// endregion
@@ -0,0 +1,60 @@
+/* indexes.go
+ * Contains EnsureIndexes, which creates the unique indexes the store's upserts rely on to keep one document per
+ * user and round.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// uniqueIndex is a unique index on a collection
+type uniqueIndex struct {
+	coll *mongo.Collection
+	keys bson.D
+}
+
+// EnsureIndexes creates the unique indexes on predictions (userid, round) and on the per-round match results, match
+// nodes, schedule and leaderboard documents, and on the version of applied migrations. Creating an index that already
+// exists is a no-op, so this is safe to call on every startup. Run it after Migrate, which removes the duplicate
+// predictions older versions could write; if duplicates still prevent an index from being built, an error is returned
+// so startup fails instead of running without the index.
+func (s *Store) EnsureIndexes(ctx context.Context) error {
+	indexes := []uniqueIndex{
+		{s.Collections.Predictions, bson.D{{Key: "userid", Value: 1}, {Key: "round", Value: 1}}},
+		{s.Collections.MatchResults, bson.D{{Key: "round", Value: 1}}},
+		{s.Collections.MatchNodes, bson.D{{Key: "round", Value: 1}}},
+		{s.Collections.MatchSchedule, bson.D{{Key: "round", Value: 1}}},
+		{s.Collections.Leaderboard, bson.D{{Key: "round", Value: 1}}},
+		{s.Collections.Migrations, bson.D{{Key: "version", Value: 1}}},
+	}
+
+	for _, index := range indexes {
+		if err := s.ensureUniqueIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureUniqueIndex creates a single unique index
+func (s *Store) ensureUniqueIndex(ctx context.Context, index uniqueIndex) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	model := mongo.IndexModel{Keys: index.keys, Options: options.Index().SetUnique(true)}
+	_, err := index.coll.Indexes().CreateOne(ctx, model)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("duplicate documents in %s prevent creating a unique index on %v; remove them and restart: %w",
+			index.coll.Name(), index.keys, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create unique index on %s: %w", index.coll.Name(), err)
+	}
+	return nil
+}
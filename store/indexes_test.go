@@ -0,0 +1,70 @@
+/* indexes_test.go
+ * Contains unit tests for indexes.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region EnsureIndexes tests
+
+func TestEnsureIndexes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	newStore := func(mt *mtest.T) *Store {
+		return &Store{Round: "test_round", Collections: Collections{
+			Predictions:   mt.Coll,
+			MatchResults:  mt.Coll,
+			MatchNodes:    mt.Coll,
+			MatchSchedule: mt.Coll,
+			Leaderboard:   mt.Coll,
+			Migrations:    mt.Coll,
+		}}
+	}
+
+	mt.Run("creates each unique index", func(mt *mtest.T) {
+		for range 6 {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+		}
+
+		require.NoError(t, newStore(mt).EnsureIndexes(context.Background()))
+
+		first := mt.GetStartedEvent()
+		require.Equal(t, "createIndexes", first.CommandName)
+		index := first.Command.Lookup("indexes").Array().Index(0).Value().Document()
+		assert.True(t, index.Lookup("unique").Boolean())
+		assert.Equal(t, "userid_1_round_1", index.Lookup("name").StringValue())
+
+		count := 1
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			assert.Equal(t, "createIndexes", event.CommandName)
+			count++
+		}
+		assert.Equal(t, 6, count)
+	})
+
+	mt.Run("fails when existing duplicates prevent an index", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11000, Message: "E11000 duplicate key error"}))
+
+		err := newStore(mt).EnsureIndexes(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate documents in")
+	})
+
+	mt.Run("returns other errors", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Message: "unauthorized"}))
+
+		err := newStore(mt).EnsureIndexes(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create unique index")
+	})
+}
+
+// endregion
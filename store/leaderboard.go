@@ -38,13 +38,20 @@ type Leaderboard struct {
 }
 
 // FetchLeaderboardFromDB returns the leaderboard entries for the current round.
-func (s *Store) FetchLeaderboardFromDB() ([]LeaderboardEntry, error) {
+func (s *Store) FetchLeaderboardFromDB(ctx context.Context) ([]LeaderboardEntry, error) {
+	return s.FetchLeaderboardForRound(ctx, s.Round)
+}
+
+// FetchLeaderboardForRound returns the leaderboard entries of the given round.
+func (s *Store) FetchLeaderboardForRound(ctx context.Context, round string) ([]LeaderboardEntry, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	s.Collections.Leaderboard.Name()
 	opts := options.FindOne()
 
 	var res Leaderboard
-	err := s.Collections.Leaderboard.FindOne(context.TODO(), bson.D{{Key: "round", Value: s.Round}}, opts).Decode(&res)
+	err := s.Collections.Leaderboard.FindOne(ctx, bson.D{{Key: "round", Value: round}}, opts).Decode(&res)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, err
@@ -55,37 +62,21 @@ func (s *Store) FetchLeaderboardFromDB() ([]LeaderboardEntry, error) {
 	return res.Entries, nil
 }
 
-// StoreLeaderboard persists the given leaderboard, inserting a new document or replacing an existing one for the current round.
-func (s *Store) StoreLeaderboard(leaderboard Leaderboard) error {
+// StoreLeaderboard persists the given leaderboard for the current round in a single upsert, replacing any existing one.
+func (s *Store) StoreLeaderboard(ctx context.Context, leaderboard Leaderboard) error {
 	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	if reflect.DeepEqual(leaderboard, Leaderboard{}) {
 		return fmt.Errorf("leaderboard is empty")
 	}
 
-	// Attempt to find an existing document
-	var res Leaderboard
-	err := s.Collections.Leaderboard.FindOne(context.TODO(), bson.D{{Key: "round", Value: s.Round}}).Decode(&res)
-	notFound := err == mongo.ErrNoDocuments
-
-	if err != nil && !notFound {
-		return fmt.Errorf("lookup for existing record failed: %w", err)
-	}
-
-	// Perform insert or update
 	s.logger().Info("updating leaderboard in db", "round", s.Round)
-	if notFound {
-		_, err := s.Collections.Leaderboard.InsertOne(context.TODO(), leaderboard)
-		if err != nil {
-			return fmt.Errorf("leaderboard insert failed: %w", err)
-		}
-		return nil
-	}
-
-	filter := bson.M{"round": s.Round}
-	update := bson.D{{Key: "$set", Value: leaderboard}}
-
-	_, err = s.Collections.Leaderboard.UpdateOne(context.TODO(), filter, update)
+	doc, err := versioned(leaderboard)
 	if err != nil {
+		return err
+	}
+	if err := upsertOne(ctx, s.Collections.Leaderboard, bson.M{"round": s.Round}, bson.D{{Key: "$set", Value: doc}}); err != nil {
 		return fmt.Errorf("leaderboard update failed: %w", err)
 	}
 	return nil
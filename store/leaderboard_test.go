@@ -6,6 +6,7 @@
 package store
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func TestFetchLeaderboardFromDB_Success(t *testing.T) {
 		})
 		mt.AddMockResponses(leaderboardDoc)
 
-		entries, err := store.FetchLeaderboardFromDB()
+		entries, err := store.FetchLeaderboardFromDB(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, entries)
 		assert.Len(t, entries, 2)
@@ -86,7 +87,7 @@ func TestFetchLeaderboardFromDB_NotFound(t *testing.T) {
 		// Mock FindOne returning no documents
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.leaderboard", mtest.FirstBatch))
 
-		entries, err := store.FetchLeaderboardFromDB()
+		entries, err := store.FetchLeaderboardFromDB(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, entries)
@@ -112,7 +113,7 @@ func TestFetchLeaderboardFromDB_DatabaseError(t *testing.T) {
 			Message: "database error",
 		}))
 
-		entries, err := store.FetchLeaderboardFromDB()
+		entries, err := store.FetchLeaderboardFromDB(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to fetch leaderboard from database")
 		assert.Nil(t, entries)
@@ -140,7 +141,7 @@ func TestFetchLeaderboardFromDB_EmptyEntries(t *testing.T) {
 		})
 		mt.AddMockResponses(leaderboardDoc)
 
-		entries, err := store.FetchLeaderboardFromDB()
+		entries, err := store.FetchLeaderboardFromDB(context.Background())
 		require.NoError(t, err)
 		assert.Empty(t, entries)
 	})
@@ -163,9 +164,7 @@ func TestStoreLeaderboard_InsertNew(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new leaderboard)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.leaderboard", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		leaderboard := Leaderboard{
@@ -185,7 +184,7 @@ func TestStoreLeaderboard_InsertNew(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
 		assert.NoError(t, err)
 	})
 }
@@ -203,17 +202,8 @@ func TestStoreLeaderboard_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document - need cursor response with getMore
-		first := mtest.CreateCursorResponse(1, "test.leaderboard", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		getMore := mtest.CreateCursorResponse(0, "test.leaderboard", mtest.NextBatch)
-		// Mock UpdateOne success
-		updateSuccess := bson.D{
-			{Key: "ok", Value: 1},
-			{Key: "nModified", Value: 1},
-		}
-		mt.AddMockResponses(first, getMore, updateSuccess)
+		// Mock the upsert matching an existing document
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		leaderboard := Leaderboard{
 			Round:     "test_round",
@@ -227,7 +217,7 @@ func TestStoreLeaderboard_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
 		assert.NoError(t, err)
 	})
 }
@@ -245,16 +235,16 @@ func TestStoreLeaderboard_EmptyLeaderboard(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(Leaderboard{})
+		err := store.StoreLeaderboard(context.Background(), Leaderboard{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "leaderboard is empty")
 	})
 }
 
-func TestStoreLeaderboard_FindOneError(t *testing.T) {
+func TestStoreLeaderboard_UpsertError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when FindOne fails", func(mt *mtest.T) {
+	mt.Run("returns error when the upsert fails", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -264,9 +254,9 @@ func TestStoreLeaderboard_FindOneError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning error
+		// Mock the upsert failing
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
-			Code:    11000,
+			Code:    2,
 			Message: "database error",
 		}))
 
@@ -278,16 +268,16 @@ func TestStoreLeaderboard_FindOneError(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "lookup for existing record failed")
+		assert.Contains(t, err.Error(), "leaderboard update failed")
 	})
 }
 
-func TestStoreLeaderboard_InsertError(t *testing.T) {
+func TestStoreLeaderboard_DuplicateKeyTwice(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when insert fails", func(mt *mtest.T) {
+	mt.Run("returns error when the retried upsert also hits a duplicate key", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -297,9 +287,12 @@ func TestStoreLeaderboard_InsertError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.leaderboard", mtest.FirstBatch))
-		// Mock InsertOne error
+		// Mock both upsert attempts racing with a concurrent insert
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "insert failed",
+		}))
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
@@ -314,16 +307,16 @@ func TestStoreLeaderboard_InsertError(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "leaderboard insert failed")
+		assert.Contains(t, err.Error(), "leaderboard update failed")
 	})
 }
 
-func TestStoreLeaderboard_UpdateError(t *testing.T) {
+func TestStoreLeaderboard_DuplicateKeyRetried(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when update fails", func(mt *mtest.T) {
+	mt.Run("retries the upsert after a duplicate key error", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -333,17 +326,13 @@ func TestStoreLeaderboard_UpdateError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document
-		existingDoc := mtest.CreateCursorResponse(1, "test.leaderboard", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		mt.AddMockResponses(existingDoc)
-		// Mock UpdateOne error
+		// Mock the first upsert losing the race to a concurrent insert, then the retry updating
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
-			Message: "update failed",
+			Message: "duplicate key",
 		}))
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		leaderboard := Leaderboard{
 			Round:     "test_round",
@@ -353,9 +342,8 @@ func TestStoreLeaderboard_UpdateError(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "leaderboard update failed")
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
+		assert.NoError(t, err)
 	})
 }
 
@@ -372,9 +360,7 @@ func TestStoreLeaderboard_MultipleEntries(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new leaderboard)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.leaderboard", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		leaderboard := Leaderboard{
@@ -389,7 +375,7 @@ func TestStoreLeaderboard_MultipleEntries(t *testing.T) {
 			},
 		}
 
-		err := store.StoreLeaderboard(leaderboard)
+		err := store.StoreLeaderboard(context.Background(), leaderboard)
 		assert.NoError(t, err)
 	})
 }
@@ -0,0 +1,70 @@
+/* match_day.go
+ * Contains the methods for interacting with the match_day_messages collection, which tracks the live-updating
+ * "match day" message the bot maintains in each guild.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"pickems-bot/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MatchDayMessage identifies the match day message posted in a guild for a round
+type MatchDayMessage struct {
+	GuildID   string `bson:"guild_id"`
+	ChannelID string `bson:"channel_id"`
+	MessageID string `bson:"message_id"`
+	Round     string `bson:"round"`
+	Day       string `bson:"day"` // date the message covers in the guild's timezone, formatted 2006-01-02
+}
+
+// FetchMatchDayMessages returns the match day message of every guild for the current round.
+func (s *Store) FetchMatchDayMessages(ctx context.Context) ([]MatchDayMessage, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.MatchDayMessages.Find(ctx, bson.M{"round": s.Round})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching match day messages from db: %w", err)
+	}
+	var results []MatchDayMessage
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of match day messages: %w", err)
+	}
+	return results, nil
+}
+
+// StoreMatchDayMessage stores a guild's match day message, replacing any previous message for the same
+// guild and round.
+func (s *Store) StoreMatchDayMessage(ctx context.Context, message MatchDayMessage) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"guild_id": message.GuildID, "round": message.Round}
+	doc, err := versioned(message)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Collections.MatchDayMessages.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to store match day message: %w", err)
+	}
+	return nil
+}
+
+// DeleteMatchDayMessage stops tracking a guild's match day message for the current round.
+func (s *Store) DeleteMatchDayMessage(ctx context.Context, guildID string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"guild_id": guildID, "round": s.Round}
+	if _, err := s.Collections.MatchDayMessages.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete match day message: %w", err)
+	}
+	return nil
+}
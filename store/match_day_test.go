@@ -0,0 +1,115 @@
+/* match_day_test.go
+ * Contains unit tests for match_day.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region FetchMatchDayMessages tests
+
+func TestFetchMatchDayMessages_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns messages for the current round", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.match_day_messages", mtest.FirstBatch,
+			bson.D{
+				{Key: "guild_id", Value: "guild1"},
+				{Key: "channel_id", Value: "channel1"},
+				{Key: "message_id", Value: "message1"},
+				{Key: "round", Value: "test_round"},
+				{Key: "day", Value: "2026-06-01"},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.match_day_messages", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		messages, err := store.FetchMatchDayMessages(context.Background())
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "guild1", messages[0].GuildID)
+		assert.Equal(t, "message1", messages[0].MessageID)
+		assert.Equal(t, "2026-06-01", messages[0].Day)
+	})
+}
+
+func TestFetchMatchDayMessages_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchMatchDayMessages(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching match day messages")
+	})
+}
+
+// endregion
+
+// region StoreMatchDayMessage tests
+
+func TestStoreMatchDayMessage_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts the message", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := store.StoreMatchDayMessage(context.Background(), MatchDayMessage{GuildID: "guild1", ChannelID: "channel1", MessageID: "message1", Round: "test_round"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestStoreMatchDayMessage_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when replace fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.StoreMatchDayMessage(context.Background(), MatchDayMessage{GuildID: "guild1", Round: "test_round"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store match day message")
+	})
+}
+
+// endregion
+
+// region DeleteMatchDayMessage tests
+
+func TestDeleteMatchDayMessage_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the message", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+
+		assert.NoError(t, store.DeleteMatchDayMessage(context.Background(), "guild1"))
+	})
+}
+
+func TestDeleteMatchDayMessage_Error(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when delete fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchDayMessages: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.DeleteMatchDayMessage(context.Background(), "guild1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete match day message")
+	})
+}
+
+// endregion
@@ -17,44 +17,39 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// StoreMatchNodes persists the raw []MatchNode slice for a round so it can be
-// used later for results display and bracket rendering.
-func (s *Store) StoreMatchNodes(nodes []sources.MatchNode, kind tournament.Kind) error {
-	filter := bson.M{"round": s.Round}
-
+// StoreMatchNodes stores the raw match nodes of the current round in a single upsert, replacing any previously
+// stored nodes.
+func (s *Store) StoreMatchNodes(ctx context.Context, nodes []sources.MatchNode, kind tournament.Kind) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	doc := bson.M{
-		"round":  s.Round,
-		"format": string(kind),
-		"nodes":  nodes,
-	}
-
-	var existing bson.M
-	err := s.Collections.MatchNodes.FindOne(context.TODO(), filter).Decode(&existing)
-	notFound := errors.Is(err, mongo.ErrNoDocuments)
-	if err != nil && !notFound {
-		return fmt.Errorf("lookup for existing match nodes record failed: %w", err)
-	}
-
-	if notFound {
-		if _, err := s.Collections.MatchNodes.InsertOne(context.TODO(), doc); err != nil {
-			return fmt.Errorf("failed to insert match nodes: %w", err)
-		}
-		return nil
+		"round":            s.Round,
+		"format":           string(kind),
+		"nodes":            nodes,
+		schemaVersionField: SchemaVersion,
 	}
-	if _, err := s.Collections.MatchNodes.UpdateOne(context.TODO(), filter, bson.M{"$set": doc}); err != nil {
-		return fmt.Errorf("failed to update match nodes: %w", err)
+	if err := upsertOne(ctx, s.Collections.MatchNodes, bson.M{"round": s.Round}, bson.M{"$set": doc}); err != nil {
+		return fmt.Errorf("failed to store match nodes: %w", err)
 	}
 	return nil
 }
 
 // FetchMatchNodesFromDb retrieves the raw []MatchNode slice for the configured round, and the tournament.Kind of the round
-// tournament.Kind could potentially be an empty string if legacy data is fetched, so callers should check that
-func (s *Store) FetchMatchNodesFromDb() ([]sources.MatchNode, tournament.Kind, error) {
+// tournament.Kind could potentially be an empty string for legacy data Migrate couldn't infer a format for, so callers
+// should check that
+func (s *Store) FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
+	return s.FetchMatchNodesForRound(ctx, s.Round)
+}
+
+// FetchMatchNodesForRound retrieves the raw match nodes and tournament.Kind of the given round.
+func (s *Store) FetchMatchNodesForRound(ctx context.Context, round string) ([]sources.MatchNode, tournament.Kind, error) {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	var doc struct {
 		Nodes  []sources.MatchNode `bson:"nodes"`
 		Format tournament.Kind     `bson:"format"`
 	}
-	err := s.Collections.MatchNodes.FindOne(context.TODO(), bson.M{"round": s.Round}).Decode(&doc)
+	err := s.Collections.MatchNodes.FindOne(ctx, bson.M{"round": round}).Decode(&doc)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, "", err
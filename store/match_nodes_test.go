@@ -33,13 +33,12 @@ func TestStoreMatchNodes_Insert(t *testing.T) {
 			},
 		}
 
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_nodes", mtest.FirstBatch))
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		nodes := []sources.MatchNode{
 			{ID: "abc_0001", Team1: "Team A", Team2: "Team B", Winner: "Team A", Score: "2-0"},
 		}
-		err := store.StoreMatchNodes(nodes, tournament.Swiss)
+		err := store.StoreMatchNodes(context.Background(), nodes, tournament.Swiss)
 		assert.NoError(t, err)
 	})
 }
@@ -55,22 +54,18 @@ func TestStoreMatchNodes_Update(t *testing.T) {
 			},
 		}
 
-		first := mtest.CreateCursorResponse(1, "test.match_nodes", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		getMore := mtest.CreateCursorResponse(0, "test.match_nodes", mtest.NextBatch)
-		updateSuccess := bson.D{{Key: "ok", Value: 1}, {Key: "nModified", Value: 1}}
-		mt.AddMockResponses(first, getMore, updateSuccess)
+		// Mock the upsert matching an existing document
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		nodes := []sources.MatchNode{
 			{ID: "abc_0001", Team1: "Team A", Team2: "Team B", Winner: "Team B", Score: "1-2"},
 		}
-		err := store.StoreMatchNodes(nodes, tournament.Swiss)
+		err := store.StoreMatchNodes(context.Background(), nodes, tournament.Swiss)
 		assert.NoError(t, err)
 	})
 }
 
-func TestStoreMatchNodes_FindOneError(t *testing.T) {
+func TestStoreMatchNodes_UpsertError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run("returns error when lookup fails", func(mt *mtest.T) {
@@ -82,13 +77,13 @@ func TestStoreMatchNodes_FindOneError(t *testing.T) {
 		}
 
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
-			Code:    11000,
+			Code:    2,
 			Message: "database error",
 		}))
 
-		err := store.StoreMatchNodes([]sources.MatchNode{}, tournament.Swiss)
+		err := store.StoreMatchNodes(context.Background(), []sources.MatchNode{}, tournament.Swiss)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "lookup for existing match nodes record failed")
+		assert.Contains(t, err.Error(), "failed to store match nodes")
 	})
 }
 
@@ -123,7 +118,7 @@ func TestFetchMatchNodesFromDb_Success(t *testing.T) {
 		})
 		mt.AddMockResponses(doc)
 
-		nodes, _, err := store.FetchMatchNodesFromDb()
+		nodes, _, err := store.FetchMatchNodesFromDb(context.Background())
 		require.NoError(t, err)
 		require.Len(t, nodes, 1)
 		assert.Equal(t, "abc_0001", nodes[0].ID)
@@ -148,7 +143,7 @@ func TestFetchMatchNodesFromDb_NotFound(t *testing.T) {
 
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_nodes", mtest.FirstBatch))
 
-		nodes, _, err := store.FetchMatchNodesFromDb()
+		nodes, _, err := store.FetchMatchNodesFromDb(context.Background())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, nodes)
 	})
@@ -183,10 +178,10 @@ func TestMatchNodes_WithRealDB(t *testing.T) {
 	}
 
 	t.Run("store and fetch match nodes", func(t *testing.T) {
-		err := store.StoreMatchNodes(nodes, tournament.Swiss)
+		err := store.StoreMatchNodes(context.Background(), nodes, tournament.Swiss)
 		require.NoError(t, err)
 
-		fetched, _, err := store.FetchMatchNodesFromDb()
+		fetched, _, err := store.FetchMatchNodesFromDb(context.Background())
 		require.NoError(t, err)
 		require.Len(t, fetched, 2)
 
@@ -206,10 +201,10 @@ func TestMatchNodes_WithRealDB(t *testing.T) {
 			{ID: "test_0001", Team1: "Team A", Team2: "Team B", Winner: "Team B", Score: "1-2"},
 		}
 
-		err := store.StoreMatchNodes(updated, tournament.Swiss)
+		err := store.StoreMatchNodes(context.Background(), updated, tournament.Swiss)
 		require.NoError(t, err)
 
-		fetched, _, err := store.FetchMatchNodesFromDb()
+		fetched, _, err := store.FetchMatchNodesFromDb(context.Background())
 		require.NoError(t, err)
 		require.Len(t, fetched, 1)
 		assert.Equal(t, "Team B", fetched[0].Winner)
@@ -9,6 +9,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"pickems-bot/metrics"
 	"pickems-bot/tournament"
@@ -19,22 +21,34 @@ import (
 )
 
 // FetchMatchResultsFromDb retrieves the match result document for the current round and decodes it into the appropriate MatchResult implementation.
-func (s *Store) FetchMatchResultsFromDb() (tournament.MatchResult, error) {
+func (s *Store) FetchMatchResultsFromDb(ctx context.Context) (tournament.MatchResult, error) {
+	return s.fetchMatchResults(ctx, s.Round)
+}
+
+// fetchMatchResults retrieves the match result document for the given round and decodes it.
+func (s *Store) fetchMatchResults(ctx context.Context, round string) (tournament.MatchResult, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	s.Collections.MatchResults.Name()
 	opts := options.FindOne()
 
 	// MatchResult is an interface, which can't be decoded by MongoDB's driver. Instead need to get raw and convert to interface later
 	var raw bson.M
 
-	err := s.Collections.MatchResults.FindOne(context.TODO(), bson.D{{Key: "round", Value: s.Round}}, opts).Decode(&raw)
+	err := s.Collections.MatchResults.FindOne(ctx, bson.D{{Key: "round", Value: round}}, opts).Decode(&raw)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("error fetching results from db: %w", err)
 	}
+	return decodeMatchResult(raw)
+}
 
+// decodeMatchResult decodes a stored match result document into the MatchResult implementation named by its
+// `type` discriminator.
+func decodeMatchResult(raw bson.M) (tournament.MatchResult, error) {
 	// Determine which type of MatchResult we fetched
 	resultType, ok := raw["type"].(string)
 	if !ok {
@@ -56,68 +70,95 @@ func (s *Store) FetchMatchResultsFromDb() (tournament.MatchResult, error) {
 // GetMatchResults returns the raw tournament.MatchResult from the DB. Format-aware
 // conversion (record → MatchResult) is the caller's responsibility — it lives
 // in the tournament package, which can't be imported here without a cycle.
-func (s *Store) GetMatchResults() (tournament.MatchResult, error) {
-	rec, err := s.FetchMatchResultsFromDb()
+func (s *Store) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
+	return s.GetMatchResultsForRound(ctx, s.Round)
+}
+
+// GetMatchResultsForRound returns the match results of the given round.
+func (s *Store) GetMatchResultsForRound(ctx context.Context, round string) (tournament.MatchResult, error) {
+	rec, err := s.fetchMatchResults(ctx, round)
 	if err != nil {
 		return nil, fmt.Errorf("error occured getting match results from db: %w", err)
 	}
 	return rec, nil
 }
 
-// StoreMatchResults persists a MatchResult to the DB. The record is BSON-marshalled
+// StoreMatchResults persists a MatchResult to the DB in a single upsert. The record is BSON-marshalled
 // using its struct tags and tagged with a top-level "type" discriminator so
 // FetchMatchResultsFromDb can decode into the right concrete type later.
 // Format-agnostic: works for any registered format without code changes here.
-func (s *Store) StoreMatchResults(matchResult tournament.MatchResult) error {
+func (s *Store) StoreMatchResults(ctx context.Context, matchResult tournament.MatchResult) error {
 	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
-	var raw bson.M
-	err := s.Collections.MatchResults.FindOne(context.TODO(), bson.M{"round": s.Round}).Decode(&raw)
-	notFound := err == mongo.ErrNoDocuments
-	if err != nil && !notFound {
-		return fmt.Errorf("lookup for existing record failed: %w", err)
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	doc, err := encodeMatchResult(matchResult)
+	if err != nil {
+		return err
+	}
+	doc[schemaVersionField] = SchemaVersion
+
+	if err := upsertOne(ctx, s.Collections.MatchResults, bson.M{"round": s.Round}, bson.M{"$set": doc}); err != nil {
+		return fmt.Errorf("failed to store match result: %w", err)
 	}
+	return nil
+}
 
-	// Marshal the record with its BSON tags, then inject the type discriminator.
+// encodeMatchResult marshals a MatchResult with its BSON tags and tags it with a top-level "type" discriminator.
+func encodeMatchResult(matchResult tournament.MatchResult) (bson.M, error) {
 	doc := bson.M{"type": string(matchResult.GetType())}
 	bsonBytes, err := bson.Marshal(matchResult)
 	if err != nil {
-		return fmt.Errorf("failed to marshal match result: %w", err)
+		return nil, fmt.Errorf("failed to marshal match result: %w", err)
 	}
 	var recordMap bson.M
 	if err := bson.Unmarshal(bsonBytes, &recordMap); err != nil {
-		return fmt.Errorf("failed to unmarshal match result into bson.M: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal match result into bson.M: %w", err)
 	}
 	for k, v := range recordMap {
 		doc[k] = v
 	}
+	return doc, nil
+}
 
-	filter := bson.M{"round": s.Round}
-	if notFound {
-		if _, err := s.Collections.MatchResults.InsertOne(context.TODO(), doc); err != nil {
-			return fmt.Errorf("failed to insert new match result: %w", err)
-		}
-		return nil
+// FetchAndUpdateMatchResults fetches match data from the configured data source and stores the result in the db,
+// with any result overrides applied
+func (s *Store) FetchAndUpdateMatchResults(ctx context.Context) error {
+	return fetchAndUpdateMatchResults(ctx, s, s.Fetcher, s.FetchTimeout, s.logger())
+}
+
+// fetchAndUpdateMatchResults implements FetchAndUpdateMatchResults for any backend
+func fetchAndUpdateMatchResults(ctx context.Context, s pipelineStore, fetcher DataSourceFetcher, fetchTimeout time.Duration, log *slog.Logger) error {
+	if fetcher == nil {
+		return errNoFetcher
 	}
-	if _, err := s.Collections.MatchResults.UpdateOne(context.TODO(), filter, bson.M{"$set": doc}); err != nil {
-		return fmt.Errorf("failed to update match result: %w", err)
+	fetchCtx, cancel := withTimeout(ctx, fetchTimeout)
+	result, nodes, err := fetcher.FetchMatchData(fetchCtx, s.GetRound())
+	cancel()
+	if err != nil {
+		return err
 	}
-	return nil
-}
 
-// FetchAndUpdateMatchResults fetches match data from the configured data source and stores the result in the db
-func (s *Store) FetchAndUpdateMatchResults() error {
-	result, nodes, err := s.Fetcher.FetchMatchData(s.Round)
+	// Pin any manual overrides the source still disagrees with before the results are stored. The raw nodes
+	// are stored as-is so an override can be dropped later without refetching.
+	overrides, err := s.FetchResultOverrides(ctx)
 	if err != nil {
 		return err
 	}
-	if err := s.StoreMatchResults(result); err != nil {
+	if overrides = reconcileOverrides(ctx, s, log, nodes, overrides); len(overrides) > 0 {
+		result, err = buildMatchResult(ApplyResultOverrides(nodes, overrides), result.GetType(), s.GetRound())
+		if err != nil {
+			return fmt.Errorf("failed to apply result overrides: %w", err)
+		}
+	}
+
+	if err := s.StoreMatchResults(ctx, result); err != nil {
 		return err
 	}
 	if result.GetType() == tournament.Swiss {
 		nodes = tournament.NormalizeSwissSections(nodes)
 	}
-	if err := s.StoreMatchNodes(nodes, result.GetType()); err != nil {
-		s.logger().Warn("failed to store match nodes", "error", fmt.Errorf("FetchAndUpdateMatchResults: %w", err))
+	if err := s.StoreMatchNodes(ctx, nodes, result.GetType()); err != nil {
+		log.Warn("failed to store match nodes", "error", fmt.Errorf("FetchAndUpdateMatchResults: %w", err))
 	}
 	return nil
 }
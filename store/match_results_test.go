@@ -7,6 +7,7 @@
 package store
 
 import (
+	"context"
 	"testing"
 
 	"pickems-bot/models"
@@ -47,7 +48,7 @@ func TestFetchMatchResultsFromDb_Swiss(t *testing.T) {
 		})
 		mt.AddMockResponses(swissDoc)
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, result)
 
@@ -91,7 +92,7 @@ func TestFetchMatchResultsFromDb_Elimination(t *testing.T) {
 		})
 		mt.AddMockResponses(elimDoc)
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, result)
 
@@ -120,7 +121,7 @@ func TestFetchMatchResultsFromDb_NotFound(t *testing.T) {
 		// Mock FindOne returning no documents
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch))
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, result)
@@ -146,7 +147,7 @@ func TestFetchMatchResultsFromDb_DatabaseError(t *testing.T) {
 			Message: "database error",
 		}))
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error fetching results from db")
 		assert.Nil(t, result)
@@ -173,7 +174,7 @@ func TestFetchMatchResultsFromDb_MissingType(t *testing.T) {
 		})
 		mt.AddMockResponses(docWithoutType)
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing or invalid `type` field")
 		assert.Nil(t, result)
@@ -200,7 +201,7 @@ func TestFetchMatchResultsFromDb_UnknownType(t *testing.T) {
 		})
 		mt.AddMockResponses(unknownTypeDoc)
 
-		result, err := store.FetchMatchResultsFromDb()
+		result, err := store.FetchMatchResultsFromDb(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unknown match result type: unknown-format")
 		assert.Nil(t, result)
@@ -235,7 +236,7 @@ func TestGetMatchResults_SwissSuccess(t *testing.T) {
 		})
 		mt.AddMockResponses(swissDoc)
 
-		result, err := store.GetMatchResults()
+		result, err := store.GetMatchResults(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.Equal(t, tournament.Swiss, result.GetType())
@@ -272,7 +273,7 @@ func TestGetMatchResults_EliminationSuccess(t *testing.T) {
 		})
 		mt.AddMockResponses(elimDoc)
 
-		result, err := store.GetMatchResults()
+		result, err := store.GetMatchResults(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.Equal(t, tournament.SingleElim, result.GetType())
@@ -302,7 +303,7 @@ func TestGetMatchResults_FetchError(t *testing.T) {
 			Message: "fetch failed",
 		}))
 
-		result, err := store.GetMatchResults()
+		result, err := store.GetMatchResults(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error occured getting match results from db")
 		assert.Nil(t, result)
@@ -326,9 +327,7 @@ func TestStoreMatchResults_InsertSwiss(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new record)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		matchResult := tournament.SwissResult{
@@ -338,7 +337,7 @@ func TestStoreMatchResults_InsertSwiss(t *testing.T) {
 			},
 		}
 
-		err := store.StoreMatchResults(matchResult)
+		err := store.StoreMatchResults(context.Background(), matchResult)
 		assert.NoError(t, err)
 	})
 }
@@ -356,9 +355,7 @@ func TestStoreMatchResults_InsertElimination(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new record)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		matchResult := tournament.EliminationResult{
@@ -368,7 +365,7 @@ func TestStoreMatchResults_InsertElimination(t *testing.T) {
 			},
 		}
 
-		err := store.StoreMatchResults(matchResult)
+		err := store.StoreMatchResults(context.Background(), matchResult)
 		assert.NoError(t, err)
 	})
 }
@@ -386,17 +383,8 @@ func TestStoreMatchResults_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document - need cursor response with getMore
-		first := mtest.CreateCursorResponse(1, "test.match_results", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		getMore := mtest.CreateCursorResponse(0, "test.match_results", mtest.NextBatch)
-		// Mock UpdateOne success
-		updateSuccess := bson.D{
-			{Key: "ok", Value: 1},
-			{Key: "nModified", Value: 1},
-		}
-		mt.AddMockResponses(first, getMore, updateSuccess)
+		// Mock the upsert matching an existing document
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		matchResult := tournament.SwissResult{
 			Teams: map[string]string{
@@ -404,15 +392,15 @@ func TestStoreMatchResults_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		err := store.StoreMatchResults(matchResult)
+		err := store.StoreMatchResults(context.Background(), matchResult)
 		assert.NoError(t, err)
 	})
 }
 
-func TestStoreMatchResults_FindOneError(t *testing.T) {
+func TestStoreMatchResults_UpsertError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when FindOne fails", func(mt *mtest.T) {
+	mt.Run("returns error when the upsert fails", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -422,9 +410,9 @@ func TestStoreMatchResults_FindOneError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning error
+		// Mock the upsert failing
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
-			Code:    11000,
+			Code:    2,
 			Message: "database error",
 		}))
 
@@ -432,16 +420,16 @@ func TestStoreMatchResults_FindOneError(t *testing.T) {
 			Teams: map[string]string{"Team A": "3-0"},
 		}
 
-		err := store.StoreMatchResults(matchResult)
+		err := store.StoreMatchResults(context.Background(), matchResult)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "lookup for existing record failed")
+		assert.Contains(t, err.Error(), "failed to store match result")
 	})
 }
 
-func TestStoreMatchResults_InsertError(t *testing.T) {
+func TestStoreMatchResults_DuplicateKeyTwice(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when insert fails", func(mt *mtest.T) {
+	mt.Run("returns error when the retried upsert also hits a duplicate key", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -451,9 +439,12 @@ func TestStoreMatchResults_InsertError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch))
-		// Mock InsertOne error
+		// Mock both upsert attempts racing with a concurrent insert
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "insert failed",
+		}))
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
@@ -464,16 +455,16 @@ func TestStoreMatchResults_InsertError(t *testing.T) {
 			Teams: map[string]string{"Team A": "3-0"},
 		}
 
-		err := store.StoreMatchResults(matchResult)
+		err := store.StoreMatchResults(context.Background(), matchResult)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to insert new match result")
+		assert.Contains(t, err.Error(), "failed to store match result")
 	})
 }
 
-func TestStoreMatchResults_UpdateError(t *testing.T) {
+func TestStoreMatchResults_DuplicateKeyRetried(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when update fails", func(mt *mtest.T) {
+	mt.Run("retries the upsert after a duplicate key error", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -483,25 +474,20 @@ func TestStoreMatchResults_UpdateError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document
-		existingDoc := mtest.CreateCursorResponse(1, "test.match_results", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		mt.AddMockResponses(existingDoc)
-		// Mock UpdateOne error
+		// Mock the first upsert losing the race to a concurrent insert, then the retry updating
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
-			Message: "update failed",
+			Message: "duplicate key",
 		}))
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		matchResult := tournament.SwissResult{
 			Teams: map[string]string{"Team A": "3-0"},
 		}
 
-		err := store.StoreMatchResults(matchResult)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to update match result")
+		err := store.StoreMatchResults(context.Background(), matchResult)
+		assert.NoError(t, err)
 	})
 }
 
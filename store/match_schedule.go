@@ -12,6 +12,7 @@ import (
 	"pickems-bot/metrics"
 	"pickems-bot/sources"
 	"sort"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,69 +26,57 @@ type UpcomingMatchDoc struct {
 }
 
 // FetchMatchSchedule returns the scheduled matches for the current round from the database.
-func (s *Store) FetchMatchSchedule() ([]sources.ScheduledMatch, error) {
+func (s *Store) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	opts := options.FindOne()
 
 	// Get UpcomingMatchDoc result from db
 	var res UpcomingMatchDoc
-	err := s.Collections.MatchSchedule.FindOne(context.TODO(), bson.D{{Key: "round", Value: s.Round}}, opts).Decode(&res)
+	err := s.Collections.MatchSchedule.FindOne(ctx, bson.D{{Key: "round", Value: s.Round}}, opts).Decode(&res)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching results from db: %w", err)
 	}
 	return res.ScheduledMatches, nil
 }
 
-// StoreMatchSchedule persists a slice of scheduled matches for the current round, inserting a new document or replacing an existing one.
-func (s *Store) StoreMatchSchedule(scheduledMatches []sources.ScheduledMatch) error {
+// StoreMatchSchedule stores the scheduled matches of the current round in a single upsert, replacing any previously
+// stored schedule.
+func (s *Store) StoreMatchSchedule(ctx context.Context, scheduledMatches []sources.ScheduledMatch) error {
 	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	if len(scheduledMatches) == 0 {
 		return fmt.Errorf("scheduled matches input has length 0, requires at least 1")
 	}
 
-	// Attempt to find an existing document
-	var raw bson.M
-	err := s.Collections.MatchSchedule.FindOne(context.TODO(), bson.M{"round": s.Round}).Decode(&raw)
-	notFound := errors.Is(err, mongo.ErrNoDocuments)
-
-	if err != nil && !notFound {
-		return fmt.Errorf("lookup for existing record failed: %w", err)
-	}
-
-	// Create bson UpcomingMatchDoc
-	filter := bson.M{"round": s.Round}
 	upcomingMatchDoc := UpcomingMatchDoc{
 		Round:            s.Round,
 		ScheduledMatches: scheduledMatches,
 	}
-	update := bson.M{"$set": upcomingMatchDoc}
 
 	s.logger().Info("updating match schedule in db", "round", s.Round)
-
-	// Perform insert or update
-	if notFound {
-		_, err := s.Collections.MatchSchedule.InsertOne(context.TODO(), upcomingMatchDoc)
-		if err != nil {
-			return fmt.Errorf("failed to insert upcoming matches: %w", err)
-		}
-		return nil
-	}
-	_, err = s.Collections.MatchSchedule.UpdateOne(context.TODO(), filter, update)
+	doc, err := versioned(upcomingMatchDoc)
 	if err != nil {
-		return fmt.Errorf("failed to update upcoming matches: %w", err)
+		return err
+	}
+	if err := upsertOne(ctx, s.Collections.MatchSchedule, bson.M{"round": s.Round}, bson.M{"$set": doc}); err != nil {
+		return fmt.Errorf("failed to store upcoming matches: %w", err)
 	}
-
 	return nil
 }
 
 // EnsureScheduledMatches verifies that at least one scheduled match exists in the database for the current round.
 // Prediction operations depend on this data being present, so callers should use this as a precondition check.
-func (s *Store) EnsureScheduledMatches() error {
+func (s *Store) EnsureScheduledMatches(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	var result struct {
 		ScheduledMatches []sources.ScheduledMatch `bson:"scheduled_matches"`
 	}
 	filter := bson.M{"round": s.Round}
-	err := s.Collections.MatchSchedule.FindOne(context.TODO(), filter).Decode(&result)
+	err := s.Collections.MatchSchedule.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return fmt.Errorf("no scheduled matches entry found for round %s", s.Round)
@@ -102,13 +91,23 @@ func (s *Store) EnsureScheduledMatches() error {
 }
 
 // FetchAndStoreSchedule fetches upcoming matches from the configured data source and persists them to the database.
-func (s *Store) FetchAndStoreSchedule() error {
-	matches, err := s.Fetcher.FetchSchedule()
+func (s *Store) FetchAndStoreSchedule(ctx context.Context) error {
+	return fetchAndStoreSchedule(ctx, s, s.Fetcher, s.FetchTimeout)
+}
+
+// fetchAndStoreSchedule implements FetchAndStoreSchedule for any backend
+func fetchAndStoreSchedule(ctx context.Context, s pipelineStore, fetcher DataSourceFetcher, fetchTimeout time.Duration) error {
+	if fetcher == nil {
+		return errNoFetcher
+	}
+	fetchCtx, cancel := withTimeout(ctx, fetchTimeout)
+	matches, err := fetcher.FetchSchedule(fetchCtx)
+	cancel()
 	if err != nil {
 		return err
 	}
 	sort.Slice(matches, func(i, j int) bool {
 		return matches[i].EpochTime < matches[j].EpochTime
 	})
-	return s.StoreMatchSchedule(matches)
+	return s.StoreMatchSchedule(ctx, matches)
 }
@@ -65,7 +65,7 @@ func TestStoreMatchSchedule_Update(t *testing.T) {
 		{Team1: "TBD", Team2: "TBD", EpochTime: 1750620600, BestOf: "3", StreamURL: "BLAST_Premier", Finished: false},
 	}
 
-	if err := store.StoreMatchSchedule(original); err != nil {
+	if err := store.StoreMatchSchedule(context.Background(), original); err != nil {
 		t.Fatalf("insert failed: %v", err)
 	}
 
@@ -78,7 +78,7 @@ func TestStoreMatchSchedule_Update(t *testing.T) {
 		{Team1: "Natus Vincere", Team2: "Team Vitality", EpochTime: 1750458600, BestOf: "3", StreamURL: "BLAST_Premier", Finished: true},
 		{Team1: "TBD", Team2: "TBD", EpochTime: 1750620600, BestOf: "3", StreamURL: "BLAST_Premier", Finished: false},
 	}
-	if err := store.StoreMatchSchedule(updated); err != nil {
+	if err := store.StoreMatchSchedule(context.Background(), updated); err != nil {
 		t.Fatalf("update failed: %v", err)
 	}
 
@@ -129,7 +129,7 @@ func TestFetchMatchSchedule_Success(t *testing.T) {
 		})
 		mt.AddMockResponses(scheduleDoc)
 
-		matches, err := store.FetchMatchSchedule()
+		matches, err := store.FetchMatchSchedule(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, matches)
 		assert.Len(t, matches, 2)
@@ -158,7 +158,7 @@ func TestFetchMatchSchedule_DatabaseError(t *testing.T) {
 			Message: "database error",
 		}))
 
-		matches, err := store.FetchMatchSchedule()
+		matches, err := store.FetchMatchSchedule(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error fetching results from db")
 		assert.Nil(t, matches)
@@ -181,7 +181,7 @@ func TestFetchMatchSchedule_NotFound(t *testing.T) {
 		// Mock FindOne returning no documents
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.scheduled_matches", mtest.FirstBatch))
 
-		matches, err := store.FetchMatchSchedule()
+		matches, err := store.FetchMatchSchedule(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error fetching results from db")
 		assert.Nil(t, matches)
@@ -205,14 +205,12 @@ func TestStoreMatchSchedule_InsertNew(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new schedule)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.scheduled_matches", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		matches := CreateSampleScheduledMatches()
 
-		err := store.StoreMatchSchedule(matches)
+		err := store.StoreMatchSchedule(context.Background(), matches)
 		assert.NoError(t, err)
 	})
 }
@@ -230,21 +228,12 @@ func TestStoreMatchSchedule_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document - need cursor response with getMore
-		first := mtest.CreateCursorResponse(1, "test.scheduled_matches", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		getMore := mtest.CreateCursorResponse(0, "test.scheduled_matches", mtest.NextBatch)
-		// Mock UpdateOne success
-		updateSuccess := bson.D{
-			{Key: "ok", Value: 1},
-			{Key: "nModified", Value: 1},
-		}
-		mt.AddMockResponses(first, getMore, updateSuccess)
+		// Mock the upsert matching an existing document
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		matches := CreateSampleScheduledMatches()
 
-		err := store.StoreMatchSchedule(matches)
+		err := store.StoreMatchSchedule(context.Background(), matches)
 		assert.NoError(t, err)
 	})
 }
@@ -262,16 +251,16 @@ func TestStoreMatchSchedule_EmptySlice(t *testing.T) {
 			},
 		}
 
-		err := store.StoreMatchSchedule([]sources.ScheduledMatch{})
+		err := store.StoreMatchSchedule(context.Background(), []sources.ScheduledMatch{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "scheduled matches input has length 0")
 	})
 }
 
-func TestStoreMatchSchedule_FindOneError(t *testing.T) {
+func TestStoreMatchSchedule_UpsertError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when FindOne fails", func(mt *mtest.T) {
+	mt.Run("returns error when the upsert fails", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -281,24 +270,24 @@ func TestStoreMatchSchedule_FindOneError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning error
+		// Mock the upsert failing
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
-			Code:    11000,
+			Code:    2,
 			Message: "database error",
 		}))
 
 		matches := CreateSampleScheduledMatches()
 
-		err := store.StoreMatchSchedule(matches)
+		err := store.StoreMatchSchedule(context.Background(), matches)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "lookup for existing record failed")
+		assert.Contains(t, err.Error(), "failed to store upcoming matches")
 	})
 }
 
-func TestStoreMatchSchedule_InsertError(t *testing.T) {
+func TestStoreMatchSchedule_DuplicateKeyTwice(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when insert fails", func(mt *mtest.T) {
+	mt.Run("returns error when the retried upsert also hits a duplicate key", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -308,9 +297,12 @@ func TestStoreMatchSchedule_InsertError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.scheduled_matches", mtest.FirstBatch))
-		// Mock InsertOne error
+		// Mock both upsert attempts racing with a concurrent insert
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "insert failed",
+		}))
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
@@ -319,16 +311,16 @@ func TestStoreMatchSchedule_InsertError(t *testing.T) {
 
 		matches := CreateSampleScheduledMatches()
 
-		err := store.StoreMatchSchedule(matches)
+		err := store.StoreMatchSchedule(context.Background(), matches)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to insert upcoming matches")
+		assert.Contains(t, err.Error(), "failed to store upcoming matches")
 	})
 }
 
-func TestStoreMatchSchedule_UpdateError(t *testing.T) {
+func TestStoreMatchSchedule_DuplicateKeyRetried(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when update fails", func(mt *mtest.T) {
+	mt.Run("retries the upsert after a duplicate key error", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -338,23 +330,18 @@ func TestStoreMatchSchedule_UpdateError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning existing document
-		existingDoc := mtest.CreateCursorResponse(1, "test.scheduled_matches", mtest.FirstBatch, bson.D{
-			{Key: "round", Value: "test_round"},
-		})
-		mt.AddMockResponses(existingDoc)
-		// Mock UpdateOne error
+		// Mock the first upsert losing the race to a concurrent insert, then the retry updating
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
-			Message: "update failed",
+			Message: "duplicate key",
 		}))
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		matches := CreateSampleScheduledMatches()
 
-		err := store.StoreMatchSchedule(matches)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to update upcoming matches")
+		err := store.StoreMatchSchedule(context.Background(), matches)
+		assert.NoError(t, err)
 	})
 }
 
@@ -387,7 +374,7 @@ func TestEnsureScheduledMatches_Success(t *testing.T) {
 		})
 		mt.AddMockResponses(scheduleDoc)
 
-		err := store.EnsureScheduledMatches()
+		err := store.EnsureScheduledMatches(context.Background())
 		assert.NoError(t, err)
 	})
 }
@@ -408,7 +395,7 @@ func TestEnsureScheduledMatches_NotFound(t *testing.T) {
 		// Mock FindOne returning no documents
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.scheduled_matches", mtest.FirstBatch))
 
-		err := store.EnsureScheduledMatches()
+		err := store.EnsureScheduledMatches(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no scheduled matches entry found for round test_round")
 	})
@@ -434,7 +421,7 @@ func TestEnsureScheduledMatches_EmptyMatches(t *testing.T) {
 		})
 		mt.AddMockResponses(scheduleDoc)
 
-		err := store.EnsureScheduledMatches()
+		err := store.EnsureScheduledMatches(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "scheduled match collection found but its results were empty")
 	})
@@ -459,7 +446,7 @@ func TestEnsureScheduledMatches_DatabaseError(t *testing.T) {
 			Message: "database error",
 		}))
 
-		err := store.EnsureScheduledMatches()
+		err := store.EnsureScheduledMatches(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error checking scheduled matches")
 	})
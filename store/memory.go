@@ -0,0 +1,827 @@
+/* memory.go
+ * Contains MemoryStore, an in-memory implementation of Interface for local development and tests. It keeps the
+ * same semantics as the MongoDB store: data is scoped by round, missing documents return ErrNotFound, and every
+ * value is copied through BSON on the way in and out, so callers never share memory with the store and get back
+ * exactly what MongoDB would return. Nothing is persisted; a restart starts from an empty store.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryStore is an in-memory store backend. The zero value is not usable; create one with NewMemoryStore.
+type MemoryStore struct {
+	Round   string
+	Fetcher DataSourceFetcher
+	// FetchTimeout bounds each data source request. Zero leaves requests bounded only by the caller's context.
+	FetchTimeout time.Duration
+	dbName       string
+	log          *slog.Logger
+
+	mu               sync.RWMutex
+	predictions      map[memoryKey]models.Prediction // (round, user ID)
+	matchResults     map[string]bson.M               // round -> encoded result with its type discriminator
+	matchNodes       map[string]memoryMatchNodes     // round
+	schedules        map[string][]sources.ScheduledMatch
+	leaderboards     map[string]Leaderboard
+	users            map[string]UserProfile
+	sentReminders    []SentReminder
+	matchDayMessages map[memoryKey]MatchDayMessage // (round, guild ID)
+	guildSettings    map[string]GuildSettings
+	auditLog         []AuditEntry
+	overrides        map[memoryKey]ResultOverride // (round, match ID)
+	teamAliases      map[string]TeamAlias
+	vrs              []VRSEntry
+}
+
+// memoryKey identifies a document scoped to a round
+type memoryKey struct {
+	round string
+	id    string
+}
+
+// memoryMatchNodes is the stored match nodes of a round
+type memoryMatchNodes struct {
+	Nodes  []sources.MatchNode `bson:"nodes"`
+	Format tournament.Kind     `bson:"format"`
+}
+
+// memoryDatabase stands in for the database handle GetDatabase returns
+type memoryDatabase struct{ name string }
+
+// Name returns the tournament name the store was created for
+func (d memoryDatabase) Name() string { return d.name }
+
+// memoryClient stands in for the client GetClient returns. There is no connection to close.
+type memoryClient struct{}
+
+// Disconnect is a no-op
+func (memoryClient) Disconnect(context.Context) error { return nil }
+
+// NewMemoryStore creates an empty in-memory store for the given round.
+// log may be nil; if so the global slog default is used.
+func NewMemoryStore(dbName string, round string, fetcher DataSourceFetcher, log *slog.Logger) *MemoryStore {
+	return &MemoryStore{
+		Round:            round,
+		Fetcher:          fetcher,
+		log:              log,
+		predictions:      make(map[memoryKey]models.Prediction),
+		matchResults:     make(map[string]bson.M),
+		matchNodes:       make(map[string]memoryMatchNodes),
+		schedules:        make(map[string][]sources.ScheduledMatch),
+		leaderboards:     make(map[string]Leaderboard),
+		users:            make(map[string]UserProfile),
+		matchDayMessages: make(map[memoryKey]MatchDayMessage),
+		guildSettings:    make(map[string]GuildSettings),
+		overrides:        make(map[memoryKey]ResultOverride),
+		teamAliases:      make(map[string]TeamAlias),
+		dbName:           dbName,
+	}
+}
+
+// Ensure MemoryStore implements Interface
+var _ Interface = (*MemoryStore)(nil)
+var _ pipelineStore = (*MemoryStore)(nil)
+
+// logger returns the store's logger, falling back to the global default when none was injected.
+func (m *MemoryStore) logger() *slog.Logger {
+	if m.log == nil {
+		return slog.Default()
+	}
+	return m.log
+}
+
+// copyValue deep-copies v by round-tripping it through BSON, the same encoding MongoDB stores it with
+func copyValue[T any](v T) (T, error) {
+	type wrapper struct {
+		V T `bson:"v"`
+	}
+	var out wrapper
+	data, err := bson.Marshal(wrapper{V: v})
+	if err != nil {
+		return out.V, fmt.Errorf("failed to encode value: %w", err)
+	}
+	if err := bson.Unmarshal(data, &out); err != nil {
+		return out.V, fmt.Errorf("failed to decode value: %w", err)
+	}
+	return out.V, nil
+}
+
+// region Getters
+
+// GetDatabase returns a stand-in database handle named after the tournament
+func (m *MemoryStore) GetDatabase() interface{ Name() string } {
+	return memoryDatabase{name: m.dbName}
+}
+
+// GetRound returns the tournament round name
+func (m *MemoryStore) GetRound() string {
+	return m.Round
+}
+
+// GetClient returns a stand-in client whose Disconnect does nothing
+func (m *MemoryStore) GetClient() interface{ Disconnect(context.Context) error } {
+	return memoryClient{}
+}
+
+// Ping succeeds unless ctx is already done; there is no database to reach
+func (m *MemoryStore) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// endregion
+
+// region Predictions
+
+// StoreUserPrediction inserts a new prediction for the given user, or replaces an existing one for the
+// prediction's round.
+func (m *MemoryStore) StoreUserPrediction(ctx context.Context, userID string, prediction models.Prediction) error {
+	stored, err := copyValue(prediction)
+	if err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memoryKey{round: prediction.Round, id: userID}
+	if stored.ID.IsZero() {
+		stored.ID = m.predictions[key].ID
+	}
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	m.predictions[key] = stored
+	return nil
+}
+
+// GetUserPrediction retrieves the stored prediction for the given user ID in the current round.
+func (m *MemoryStore) GetUserPrediction(ctx context.Context, userID string) (models.Prediction, error) {
+	return m.GetUserPredictionForRound(ctx, userID, m.Round)
+}
+
+// GetUserPredictionForRound retrieves the stored prediction for the given user ID in the given round.
+func (m *MemoryStore) GetUserPredictionForRound(ctx context.Context, userID string, round string) (models.Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	prediction, ok := m.predictions[memoryKey{round: round, id: userID}]
+	if !ok {
+		return models.Prediction{}, ErrNotFound
+	}
+	return copyValue(prediction)
+}
+
+// GetUserPredictionByUsername retrieves the stored prediction for the given username (case-insensitive) in the
+// current round.
+func (m *MemoryStore) GetUserPredictionByUsername(ctx context.Context, username string) (models.Prediction, error) {
+	return m.GetUserPredictionByUsernameForRound(ctx, username, m.Round)
+}
+
+// GetUserPredictionByUsernameForRound retrieves the stored prediction for the given username (case-insensitive) in
+// the given round.
+func (m *MemoryStore) GetUserPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, prediction := range m.sortedPredictions(round) {
+		if strings.EqualFold(prediction.Username, username) {
+			return copyValue(prediction)
+		}
+	}
+	return models.Prediction{}, ErrNotFound
+}
+
+// DeleteUserPrediction removes the given user's prediction for the current round. Returns ErrNotFound when the
+// user has no prediction stored.
+func (m *MemoryStore) DeleteUserPrediction(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memoryKey{round: m.Round, id: userID}
+	if _, ok := m.predictions[key]; !ok {
+		return ErrNotFound
+	}
+	delete(m.predictions, key)
+	return nil
+}
+
+// FetchRounds returns every round with stored predictions, match results, match nodes, a schedule or a leaderboard,
+// sorted by name.
+func (m *MemoryStore) FetchRounds(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var rounds []string
+	for key := range m.predictions {
+		rounds = append(rounds, key.round)
+	}
+	for _, keys := range [][]string{
+		slices.Collect(maps.Keys(m.matchResults)),
+		slices.Collect(maps.Keys(m.matchNodes)),
+		slices.Collect(maps.Keys(m.schedules)),
+		slices.Collect(maps.Keys(m.leaderboards)),
+	} {
+		rounds = append(rounds, keys...)
+	}
+	return sortedRounds(rounds), nil
+}
+
+// GetAllUserPredictions returns all stored predictions for the current round, ordered by user ID.
+func (m *MemoryStore) GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	predictions := m.sortedPredictions(m.Round)
+	if len(predictions) == 0 {
+		return nil, nil
+	}
+	return copyValue(predictions)
+}
+
+// sortedPredictions returns the predictions of a round ordered by user ID. Callers must hold mu.
+func (m *MemoryStore) sortedPredictions(round string) []models.Prediction {
+	var keys []memoryKey
+	for key := range m.predictions {
+		if key.round == round {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].id < keys[j].id })
+	predictions := make([]models.Prediction, len(keys))
+	for i, key := range keys {
+		predictions[i] = m.predictions[key]
+	}
+	return predictions
+}
+
+// GetValidTeams returns the valid team names and tournament format for the current round, derived from the
+// stored match results.
+func (m *MemoryStore) GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error) {
+	results, err := m.fetchMatchResults(m.Round)
+	if err != nil {
+		return nil, "", err
+	}
+	return results.GetTeamNames(), results.GetType(), nil
+}
+
+// endregion
+
+// region Match results and nodes
+
+// GetMatchResults returns the stored match results for the current round.
+func (m *MemoryStore) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
+	return m.GetMatchResultsForRound(ctx, m.Round)
+}
+
+// GetMatchResultsForRound returns the match results of the given round.
+func (m *MemoryStore) GetMatchResultsForRound(ctx context.Context, round string) (tournament.MatchResult, error) {
+	results, err := m.fetchMatchResults(round)
+	if err != nil {
+		return nil, fmt.Errorf("error occured getting match results from db: %w", err)
+	}
+	return results, nil
+}
+
+// fetchMatchResults decodes the stored match results of the given round
+func (m *MemoryStore) fetchMatchResults(round string) (tournament.MatchResult, error) {
+	m.mu.RLock()
+	raw, ok := m.matchResults[round]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return decodeMatchResult(raw)
+}
+
+// StoreMatchResults stores the match results of the current round, tagged with their type discriminator
+func (m *MemoryStore) StoreMatchResults(ctx context.Context, matchResult tournament.MatchResult) error {
+	doc, err := encodeMatchResult(matchResult)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchResults[m.Round] = doc
+	return nil
+}
+
+// StoreMatchNodes stores the raw match nodes of the current round
+func (m *MemoryStore) StoreMatchNodes(ctx context.Context, nodes []sources.MatchNode, kind tournament.Kind) error {
+	stored, err := copyValue(memoryMatchNodes{Nodes: nodes, Format: kind})
+	if err != nil {
+		return fmt.Errorf("failed to store match nodes: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchNodes[m.Round] = stored
+	return nil
+}
+
+// FetchMatchNodesFromDb retrieves the raw match nodes and tournament.Kind of the current round
+func (m *MemoryStore) FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
+	return m.FetchMatchNodesForRound(ctx, m.Round)
+}
+
+// FetchMatchNodesForRound retrieves the raw match nodes and tournament.Kind of the given round.
+func (m *MemoryStore) FetchMatchNodesForRound(ctx context.Context, round string) ([]sources.MatchNode, tournament.Kind, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stored, ok := m.matchNodes[round]
+	if !ok {
+		return nil, "", ErrNotFound
+	}
+	out, err := copyValue(stored)
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Nodes, out.Format, nil
+}
+
+// FetchAndUpdateMatchResults fetches match data from the configured data source and stores the result, with any
+// result overrides applied
+func (m *MemoryStore) FetchAndUpdateMatchResults(ctx context.Context) error {
+	return fetchAndUpdateMatchResults(ctx, m, m.Fetcher, m.FetchTimeout, m.logger())
+}
+
+// RebuildMatchResults rebuilds the stored match results from the stored match nodes with the current overrides
+// applied, without calling the data source.
+func (m *MemoryStore) RebuildMatchResults(ctx context.Context) error {
+	return rebuildMatchResults(ctx, m)
+}
+
+// endregion
+
+// region Schedule
+
+// FetchMatchSchedule returns the scheduled matches for the current round.
+func (m *MemoryStore) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	matches, ok := m.schedules[m.Round]
+	if !ok {
+		return nil, fmt.Errorf("error fetching results from db: %w", ErrNotFound)
+	}
+	return copyValue(matches)
+}
+
+// StoreMatchSchedule replaces the scheduled matches of the current round.
+func (m *MemoryStore) StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error {
+	if len(matches) == 0 {
+		return fmt.Errorf("scheduled matches input has length 0, requires at least 1")
+	}
+	stored, err := copyValue(matches)
+	if err != nil {
+		return fmt.Errorf("failed to store match schedule: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[m.Round] = stored
+	return nil
+}
+
+// EnsureScheduledMatches verifies that at least one scheduled match is stored for the current round.
+func (m *MemoryStore) EnsureScheduledMatches(ctx context.Context) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	matches, ok := m.schedules[m.Round]
+	if !ok {
+		return fmt.Errorf("no scheduled matches entry found for round %s", m.Round)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("scheduled match collection found but its results were empty for round %s", m.Round)
+	}
+	return nil
+}
+
+// FetchAndStoreSchedule fetches upcoming matches from the configured data source and stores them.
+func (m *MemoryStore) FetchAndStoreSchedule(ctx context.Context) error {
+	return fetchAndStoreSchedule(ctx, m, m.Fetcher, m.FetchTimeout)
+}
+
+// endregion
+
+// region Leaderboard and VRS
+
+// StoreLeaderboard replaces the leaderboard of the current round.
+func (m *MemoryStore) StoreLeaderboard(ctx context.Context, leaderboard Leaderboard) error {
+	if reflect.DeepEqual(leaderboard, Leaderboard{}) {
+		return fmt.Errorf("leaderboard is empty")
+	}
+	stored, err := copyValue(leaderboard)
+	if err != nil {
+		return fmt.Errorf("failed to store leaderboard: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored.ID.IsZero() {
+		stored.ID = m.leaderboards[m.Round].ID
+	}
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	m.leaderboards[m.Round] = stored
+	return nil
+}
+
+// FetchLeaderboardFromDB returns the leaderboard entries for the current round.
+func (m *MemoryStore) FetchLeaderboardFromDB(ctx context.Context) ([]LeaderboardEntry, error) {
+	return m.FetchLeaderboardForRound(ctx, m.Round)
+}
+
+// FetchLeaderboardForRound returns the leaderboard entries of the given round.
+func (m *MemoryStore) FetchLeaderboardForRound(ctx context.Context, round string) ([]LeaderboardEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	leaderboard, ok := m.leaderboards[round]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyValue(leaderboard.Entries)
+}
+
+// FetchVrsDataFromDB returns the latest VRS rankings set with SetVRSData.
+func (m *MemoryStore) FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	dates := vrsStandingsDates(m.vrs)
+	if len(dates) == 0 {
+		return nil, nil
+	}
+	return copyValue(vrsSnapshot(m.vrs, dates[len(dates)-1]))
+}
+
+// FetchVrsStandingsDates returns the standings dates there are VRS snapshots of, oldest first.
+func (m *MemoryStore) FetchVrsStandingsDates(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return vrsStandingsDates(m.vrs), nil
+}
+
+// FetchVrsSnapshot returns the VRS rankings as of the given standings date, ordered by standing.
+func (m *MemoryStore) FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]VRSEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return copyValue(vrsSnapshot(m.vrs, standingsDate))
+}
+
+// SetVRSData replaces the VRS snapshots of the standings dates in entries, keeping those of other dates. The MongoDB
+// rankings are synced by an external job, so the in-memory store has to be seeded explicitly.
+func (m *MemoryStore) SetVRSData(ctx context.Context, entries []VRSEntry) error {
+	stored, err := copyValue(entries)
+	if err != nil {
+		return fmt.Errorf("failed to store VRS data: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.vrs = replaceVRSSnapshots(m.vrs, stored)
+	return nil
+}
+
+// endregion
+
+// region Users and reminders
+
+// TrackUser records an interaction from the given user, creating their profile if it doesn't exist yet. The
+// profile's guild is only updated by interactions in a guild.
+func (m *MemoryStore) TrackUser(ctx context.Context, user models.User) error {
+	m.updateUser(user.UserID, func(profile *UserProfile) {
+		profile.Username = user.Username
+		profile.DisplayName = user.DisplayName
+		profile.LastSeen = time.Now().UTC()
+		if user.GuildID != "" {
+			profile.GuildID = user.GuildID
+		}
+	})
+	return nil
+}
+
+// SetRemindersEnabled opts the given user in to (or out of) pre-lock reminder DMs.
+func (m *MemoryStore) SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error {
+	m.updateUser(userID, func(profile *UserProfile) { profile.RemindersOff = !enabled })
+	return nil
+}
+
+// SetUserLocale sets the locale bot responses to the given user are translated into. An empty locale clears it.
+func (m *MemoryStore) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	m.updateUser(userID, func(profile *UserProfile) { profile.Locale = locale })
+	return nil
+}
+
+// SetUserTimezone sets the IANA timezone plain-text times are shown to the given user in. An empty timezone
+// clears it.
+func (m *MemoryStore) SetUserTimezone(ctx context.Context, userID string, timezone string) error {
+	m.updateUser(userID, func(profile *UserProfile) { profile.Timezone = timezone })
+	return nil
+}
+
+// updateUser applies update to a user's profile, creating the profile if it doesn't exist yet
+func (m *MemoryStore) updateUser(userID string, update func(profile *UserProfile)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	profile, ok := m.users[userID]
+	if !ok {
+		profile = UserProfile{UserID: userID}
+	}
+	update(&profile)
+	m.users[userID] = profile
+}
+
+// GetUserProfile returns the profile of the given user. Returns ErrNotFound if they have never been seen.
+func (m *MemoryStore) GetUserProfile(ctx context.Context, userID string) (UserProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	profile, ok := m.users[userID]
+	if !ok {
+		return UserProfile{}, ErrNotFound
+	}
+	return copyValue(profile)
+}
+
+// GetUserProfileByUsername returns the profile of the user currently going by the given username (case-insensitive).
+// If several users have gone by it, the one seen most recently is returned. Returns ErrNotFound if nobody has.
+func (m *MemoryStore) GetUserProfileByUsername(ctx context.Context, username string) (UserProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found *UserProfile
+	for _, profile := range m.users {
+		if !strings.EqualFold(profile.Username, username) {
+			continue
+		}
+		// Ties go to the lowest user ID so the result doesn't depend on map order
+		if found == nil || profile.LastSeen.After(found.LastSeen) ||
+			(profile.LastSeen.Equal(found.LastSeen) && profile.UserID < found.UserID) {
+			found = &profile
+		}
+	}
+	if found == nil {
+		return UserProfile{}, ErrNotFound
+	}
+	return *found, nil
+}
+
+// FetchUserProfiles returns every tracked user profile, ordered by user ID.
+func (m *MemoryStore) FetchUserProfiles(ctx context.Context) ([]UserProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.users) == 0 {
+		return nil, nil
+	}
+	profiles := make([]UserProfile, 0, len(m.users))
+	for _, profile := range m.users {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].UserID < profiles[j].UserID })
+	return copyValue(profiles)
+}
+
+// FetchPredictionUserIDs returns the distinct IDs of every user that has stored a prediction in any round.
+func (m *MemoryStore) FetchPredictionUserIDs(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seen := make(map[string]bool)
+	ids := []string{}
+	for key := range m.predictions {
+		if key.id != "" && !seen[key.id] {
+			seen[key.id] = true
+			ids = append(ids, key.id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// FetchSentReminders returns every reminder sent for the current round.
+func (m *MemoryStore) FetchSentReminders(ctx context.Context) ([]SentReminder, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var reminders []SentReminder
+	for _, reminder := range m.sentReminders {
+		if reminder.Round == m.Round {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return copyValue(reminders)
+}
+
+// StoreSentReminder records that a reminder has been sent. Recording the same reminder twice is a no-op.
+func (m *MemoryStore) StoreSentReminder(ctx context.Context, reminder SentReminder) error {
+	stored, err := copyValue(reminder)
+	if err != nil {
+		return fmt.Errorf("failed to record sent reminder: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.sentReminders {
+		if r.UserID == reminder.UserID && r.GuildID == reminder.GuildID && r.Round == reminder.Round && r.Lead == reminder.Lead {
+			return nil
+		}
+	}
+	m.sentReminders = append(m.sentReminders, stored)
+	return nil
+}
+
+// endregion
+
+// region Match day messages
+
+// FetchMatchDayMessages returns the match day message of every guild for the current round, ordered by guild ID.
+func (m *MemoryStore) FetchMatchDayMessages(ctx context.Context) ([]MatchDayMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var messages []MatchDayMessage
+	for key, message := range m.matchDayMessages {
+		if key.round == m.Round {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].GuildID < messages[j].GuildID })
+	return copyValue(messages)
+}
+
+// StoreMatchDayMessage stores a guild's match day message, replacing any previous message for the same guild and
+// round.
+func (m *MemoryStore) StoreMatchDayMessage(ctx context.Context, message MatchDayMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchDayMessages[memoryKey{round: message.Round, id: message.GuildID}] = message
+	return nil
+}
+
+// DeleteMatchDayMessage stops tracking a guild's match day message for the current round.
+func (m *MemoryStore) DeleteMatchDayMessage(ctx context.Context, guildID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.matchDayMessages, memoryKey{round: m.Round, id: guildID})
+	return nil
+}
+
+// endregion
+
+// region Guild settings
+
+// GetGuildSettings returns the settings stored for a guild. Returns ErrNotFound when the guild has never been
+// configured.
+func (m *MemoryStore) GetGuildSettings(ctx context.Context, guildID string) (GuildSettings, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	settings, ok := m.guildSettings[guildID]
+	if !ok {
+		return GuildSettings{}, ErrNotFound
+	}
+	return settings, nil
+}
+
+// FetchAllGuildSettings returns the settings of every configured guild, ordered by guild ID.
+func (m *MemoryStore) FetchAllGuildSettings(ctx context.Context) ([]GuildSettings, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var all []GuildSettings
+	for _, settings := range m.guildSettings {
+		all = append(all, settings)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].GuildID < all[j].GuildID })
+	return all, nil
+}
+
+// StoreGuildSettings stores a guild's settings, replacing any previous settings for the guild.
+func (m *MemoryStore) StoreGuildSettings(ctx context.Context, settings GuildSettings) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.guildSettings[settings.GuildID] = settings
+	return nil
+}
+
+// endregion
+
+// region Audit log
+
+// StoreAuditEntry appends an entry to the audit log.
+func (m *MemoryStore) StoreAuditEntry(ctx context.Context, entry AuditEntry) error {
+	stored, err := copyValue(entry)
+	if err != nil {
+		return fmt.Errorf("failed to store audit entry: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.auditLog = append(m.auditLog, stored)
+	return nil
+}
+
+// FetchAuditEntries returns up to limit of the most recent audit log entries for a guild, newest first. Entries
+// not tied to a guild are included for every guild. A limit of zero or less returns every entry.
+func (m *MemoryStore) FetchAuditEntries(ctx context.Context, guildID string, limit int) ([]AuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var entries []AuditEntry
+	for _, entry := range m.auditLog {
+		if entry.GuildID == guildID || entry.GuildID == "" {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.After(entries[j].Timestamp) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return copyValue(entries)
+}
+
+// endregion
+
+// region Result overrides
+
+// FetchResultOverrides returns the overrides for the current round, ordered by match ID.
+func (m *MemoryStore) FetchResultOverrides(ctx context.Context) ([]ResultOverride, error) {
+	return m.FetchResultOverridesForRound(ctx, m.Round)
+}
+
+// FetchResultOverridesForRound returns the result overrides of the given round.
+func (m *MemoryStore) FetchResultOverridesForRound(ctx context.Context, round string) ([]ResultOverride, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var overrides []ResultOverride
+	for key, override := range m.overrides {
+		if key.round == round {
+			overrides = append(overrides, override)
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].MatchID < overrides[j].MatchID })
+	return copyValue(overrides)
+}
+
+// StoreResultOverride stores an override, replacing any existing override for the same match.
+func (m *MemoryStore) StoreResultOverride(ctx context.Context, override ResultOverride) error {
+	stored, err := copyValue(override)
+	if err != nil {
+		return fmt.Errorf("failed to store result override: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overrides[memoryKey{round: override.Round, id: override.MatchID}] = stored
+	return nil
+}
+
+// DeleteResultOverride removes the override for a match in the current round. Returns ErrNotFound when the match
+// has no override.
+func (m *MemoryStore) DeleteResultOverride(ctx context.Context, matchID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := memoryKey{round: m.Round, id: matchID}
+	if _, ok := m.overrides[key]; !ok {
+		return ErrNotFound
+	}
+	delete(m.overrides, key)
+	return nil
+}
+
+// endregion
+
+// region Team aliases
+
+// FetchTeamAliases returns every team alias, sorted by alias.
+func (m *MemoryStore) FetchTeamAliases(ctx context.Context) ([]TeamAlias, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var aliases []TeamAlias
+	for _, alias := range m.teamAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	return copyValue(aliases)
+}
+
+// StoreTeamAlias stores an alias, replacing any existing alias with the same name.
+func (m *MemoryStore) StoreTeamAlias(ctx context.Context, alias TeamAlias) error {
+	stored, err := copyValue(alias)
+	if err != nil {
+		return fmt.Errorf("failed to store team alias: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.teamAliases[alias.Alias] = stored
+	return nil
+}
+
+// DeleteTeamAlias removes an alias by its normalised name. Returns ErrNotFound when there is no such alias.
+func (m *MemoryStore) DeleteTeamAlias(ctx context.Context, alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.teamAliases[alias]; !ok {
+		return ErrNotFound
+	}
+	delete(m.teamAliases, alias)
+	return nil
+}
+
+// endregion
@@ -0,0 +1,547 @@
+/* memory_test.go
+ * Unit tests for MemoryStore. These check it keeps the semantics of the MongoDB store: round scoping, not-found
+ * errors, upserts and isolation between callers and stored values.
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFetcher is a DataSourceFetcher returning fixed data
+type stubFetcher struct {
+	result   tournament.MatchResult
+	nodes    []sources.MatchNode
+	schedule []sources.ScheduledMatch
+	err      error
+}
+
+func (f stubFetcher) FetchMatchData(ctx context.Context, round string) (tournament.MatchResult, []sources.MatchNode, error) {
+	return f.result, f.nodes, f.err
+}
+
+func (f stubFetcher) FetchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	return f.schedule, f.err
+}
+
+// region Prediction tests
+
+func TestMemoryStore_UserPrediction_RoundTrip(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	prediction := models.Prediction{UserID: "u1", Username: "Alice", Format: "swiss", Round: "stage_1", Win: []string{"A", "B"}}
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", prediction))
+
+	got, err := m.GetUserPrediction(ctx, "u1")
+	require.NoError(t, err)
+	assert.False(t, got.ID.IsZero(), "an ID should be assigned on insert")
+	assert.Equal(t, []string{"A", "B"}, got.Win)
+
+	byName, err := m.GetUserPredictionByUsername(ctx, "aLiCe")
+	require.NoError(t, err)
+	assert.Equal(t, got.ID, byName.ID)
+}
+
+func TestMemoryStore_UserPrediction_ReplaceKeepsID(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1", Win: []string{"A"}}))
+	first, _ := m.GetUserPrediction(ctx, "u1")
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1", Win: []string{"B"}}))
+
+	all, err := m.GetAllUserPredictions(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, first.ID, all[0].ID)
+	assert.Equal(t, []string{"B"}, all[0].Win)
+}
+
+func TestMemoryStore_UserPrediction_ScopedByRound(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Username: "Alice", Round: "stage_0"}))
+
+	_, err := m.GetUserPrediction(ctx, "u1")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = m.GetUserPredictionByUsername(ctx, "Alice")
+	assert.ErrorIs(t, err, ErrNotFound)
+	all, err := m.GetAllUserPredictions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	ids, err := m.FetchPredictionUserIDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, ids, "past predictors span every round")
+}
+
+func TestMemoryStore_DeleteUserPrediction(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, m.DeleteUserPrediction(ctx, "u1"), ErrNotFound)
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1"}))
+	require.NoError(t, m.DeleteUserPrediction(ctx, "u1"))
+
+	_, err := m.GetUserPrediction(ctx, "u1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore_ReturnedValuesAreCopies(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	prediction := models.Prediction{UserID: "u1", Round: "stage_1", Win: []string{"A"}}
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", prediction))
+	prediction.Win[0] = "changed after store"
+
+	got, err := m.GetUserPrediction(ctx, "u1")
+	require.NoError(t, err)
+	got.Win[0] = "changed after fetch"
+
+	again, err := m.GetUserPrediction(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, again.Win)
+}
+
+// endregion
+
+// region Match results and nodes tests
+
+func TestMemoryStore_MatchResults_RoundTrip(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	_, err := m.GetMatchResults(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	results := tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "3-0", "Beta": "0-3"}}
+	require.NoError(t, m.StoreMatchResults(ctx, results))
+
+	got, err := m.GetMatchResults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, results, got)
+
+	teams, kind, err := m.GetValidTeams(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Alpha", "Beta"}, teams)
+	assert.Equal(t, tournament.Swiss, kind)
+}
+
+func TestMemoryStore_MatchNodes_RoundTrip(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	_, _, err := m.FetchMatchNodesFromDb(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	nodes := []sources.MatchNode{{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha", Score: "2-0"}}
+	require.NoError(t, m.StoreMatchNodes(ctx, nodes, tournament.SingleElim))
+
+	got, kind, err := m.FetchMatchNodesFromDb(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tournament.SingleElim, kind)
+	assert.Equal(t, nodes, got)
+}
+
+func TestMemoryStore_FetchAndUpdateMatchResults_ReconcilesOverrides(t *testing.T) {
+	nodes := []sources.MatchNode{
+		{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha", Score: "2-0", Section: "Round 1"},
+		{ID: "m2", Team1: "Gamma", Team2: "Delta", Winner: "Gamma", Score: "2-1", Section: "Round 1"},
+	}
+	fetcher := stubFetcher{result: tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "1-0"}}, nodes: nodes}
+	m := NewMemoryStore("test_db", "stage_1", fetcher, nil)
+	ctx := context.Background()
+
+	// m1's override agrees with the source and is cleared; m2's still disagrees and stays pinned
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Alpha"}))
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m2", Round: "stage_1", Winner: "Delta"}))
+
+	require.NoError(t, m.FetchAndUpdateMatchResults(ctx))
+
+	overrides, err := m.FetchResultOverrides(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "m2", overrides[0].MatchID)
+
+	results, err := m.GetMatchResults(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1-0", results.(tournament.SwissResult).Teams["Delta"])
+
+	stored, _, err := m.FetchMatchNodesFromDb(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Gamma", stored[1].Winner, "stored nodes stay raw")
+
+	audit, err := m.FetchAuditEntries(ctx, "guild1", 10)
+	require.NoError(t, err)
+	require.Len(t, audit, 1)
+	assert.Equal(t, "data source", audit[0].Username)
+}
+
+func TestMemoryStore_FetchAndUpdateMatchResults_NoFetcher(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	assert.Error(t, m.FetchAndUpdateMatchResults(context.Background()))
+}
+
+func TestMemoryStore_RebuildMatchResults_NoNodes(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	assert.ErrorIs(t, m.RebuildMatchResults(context.Background()), ErrNotFound)
+}
+
+// endregion
+
+// region Schedule tests
+
+func TestMemoryStore_Schedule(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	assert.Error(t, m.EnsureScheduledMatches(ctx))
+	_, err := m.FetchMatchSchedule(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Error(t, m.StoreMatchSchedule(ctx, nil))
+
+	matches := []sources.ScheduledMatch{{Team1: "Alpha", Team2: "Beta", EpochTime: 100}}
+	require.NoError(t, m.StoreMatchSchedule(ctx, matches))
+	assert.NoError(t, m.EnsureScheduledMatches(ctx))
+
+	got, err := m.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, matches, got)
+}
+
+func TestMemoryStore_FetchAndStoreSchedule_SortsByTime(t *testing.T) {
+	fetcher := stubFetcher{schedule: []sources.ScheduledMatch{{Team1: "Late", EpochTime: 200}, {Team1: "Early", EpochTime: 100}}}
+	m := NewMemoryStore("test_db", "stage_1", fetcher, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.FetchAndStoreSchedule(ctx))
+
+	got, err := m.FetchMatchSchedule(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Early", got[0].Team1)
+}
+
+func TestMemoryStore_FetchAndStoreSchedule_FetchError(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", stubFetcher{err: errors.New("source down")}, nil)
+	assert.EqualError(t, m.FetchAndStoreSchedule(context.Background()), "source down")
+}
+
+// endregion
+
+// region Leaderboard and VRS tests
+
+func TestMemoryStore_Leaderboard(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	_, err := m.FetchLeaderboardFromDB(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Error(t, m.StoreLeaderboard(ctx, Leaderboard{}))
+
+	entries := []LeaderboardEntry{{UserID: "u1", Username: "Alice", Score: 3}}
+	require.NoError(t, m.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", Entries: entries}))
+
+	got, err := m.FetchLeaderboardFromDB(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entries, got)
+
+	m.Round = "stage_2"
+	_, err = m.FetchLeaderboardFromDB(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore_VRS(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	got, err := m.FetchVrsDataFromDB(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	entries := []VRSEntry{{Standing: 1, Points: 2000, TeamName: "Alpha", Roster: []string{"p1"}}}
+	require.NoError(t, m.SetVRSData(ctx, entries))
+
+	got, err = m.FetchVrsDataFromDB(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entries, got)
+}
+
+func TestMemoryStore_VRSHistory(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.SetVRSData(ctx, []VRSEntry{
+		{Standing: 2, TeamName: "Alpha", StandingsDate: "2026_05_04"},
+		{Standing: 1, TeamName: "Bravo", StandingsDate: "2026_05_04"},
+	}))
+	require.NoError(t, m.SetVRSData(ctx, []VRSEntry{{Standing: 1, TeamName: "Alpha", StandingsDate: "2026_06_01"}}))
+	require.NoError(t, m.SetVRSData(ctx, []VRSEntry{{Standing: 1, TeamName: "Charlie", StandingsDate: "2026_06_01"}}))
+
+	dates, err := m.FetchVrsStandingsDates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026_05_04", "2026_06_01"}, dates)
+
+	latest, err := m.FetchVrsDataFromDB(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []VRSEntry{{Standing: 1, TeamName: "Charlie", StandingsDate: "2026_06_01"}}, latest, "a date's snapshot is replaced")
+
+	snapshot, err := m.FetchVrsSnapshot(ctx, "2026_05_04")
+	require.NoError(t, err)
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, "Bravo", snapshot[0].TeamName, "earlier snapshots are kept and ordered by standing")
+}
+
+// endregion
+
+// region Users and reminders tests
+
+func TestMemoryStore_GetUserProfileByUsername(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.TrackUser(ctx, models.User{UserID: "u1", Username: "Alice"}))
+	require.NoError(t, m.TrackUser(ctx, models.User{UserID: "u2", Username: "a.ice"}))
+
+	profile, err := m.GetUserProfileByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", profile.UserID)
+
+	_, err = m.GetUserProfileByUsername(ctx, "a.+")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryStore_Users(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	_, err := m.GetUserProfile(ctx, "u1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, m.SetRemindersEnabled(ctx, "u1", false))
+	require.NoError(t, m.TrackUser(ctx, models.User{UserID: "u1", Username: "Alice", GuildID: "g1"}))
+	require.NoError(t, m.TrackUser(ctx, models.User{UserID: "u1", Username: "Alice", DisplayName: "Ally"}))
+	require.NoError(t, m.SetUserLocale(ctx, "u1", "pl"))
+	require.NoError(t, m.SetUserTimezone(ctx, "u1", "Europe/Warsaw"))
+
+	profile, err := m.GetUserProfile(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", profile.Username)
+	assert.Equal(t, "g1", profile.GuildID, "a DM keeps the last guild")
+	assert.Equal(t, "Ally", profile.DisplayName)
+	assert.True(t, profile.RemindersOff, "tracking a user keeps their reminder preference")
+	assert.Equal(t, "pl", profile.Locale)
+	assert.Equal(t, "Europe/Warsaw", profile.Timezone)
+	assert.WithinDuration(t, time.Now(), profile.LastSeen, time.Minute)
+
+	require.NoError(t, m.SetUserLocale(ctx, "u1", ""))
+	profiles, err := m.FetchUserProfiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.Empty(t, profiles[0].Locale)
+}
+
+func TestMemoryStore_SentReminders(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	reminder := SentReminder{UserID: "u1", Round: "stage_1", Lead: "1h0m0s", SentAt: time.Now()}
+	require.NoError(t, m.StoreSentReminder(ctx, reminder))
+	require.NoError(t, m.StoreSentReminder(ctx, reminder))
+	require.NoError(t, m.StoreSentReminder(ctx, SentReminder{UserID: "u1", Round: "stage_0", Lead: "1h0m0s"}))
+
+	got, err := m.FetchSentReminders(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "u1", got[0].UserID)
+}
+
+// endregion
+
+// region Guild tests
+
+func TestMemoryStore_MatchDayMessages(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g1", MessageID: "old", Round: "stage_1"}))
+	require.NoError(t, m.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g1", MessageID: "new", Round: "stage_1"}))
+	require.NoError(t, m.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g2", MessageID: "other", Round: "stage_0"}))
+
+	got, err := m.FetchMatchDayMessages(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "new", got[0].MessageID)
+
+	require.NoError(t, m.DeleteMatchDayMessage(ctx, "g1"))
+	require.NoError(t, m.DeleteMatchDayMessage(ctx, "g1"), "deleting a missing message is not an error")
+	got, err = m.FetchMatchDayMessages(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestMemoryStore_GuildSettings(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	_, err := m.GetGuildSettings(ctx, "g1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, m.StoreGuildSettings(ctx, GuildSettings{GuildID: "g1", Prefix: "!"}))
+	require.NoError(t, m.StoreGuildSettings(ctx, GuildSettings{GuildID: "g1", Locale: "pt"}))
+
+	got, err := m.GetGuildSettings(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, GuildSettings{GuildID: "g1", Locale: "pt"}, got, "storing settings replaces them")
+
+	all, err := m.FetchAllGuildSettings(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestMemoryStore_AuditLog_NewestFirstWithLimit(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	require.NoError(t, m.StoreAuditEntry(ctx, AuditEntry{GuildID: "g1", Command: "oldest", Timestamp: now.Add(-2 * time.Hour)}))
+	require.NoError(t, m.StoreAuditEntry(ctx, AuditEntry{GuildID: "", Command: "global", Timestamp: now.Add(-time.Hour)}))
+	require.NoError(t, m.StoreAuditEntry(ctx, AuditEntry{GuildID: "g2", Command: "other guild", Timestamp: now}))
+	require.NoError(t, m.StoreAuditEntry(ctx, AuditEntry{GuildID: "g1", Command: "newest", Timestamp: now}))
+
+	got, err := m.FetchAuditEntries(ctx, "g1", 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "newest", got[0].Command)
+	assert.Equal(t, "global", got[1].Command)
+}
+
+// endregion
+
+// region Overrides and aliases tests
+
+func TestMemoryStore_ResultOverrides(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, m.DeleteResultOverride(ctx, "m1"), ErrNotFound)
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Alpha"}))
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Beta"}))
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_0", Winner: "Gamma"}))
+
+	got, err := m.FetchResultOverrides(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Beta", got[0].Winner)
+
+	require.NoError(t, m.DeleteResultOverride(ctx, "m1"))
+	got, err = m.FetchResultOverrides(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestMemoryStore_TeamAliases(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, m.DeleteTeamAlias(ctx, "navi"), ErrNotFound)
+	require.NoError(t, m.StoreTeamAlias(ctx, TeamAlias{Alias: "navi", Team: "Natus Vincere"}))
+	require.NoError(t, m.StoreTeamAlias(ctx, TeamAlias{Alias: "faze", Team: "FaZe Clan"}))
+
+	got, err := m.FetchTeamAliases(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "faze", got[0].Alias)
+
+	require.NoError(t, m.DeleteTeamAlias(ctx, "navi"))
+	got, err = m.FetchTeamAliases(ctx)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+// endregion
+
+// region Round tests
+
+func TestMemoryStore_ReadsOtherRounds(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+	ctx := context.Background()
+
+	results := tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "3-0"}}
+	nodes := []sources.MatchNode{{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha"}}
+	entries := []LeaderboardEntry{{UserID: "u1", Username: "Alice", Score: 3}}
+	require.NoError(t, m.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Username: "Alice", Round: "stage_1"}))
+	require.NoError(t, m.StoreMatchResults(ctx, results))
+	require.NoError(t, m.StoreMatchNodes(ctx, nodes, tournament.Swiss))
+	require.NoError(t, m.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", Entries: entries}))
+	require.NoError(t, m.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Beta"}))
+
+	m.Round = "stage_2"
+	require.NoError(t, m.StoreUserPrediction(ctx, "u2", models.Prediction{UserID: "u2", Username: "Bob", Round: "stage_2"}))
+
+	pred, err := m.GetUserPredictionForRound(ctx, "u1", "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", pred.Username)
+	pred, err = m.GetUserPredictionByUsernameForRound(ctx, "alice", "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", pred.UserID)
+	_, err = m.GetUserPredictionForRound(ctx, "u2", "stage_1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	gotResults, err := m.GetMatchResultsForRound(ctx, "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, results, gotResults)
+	_, err = m.GetMatchResults(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	gotNodes, kind, err := m.FetchMatchNodesForRound(ctx, "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, nodes, gotNodes)
+	assert.Equal(t, tournament.Swiss, kind)
+
+	gotEntries, err := m.FetchLeaderboardForRound(ctx, "stage_1")
+	require.NoError(t, err)
+	assert.Equal(t, entries, gotEntries)
+
+	overrides, err := m.FetchResultOverridesForRound(ctx, "stage_1")
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "Beta", overrides[0].Winner)
+
+	rounds, err := m.FetchRounds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stage_1", "stage_2"}, rounds)
+}
+
+// endregion
+
+// region Getter tests
+
+func TestMemoryStore_Getters(t *testing.T) {
+	m := NewMemoryStore("test_db", "stage_1", nil, nil)
+
+	assert.Equal(t, "test_db", m.GetDatabase().Name())
+	assert.Equal(t, "stage_1", m.GetRound())
+	assert.NoError(t, m.GetClient().Disconnect(context.Background()))
+	assert.NoError(t, m.Ping(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, m.Ping(ctx), context.Canceled)
+}
+
+// endregion
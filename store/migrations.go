@@ -0,0 +1,329 @@
+/* migrations.go
+ * Contains the schema version every document is written with, and the migration runner that upgrades documents
+ * written by older versions of the bot at startup. Applied migrations are recorded in the migrations collection so
+ * each one runs once.
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pickems-bot/tournament"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SchemaVersion is the layout version of the documents this version of the bot writes. Bump it, and add a migration
+// upgrading documents to the new version, whenever a stored layout changes.
+const SchemaVersion = 1
+
+// schemaVersionField is the document field SchemaVersion is stored in. Documents written before versioning was
+// introduced don't have it.
+const schemaVersionField = "schema_version"
+
+// migration upgrades stored documents to the layout of Version. Up must be safe to run again if it fails part way,
+// since it is only recorded as applied once it returns successfully. It returns the number of documents it modified.
+type migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, s *Store) (int64, error)
+}
+
+// migrations lists every migration in the order they are applied
+var migrations = []migration{
+	{
+		Version:     1,
+		Description: "backfill format on legacy match nodes and predictions, and stamp schema_version",
+		Up:          migrateV1,
+	},
+	{
+		Version:     2,
+		Description: "remove duplicate predictions, keeping the newest per user and round",
+		Up:          migrateV2,
+	},
+}
+
+// MigrationRecord is the record of an applied migration in the migrations collection
+type MigrationRecord struct {
+	Version     int       `bson:"version"`
+	Description string    `bson:"description"`
+	Modified    int64     `bson:"modified"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrate applies every migration not yet recorded in the migrations collection, in order, recording each one as it
+// completes. It is called on startup, before the bot reads anything, so documents written by older versions are
+// upgraded instead of being skipped as stale.
+func (s *Store) Migrate(ctx context.Context) error {
+	applied, err := s.FetchAppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, record := range applied {
+		done[record.Version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.Version] {
+			continue
+		}
+		s.logger().Info("applying migration", "version", m.Version, "description", m.Description)
+		modified, err := m.Up(ctx, s)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		record := MigrationRecord{Version: m.Version, Description: m.Description, Modified: modified, AppliedAt: time.Now().UTC()}
+		if err := s.recordMigration(ctx, record); err != nil {
+			return err
+		}
+		s.logger().Info("applied migration", "version", m.Version, "modified", modified)
+	}
+	return nil
+}
+
+// FetchAppliedMigrations returns the record of every migration applied to the database, oldest first.
+func (s *Store) FetchAppliedMigrations(ctx context.Context) ([]MigrationRecord, error) {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.Migrations.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching applied migrations from db: %w", err)
+	}
+	var records []MigrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of migrations: %w", err)
+	}
+	return records, nil
+}
+
+// recordMigration marks a migration as applied
+func (s *Store) recordMigration(ctx context.Context, record MigrationRecord) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	if err := upsertOne(ctx, s.Collections.Migrations, bson.M{"version": record.Version}, bson.M{"$set": record}); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", record.Version, err)
+	}
+	return nil
+}
+
+// versioned marshals doc into a bson.D tagged with the current SchemaVersion, for writes that store a struct.
+func versioned(doc any) (bson.D, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+	var d bson.D
+	if err := bson.Unmarshal(raw, &d); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+	return append(d, bson.E{Key: schemaVersionField, Value: SchemaVersion}), nil
+}
+
+// missingFormat matches documents with no format recorded
+var missingFormat = bson.M{"$or": bson.A{
+	bson.M{"format": bson.M{"$exists": false}},
+	bson.M{"format": ""},
+}}
+
+// migrateV1 backfills the format of match nodes and predictions stored before the format was recorded, then stamps
+// every document written before versioning with schema_version 1, except those whose format couldn't be inferred.
+func migrateV1(ctx context.Context, s *Store) (int64, error) {
+	var total int64
+	for _, step := range []func(context.Context) (int64, error){s.backfillMatchNodeFormats, s.backfillPredictionFormats, s.stampSchemaVersion} {
+		modified, err := step(ctx)
+		total += modified
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// roundFormat returns the format of the given round, taken from the type of its stored match results. Returns an
+// empty Kind if the round has no match results.
+func (s *Store) roundFormat(ctx context.Context, round string) (tournament.Kind, error) {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	var doc struct {
+		Type tournament.Kind `bson:"type"`
+	}
+	err := s.Collections.MatchResults.FindOne(ctx, bson.M{"round": round}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error fetching match results of round %s: %w", round, err)
+	}
+	return doc.Type, nil
+}
+
+// backfillMatchNodeFormats sets the format of legacy match nodes documents from their round's match results.
+// FetchMatchNodesFromDb returns an empty Kind for documents it can't infer a format for.
+func (s *Store) backfillMatchNodeFormats(ctx context.Context) (int64, error) {
+	return s.backfillFormats(ctx, s.Collections.MatchNodes, "match nodes")
+}
+
+// backfillPredictionFormats sets the format of legacy predictions from their round's match results. Predictions in
+// rounds without results are recognised as swiss by their win, advance or lose picks, the fields only swiss uses.
+func (s *Store) backfillPredictionFormats(ctx context.Context) (int64, error) {
+	modified, err := s.backfillFormats(ctx, s.Collections.Predictions, "predictions")
+	if err != nil {
+		return modified, err
+	}
+
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"$and": bson.A{missingFormat, bson.M{"$or": bson.A{
+		bson.M{"win.0": bson.M{"$exists": true}},
+		bson.M{"advance.0": bson.M{"$exists": true}},
+		bson.M{"lose.0": bson.M{"$exists": true}},
+	}}}}
+	res, err := s.Collections.Predictions.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"format": string(tournament.Swiss)}})
+	if err != nil {
+		return modified, fmt.Errorf("failed to backfill format of swiss predictions: %w", err)
+	}
+	return modified + res.ModifiedCount, nil
+}
+
+// backfillFormats sets the format of every document in coll that has none to the format of its round's match
+// results. Rounds without match results are left for the caller to handle.
+func (s *Store) backfillFormats(ctx context.Context, coll *mongo.Collection, name string) (int64, error) {
+	distinctCtx, cancel := withTimeout(ctx, s.OpTimeout)
+	rounds, err := coll.Distinct(distinctCtx, "round", missingFormat)
+	cancel()
+	if err != nil {
+		return 0, fmt.Errorf("error fetching rounds of %s without a format: %w", name, err)
+	}
+
+	var modified int64
+	for _, value := range rounds {
+		round, ok := value.(string)
+		if !ok {
+			continue
+		}
+		kind, err := s.roundFormat(ctx, round)
+		if err != nil {
+			return modified, err
+		}
+		if kind == "" {
+			s.logger().Warn("cannot infer format of legacy documents without match results", "collection", name, "round", round)
+			continue
+		}
+
+		updateCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		filter := bson.M{"$and": bson.A{missingFormat, bson.M{"round": round}}}
+		res, err := coll.UpdateMany(updateCtx, filter, bson.M{"$set": bson.M{"format": string(kind)}})
+		cancel()
+		if err != nil {
+			return modified, fmt.Errorf("failed to backfill format of %s in round %s: %w", name, round, err)
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, nil
+}
+
+// stampSchemaVersion sets schema_version 1 on every document written before versioning was introduced. Match nodes
+// and predictions whose format couldn't be backfilled are left unstamped, so they aren't mistaken for upgraded
+// documents, and are counted in a warning. The VRS collection is maintained outside the bot and is left alone.
+func (s *Store) stampSchemaVersion(ctx context.Context) (int64, error) {
+	unstamped := bson.M{schemaVersionField: bson.M{"$exists": false}}
+	var modified int64
+	for _, coll := range s.versionedCollections() {
+		filter := unstamped
+		if s.needsFormat(coll) {
+			filter = bson.M{"$and": bson.A{unstamped, bson.M{"format": bson.M{"$nin": bson.A{nil, ""}}}}}
+		}
+		stampCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		res, err := coll.UpdateMany(stampCtx, filter, bson.M{"$set": bson.M{schemaVersionField: 1}})
+		cancel()
+		if err != nil {
+			return modified, fmt.Errorf("failed to stamp schema version on %s: %w", coll.Name(), err)
+		}
+		modified += res.ModifiedCount
+	}
+
+	for _, coll := range []*mongo.Collection{s.Collections.MatchNodes, s.Collections.Predictions} {
+		countCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		count, err := coll.CountDocuments(countCtx, bson.M{"$and": bson.A{unstamped, missingFormat}})
+		cancel()
+		if err != nil {
+			return modified, fmt.Errorf("failed to count unstamped documents in %s: %w", coll.Name(), err)
+		}
+		if count > 0 {
+			s.logger().Warn("left legacy documents without a format unstamped", "collection", coll.Name(), "count", count)
+		}
+	}
+	return modified, nil
+}
+
+// migrateV2 removes the duplicate predictions that racing writes of older versions could create, so the unique
+// (userid, round) index EnsureIndexes builds can be created. The newest document of each user and round, by _id, is
+// kept.
+func migrateV2(ctx context.Context, s *Store) (int64, error) {
+	aggregateCtx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "userid", Value: "$userid"}, {Key: "round", Value: "$round"}}},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "ids.1", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+	}
+	cursor, err := s.Collections.Predictions.Aggregate(aggregateCtx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("error finding duplicate predictions: %w", err)
+	}
+	var groups []struct {
+		IDs []any `bson:"ids"`
+	}
+	if err := cursor.All(aggregateCtx, &groups); err != nil {
+		return 0, fmt.Errorf("error unpacking cursor into slice of duplicate predictions: %w", err)
+	}
+
+	var stale bson.A
+	for _, group := range groups {
+		stale = append(stale, group.IDs[1:]...)
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	deleteCtx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	res, err := s.Collections.Predictions.DeleteMany(deleteCtx, bson.M{"_id": bson.M{"$in": stale}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate predictions: %w", err)
+	}
+	s.logger().Warn("removed duplicate predictions", "users_and_rounds", len(groups), "deleted", res.DeletedCount)
+	return res.DeletedCount, nil
+}
+
+// needsFormat reports whether the documents of coll can only be read once their format is known
+func (s *Store) needsFormat(coll *mongo.Collection) bool {
+	return coll == s.Collections.MatchNodes || coll == s.Collections.Predictions
+}
+
+// versionedCollections returns every collection whose documents carry a schema_version
+func (s *Store) versionedCollections() []*mongo.Collection {
+	return []*mongo.Collection{
+		s.Collections.Predictions,
+		s.Collections.MatchResults,
+		s.Collections.MatchNodes,
+		s.Collections.MatchSchedule,
+		s.Collections.Leaderboard,
+		s.Collections.Users,
+		s.Collections.Reminders,
+		s.Collections.MatchDayMessages,
+		s.Collections.GuildSettings,
+		s.Collections.AuditLog,
+		s.Collections.Overrides,
+		s.Collections.TeamAliases,
+	}
+}
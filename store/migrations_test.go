@@ -0,0 +1,338 @@
+/* migrations_test.go
+ * Contains unit tests for migrations.go
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pickems-bot/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// withMigrations replaces the registered migrations for the duration of a test
+func withMigrations(t *testing.T, replacement []migration) {
+	original := migrations
+	migrations = replacement
+	t.Cleanup(func() { migrations = original })
+}
+
+// allCollections returns a Collections with every collection set to the mock collection
+func allCollections(mt *mtest.T) Collections {
+	return Collections{
+		Predictions:      mt.Coll,
+		MatchResults:     mt.Coll,
+		MatchNodes:       mt.Coll,
+		MatchSchedule:    mt.Coll,
+		Leaderboard:      mt.Coll,
+		Users:            mt.Coll,
+		Reminders:        mt.Coll,
+		MatchDayMessages: mt.Coll,
+		GuildSettings:    mt.Coll,
+		AuditLog:         mt.Coll,
+		Overrides:        mt.Coll,
+		TeamAliases:      mt.Coll,
+		Migrations:       mt.Coll,
+	}
+}
+
+// updateManyResponse mocks an UpdateMany that modified n documents
+func updateManyResponse(n int) bson.D {
+	return bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: n}, {Key: "nModified", Value: n}}
+}
+
+// countResponse mocks a CountDocuments that counted n documents
+func countResponse(n int) bson.D {
+	return mtest.CreateCursorResponse(0, "test.coll", mtest.FirstBatch, bson.D{{Key: "n", Value: n}})
+}
+
+// region Migrate tests
+
+func TestMigrate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("skips migrations that are already recorded", func(mt *mtest.T) {
+		ran := false
+		withMigrations(t, []migration{{Version: 1, Description: "first", Up: func(context.Context, *Store) (int64, error) {
+			ran = true
+			return 0, nil
+		}}})
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.migrations", mtest.FirstBatch,
+			bson.D{{Key: "version", Value: 1}, {Key: "description", Value: "first"}},
+		))
+
+		require.NoError(t, store.Migrate(context.Background()))
+		assert.False(t, ran)
+	})
+
+	mt.Run("applies pending migrations in order and records them", func(mt *mtest.T) {
+		var order []int
+		up := func(version int) func(context.Context, *Store) (int64, error) {
+			return func(context.Context, *Store) (int64, error) {
+				order = append(order, version)
+				return 3, nil
+			}
+		}
+		withMigrations(t, []migration{
+			{Version: 1, Description: "first", Up: up(1)},
+			{Version: 2, Description: "second", Up: up(2)},
+		})
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.migrations", mtest.FirstBatch, bson.D{{Key: "version", Value: 1}}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		require.NoError(t, store.Migrate(context.Background()))
+		assert.Equal(t, []int{2}, order)
+
+		mt.GetStartedEvent() // find
+		recorded := mt.GetStartedEvent()
+		require.Equal(t, "update", recorded.CommandName)
+		set := recorded.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u", "$set").Document()
+		assert.Equal(t, int32(2), set.Lookup("version").Int32())
+		assert.Equal(t, int64(3), set.Lookup("modified").Int64())
+	})
+
+	mt.Run("does not record a failed migration", func(mt *mtest.T) {
+		withMigrations(t, []migration{{Version: 1, Description: "first", Up: func(context.Context, *Store) (int64, error) {
+			return 0, errors.New("boom")
+		}}})
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.migrations", mtest.FirstBatch))
+
+		err := store.Migrate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "migration 1 (first) failed")
+
+		mt.GetStartedEvent() // find
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("returns an error when applied migrations can't be fetched", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.Migrate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching applied migrations from db")
+	})
+}
+
+// endregion
+
+// region migrateV1 tests
+
+func TestBackfillMatchNodeFormats(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets the format from each round's match results", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "values", Value: bson.A{"stage_1", "stage_2"}}},
+			mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch, bson.D{{Key: "round", Value: "stage_1"}, {Key: "type", Value: "swiss"}}),
+			updateManyResponse(1),
+			mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch),
+		)
+
+		modified, err := store.backfillMatchNodeFormats(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), modified)
+
+		var update *bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "update" {
+				update = &event.Command
+			}
+		}
+		require.NotNil(t, update)
+		u := update.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, "swiss", u.Lookup("u", "$set", "format").StringValue())
+		assert.True(t, u.Lookup("multi").Boolean())
+	})
+
+	mt.Run("returns an error when the update fails", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "values", Value: bson.A{"stage_1"}}},
+			mtest.CreateCursorResponse(0, "test.match_results", mtest.FirstBatch, bson.D{{Key: "type", Value: "swiss"}}),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}),
+		)
+
+		_, err := store.backfillMatchNodeFormats(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to backfill format of match nodes in round stage_1")
+	})
+}
+
+func TestBackfillPredictionFormats(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("falls back to swiss for predictions with swiss picks", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			bson.D{{Key: "ok", Value: 1}, {Key: "values", Value: bson.A{}}},
+			updateManyResponse(2),
+		)
+
+		modified, err := store.backfillPredictionFormats(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), modified)
+
+		mt.GetStartedEvent() // distinct
+		update := mt.GetStartedEvent()
+		require.Equal(t, "update", update.CommandName)
+		assert.Equal(t, "swiss", update.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u", "$set", "format").StringValue())
+	})
+}
+
+func TestStampSchemaVersion(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stamps every versioned collection", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		collections := len(store.versionedCollections())
+		for range collections {
+			mt.AddMockResponses(updateManyResponse(1))
+		}
+		mt.AddMockResponses(countResponse(0), countResponse(0))
+
+		modified, err := store.stampSchemaVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(collections), modified)
+	})
+
+	mt.Run("leaves documents without a format unstamped", func(mt *mtest.T) {
+		collections := allCollections(mt)
+		// Give the other collections their own handle so only predictions and match nodes need a format
+		collections.Users = mt.DB.Collection("users")
+		store := &Store{Collections: collections}
+		for range store.versionedCollections() {
+			mt.AddMockResponses(updateManyResponse(0))
+		}
+		mt.AddMockResponses(countResponse(0), countResponse(2))
+
+		_, err := store.stampSchemaVersion(context.Background())
+		require.NoError(t, err)
+
+		var predictions, users bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName != "update" {
+				continue
+			}
+			switch event.Command.Lookup("update").StringValue() {
+			case mt.Coll.Name():
+				predictions = event.Command
+			case "users":
+				users = event.Command
+			}
+		}
+		require.NotNil(t, predictions)
+		require.NotNil(t, users)
+		q := predictions.Lookup("updates").Array().Index(0).Value().Document().Lookup("q")
+		assert.Contains(t, q.String(), `"format"`)
+		q = users.Lookup("updates").Array().Index(0).Value().Document().Lookup("q")
+		assert.NotContains(t, q.String(), `"format"`)
+	})
+
+	mt.Run("returns an error when stamping fails", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.stampSchemaVersion(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to stamp schema version")
+	})
+}
+
+func TestMigrateV2(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes all but the newest prediction of each user and round", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch,
+				bson.D{{Key: "ids", Value: bson.A{"c", "b", "a"}}},
+				bson.D{{Key: "ids", Value: bson.A{"e", "d"}}},
+			),
+			bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 3}},
+		)
+
+		deleted, err := migrateV2(context.Background(), store)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+
+		aggregate := mt.GetStartedEvent()
+		require.Equal(t, "aggregate", aggregate.CommandName)
+		del := mt.GetStartedEvent()
+		require.Equal(t, "delete", del.CommandName)
+		ids := del.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q", "_id", "$in").Array()
+		values, err := ids.Values()
+		require.NoError(t, err)
+		var got []string
+		for _, v := range values {
+			got = append(got, v.StringValue())
+		}
+		assert.Equal(t, []string{"b", "a", "d"}, got)
+	})
+
+	mt.Run("deletes nothing without duplicates", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
+
+		deleted, err := migrateV2(context.Background(), store)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		mt.GetStartedEvent() // aggregate
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("returns an error when duplicates can't be found", func(mt *mtest.T) {
+		store := &Store{Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := migrateV2(context.Background(), store)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error finding duplicate predictions")
+	})
+}
+
+// endregion
+
+// region versioned tests
+
+func TestVersioned(t *testing.T) {
+	doc, err := versioned(models.Prediction{UserID: "u1", Round: "stage_1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.D{
+		{Key: "userid", Value: "u1"},
+		{Key: "round", Value: "stage_1"},
+		{Key: schemaVersionField, Value: SchemaVersion},
+	}, doc)
+}
+
+func TestStoreUserPrediction_StampsSchemaVersion(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("writes the current schema version", func(mt *mtest.T) {
+		store := &Store{Round: "stage_1", Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		require.NoError(t, store.StoreUserPrediction(context.Background(), "u1", models.Prediction{UserID: "u1", Round: "stage_1"}))
+
+		set := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u", "$set").Document()
+		assert.Equal(t, int32(SchemaVersion), set.Lookup(schemaVersionField).Int32())
+	})
+}
+
+// endregion
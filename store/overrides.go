@@ -0,0 +1,183 @@
+/* overrides.go
+ * Contains the methods for interacting with the result_overrides collection. Overrides let admins pin the
+ * winner (and optionally the score) of a match node while the data source is wrong. They are applied on top of
+ * the raw match nodes before results are built, and are removed once the source agrees.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ResultOverride pins the result of a single match node for a round
+type ResultOverride struct {
+	MatchID   string    `bson:"match_id"`
+	Round     string    `bson:"round"`
+	Winner    string    `bson:"winner"`
+	Score     string    `bson:"score,omitempty"` // empty keeps the source's score
+	SetBy     string    `bson:"set_by"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// AgreesWith reports whether the source data for a match node already matches the override
+func (o ResultOverride) AgreesWith(node sources.MatchNode) bool {
+	return node.Winner == o.Winner && (o.Score == "" || node.Score == o.Score)
+}
+
+// ApplyResultOverrides returns a copy of nodes with each override's winner and score pinned onto the node with
+// the matching ID. Overrides for unknown IDs are ignored.
+func ApplyResultOverrides(nodes []sources.MatchNode, overrides []ResultOverride) []sources.MatchNode {
+	if len(overrides) == 0 {
+		return nodes
+	}
+	byID := make(map[string]ResultOverride, len(overrides))
+	for _, o := range overrides {
+		byID[o.MatchID] = o
+	}
+	out := make([]sources.MatchNode, len(nodes))
+	for i, node := range nodes {
+		if o, ok := byID[node.ID]; ok {
+			node.Winner = o.Winner
+			if o.Score != "" {
+				node.Score = o.Score
+			}
+		}
+		out[i] = node
+	}
+	return out
+}
+
+// FetchResultOverrides returns the overrides for the current round.
+func (s *Store) FetchResultOverrides(ctx context.Context) ([]ResultOverride, error) {
+	return s.FetchResultOverridesForRound(ctx, s.Round)
+}
+
+// FetchResultOverridesForRound returns the result overrides of the given round.
+func (s *Store) FetchResultOverridesForRound(ctx context.Context, round string) ([]ResultOverride, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.Overrides.Find(ctx, bson.M{"round": round})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching result overrides from db: %w", err)
+	}
+	var results []ResultOverride
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of result overrides: %w", err)
+	}
+	return results, nil
+}
+
+// StoreResultOverride stores an override, replacing any existing override for the same match.
+func (s *Store) StoreResultOverride(ctx context.Context, override ResultOverride) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"round": override.Round, "match_id": override.MatchID}
+	doc, err := versioned(override)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Collections.Overrides.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to store result override: %w", err)
+	}
+	return nil
+}
+
+// DeleteResultOverride removes the override for a match in the current round. Returns ErrNotFound
+// when the match has no override.
+func (s *Store) DeleteResultOverride(ctx context.Context, matchID string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	res, err := s.Collections.Overrides.DeleteOne(ctx, bson.M{"round": s.Round, "match_id": matchID})
+	if err != nil {
+		return fmt.Errorf("failed to delete result override: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RebuildMatchResults rebuilds the stored match results from the stored match nodes with the current
+// overrides applied, without calling the data source.
+func (s *Store) RebuildMatchResults(ctx context.Context) error {
+	return rebuildMatchResults(ctx, s)
+}
+
+// rebuildMatchResults implements RebuildMatchResults for any backend
+func rebuildMatchResults(ctx context.Context, s pipelineStore) error {
+	nodes, kind, err := s.FetchMatchNodesFromDb(ctx)
+	if err != nil {
+		return err
+	}
+	if kind == "" {
+		return fmt.Errorf("stored match nodes have no format, cannot rebuild results")
+	}
+	overrides, err := s.FetchResultOverrides(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := buildMatchResult(ApplyResultOverrides(nodes, overrides), kind, s.GetRound())
+	if err != nil {
+		return err
+	}
+	return s.StoreMatchResults(ctx, result)
+}
+
+// reconcileOverrides deletes the overrides the source now agrees with, recording each in the audit log, and
+// returns the ones still in effect.
+func reconcileOverrides(ctx context.Context, s pipelineStore, log *slog.Logger, nodes []sources.MatchNode, overrides []ResultOverride) []ResultOverride {
+	byID := make(map[string]sources.MatchNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+
+	var active []ResultOverride
+	for _, o := range overrides {
+		node, ok := byID[o.MatchID]
+		if !ok || !o.AgreesWith(node) {
+			active = append(active, o)
+			continue
+		}
+		if err := s.DeleteResultOverride(ctx, o.MatchID); err != nil {
+			log.Warn("failed to clear result override", "match", o.MatchID, "error", fmt.Errorf("reconcileOverrides: %w", err))
+			active = append(active, o)
+			continue
+		}
+		log.Info("cleared result override, source now agrees", "match", o.MatchID, "winner", o.Winner)
+		entry := AuditEntry{
+			Username:  "data source",
+			Command:   "override",
+			Args:      fmt.Sprintf("clear %s (source agrees: %s %s)", o.MatchID, o.Winner, node.Score),
+			Round:     s.GetRound(),
+			Result:    "ok",
+			Timestamp: time.Now().UTC(),
+		}
+		if err := s.StoreAuditEntry(ctx, entry); err != nil {
+			log.Warn("failed to audit cleared override", "match", o.MatchID, "error", fmt.Errorf("reconcileOverrides: %w", err))
+		}
+	}
+	return active
+}
+
+// buildMatchResult builds a MatchResult of the given kind from match nodes
+func buildMatchResult(nodes []sources.MatchNode, kind tournament.Kind, round string) (tournament.MatchResult, error) {
+	format, err := tournament.Get(kind)
+	if err != nil {
+		return nil, err
+	}
+	return format.BuildFromMatchNodes(nodes, round)
+}
@@ -0,0 +1,242 @@
+/* overrides_test.go
+ * Contains unit tests for overrides.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region ApplyResultOverrides tests
+
+func TestApplyResultOverrides_PinsWinnerAndScore(t *testing.T) {
+	nodes := []sources.MatchNode{
+		{ID: "m1", Team1: "Team A", Team2: "Team B", Winner: "Team B", Score: "0-2"},
+		{ID: "m2", Team1: "Team C", Team2: "Team D", Winner: "Team C", Score: "2-1"},
+	}
+	overrides := []ResultOverride{
+		{MatchID: "m1", Winner: "Team A", Score: "2-0"},
+		{MatchID: "m2", Winner: "Team D"},
+		{MatchID: "missing", Winner: "Team Z"},
+	}
+
+	out := ApplyResultOverrides(nodes, overrides)
+
+	require.Len(t, out, 2)
+	assert.Equal(t, "Team A", out[0].Winner)
+	assert.Equal(t, "2-0", out[0].Score)
+	assert.Equal(t, "Team D", out[1].Winner)
+	assert.Equal(t, "2-1", out[1].Score, "score is kept when the override doesn't pin one")
+	assert.Equal(t, "Team B", nodes[0].Winner, "input nodes must not be modified")
+}
+
+func TestApplyResultOverrides_NoOverrides(t *testing.T) {
+	nodes := []sources.MatchNode{{ID: "m1", Winner: "Team A"}}
+	assert.Equal(t, nodes, ApplyResultOverrides(nodes, nil))
+}
+
+func TestResultOverride_AgreesWith(t *testing.T) {
+	node := sources.MatchNode{ID: "m1", Winner: "Team A", Score: "2-1"}
+
+	assert.True(t, ResultOverride{Winner: "Team A"}.AgreesWith(node))
+	assert.True(t, ResultOverride{Winner: "Team A", Score: "2-1"}.AgreesWith(node))
+	assert.False(t, ResultOverride{Winner: "Team A", Score: "2-0"}.AgreesWith(node))
+	assert.False(t, ResultOverride{Winner: "Team B"}.AgreesWith(node))
+}
+
+// endregion
+
+// region FetchResultOverrides tests
+
+func TestFetchResultOverrides_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns overrides for the current round", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.result_overrides", mtest.FirstBatch,
+			bson.D{
+				{Key: "match_id", Value: "m1"},
+				{Key: "round", Value: "test_round"},
+				{Key: "winner", Value: "Team A"},
+				{Key: "score", Value: "2-0"},
+				{Key: "set_by", Value: "admin"},
+				{Key: "created_at", Value: time.Now()},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.result_overrides", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		overrides, err := store.FetchResultOverrides(context.Background())
+		require.NoError(t, err)
+		require.Len(t, overrides, 1)
+		assert.Equal(t, "m1", overrides[0].MatchID)
+		assert.Equal(t, "Team A", overrides[0].Winner)
+		assert.Equal(t, "admin", overrides[0].SetBy)
+	})
+}
+
+func TestFetchResultOverrides_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchResultOverrides(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching result overrides")
+	})
+}
+
+// endregion
+
+// region StoreResultOverride / DeleteResultOverride tests
+
+func TestStoreResultOverride_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts the override", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := store.StoreResultOverride(context.Background(), ResultOverride{MatchID: "m1", Round: "test_round", Winner: "Team A"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestStoreResultOverride_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when replace fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.StoreResultOverride(context.Background(), ResultOverride{MatchID: "m1", Round: "test_round"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store result override")
+	})
+}
+
+func TestDeleteResultOverride(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the override", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+
+		assert.NoError(t, store.DeleteResultOverride(context.Background(), "m1"))
+	})
+
+	mt.Run("returns ErrNoDocuments when there is no override", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		assert.Equal(t, mongo.ErrNoDocuments, store.DeleteResultOverride(context.Background(), "m1"))
+	})
+
+	mt.Run("returns error when delete fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.DeleteResultOverride(context.Background(), "m1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete result override")
+	})
+}
+
+// endregion
+
+// region reconcileOverrides tests
+
+func TestReconcileOverrides_ClearsAgreedAndAudits(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("removes overrides the source agrees with", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll, AuditLog: mt.Coll}}
+		// delete of m1, then the audit log insert
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}}, mtest.CreateSuccessResponse())
+
+		nodes := []sources.MatchNode{
+			{ID: "m1", Winner: "Team A", Score: "2-0"},
+			{ID: "m2", Winner: "Team C", Score: "2-1"},
+		}
+		overrides := []ResultOverride{
+			{MatchID: "m1", Winner: "Team A"},
+			{MatchID: "m2", Winner: "Team D"},
+			{MatchID: "gone", Winner: "Team Z"},
+		}
+
+		active := reconcileOverrides(context.Background(), store, store.logger(), nodes, overrides)
+
+		require.Len(t, active, 2)
+		assert.Equal(t, "m2", active[0].MatchID)
+		assert.Equal(t, "gone", active[1].MatchID)
+	})
+
+	mt.Run("keeps the override when deleting fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Overrides: mt.Coll, AuditLog: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		active := reconcileOverrides(context.Background(), store, store.logger(), []sources.MatchNode{{ID: "m1", Winner: "Team A"}}, []ResultOverride{{MatchID: "m1", Winner: "Team A"}})
+
+		require.Len(t, active, 1)
+	})
+}
+
+// endregion
+
+// region RebuildMatchResults tests
+
+func TestRebuildMatchResults_AppliesOverrides(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stores results built from overridden nodes", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchNodes: mt.Coll, Overrides: mt.Coll, MatchResults: mt.Coll}}
+		nodes := mtest.CreateCursorResponse(0, "test.match_nodes", mtest.FirstBatch, bson.D{
+			{Key: "round", Value: "test_round"},
+			{Key: "format", Value: "swiss"},
+			{Key: "nodes", Value: bson.A{
+				bson.D{{Key: "id", Value: "m1"}, {Key: "team1", Value: "Team A"}, {Key: "team2", Value: "Team B"}, {Key: "winner", Value: "Team B"}},
+			}},
+		})
+		overrides := mtest.CreateCursorResponse(0, "test.result_overrides", mtest.FirstBatch,
+			bson.D{{Key: "match_id", Value: "m1"}, {Key: "round", Value: "test_round"}, {Key: "winner", Value: "Team A"}},
+		)
+		mt.AddMockResponses(nodes, overrides, mtest.CreateSuccessResponse())
+
+		require.NoError(t, store.RebuildMatchResults(context.Background()))
+
+		upserted := mt.GetStartedEvent()
+		for upserted != nil && upserted.CommandName != "update" {
+			upserted = mt.GetStartedEvent()
+		}
+		require.NotNil(t, upserted)
+		doc := upserted.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u", "$set").Document()
+		assert.Equal(t, "1-0", doc.Lookup("teams", "Team A").StringValue())
+		assert.Equal(t, "0-1", doc.Lookup("teams", "Team B").StringValue())
+	})
+}
+
+func TestRebuildMatchResults_NoNodes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns ErrNoDocuments when no nodes are stored", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{MatchNodes: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.match_nodes", mtest.FirstBatch))
+
+		assert.ErrorIs(t, store.RebuildMatchResults(context.Background()), mongo.ErrNoDocuments)
+	})
+}
+
+// endregion
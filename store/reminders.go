@@ -0,0 +1,60 @@
+/* reminders.go
+ * Contains the methods for interacting with the reminders collection, which records every reminder DM
+ * that has been sent so a restart never sends the same reminder twice.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pickems-bot/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SentReminder records a single reminder sent for a round: either a DM to a user (UserID set) or a notice
+// posted to a guild's reminder channel (GuildID set)
+type SentReminder struct {
+	UserID  string    `bson:"userid"`
+	GuildID string    `bson:"guild_id"`
+	Round   string    `bson:"round"`
+	Lead    string    `bson:"lead"` // lead time the reminder was sent for, e.g. "1h0m0s"
+	SentAt  time.Time `bson:"sent_at"`
+}
+
+// FetchSentReminders returns every reminder sent for the current round.
+func (s *Store) FetchSentReminders(ctx context.Context) ([]SentReminder, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.Reminders.Find(ctx, bson.M{"round": s.Round})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sent reminders from db: %w", err)
+	}
+	var results []SentReminder
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of sent reminders: %w", err)
+	}
+	return results, nil
+}
+
+// StoreSentReminder records that a reminder has been sent. Recording the same reminder twice is a no-op.
+func (s *Store) StoreSentReminder(ctx context.Context, reminder SentReminder) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"userid": reminder.UserID, "guild_id": reminder.GuildID, "round": reminder.Round, "lead": reminder.Lead}
+	doc, err := versioned(reminder)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$setOnInsert": doc}
+	if _, err := s.Collections.Reminders.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to record sent reminder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/* reminders_test.go
+ * Contains unit tests for reminders.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region FetchSentReminders tests
+
+func TestFetchSentReminders_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns reminders for the current round", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Reminders: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.reminders", mtest.FirstBatch,
+			bson.D{
+				{Key: "userid", Value: "user1"},
+				{Key: "round", Value: "test_round"},
+				{Key: "lead", Value: "1h0m0s"},
+				{Key: "sent_at", Value: time.Now()},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.reminders", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		reminders, err := store.FetchSentReminders(context.Background())
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, "user1", reminders[0].UserID)
+		assert.Equal(t, "1h0m0s", reminders[0].Lead)
+	})
+}
+
+func TestFetchSentReminders_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Reminders: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchSentReminders(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching sent reminders")
+	})
+}
+
+// endregion
+
+// region StoreSentReminder tests
+
+func TestStoreSentReminder_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("records the reminder", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Reminders: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := store.StoreSentReminder(context.Background(), SentReminder{UserID: "user1", Round: "test_round", Lead: "1h0m0s", SentAt: time.Now()})
+		assert.NoError(t, err)
+	})
+}
+
+func TestStoreSentReminder_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when upsert fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Reminders: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.StoreSentReminder(context.Background(), SentReminder{UserID: "user1", Round: "test_round", Lead: "1h0m0s"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to record sent reminder")
+	})
+}
+
+// endregion
@@ -0,0 +1,51 @@
+/* rounds.go
+ * Contains FetchRounds, which lists the rounds the database holds data for so earlier stages can still be looked up
+ * after the configured round moves on.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"pickems-bot/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FetchRounds returns every round with stored predictions, match results, match nodes, a schedule or a leaderboard,
+// sorted by name.
+func (s *Store) FetchRounds(ctx context.Context) ([]string, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	var rounds []string
+	for _, coll := range []*mongo.Collection{
+		s.Collections.Predictions,
+		s.Collections.MatchResults,
+		s.Collections.MatchNodes,
+		s.Collections.MatchSchedule,
+		s.Collections.Leaderboard,
+	} {
+		distinctCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		values, err := coll.Distinct(distinctCtx, "round", bson.M{})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching rounds from %s: %w", coll.Name(), err)
+		}
+		for _, value := range values {
+			if round, ok := value.(string); ok {
+				rounds = append(rounds, round)
+			}
+		}
+	}
+	return sortedRounds(rounds), nil
+}
+
+// sortedRounds sorts rounds by name and drops duplicates and empty names
+func sortedRounds(rounds []string) []string {
+	rounds = slices.DeleteFunc(rounds, func(round string) bool { return round == "" })
+	slices.Sort(rounds)
+	return slices.Compact(rounds)
+}
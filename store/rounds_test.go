@@ -0,0 +1,93 @@
+/* rounds_test.go
+ * Contains unit tests for rounds.go and the round-parameterised reads of the MongoDB store
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// distinctResponse mocks a distinct command returning values
+func distinctResponse(values ...any) bson.D {
+	return bson.D{{Key: "ok", Value: 1}, {Key: "values", Value: append(bson.A{}, values...)}}
+}
+
+// region FetchRounds tests
+
+func TestStore_FetchRounds(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("merges the rounds of every per-round collection", func(mt *mtest.T) {
+		store := &Store{Round: "stage_2", Collections: allCollections(mt)}
+		mt.AddMockResponses(
+			distinctResponse("stage_2", "stage_1"),
+			distinctResponse("stage_1"),
+			distinctResponse("stage_1", ""),
+			distinctResponse(),
+			distinctResponse("playoffs"),
+		)
+
+		rounds, err := store.FetchRounds(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"playoffs", "stage_1", "stage_2"}, rounds)
+	})
+
+	mt.Run("returns an error when a lookup fails", func(mt *mtest.T) {
+		store := &Store{Round: "stage_2", Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchRounds(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching rounds")
+	})
+}
+
+// endregion
+
+// region Round-parameterised read tests
+
+func TestStore_GetUserPredictionForRound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters by the given round instead of the current one", func(mt *mtest.T) {
+		store := &Store{Round: "stage_2", Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch,
+			bson.D{{Key: "userid", Value: "u1"}, {Key: "username", Value: "Alice"}, {Key: "round", Value: "stage_1"}},
+		))
+
+		pred, err := store.GetUserPredictionForRound(context.Background(), "u1", "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", pred.Username)
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		assert.Equal(t, "stage_1", filter.Lookup("round").StringValue())
+	})
+}
+
+func TestStore_FetchLeaderboardForRound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters by the given round instead of the current one", func(mt *mtest.T) {
+		store := &Store{Round: "stage_2", Collections: allCollections(mt)}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.leaderboard", mtest.FirstBatch, bson.D{
+			{Key: "round", Value: "stage_1"},
+			{Key: "entries", Value: bson.A{bson.D{{Key: "userid", Value: "u1"}, {Key: "username", Value: "Alice"}}}},
+		}))
+
+		entries, err := store.FetchLeaderboardForRound(context.Background(), "stage_1")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		assert.Equal(t, "stage_1", filter.Lookup("round").StringValue())
+	})
+}
+
+// endregion
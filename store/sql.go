@@ -0,0 +1,1083 @@
+/* sql.go
+ * Contains SQLStore, a relational implementation of Interface backed by SQLite or PostgreSQL. It keeps the same
+ * semantics as the MongoDB store: data is scoped by round, missing rows return ErrNotFound, and stores replace
+ * whatever was there for the same key. Like MongoDB's database-per-tournament layout, each tournament should use
+ * its own SQLite file or PostgreSQL database. The schema lives in sql_schema.go.
+ */
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// SQL dialects accepted by NewSQLStore
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// sqlDrivers maps each dialect to the database/sql driver that serves it
+var sqlDrivers = map[string]string{
+	SQLite:   "sqlite",
+	Postgres: "pgx",
+}
+
+// SQLStore is a SQLite or PostgreSQL store backend. Create one with NewSQLStore.
+type SQLStore struct {
+	DB      *sql.DB
+	Round   string
+	Fetcher DataSourceFetcher
+	// OpTimeout bounds each database operation and FetchTimeout each data source request, on top of whatever
+	// deadline the caller's context carries. Zero leaves them bounded only by the caller's context.
+	OpTimeout    time.Duration
+	FetchTimeout time.Duration
+	dialect      string
+	dbName       string
+	log          *slog.Logger
+}
+
+// sqlDatabase is the database handle GetDatabase returns
+type sqlDatabase struct{ name string }
+
+// Name returns the tournament name the store was created for
+func (d sqlDatabase) Name() string { return d.name }
+
+// sqlClient is the client GetClient returns
+type sqlClient struct{ db *sql.DB }
+
+// Disconnect closes the connection pool
+func (c sqlClient) Disconnect(context.Context) error { return c.db.Close() }
+
+// NewSQLStore opens a SQLite or PostgreSQL database and creates any missing tables. dialect is SQLite or Postgres;
+// dsn is a file path (or ":memory:") for SQLite and a connection URL for PostgreSQL. ctx bounds the schema setup.
+// log may be nil; if so the global slog default is used.
+func NewSQLStore(ctx context.Context, dialect string, dsn string, dbName string, round string, fetcher DataSourceFetcher, log *slog.Logger) (*SQLStore, error) {
+	driver, ok := sqlDrivers[dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == SQLite {
+		// SQLite allows one writer at a time, and a single connection keeps a ":memory:" database shared
+		db.SetMaxOpenConns(1)
+	}
+
+	s := &SQLStore{
+		DB:      db,
+		Round:   round,
+		Fetcher: fetcher,
+		dialect: dialect,
+		dbName:  dbName,
+		log:     log,
+	}
+	if err := s.createSchema(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Ensure SQLStore implements Interface
+var _ Interface = (*SQLStore)(nil)
+var _ pipelineStore = (*SQLStore)(nil)
+
+// logger returns the store's logger, falling back to the global default when none was injected.
+func (s *SQLStore) logger() *slog.Logger {
+	if s.log == nil {
+		return slog.Default()
+	}
+	return s.log
+}
+
+// createSchema creates any missing tables
+func (s *SQLStore) createSchema(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	for _, statement := range sqlSchema {
+		if _, err := s.DB.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// rebind rewrites the ? placeholders queries are written with into the dialect's own
+func (s *SQLStore) rebind(query string) string {
+	if s.dialect != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// exec runs a statement outside a transaction
+func (s *SQLStore) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	metrics.SQLOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	return s.DB.ExecContext(ctx, s.rebind(query), args...)
+}
+
+// inTx runs fn in a transaction, committing if it succeeds and rolling back otherwise
+func (s *SQLStore) inTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	metrics.SQLOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// queryOne runs a query expected to return at least one row and scans the first. Returns ErrNotFound when there
+// are no rows.
+func (s *SQLStore) queryOne(ctx context.Context, query string, args []any, dest ...any) error {
+	metrics.SQLOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	err := s.DB.QueryRowContext(ctx, s.rebind(query), args...).Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// sqlScanner is the part of *sql.Rows a row scanner needs
+type sqlScanner interface {
+	Scan(dest ...any) error
+}
+
+// queryAll runs a query and scans every row with scan. Returns nil when there are no rows.
+func queryAll[T any](ctx context.Context, s *SQLStore, query string, args []any, scan func(row sqlScanner) (T, error)) ([]T, error) {
+	metrics.SQLOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	rows, err := s.DB.QueryContext(ctx, s.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// toMillis converts a timestamp to the Unix milliseconds it is stored as. The zero time is stored as 0.
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromMillis converts stored Unix milliseconds back to a UTC timestamp
+func fromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+// encodeStrings encodes a string list as a JSON array. An empty list is stored as null, as MongoDB omits it.
+func encodeStrings(v []string) (string, error) {
+	if len(v) == 0 {
+		return "null", nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// decodeStrings decodes a string list stored by encodeStrings
+func decodeStrings(data string) ([]string, error) {
+	var v []string
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return nil, fmt.Errorf("failed to decode string list: %w", err)
+	}
+	return v, nil
+}
+
+// region Getters
+
+// GetDatabase returns a database handle named after the tournament
+func (s *SQLStore) GetDatabase() interface{ Name() string } {
+	return sqlDatabase{name: s.dbName}
+}
+
+// GetRound returns the tournament round name
+func (s *SQLStore) GetRound() string {
+	return s.Round
+}
+
+// GetClient returns a client whose Disconnect closes the database
+func (s *SQLStore) GetClient() interface{ Disconnect(context.Context) error } {
+	return sqlClient{db: s.DB}
+}
+
+// Ping checks the database is reachable
+func (s *SQLStore) Ping(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	return s.DB.PingContext(ctx)
+}
+
+// endregion
+
+// region Predictions
+
+// predictionColumns are the columns scanPrediction reads, in order
+const predictionColumns = "id, user_id, username, format, round, win, advance, lose, progression"
+
+// scanPrediction scans a row selected with predictionColumns
+func scanPrediction(row sqlScanner) (models.Prediction, error) {
+	var p models.Prediction
+	var id, win, advance, lose, progression string
+	if err := row.Scan(&id, &p.UserID, &p.Username, &p.Format, &p.Round, &win, &advance, &lose, &progression); err != nil {
+		return models.Prediction{}, err
+	}
+	var err error
+	if p.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return models.Prediction{}, fmt.Errorf("invalid prediction id %q: %w", id, err)
+	}
+	if p.Win, err = decodeStrings(win); err != nil {
+		return models.Prediction{}, err
+	}
+	if p.Advance, err = decodeStrings(advance); err != nil {
+		return models.Prediction{}, err
+	}
+	if p.Lose, err = decodeStrings(lose); err != nil {
+		return models.Prediction{}, err
+	}
+	if progression != "" {
+		if err := bson.UnmarshalExtJSON([]byte(progression), false, &p.Progression); err != nil {
+			return models.Prediction{}, fmt.Errorf("failed to decode progression: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// StoreUserPrediction inserts a new prediction for the given user, or replaces an existing one for the
+// prediction's round. An existing prediction keeps its ID unless the new one carries its own.
+func (s *SQLStore) StoreUserPrediction(ctx context.Context, userID string, prediction models.Prediction) error {
+	win, err := encodeStrings(prediction.Win)
+	if err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
+	}
+	advance, err := encodeStrings(prediction.Advance)
+	if err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
+	}
+	lose, err := encodeStrings(prediction.Lose)
+	if err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
+	}
+	var progression string
+	if len(prediction.Progression) > 0 {
+		data, err := bson.MarshalExtJSON(prediction.Progression, false, false)
+		if err != nil {
+			return fmt.Errorf("failed to store user prediction: %w", err)
+		}
+		progression = string(data)
+	}
+
+	id := prediction.ID
+	keepID := id.IsZero()
+	if keepID {
+		id = primitive.NewObjectID()
+	}
+	update := "username = excluded.username, format = excluded.format, win = excluded.win, advance = excluded.advance, " +
+		"lose = excluded.lose, progression = excluded.progression"
+	if !keepID {
+		update += ", id = excluded.id"
+	}
+
+	_, err = s.exec(ctx, `INSERT INTO predictions (round, user_id, id, username, format, win, advance, lose, progression)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (round, user_id) DO UPDATE SET `+update,
+		prediction.Round, userID, id.Hex(), prediction.Username, prediction.Format, win, advance, lose, progression)
+	if err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
+	}
+	return nil
+}
+
+// FetchRounds returns every round with stored predictions, match results, match nodes, a schedule or a leaderboard,
+// sorted by name.
+func (s *SQLStore) FetchRounds(ctx context.Context) ([]string, error) {
+	rounds, err := queryAll(ctx, s, `SELECT round FROM predictions UNION SELECT round FROM match_results
+		UNION SELECT round FROM match_node_rounds UNION SELECT round FROM match_schedule UNION SELECT round FROM leaderboards`,
+		nil, func(row sqlScanner) (string, error) {
+			var round string
+			err := row.Scan(&round)
+			return round, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rounds from db: %w", err)
+	}
+	return sortedRounds(rounds), nil
+}
+
+// GetUserPrediction retrieves the stored prediction for the given user ID in the current round.
+func (s *SQLStore) GetUserPrediction(ctx context.Context, userID string) (models.Prediction, error) {
+	return s.GetUserPredictionForRound(ctx, userID, s.Round)
+}
+
+// GetUserPredictionForRound retrieves the stored prediction for the given user ID in the given round.
+func (s *SQLStore) GetUserPredictionForRound(ctx context.Context, userID string, round string) (models.Prediction, error) {
+	predictions, err := queryAll(ctx, s, "SELECT "+predictionColumns+" FROM predictions WHERE round = ? AND user_id = ?",
+		[]any{round, userID}, scanPrediction)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+	if len(predictions) == 0 {
+		return models.Prediction{}, ErrNotFound
+	}
+	return predictions[0], nil
+}
+
+// GetUserPredictionByUsername retrieves the stored prediction for the given username (case-insensitive) in the
+// current round.
+func (s *SQLStore) GetUserPredictionByUsername(ctx context.Context, username string) (models.Prediction, error) {
+	return s.GetUserPredictionByUsernameForRound(ctx, username, s.Round)
+}
+
+// GetUserPredictionByUsernameForRound retrieves the stored prediction for the given username (case-insensitive) in
+// the given round.
+func (s *SQLStore) GetUserPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.Prediction, error) {
+	predictions, err := queryAll(ctx, s, "SELECT "+predictionColumns+" FROM predictions WHERE round = ? AND LOWER(username) = LOWER(?) ORDER BY user_id LIMIT 1",
+		[]any{round, username}, scanPrediction)
+	if err != nil {
+		return models.Prediction{}, err
+	}
+	if len(predictions) == 0 {
+		return models.Prediction{}, ErrNotFound
+	}
+	return predictions[0], nil
+}
+
+// DeleteUserPrediction removes the given user's prediction for the current round. Returns ErrNotFound when the
+// user has no prediction stored.
+func (s *SQLStore) DeleteUserPrediction(ctx context.Context, userID string) error {
+	res, err := s.exec(ctx, "DELETE FROM predictions WHERE round = ? AND user_id = ?", s.Round, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user prediction: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// requireAffected returns ErrNotFound when a statement changed no rows
+func requireAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetAllUserPredictions returns all stored predictions for the current round, ordered by user ID.
+func (s *SQLStore) GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error) {
+	return queryAll(ctx, s, "SELECT "+predictionColumns+" FROM predictions WHERE round = ? ORDER BY user_id",
+		[]any{s.Round}, scanPrediction)
+}
+
+// GetValidTeams returns the valid team names and tournament format for the current round, derived from the
+// stored match results.
+func (s *SQLStore) GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error) {
+	results, err := s.fetchMatchResults(ctx, s.Round)
+	if err != nil {
+		return nil, "", err
+	}
+	return results.GetTeamNames(), results.GetType(), nil
+}
+
+// endregion
+
+// region Match results and nodes
+
+// GetMatchResults returns the stored match results for the current round.
+func (s *SQLStore) GetMatchResults(ctx context.Context) (tournament.MatchResult, error) {
+	return s.GetMatchResultsForRound(ctx, s.Round)
+}
+
+// GetMatchResultsForRound returns the match results of the given round.
+func (s *SQLStore) GetMatchResultsForRound(ctx context.Context, round string) (tournament.MatchResult, error) {
+	results, err := s.fetchMatchResults(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("error occured getting match results from db: %w", err)
+	}
+	return results, nil
+}
+
+// fetchMatchResults decodes the stored match results of the given round
+func (s *SQLStore) fetchMatchResults(ctx context.Context, round string) (tournament.MatchResult, error) {
+	var data string
+	if err := s.queryOne(ctx, "SELECT data FROM match_results WHERE round = ?", []any{round}, &data); err != nil {
+		return nil, err
+	}
+	var raw bson.M
+	if err := bson.UnmarshalExtJSON([]byte(data), false, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode match results: %w", err)
+	}
+	return decodeMatchResult(raw)
+}
+
+// StoreMatchResults stores the match results of the current round. The type column is the same discriminator the
+// MongoDB store tags documents with; the data column holds the full document as extended JSON.
+func (s *SQLStore) StoreMatchResults(ctx context.Context, matchResult tournament.MatchResult) error {
+	doc, err := encodeMatchResult(matchResult)
+	if err != nil {
+		return err
+	}
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return fmt.Errorf("failed to marshal match result: %w", err)
+	}
+	_, err = s.exec(ctx, `INSERT INTO match_results (round, type, data) VALUES (?, ?, ?)
+		ON CONFLICT (round) DO UPDATE SET type = excluded.type, data = excluded.data`,
+		s.Round, string(matchResult.GetType()), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store match results: %w", err)
+	}
+	return nil
+}
+
+// StoreMatchNodes replaces the raw match nodes of the current round
+func (s *SQLStore) StoreMatchNodes(ctx context.Context, nodes []sources.MatchNode, kind tournament.Kind) error {
+	err := s.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO match_node_rounds (round, format) VALUES (?, ?)
+			ON CONFLICT (round) DO UPDATE SET format = excluded.format`), s.Round, string(kind)); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM match_nodes WHERE round = ?"), s.Round); err != nil {
+			return err
+		}
+		for i, node := range nodes {
+			_, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO match_nodes
+				(round, position, id, team1, team2, winner, score, section, status, finished_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				s.Round, i, node.ID, node.Team1, node.Team2, node.Winner, node.Score, node.Section, node.Status, toMillis(node.FinishedAt))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store match nodes: %w", err)
+	}
+	return nil
+}
+
+// FetchMatchNodesFromDb retrieves the raw match nodes and tournament.Kind of the current round
+func (s *SQLStore) FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error) {
+	return s.FetchMatchNodesForRound(ctx, s.Round)
+}
+
+// FetchMatchNodesForRound retrieves the raw match nodes and tournament.Kind of the given round.
+func (s *SQLStore) FetchMatchNodesForRound(ctx context.Context, round string) ([]sources.MatchNode, tournament.Kind, error) {
+	var kind string
+	if err := s.queryOne(ctx, "SELECT format FROM match_node_rounds WHERE round = ?", []any{round}, &kind); err != nil {
+		return nil, "", err
+	}
+	nodes, err := queryAll(ctx, s, `SELECT id, team1, team2, winner, score, section, status, finished_at
+		FROM match_nodes WHERE round = ? ORDER BY position`, []any{round}, func(row sqlScanner) (sources.MatchNode, error) {
+		var node sources.MatchNode
+		var finishedAt int64
+		err := row.Scan(&node.ID, &node.Team1, &node.Team2, &node.Winner, &node.Score, &node.Section, &node.Status, &finishedAt)
+		node.FinishedAt = fromMillis(finishedAt)
+		return node, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return nodes, tournament.Kind(kind), nil
+}
+
+// FetchAndUpdateMatchResults fetches match data from the configured data source and stores the result, with any
+// result overrides applied
+func (s *SQLStore) FetchAndUpdateMatchResults(ctx context.Context) error {
+	return fetchAndUpdateMatchResults(ctx, s, s.Fetcher, s.FetchTimeout, s.logger())
+}
+
+// RebuildMatchResults rebuilds the stored match results from the stored match nodes with the current overrides
+// applied, without calling the data source.
+func (s *SQLStore) RebuildMatchResults(ctx context.Context) error {
+	return rebuildMatchResults(ctx, s)
+}
+
+// endregion
+
+// region Schedule
+
+// FetchMatchSchedule returns the scheduled matches for the current round.
+func (s *SQLStore) FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error) {
+	matches, err := queryAll(ctx, s, `SELECT team1, team2, epoch_time, best_of, stream_url, finished, live
+		FROM match_schedule WHERE round = ? ORDER BY position`, []any{s.Round}, func(row sqlScanner) (sources.ScheduledMatch, error) {
+		var m sources.ScheduledMatch
+		err := row.Scan(&m.Team1, &m.Team2, &m.EpochTime, &m.BestOf, &m.StreamURL, &m.Finished, &m.Live)
+		return m, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching results from db: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("error fetching results from db: %w", ErrNotFound)
+	}
+	return matches, nil
+}
+
+// StoreMatchSchedule replaces the scheduled matches of the current round.
+func (s *SQLStore) StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error {
+	if len(matches) == 0 {
+		return fmt.Errorf("scheduled matches input has length 0, requires at least 1")
+	}
+	err := s.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM match_schedule WHERE round = ?"), s.Round); err != nil {
+			return err
+		}
+		for i, m := range matches {
+			_, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO match_schedule
+				(round, position, team1, team2, epoch_time, best_of, stream_url, finished, live)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				s.Round, i, m.Team1, m.Team2, m.EpochTime, m.BestOf, m.StreamURL, m.Finished, m.Live)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store match schedule: %w", err)
+	}
+	return nil
+}
+
+// EnsureScheduledMatches verifies that at least one scheduled match is stored for the current round.
+func (s *SQLStore) EnsureScheduledMatches(ctx context.Context) error {
+	var count int
+	if err := s.queryOne(ctx, "SELECT COUNT(*) FROM match_schedule WHERE round = ?", []any{s.Round}, &count); err != nil {
+		return fmt.Errorf("failed to count scheduled matches: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("no scheduled matches entry found for round %s", s.Round)
+	}
+	return nil
+}
+
+// FetchAndStoreSchedule fetches upcoming matches from the configured data source and stores them.
+func (s *SQLStore) FetchAndStoreSchedule(ctx context.Context) error {
+	return fetchAndStoreSchedule(ctx, s, s.Fetcher, s.FetchTimeout)
+}
+
+// endregion
+
+// region Leaderboard and VRS
+
+// StoreLeaderboard replaces the leaderboard of the current round. An existing leaderboard keeps its ID unless the
+// new one carries its own.
+func (s *SQLStore) StoreLeaderboard(ctx context.Context, leaderboard Leaderboard) error {
+	if reflect.DeepEqual(leaderboard, Leaderboard{}) {
+		return fmt.Errorf("leaderboard is empty")
+	}
+
+	id := leaderboard.ID
+	keepID := id.IsZero()
+	if keepID {
+		id = primitive.NewObjectID()
+	}
+	update := "updated_at = excluded.updated_at"
+	if !keepID {
+		update += ", id = excluded.id"
+	}
+
+	s.logger().Info("updating leaderboard in db", "round", s.Round)
+	err := s.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO leaderboards (round, id, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT (round) DO UPDATE SET `+update), s.Round, id.Hex(), toMillis(leaderboard.UpdatedAt)); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM leaderboard_entries WHERE round = ?"), s.Round); err != nil {
+			return err
+		}
+		for i, e := range leaderboard.Entries {
+			_, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO leaderboard_entries
+				(round, position, user_id, username, score, successes, pending, failed)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+				s.Round, i, e.UserID, e.Username, e.Score, e.Successes, e.Pending, e.Failed)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("leaderboard update failed: %w", err)
+	}
+	return nil
+}
+
+// FetchLeaderboardFromDB returns the leaderboard entries for the current round.
+func (s *SQLStore) FetchLeaderboardFromDB(ctx context.Context) ([]LeaderboardEntry, error) {
+	return s.FetchLeaderboardForRound(ctx, s.Round)
+}
+
+// FetchLeaderboardForRound returns the leaderboard entries of the given round.
+func (s *SQLStore) FetchLeaderboardForRound(ctx context.Context, round string) ([]LeaderboardEntry, error) {
+	var id string
+	if err := s.queryOne(ctx, "SELECT id FROM leaderboards WHERE round = ?", []any{round}, &id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch leaderboard from database: %w", err)
+	}
+	entries, err := queryAll(ctx, s, `SELECT user_id, username, score, successes, pending, failed
+		FROM leaderboard_entries WHERE round = ? ORDER BY position`, []any{round}, func(row sqlScanner) (LeaderboardEntry, error) {
+		var e LeaderboardEntry
+		err := row.Scan(&e.UserID, &e.Username, &e.Score, &e.Successes, &e.Pending, &e.Failed)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leaderboard from database: %w", err)
+	}
+	return entries, nil
+}
+
+// FetchVrsDataFromDB returns the latest VRS rankings, ordered by standing.
+func (s *SQLStore) FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error) {
+	dates, err := s.FetchVrsStandingsDates(ctx)
+	if err != nil || len(dates) == 0 {
+		return nil, err
+	}
+	return s.FetchVrsSnapshot(ctx, dates[len(dates)-1])
+}
+
+// FetchVrsStandingsDates returns the standings dates there are VRS snapshots of, oldest first.
+func (s *SQLStore) FetchVrsStandingsDates(ctx context.Context) ([]string, error) {
+	return queryAll(ctx, s, `SELECT DISTINCT standings_date FROM vrs_rankings ORDER BY standings_date`, nil,
+		func(row sqlScanner) (string, error) {
+			var date string
+			err := row.Scan(&date)
+			return date, err
+		})
+}
+
+// FetchVrsSnapshot returns the VRS rankings as of the given standings date, ordered by standing.
+func (s *SQLStore) FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]VRSEntry, error) {
+	return queryAll(ctx, s, `SELECT standing, points, team_name, roster, standings_date, synced_at
+		FROM vrs_rankings WHERE standings_date = ? ORDER BY standing, team_name`, []any{standingsDate},
+		func(row sqlScanner) (VRSEntry, error) {
+			var e VRSEntry
+			var roster string
+			var syncedAt int64
+			if err := row.Scan(&e.Standing, &e.Points, &e.TeamName, &roster, &e.StandingsDate, &syncedAt); err != nil {
+				return VRSEntry{}, err
+			}
+			e.SyncedAt = fromMillis(syncedAt)
+			var err error
+			e.Roster, err = decodeStrings(roster)
+			return e, err
+		})
+}
+
+// SetVRSData replaces the VRS snapshots of the standings dates in entries, keeping those of other dates. MongoDB
+// deployments get them from an external sync job writing to a separate database, so SQL deployments have to load
+// them explicitly.
+func (s *SQLStore) SetVRSData(ctx context.Context, entries []VRSEntry) error {
+	err := s.inTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		for _, date := range vrsStandingsDates(entries) {
+			if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM vrs_rankings WHERE standings_date = ?"), date); err != nil {
+				return err
+			}
+		}
+		for _, e := range entries {
+			roster, err := encodeStrings(e.Roster)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, s.rebind(`INSERT INTO vrs_rankings
+				(standing, points, team_name, roster, standings_date, synced_at) VALUES (?, ?, ?, ?, ?, ?)`),
+				e.Standing, e.Points, e.TeamName, roster, e.StandingsDate, toMillis(e.SyncedAt))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store VRS data: %w", err)
+	}
+	return nil
+}
+
+// endregion
+
+// region Users and reminders
+
+// TrackUser records an interaction from the given user, creating their profile if it doesn't exist yet. The
+// profile's guild is only updated by interactions in a guild.
+func (s *SQLStore) TrackUser(ctx context.Context, user models.User) error {
+	_, err := s.exec(ctx, `INSERT INTO users (user_id, username, display_name, last_seen, guild_id) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET username = excluded.username, display_name = excluded.display_name,
+		last_seen = excluded.last_seen, guild_id = CASE WHEN excluded.guild_id = '' THEN users.guild_id ELSE excluded.guild_id END`,
+		user.UserID, user.Username, user.DisplayName, toMillis(time.Now().UTC()), user.GuildID)
+	if err != nil {
+		return fmt.Errorf("failed to track user: %w", err)
+	}
+	return nil
+}
+
+// SetRemindersEnabled opts the given user in to (or out of) pre-lock reminder DMs.
+func (s *SQLStore) SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error {
+	_, err := s.exec(ctx, `INSERT INTO users (user_id, reminders_off) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET reminders_off = excluded.reminders_off`, userID, !enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder preference: %w", err)
+	}
+	return nil
+}
+
+// SetUserLocale sets the locale bot responses to the given user are translated into. An empty locale clears it.
+func (s *SQLStore) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	_, err := s.exec(ctx, `INSERT INTO users (user_id, locale) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET locale = excluded.locale`, userID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to update user locale: %w", err)
+	}
+	return nil
+}
+
+// SetUserTimezone sets the IANA timezone plain-text times are shown to the given user in. An empty timezone
+// clears it.
+func (s *SQLStore) SetUserTimezone(ctx context.Context, userID string, timezone string) error {
+	_, err := s.exec(ctx, `INSERT INTO users (user_id, timezone) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET timezone = excluded.timezone`, userID, timezone)
+	if err != nil {
+		return fmt.Errorf("failed to update user timezone: %w", err)
+	}
+	return nil
+}
+
+// userColumns are the columns scanUserProfile reads, in order
+const userColumns = "user_id, username, display_name, last_seen, reminders_off, locale, timezone, guild_id"
+
+// scanUserProfile scans a row selected with userColumns
+func scanUserProfile(row sqlScanner) (UserProfile, error) {
+	var p UserProfile
+	var lastSeen int64
+	err := row.Scan(&p.UserID, &p.Username, &p.DisplayName, &lastSeen, &p.RemindersOff, &p.Locale, &p.Timezone, &p.GuildID)
+	p.LastSeen = fromMillis(lastSeen)
+	return p, err
+}
+
+// GetUserProfile returns the profile of the given user. Returns ErrNotFound if they have never been seen.
+func (s *SQLStore) GetUserProfile(ctx context.Context, userID string) (UserProfile, error) {
+	profiles, err := queryAll(ctx, s, "SELECT "+userColumns+" FROM users WHERE user_id = ?", []any{userID}, scanUserProfile)
+	if err != nil {
+		return UserProfile{}, err
+	}
+	if len(profiles) == 0 {
+		return UserProfile{}, ErrNotFound
+	}
+	return profiles[0], nil
+}
+
+// GetUserProfileByUsername returns the profile of the user currently going by the given username (case-insensitive).
+// If several users have gone by it, the one seen most recently is returned. Returns ErrNotFound if nobody has.
+func (s *SQLStore) GetUserProfileByUsername(ctx context.Context, username string) (UserProfile, error) {
+	profiles, err := queryAll(ctx, s, "SELECT "+userColumns+" FROM users WHERE LOWER(username) = LOWER(?) ORDER BY last_seen DESC, user_id LIMIT 1",
+		[]any{username}, scanUserProfile)
+	if err != nil {
+		return UserProfile{}, err
+	}
+	if len(profiles) == 0 {
+		return UserProfile{}, ErrNotFound
+	}
+	return profiles[0], nil
+}
+
+// FetchUserProfiles returns every tracked user profile, ordered by user ID.
+func (s *SQLStore) FetchUserProfiles(ctx context.Context) ([]UserProfile, error) {
+	return queryAll(ctx, s, "SELECT "+userColumns+" FROM users ORDER BY user_id", nil, scanUserProfile)
+}
+
+// FetchPredictionUserIDs returns the distinct IDs of every user that has stored a prediction in any round.
+func (s *SQLStore) FetchPredictionUserIDs(ctx context.Context) ([]string, error) {
+	ids, err := queryAll(ctx, s, "SELECT DISTINCT user_id FROM predictions WHERE user_id <> '' ORDER BY user_id", nil,
+		func(row sqlScanner) (string, error) {
+			var id string
+			err := row.Scan(&id)
+			return id, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	return ids, nil
+}
+
+// FetchSentReminders returns every reminder sent for the current round.
+func (s *SQLStore) FetchSentReminders(ctx context.Context) ([]SentReminder, error) {
+	return queryAll(ctx, s, "SELECT user_id, guild_id, round, lead, sent_at FROM sent_reminders WHERE round = ?",
+		[]any{s.Round}, func(row sqlScanner) (SentReminder, error) {
+			var r SentReminder
+			var sentAt int64
+			err := row.Scan(&r.UserID, &r.GuildID, &r.Round, &r.Lead, &sentAt)
+			r.SentAt = fromMillis(sentAt)
+			return r, err
+		})
+}
+
+// StoreSentReminder records that a reminder has been sent. Recording the same reminder twice is a no-op.
+func (s *SQLStore) StoreSentReminder(ctx context.Context, reminder SentReminder) error {
+	_, err := s.exec(ctx, `INSERT INTO sent_reminders (user_id, guild_id, round, lead, sent_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, guild_id, round, lead) DO NOTHING`,
+		reminder.UserID, reminder.GuildID, reminder.Round, reminder.Lead, toMillis(reminder.SentAt))
+	if err != nil {
+		return fmt.Errorf("failed to record sent reminder: %w", err)
+	}
+	return nil
+}
+
+// endregion
+
+// region Match day messages
+
+// FetchMatchDayMessages returns the match day message of every guild for the current round, ordered by guild ID.
+func (s *SQLStore) FetchMatchDayMessages(ctx context.Context) ([]MatchDayMessage, error) {
+	return queryAll(ctx, s, "SELECT guild_id, channel_id, message_id, round, day FROM match_day_messages WHERE round = ? ORDER BY guild_id",
+		[]any{s.Round}, func(row sqlScanner) (MatchDayMessage, error) {
+			var m MatchDayMessage
+			err := row.Scan(&m.GuildID, &m.ChannelID, &m.MessageID, &m.Round, &m.Day)
+			return m, err
+		})
+}
+
+// StoreMatchDayMessage stores a guild's match day message, replacing any previous message for the same guild and
+// round.
+func (s *SQLStore) StoreMatchDayMessage(ctx context.Context, message MatchDayMessage) error {
+	_, err := s.exec(ctx, `INSERT INTO match_day_messages (round, guild_id, channel_id, message_id, day) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (round, guild_id) DO UPDATE SET channel_id = excluded.channel_id, message_id = excluded.message_id, day = excluded.day`,
+		message.Round, message.GuildID, message.ChannelID, message.MessageID, message.Day)
+	if err != nil {
+		return fmt.Errorf("failed to store match day message: %w", err)
+	}
+	return nil
+}
+
+// DeleteMatchDayMessage stops tracking a guild's match day message for the current round.
+func (s *SQLStore) DeleteMatchDayMessage(ctx context.Context, guildID string) error {
+	if _, err := s.exec(ctx, "DELETE FROM match_day_messages WHERE round = ? AND guild_id = ?", s.Round, guildID); err != nil {
+		return fmt.Errorf("failed to delete match day message: %w", err)
+	}
+	return nil
+}
+
+// endregion
+
+// region Guild settings
+
+// guildSettingsColumns are the columns scanGuildSettings reads, in order
+const guildSettingsColumns = "guild_id, prefix, announcement_channel, reminder_channel, admin_role_id, locale, timezone"
+
+// scanGuildSettings scans a row selected with guildSettingsColumns
+func scanGuildSettings(row sqlScanner) (GuildSettings, error) {
+	var g GuildSettings
+	err := row.Scan(&g.GuildID, &g.Prefix, &g.AnnouncementChannel, &g.ReminderChannel, &g.AdminRoleID, &g.Locale, &g.Timezone)
+	return g, err
+}
+
+// GetGuildSettings returns the settings stored for a guild. Returns ErrNotFound when the guild has never been
+// configured.
+func (s *SQLStore) GetGuildSettings(ctx context.Context, guildID string) (GuildSettings, error) {
+	settings, err := queryAll(ctx, s, "SELECT "+guildSettingsColumns+" FROM guild_settings WHERE guild_id = ?",
+		[]any{guildID}, scanGuildSettings)
+	if err != nil {
+		return GuildSettings{}, err
+	}
+	if len(settings) == 0 {
+		return GuildSettings{}, ErrNotFound
+	}
+	return settings[0], nil
+}
+
+// FetchAllGuildSettings returns the settings of every configured guild, ordered by guild ID.
+func (s *SQLStore) FetchAllGuildSettings(ctx context.Context) ([]GuildSettings, error) {
+	return queryAll(ctx, s, "SELECT "+guildSettingsColumns+" FROM guild_settings ORDER BY guild_id", nil, scanGuildSettings)
+}
+
+// StoreGuildSettings stores a guild's settings, replacing any previous settings for the guild.
+func (s *SQLStore) StoreGuildSettings(ctx context.Context, settings GuildSettings) error {
+	_, err := s.exec(ctx, `INSERT INTO guild_settings (`+guildSettingsColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (guild_id) DO UPDATE SET prefix = excluded.prefix, announcement_channel = excluded.announcement_channel,
+		reminder_channel = excluded.reminder_channel, admin_role_id = excluded.admin_role_id, locale = excluded.locale,
+		timezone = excluded.timezone`,
+		settings.GuildID, settings.Prefix, settings.AnnouncementChannel, settings.ReminderChannel, settings.AdminRoleID,
+		settings.Locale, settings.Timezone)
+	if err != nil {
+		return fmt.Errorf("failed to store guild settings: %w", err)
+	}
+	return nil
+}
+
+// endregion
+
+// region Audit log
+
+// StoreAuditEntry appends an entry to the audit log.
+func (s *SQLStore) StoreAuditEntry(ctx context.Context, entry AuditEntry) error {
+	_, err := s.exec(ctx, `INSERT INTO audit_log (guild_id, user_id, username, command, args, round, result, logged_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.GuildID, entry.UserID, entry.Username, entry.Command, entry.Args, entry.Round, entry.Result, toMillis(entry.Timestamp))
+	if err != nil {
+		return fmt.Errorf("failed to store audit entry: %w", err)
+	}
+	return nil
+}
+
+// FetchAuditEntries returns up to limit of the most recent audit log entries for a guild, newest first. Entries
+// not tied to a guild are included for every guild. A limit of zero or less returns every entry.
+func (s *SQLStore) FetchAuditEntries(ctx context.Context, guildID string, limit int) ([]AuditEntry, error) {
+	query := `SELECT guild_id, user_id, username, command, args, round, result, logged_at
+		FROM audit_log WHERE guild_id = ? OR guild_id = '' ORDER BY logged_at DESC`
+	args := []any{guildID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	return queryAll(ctx, s, query, args, func(row sqlScanner) (AuditEntry, error) {
+		var e AuditEntry
+		var loggedAt int64
+		err := row.Scan(&e.GuildID, &e.UserID, &e.Username, &e.Command, &e.Args, &e.Round, &e.Result, &loggedAt)
+		e.Timestamp = fromMillis(loggedAt)
+		return e, err
+	})
+}
+
+// endregion
+
+// region Result overrides
+
+// FetchResultOverrides returns the overrides for the current round, ordered by match ID.
+func (s *SQLStore) FetchResultOverrides(ctx context.Context) ([]ResultOverride, error) {
+	return s.FetchResultOverridesForRound(ctx, s.Round)
+}
+
+// FetchResultOverridesForRound returns the result overrides of the given round.
+func (s *SQLStore) FetchResultOverridesForRound(ctx context.Context, round string) ([]ResultOverride, error) {
+	return queryAll(ctx, s, "SELECT match_id, round, winner, score, set_by, created_at FROM result_overrides WHERE round = ? ORDER BY match_id",
+		[]any{round}, func(row sqlScanner) (ResultOverride, error) {
+			var o ResultOverride
+			var createdAt int64
+			err := row.Scan(&o.MatchID, &o.Round, &o.Winner, &o.Score, &o.SetBy, &createdAt)
+			o.CreatedAt = fromMillis(createdAt)
+			return o, err
+		})
+}
+
+// StoreResultOverride stores an override, replacing any existing override for the same match.
+func (s *SQLStore) StoreResultOverride(ctx context.Context, override ResultOverride) error {
+	_, err := s.exec(ctx, `INSERT INTO result_overrides (round, match_id, winner, score, set_by, created_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (round, match_id) DO UPDATE SET winner = excluded.winner, score = excluded.score, set_by = excluded.set_by,
+		created_at = excluded.created_at`,
+		override.Round, override.MatchID, override.Winner, override.Score, override.SetBy, toMillis(override.CreatedAt))
+	if err != nil {
+		return fmt.Errorf("failed to store result override: %w", err)
+	}
+	return nil
+}
+
+// DeleteResultOverride removes the override for a match in the current round. Returns ErrNotFound when the match
+// has no override.
+func (s *SQLStore) DeleteResultOverride(ctx context.Context, matchID string) error {
+	res, err := s.exec(ctx, "DELETE FROM result_overrides WHERE round = ? AND match_id = ?", s.Round, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to delete result override: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// endregion
+
+// region Team aliases
+
+// FetchTeamAliases returns every team alias, sorted by alias.
+func (s *SQLStore) FetchTeamAliases(ctx context.Context) ([]TeamAlias, error) {
+	return queryAll(ctx, s, "SELECT alias, team, set_by, created_at FROM team_aliases ORDER BY alias", nil,
+		func(row sqlScanner) (TeamAlias, error) {
+			var a TeamAlias
+			var createdAt int64
+			err := row.Scan(&a.Alias, &a.Team, &a.SetBy, &createdAt)
+			a.CreatedAt = fromMillis(createdAt)
+			return a, err
+		})
+}
+
+// StoreTeamAlias stores an alias, replacing any existing alias with the same name.
+func (s *SQLStore) StoreTeamAlias(ctx context.Context, alias TeamAlias) error {
+	_, err := s.exec(ctx, `INSERT INTO team_aliases (alias, team, set_by, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (alias) DO UPDATE SET team = excluded.team, set_by = excluded.set_by, created_at = excluded.created_at`,
+		alias.Alias, alias.Team, alias.SetBy, toMillis(alias.CreatedAt))
+	if err != nil {
+		return fmt.Errorf("failed to store team alias: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeamAlias removes an alias by its normalised name. Returns ErrNotFound when there is no such alias.
+func (s *SQLStore) DeleteTeamAlias(ctx context.Context, alias string) error {
+	res, err := s.exec(ctx, "DELETE FROM team_aliases WHERE alias = ?", alias)
+	if err != nil {
+		return fmt.Errorf("failed to delete team alias: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// endregion
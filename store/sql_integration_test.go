@@ -0,0 +1,55 @@
+//go:build integration
+
+/* sql_integration_test.go
+ * Runs the SQLStore suite against PostgreSQL. Each store gets its own schema, dropped when the test ends.
+ */
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"pickems-bot/testhelpers"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSQLStore_Postgres(t *testing.T) {
+	uri := testhelpers.PostgresURI(t)
+
+	runSQLStoreSuite(t, func(t *testing.T, fetcher DataSourceFetcher) *SQLStore {
+		t.Helper()
+		schema := "pickems_test_" + primitive.NewObjectID().Hex()
+		admin, err := sql.Open("pgx", uri)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = admin.Close() })
+		_, err = admin.Exec("CREATE SCHEMA " + schema)
+		require.NoError(t, err)
+		t.Cleanup(func() { _, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+		s, err := NewSQLStore(context.Background(), Postgres, withSearchPath(t, uri, schema), "test_db", "stage_1", fetcher, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = s.GetClient().Disconnect(context.Background()) })
+		return s
+	})
+}
+
+// withSearchPath points a connection URL at the given schema
+func withSearchPath(t *testing.T, uri string, schema string) string {
+	t.Helper()
+	if !strings.Contains(uri, "://") {
+		return fmt.Sprintf("%s search_path=%s", uri, schema)
+	}
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
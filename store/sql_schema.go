@@ -0,0 +1,144 @@
+/* sql_schema.go
+ * Contains the relational schema SQLStore creates on startup. Each table mirrors a MongoDB collection: scalar fields
+ * are columns, string lists are JSON arrays, and nested documents (match results, prediction progression) are
+ * stored as MongoDB extended JSON so they decode exactly as they would from MongoDB. Timestamps are Unix
+ * milliseconds, the precision MongoDB keeps. The statements are written to run unchanged on SQLite and PostgreSQL.
+ */
+
+package store
+
+// sqlSchema creates every table SQLStore uses. Each statement is idempotent.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS predictions (
+		round       TEXT NOT NULL,
+		user_id     TEXT NOT NULL,
+		id          TEXT NOT NULL,
+		username    TEXT NOT NULL DEFAULT '',
+		format      TEXT NOT NULL DEFAULT '',
+		win         TEXT NOT NULL DEFAULT 'null',
+		advance     TEXT NOT NULL DEFAULT 'null',
+		lose        TEXT NOT NULL DEFAULT 'null',
+		progression TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (round, user_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_results (
+		round TEXT PRIMARY KEY,
+		type  TEXT NOT NULL,
+		data  TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_node_rounds (
+		round  TEXT PRIMARY KEY,
+		format TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_nodes (
+		round       TEXT NOT NULL,
+		position    INTEGER NOT NULL,
+		id          TEXT NOT NULL DEFAULT '',
+		team1       TEXT NOT NULL DEFAULT '',
+		team2       TEXT NOT NULL DEFAULT '',
+		winner      TEXT NOT NULL DEFAULT '',
+		score       TEXT NOT NULL DEFAULT '',
+		section     TEXT NOT NULL DEFAULT '',
+		status      TEXT NOT NULL DEFAULT '',
+		finished_at BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (round, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_schedule (
+		round      TEXT NOT NULL,
+		position   INTEGER NOT NULL,
+		team1      TEXT NOT NULL DEFAULT '',
+		team2      TEXT NOT NULL DEFAULT '',
+		epoch_time BIGINT NOT NULL DEFAULT 0,
+		best_of    TEXT NOT NULL DEFAULT '',
+		stream_url TEXT NOT NULL DEFAULT '',
+		finished   BOOLEAN NOT NULL DEFAULT FALSE,
+		live       BOOLEAN NOT NULL DEFAULT FALSE,
+		PRIMARY KEY (round, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS leaderboards (
+		round      TEXT PRIMARY KEY,
+		id         TEXT NOT NULL,
+		updated_at BIGINT NOT NULL DEFAULT 0
+	)`,
+	`CREATE TABLE IF NOT EXISTS leaderboard_entries (
+		round     TEXT NOT NULL,
+		position  INTEGER NOT NULL,
+		user_id   TEXT NOT NULL DEFAULT '',
+		username  TEXT NOT NULL DEFAULT '',
+		score     INTEGER NOT NULL DEFAULT 0,
+		successes INTEGER NOT NULL DEFAULT 0,
+		pending   INTEGER NOT NULL DEFAULT 0,
+		failed    INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (round, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS vrs_rankings (
+		standing       INTEGER NOT NULL DEFAULT 0,
+		points         INTEGER NOT NULL DEFAULT 0,
+		team_name      TEXT NOT NULL DEFAULT '',
+		roster         TEXT NOT NULL DEFAULT 'null',
+		standings_date TEXT NOT NULL DEFAULT '',
+		synced_at      BIGINT NOT NULL DEFAULT 0
+	)`,
+	`CREATE TABLE IF NOT EXISTS users (
+		user_id       TEXT PRIMARY KEY,
+		username      TEXT NOT NULL DEFAULT '',
+		display_name  TEXT NOT NULL DEFAULT '',
+		last_seen     BIGINT NOT NULL DEFAULT 0,
+		reminders_off BOOLEAN NOT NULL DEFAULT FALSE,
+		locale        TEXT NOT NULL DEFAULT '',
+		timezone      TEXT NOT NULL DEFAULT '',
+		guild_id      TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS sent_reminders (
+		user_id  TEXT NOT NULL,
+		guild_id TEXT NOT NULL,
+		round    TEXT NOT NULL,
+		lead     TEXT NOT NULL,
+		sent_at  BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (user_id, guild_id, round, lead)
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_day_messages (
+		round      TEXT NOT NULL,
+		guild_id   TEXT NOT NULL,
+		channel_id TEXT NOT NULL DEFAULT '',
+		message_id TEXT NOT NULL DEFAULT '',
+		day        TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (round, guild_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS guild_settings (
+		guild_id             TEXT PRIMARY KEY,
+		prefix               TEXT NOT NULL DEFAULT '',
+		announcement_channel TEXT NOT NULL DEFAULT '',
+		reminder_channel     TEXT NOT NULL DEFAULT '',
+		admin_role_id        TEXT NOT NULL DEFAULT '',
+		locale               TEXT NOT NULL DEFAULT '',
+		timezone             TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS audit_log (
+		guild_id  TEXT NOT NULL DEFAULT '',
+		user_id   TEXT NOT NULL DEFAULT '',
+		username  TEXT NOT NULL DEFAULT '',
+		command   TEXT NOT NULL DEFAULT '',
+		args      TEXT NOT NULL DEFAULT '',
+		round     TEXT NOT NULL DEFAULT '',
+		result    TEXT NOT NULL DEFAULT '',
+		logged_at BIGINT NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS audit_log_guild_logged_at ON audit_log (guild_id, logged_at)`,
+	`CREATE INDEX IF NOT EXISTS vrs_rankings_standings_date ON vrs_rankings (standings_date, standing)`,
+	`CREATE TABLE IF NOT EXISTS result_overrides (
+		round      TEXT NOT NULL,
+		match_id   TEXT NOT NULL,
+		winner     TEXT NOT NULL DEFAULT '',
+		score      TEXT NOT NULL DEFAULT '',
+		set_by     TEXT NOT NULL DEFAULT '',
+		created_at BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (round, match_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_aliases (
+		alias      TEXT PRIMARY KEY,
+		team       TEXT NOT NULL DEFAULT '',
+		set_by     TEXT NOT NULL DEFAULT '',
+		created_at BIGINT NOT NULL DEFAULT 0
+	)`,
+}
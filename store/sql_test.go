@@ -0,0 +1,578 @@
+/* sql_test.go
+ * Unit tests for SQLStore, run against an in-memory SQLite database. The same suite runs against PostgreSQL in
+ * sql_integration_test.go, so both dialects are held to the semantics of the MongoDB store.
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pickems-bot/models"
+	"pickems-bot/sources"
+	"pickems-bot/tournament"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newSQLiteTestStore returns a SQLStore backed by a fresh in-memory SQLite database
+func newSQLiteTestStore(t *testing.T, fetcher DataSourceFetcher) *SQLStore {
+	t.Helper()
+	s, err := NewSQLStore(context.Background(), SQLite, ":memory:", "test_db", "stage_1", fetcher, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.GetClient().Disconnect(context.Background()) })
+	return s
+}
+
+func TestSQLStore_SQLite(t *testing.T) {
+	runSQLStoreSuite(t, newSQLiteTestStore)
+}
+
+func TestNewSQLStore_UnsupportedDialect(t *testing.T) {
+	_, err := NewSQLStore(context.Background(), "oracle", "", "test_db", "stage_1", nil, nil)
+	assert.ErrorContains(t, err, "unsupported SQL dialect")
+}
+
+func TestNewSQLStore_ReopensExistingSchema(t *testing.T) {
+	path := t.TempDir() + "/pickems.db"
+	ctx := context.Background()
+
+	s, err := NewSQLStore(context.Background(), SQLite, path, "test_db", "stage_1", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.StoreTeamAlias(ctx, TeamAlias{Alias: "navi", Team: "Natus Vincere"}))
+	require.NoError(t, s.GetClient().Disconnect(ctx))
+
+	s, err = NewSQLStore(context.Background(), SQLite, path, "test_db", "stage_1", nil, nil)
+	require.NoError(t, err)
+	defer s.GetClient().Disconnect(ctx)
+	aliases, err := s.FetchTeamAliases(ctx)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+}
+
+func TestSQLStore_Rebind(t *testing.T) {
+	sqlite := &SQLStore{dialect: SQLite}
+	postgres := &SQLStore{dialect: Postgres}
+	query := "SELECT a FROM t WHERE b = ? AND c = ?"
+
+	assert.Equal(t, query, sqlite.rebind(query))
+	assert.Equal(t, "SELECT a FROM t WHERE b = $1 AND c = $2", postgres.rebind(query))
+}
+
+// runSQLStoreSuite runs every SQLStore test against stores created by newStore. Each store must start empty.
+func runSQLStoreSuite(t *testing.T, newStore func(t *testing.T, fetcher DataSourceFetcher) *SQLStore) {
+	// region Prediction tests
+
+	t.Run("UserPrediction_RoundTrip", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		prediction := models.Prediction{
+			UserID: "u1", Username: "Alice", Format: "single-elimination", Round: "stage_1",
+			Progression: map[string]models.TeamProgress{"Alpha": {Round: "final", Status: "advanced"}},
+		}
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", prediction))
+
+		got, err := s.GetUserPrediction(ctx, "u1")
+		require.NoError(t, err)
+		assert.False(t, got.ID.IsZero(), "an ID should be assigned on insert")
+		prediction.ID = got.ID
+		assert.Equal(t, prediction, got)
+
+		byName, err := s.GetUserPredictionByUsername(ctx, "aLiCe")
+		require.NoError(t, err)
+		assert.Equal(t, got, byName)
+	})
+
+	t.Run("UserPrediction_ReplaceKeepsID", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1", Win: []string{"A"}}))
+		first, err := s.GetUserPrediction(ctx, "u1")
+		require.NoError(t, err)
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1", Win: []string{"B"}}))
+
+		all, err := s.GetAllUserPredictions(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, first.ID, all[0].ID)
+		assert.Equal(t, []string{"B"}, all[0].Win)
+
+		explicit := primitive.NewObjectID()
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{ID: explicit, UserID: "u1", Round: "stage_1"}))
+		got, err := s.GetUserPrediction(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, explicit, got.ID)
+		assert.Nil(t, got.Win)
+	})
+
+	t.Run("UserPrediction_ScopedByRound", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Username: "Alice", Round: "stage_0"}))
+
+		_, err := s.GetUserPrediction(ctx, "u1")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = s.GetUserPredictionByUsername(ctx, "Alice")
+		assert.ErrorIs(t, err, ErrNotFound)
+		all, err := s.GetAllUserPredictions(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+
+		ids, err := s.FetchPredictionUserIDs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, ids, "past predictors span every round")
+	})
+
+	t.Run("DeleteUserPrediction", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		assert.ErrorIs(t, s.DeleteUserPrediction(ctx, "u1"), ErrNotFound)
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Round: "stage_1"}))
+		require.NoError(t, s.DeleteUserPrediction(ctx, "u1"))
+
+		_, err := s.GetUserPrediction(ctx, "u1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	// endregion
+
+	// region Match results and nodes tests
+
+	t.Run("MatchResults_RoundTrip", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		_, err := s.GetMatchResults(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		results := tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "3-0", "Beta": "0-3"}}
+		require.NoError(t, s.StoreMatchResults(ctx, results))
+		require.NoError(t, s.StoreMatchResults(ctx, results))
+
+		got, err := s.GetMatchResults(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, results, got)
+
+		var kind string
+		require.NoError(t, s.queryOne(ctx, "SELECT type FROM match_results WHERE round = ?", []any{"stage_1"}, &kind))
+		assert.Equal(t, string(tournament.Swiss), kind, "the type discriminator is stored in its own column")
+
+		teams, format, err := s.GetValidTeams(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Alpha", "Beta"}, teams)
+		assert.Equal(t, tournament.Swiss, format)
+	})
+
+	t.Run("MatchNodes_RoundTrip", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		_, _, err := s.FetchMatchNodesFromDb(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		finishedAt := time.Date(2026, 6, 1, 18, 30, 0, 0, time.UTC)
+		nodes := []sources.MatchNode{
+			{ID: "m2", Team1: "Gamma", Team2: "Delta", Section: "Final", Status: "not_started"},
+			{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha", Score: "2-0", Section: "Semifinal", Status: "finished", FinishedAt: finishedAt},
+		}
+		require.NoError(t, s.StoreMatchNodes(ctx, nodes, tournament.SingleElim))
+
+		got, kind, err := s.FetchMatchNodesFromDb(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tournament.SingleElim, kind)
+		assert.Equal(t, nodes, got, "nodes keep their order and fields")
+
+		require.NoError(t, s.StoreMatchNodes(ctx, nodes[:1], tournament.SingleElim))
+		got, _, err = s.FetchMatchNodesFromDb(ctx)
+		require.NoError(t, err)
+		assert.Len(t, got, 1, "storing nodes replaces the round's previous nodes")
+	})
+
+	t.Run("FetchAndUpdateMatchResults_ReconcilesOverrides", func(t *testing.T) {
+		nodes := []sources.MatchNode{
+			{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha", Score: "2-0", Section: "Round 1"},
+			{ID: "m2", Team1: "Gamma", Team2: "Delta", Winner: "Gamma", Score: "2-1", Section: "Round 1"},
+		}
+		fetcher := stubFetcher{result: tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "1-0"}}, nodes: nodes}
+		s := newStore(t, fetcher)
+		ctx := context.Background()
+
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Alpha"}))
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m2", Round: "stage_1", Winner: "Delta"}))
+
+		require.NoError(t, s.FetchAndUpdateMatchResults(ctx))
+
+		overrides, err := s.FetchResultOverrides(ctx)
+		require.NoError(t, err)
+		require.Len(t, overrides, 1)
+		assert.Equal(t, "m2", overrides[0].MatchID)
+
+		results, err := s.GetMatchResults(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "1-0", results.(tournament.SwissResult).Teams["Delta"])
+	})
+
+	// endregion
+
+	// region Schedule tests
+
+	t.Run("Schedule", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		assert.Error(t, s.EnsureScheduledMatches(ctx))
+		_, err := s.FetchMatchSchedule(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Error(t, s.StoreMatchSchedule(ctx, nil))
+
+		matches := []sources.ScheduledMatch{
+			{Team1: "Alpha", Team2: "Beta", EpochTime: 100, BestOf: "3", StreamURL: "https://example.com", Live: true},
+			{Team1: "Gamma", Team2: "Delta", EpochTime: 200, Finished: true},
+		}
+		require.NoError(t, s.StoreMatchSchedule(ctx, matches))
+		assert.NoError(t, s.EnsureScheduledMatches(ctx))
+
+		got, err := s.FetchMatchSchedule(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, matches, got)
+	})
+
+	t.Run("FetchAndStoreSchedule", func(t *testing.T) {
+		fetcher := stubFetcher{schedule: []sources.ScheduledMatch{{Team1: "Late", EpochTime: 200}, {Team1: "Early", EpochTime: 100}}}
+		s := newStore(t, fetcher)
+		ctx := context.Background()
+
+		require.NoError(t, s.FetchAndStoreSchedule(ctx))
+
+		got, err := s.FetchMatchSchedule(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "Early", got[0].Team1)
+	})
+
+	// endregion
+
+	// region Leaderboard and VRS tests
+
+	t.Run("Leaderboard", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		_, err := s.FetchLeaderboardFromDB(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Error(t, s.StoreLeaderboard(ctx, Leaderboard{}))
+
+		entries := []LeaderboardEntry{
+			{UserID: "u2", Username: "Bob", Score: 5, ScoreResult: models.ScoreResult{Successes: 5, Pending: 1}},
+			{UserID: "u1", Username: "Alice", Score: 3, ScoreResult: models.ScoreResult{Successes: 3, Failed: 2}},
+		}
+		require.NoError(t, s.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", UpdatedAt: time.Now(), Entries: entries}))
+		require.NoError(t, s.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", UpdatedAt: time.Now(), Entries: entries}))
+
+		got, err := s.FetchLeaderboardFromDB(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entries, got)
+
+		s.Round = "stage_2"
+		_, err = s.FetchLeaderboardFromDB(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("VRS", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		got, err := s.FetchVrsDataFromDB(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		syncedAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+		entries := []VRSEntry{
+			{Standing: 1, Points: 2000, TeamName: "Alpha", Roster: []string{"p1", "p2"}, StandingsDate: "2026-06-01", SyncedAt: syncedAt},
+			{Standing: 2, Points: 1900, TeamName: "Beta", StandingsDate: "2026-06-01", SyncedAt: syncedAt},
+		}
+		require.NoError(t, s.SetVRSData(ctx, entries[1:]))
+		require.NoError(t, s.SetVRSData(ctx, []VRSEntry{entries[1], entries[0]}))
+
+		got, err = s.FetchVrsDataFromDB(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entries, got, "rankings are replaced and ordered by standing")
+	})
+
+	t.Run("VRS history", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		require.NoError(t, s.SetVRSData(ctx, []VRSEntry{
+			{Standing: 2, TeamName: "Alpha", StandingsDate: "2026_05_04"},
+			{Standing: 1, TeamName: "Beta", StandingsDate: "2026_05_04"},
+		}))
+		require.NoError(t, s.SetVRSData(ctx, []VRSEntry{{Standing: 1, TeamName: "Alpha", StandingsDate: "2026_06_01"}}))
+
+		dates, err := s.FetchVrsStandingsDates(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026_05_04", "2026_06_01"}, dates)
+
+		latest, err := s.FetchVrsDataFromDB(ctx)
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, "Alpha", latest[0].TeamName)
+
+		snapshot, err := s.FetchVrsSnapshot(ctx, "2026_05_04")
+		require.NoError(t, err)
+		require.Len(t, snapshot, 2, "earlier snapshots are kept")
+		assert.Equal(t, "Beta", snapshot[0].TeamName)
+	})
+
+	// endregion
+
+	// region Users and reminders tests
+
+	t.Run("GetUserProfileByUsername", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		require.NoError(t, s.TrackUser(ctx, models.User{UserID: "u1", Username: "old_name"}))
+		time.Sleep(2 * time.Millisecond) // last_seen is stored in milliseconds
+		require.NoError(t, s.TrackUser(ctx, models.User{UserID: "u2", Username: "Old_Name"}))
+
+		profile, err := s.GetUserProfileByUsername(ctx, "OLD_NAME")
+		require.NoError(t, err)
+		assert.Equal(t, "u2", profile.UserID, "the most recently seen user wins")
+
+		_, err = s.GetUserProfileByUsername(ctx, "old%")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		_, err := s.GetUserProfile(ctx, "u1")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, s.SetRemindersEnabled(ctx, "u1", false))
+		require.NoError(t, s.TrackUser(ctx, models.User{UserID: "u1", Username: "Alice", GuildID: "g1"}))
+		require.NoError(t, s.TrackUser(ctx, models.User{UserID: "u1", Username: "Alice", DisplayName: "Ally"}))
+		require.NoError(t, s.SetUserLocale(ctx, "u1", "pl"))
+		require.NoError(t, s.SetUserTimezone(ctx, "u1", "Europe/Warsaw"))
+
+		profile, err := s.GetUserProfile(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", profile.Username)
+		assert.Equal(t, "g1", profile.GuildID, "a DM keeps the last guild")
+		assert.Equal(t, "Ally", profile.DisplayName)
+		assert.True(t, profile.RemindersOff, "tracking a user keeps their reminder preference")
+		assert.Equal(t, "pl", profile.Locale)
+		assert.Equal(t, "Europe/Warsaw", profile.Timezone)
+		assert.WithinDuration(t, time.Now(), profile.LastSeen, time.Minute)
+
+		require.NoError(t, s.SetUserLocale(ctx, "u1", ""))
+		profiles, err := s.FetchUserProfiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, profiles, 1)
+		assert.Empty(t, profiles[0].Locale)
+	})
+
+	t.Run("SentReminders", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		reminder := SentReminder{UserID: "u1", Round: "stage_1", Lead: "1h0m0s", SentAt: time.Now()}
+		require.NoError(t, s.StoreSentReminder(ctx, reminder))
+		require.NoError(t, s.StoreSentReminder(ctx, reminder))
+		require.NoError(t, s.StoreSentReminder(ctx, SentReminder{UserID: "u1", Round: "stage_0", Lead: "1h0m0s"}))
+
+		got, err := s.FetchSentReminders(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "u1", got[0].UserID)
+		assert.WithinDuration(t, reminder.SentAt, got[0].SentAt, time.Millisecond)
+	})
+
+	// endregion
+
+	// region Guild tests
+
+	t.Run("MatchDayMessages", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		require.NoError(t, s.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g1", MessageID: "old", Round: "stage_1"}))
+		require.NoError(t, s.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g1", ChannelID: "c1", MessageID: "new", Round: "stage_1", Day: "2026-06-01"}))
+		require.NoError(t, s.StoreMatchDayMessage(ctx, MatchDayMessage{GuildID: "g2", MessageID: "other", Round: "stage_0"}))
+
+		got, err := s.FetchMatchDayMessages(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []MatchDayMessage{{GuildID: "g1", ChannelID: "c1", MessageID: "new", Round: "stage_1", Day: "2026-06-01"}}, got)
+
+		require.NoError(t, s.DeleteMatchDayMessage(ctx, "g1"))
+		require.NoError(t, s.DeleteMatchDayMessage(ctx, "g1"), "deleting a missing message is not an error")
+		got, err = s.FetchMatchDayMessages(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("GuildSettings", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		_, err := s.GetGuildSettings(ctx, "g1")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, s.StoreGuildSettings(ctx, GuildSettings{GuildID: "g1", Prefix: "!", AdminRoleID: "r1"}))
+		require.NoError(t, s.StoreGuildSettings(ctx, GuildSettings{GuildID: "g1", Locale: "pt"}))
+
+		got, err := s.GetGuildSettings(ctx, "g1")
+		require.NoError(t, err)
+		assert.Equal(t, GuildSettings{GuildID: "g1", Locale: "pt"}, got, "storing settings replaces them")
+
+		all, err := s.FetchAllGuildSettings(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("AuditLog_NewestFirstWithLimit", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		require.NoError(t, s.StoreAuditEntry(ctx, AuditEntry{GuildID: "g1", Command: "oldest", Timestamp: now.Add(-2 * time.Hour)}))
+		require.NoError(t, s.StoreAuditEntry(ctx, AuditEntry{GuildID: "", Command: "global", Timestamp: now.Add(-time.Hour)}))
+		require.NoError(t, s.StoreAuditEntry(ctx, AuditEntry{GuildID: "g2", Command: "other guild", Timestamp: now}))
+		require.NoError(t, s.StoreAuditEntry(ctx, AuditEntry{GuildID: "g1", Command: "newest", Args: "x", Result: "ok", Timestamp: now}))
+
+		got, err := s.FetchAuditEntries(ctx, "g1", 2)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "newest", got[0].Command)
+		assert.Equal(t, "x", got[0].Args)
+		assert.Equal(t, "global", got[1].Command)
+
+		all, err := s.FetchAuditEntries(ctx, "g1", 0)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	// endregion
+
+	// region Overrides and aliases tests
+
+	t.Run("ResultOverrides", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		assert.ErrorIs(t, s.DeleteResultOverride(ctx, "m1"), ErrNotFound)
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Alpha"}))
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Beta", Score: "2-1"}))
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_0", Winner: "Gamma"}))
+
+		got, err := s.FetchResultOverrides(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "Beta", got[0].Winner)
+		assert.Equal(t, "2-1", got[0].Score)
+
+		require.NoError(t, s.DeleteResultOverride(ctx, "m1"))
+		got, err = s.FetchResultOverrides(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("TeamAliases", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		assert.ErrorIs(t, s.DeleteTeamAlias(ctx, "navi"), ErrNotFound)
+		require.NoError(t, s.StoreTeamAlias(ctx, TeamAlias{Alias: "navi", Team: "Natus Vincere"}))
+		require.NoError(t, s.StoreTeamAlias(ctx, TeamAlias{Alias: "faze", Team: "FaZe Clan"}))
+		require.NoError(t, s.StoreTeamAlias(ctx, TeamAlias{Alias: "navi", Team: "NAVI"}))
+
+		got, err := s.FetchTeamAliases(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "faze", got[0].Alias)
+		assert.Equal(t, "NAVI", got[1].Team)
+
+		require.NoError(t, s.DeleteTeamAlias(ctx, "navi"))
+		got, err = s.FetchTeamAliases(ctx)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+
+	// endregion
+
+	// region Round tests
+
+	t.Run("ReadsOtherRounds", func(t *testing.T) {
+		s := newStore(t, nil)
+		ctx := context.Background()
+
+		results := tournament.SwissResult{Round: "stage_1", Teams: map[string]string{"Alpha": "3-0"}}
+		nodes := []sources.MatchNode{{ID: "m1", Team1: "Alpha", Team2: "Beta", Winner: "Alpha"}}
+		entries := []LeaderboardEntry{{UserID: "u1", Username: "Alice", Score: 3}}
+		require.NoError(t, s.StoreUserPrediction(ctx, "u1", models.Prediction{UserID: "u1", Username: "Alice", Round: "stage_1"}))
+		require.NoError(t, s.StoreMatchResults(ctx, results))
+		require.NoError(t, s.StoreMatchNodes(ctx, nodes, tournament.Swiss))
+		require.NoError(t, s.StoreLeaderboard(ctx, Leaderboard{Round: "stage_1", Entries: entries}))
+		require.NoError(t, s.StoreResultOverride(ctx, ResultOverride{MatchID: "m1", Round: "stage_1", Winner: "Beta"}))
+
+		s.Round = "stage_2"
+		require.NoError(t, s.StoreUserPrediction(ctx, "u2", models.Prediction{UserID: "u2", Username: "Bob", Round: "stage_2"}))
+
+		pred, err := s.GetUserPredictionForRound(ctx, "u1", "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", pred.Username)
+		pred, err = s.GetUserPredictionByUsernameForRound(ctx, "alice", "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, "u1", pred.UserID)
+		_, err = s.GetUserPredictionForRound(ctx, "u2", "stage_1")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		gotResults, err := s.GetMatchResultsForRound(ctx, "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, results, gotResults)
+		_, err = s.GetMatchResults(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		gotNodes, kind, err := s.FetchMatchNodesForRound(ctx, "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, nodes, gotNodes)
+		assert.Equal(t, tournament.Swiss, kind)
+
+		gotEntries, err := s.FetchLeaderboardForRound(ctx, "stage_1")
+		require.NoError(t, err)
+		assert.Equal(t, entries, gotEntries)
+
+		overrides, err := s.FetchResultOverridesForRound(ctx, "stage_1")
+		require.NoError(t, err)
+		require.Len(t, overrides, 1)
+		assert.Equal(t, "Beta", overrides[0].Winner)
+
+		rounds, err := s.FetchRounds(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"stage_1", "stage_2"}, rounds)
+	})
+
+	// endregion
+
+	// region Getter tests
+
+	t.Run("Getters", func(t *testing.T) {
+		s := newStore(t, nil)
+
+		assert.Equal(t, "test_db", s.GetDatabase().Name())
+		assert.Equal(t, "stage_1", s.GetRound())
+		assert.NoError(t, s.Ping(context.Background()))
+	})
+
+	// endregion
+}
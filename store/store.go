@@ -8,6 +8,7 @@ package store
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -18,22 +19,36 @@ type Store struct {
 	Client             *mongo.Client
 	TournamentDatabase *mongo.Database
 	VRSDatabase        *mongo.Database
-	Round              string
-	Collections        Collections
-	Fetcher            DataSourceFetcher
-	log                *slog.Logger
+	// VRSCollections are the collections of VRSDatabase the rankings are read from. Empty means
+	// DefaultVRSCollections, which follows the year.
+	VRSCollections []string
+	Round          string
+	Collections    Collections
+	Fetcher        DataSourceFetcher
+	// OpTimeout bounds each database operation and FetchTimeout each data source request, on top of whatever
+	// deadline the caller's context carries. Zero leaves them bounded only by the caller's context.
+	OpTimeout    time.Duration
+	FetchTimeout time.Duration
+	log          *slog.Logger
 }
 
 // Collections represents all of the structs the store object calls
-// All except VRS are part of this tournament's definited database
-// VRS lives in a seperate database, on the same mongo server
+// All are part of this tournament's definited database. The VRS rankings live in a seperate database, on the same
+// mongo server, see VRSDatabase
 type Collections struct {
-	Predictions   *mongo.Collection
-	MatchResults  *mongo.Collection
-	MatchNodes    *mongo.Collection
-	MatchSchedule *mongo.Collection
-	Leaderboard   *mongo.Collection
-	VRS           *mongo.Collection
+	Predictions      *mongo.Collection
+	MatchResults     *mongo.Collection
+	MatchNodes       *mongo.Collection
+	MatchSchedule    *mongo.Collection
+	Leaderboard      *mongo.Collection
+	Users            *mongo.Collection
+	Reminders        *mongo.Collection
+	MatchDayMessages *mongo.Collection
+	GuildSettings    *mongo.Collection
+	AuditLog         *mongo.Collection
+	Overrides        *mongo.Collection
+	TeamAliases      *mongo.Collection
+	Migrations       *mongo.Collection
 }
 
 // logger returns the store's logger, falling back to the global default when none was injected.
@@ -44,6 +59,27 @@ func (s *Store) logger() *slog.Logger {
 	return s.log
 }
 
+// withTimeout derives a context for a single database operation or data source request. A zero timeout only
+// inherits ctx's deadline and cancellation.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// upsertOne applies update to the document matching filter, inserting one if none matches. Two concurrent upserts
+// can both miss and try to insert; the unique index from EnsureIndexes rejects the second, which is retried once
+// and then updates the document the first inserted.
+func upsertOne(ctx context.Context, coll *mongo.Collection, filter any, update any) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := coll.UpdateOne(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(err) {
+		_, err = coll.UpdateOne(ctx, filter, update, opts)
+	}
+	return err
+}
+
 // NewStore initializes Store. Sets global values and initialises db connection.
 // log may be nil; if so the global slog default is used.
 func NewStore(dbName string, mongoURI string, round string, fetcher DataSourceFetcher, log *slog.Logger) (*Store, error) {
@@ -52,7 +88,7 @@ func NewStore(dbName string, mongoURI string, round string, fetcher DataSourceFe
 		return nil, err
 	}
 	db := client.Database(dbName)
-	vrsDb := client.Database("VRS_RANKINGS") // Hardcoded name for now, exists on the db server independent of tournament, and is updated externally
+	vrsDb := client.Database(DefaultVRSDatabase) // Exists on the db server independent of tournament, and is updated externally
 
 	return &Store{
 		Client:             client,
@@ -60,12 +96,19 @@ func NewStore(dbName string, mongoURI string, round string, fetcher DataSourceFe
 		VRSDatabase:        vrsDb,
 		Round:              round,
 		Collections: Collections{
-			Predictions:   db.Collection("user_predictions"),
-			MatchResults:  db.Collection("match_results"),
-			MatchNodes:    db.Collection("match_nodes"),
-			MatchSchedule: db.Collection("scheduled_matches"),
-			Leaderboard:   db.Collection("leaderboard"),
-			VRS:           vrsDb.Collection("2026"),
+			Predictions:      db.Collection("user_predictions"),
+			MatchResults:     db.Collection("match_results"),
+			MatchNodes:       db.Collection("match_nodes"),
+			MatchSchedule:    db.Collection("scheduled_matches"),
+			Leaderboard:      db.Collection("leaderboard"),
+			Users:            db.Collection("users"),
+			Reminders:        db.Collection("reminders"),
+			MatchDayMessages: db.Collection("match_day_messages"),
+			GuildSettings:    db.Collection("guild_settings"),
+			AuditLog:         db.Collection("audit_log"),
+			Overrides:        db.Collection("result_overrides"),
+			TeamAliases:      db.Collection("team_aliases"),
+			Migrations:       db.Collection("migrations"),
 		},
 		Fetcher: fetcher,
 		log:     log,
@@ -7,38 +7,110 @@ package store
 
 import (
 	"context"
+	"errors"
 
 	"pickems-bot/models"
 	"pickems-bot/sources"
 	"pickems-bot/tournament"
+
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Interface defines the methods that Store implements.
 // This allows for mocking in tests.
 type Interface interface {
-	EnsureScheduledMatches() error
-	GetValidTeams() ([]string, tournament.Kind, error)
-	StoreUserPrediction(userID string, prediction models.Prediction) error
-	GetUserPrediction(userID string) (models.Prediction, error)
-	GetUserPredictionByUsername(username string) (models.Prediction, error)
-	GetMatchResults() (tournament.MatchResult, error)
-	GetAllUserPredictions() ([]models.Prediction, error)
-	FetchMatchSchedule() ([]sources.ScheduledMatch, error)
-	StoreMatchSchedule(matches []sources.ScheduledMatch) error
-	FetchAndStoreSchedule() error
+	EnsureScheduledMatches(ctx context.Context) error
+	GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error)
+	StoreUserPrediction(ctx context.Context, userID string, prediction models.Prediction) error
+	GetUserPrediction(ctx context.Context, userID string) (models.Prediction, error)
+	GetUserPredictionByUsername(ctx context.Context, username string) (models.Prediction, error)
+	DeleteUserPrediction(ctx context.Context, userID string) error
+	GetMatchResults(ctx context.Context) (tournament.MatchResult, error)
+	GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error)
+	FetchMatchSchedule(ctx context.Context) ([]sources.ScheduledMatch, error)
+	StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error
+	FetchAndStoreSchedule(ctx context.Context) error
 	Ping(ctx context.Context) error
 
 	// Getter methods for accessing fields
 	GetDatabase() interface{ Name() string }
 	GetRound() string
 	GetClient() interface{ Disconnect(context.Context) error }
-	FetchAndUpdateMatchResults() error
-	FetchMatchNodesFromDb() ([]sources.MatchNode, tournament.Kind, error)
-	StoreLeaderboard(leaderboard Leaderboard) error
-	FetchLeaderboardFromDB() ([]LeaderboardEntry, error)
-	FetchVrsDataFromDB() ([]VRSEntry, error)
+	FetchAndUpdateMatchResults(ctx context.Context) error
+	FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error)
+	StoreLeaderboard(ctx context.Context, leaderboard Leaderboard) error
+	FetchLeaderboardFromDB(ctx context.Context) ([]LeaderboardEntry, error)
+	FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error)
+	FetchVrsStandingsDates(ctx context.Context) ([]string, error)
+	FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]VRSEntry, error)
+
+	// Round-parameterised reads, for looking back at rounds other than the configured one
+	FetchRounds(ctx context.Context) ([]string, error)
+	GetUserPredictionForRound(ctx context.Context, userID string, round string) (models.Prediction, error)
+	GetUserPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.Prediction, error)
+	GetMatchResultsForRound(ctx context.Context, round string) (tournament.MatchResult, error)
+	FetchMatchNodesForRound(ctx context.Context, round string) ([]sources.MatchNode, tournament.Kind, error)
+	FetchLeaderboardForRound(ctx context.Context, round string) ([]LeaderboardEntry, error)
+	FetchResultOverridesForRound(ctx context.Context, round string) ([]ResultOverride, error)
+
+	// Users and reminders
+	TrackUser(ctx context.Context, user models.User) error
+	SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error
+	GetUserProfile(ctx context.Context, userID string) (UserProfile, error)
+	GetUserProfileByUsername(ctx context.Context, username string) (UserProfile, error)
+	SetUserLocale(ctx context.Context, userID string, locale string) error
+	SetUserTimezone(ctx context.Context, userID string, timezone string) error
+	FetchUserProfiles(ctx context.Context) ([]UserProfile, error)
+	FetchPredictionUserIDs(ctx context.Context) ([]string, error)
+	FetchSentReminders(ctx context.Context) ([]SentReminder, error)
+	StoreSentReminder(ctx context.Context, reminder SentReminder) error
+
+	// Match day messages
+	FetchMatchDayMessages(ctx context.Context) ([]MatchDayMessage, error)
+	StoreMatchDayMessage(ctx context.Context, message MatchDayMessage) error
+	DeleteMatchDayMessage(ctx context.Context, guildID string) error
+
+	// Guild settings
+	GetGuildSettings(ctx context.Context, guildID string) (GuildSettings, error)
+	FetchAllGuildSettings(ctx context.Context) ([]GuildSettings, error)
+	StoreGuildSettings(ctx context.Context, settings GuildSettings) error
+
+	// Audit log
+	StoreAuditEntry(ctx context.Context, entry AuditEntry) error
+	FetchAuditEntries(ctx context.Context, guildID string, limit int) ([]AuditEntry, error)
+
+	// Result overrides
+	FetchResultOverrides(ctx context.Context) ([]ResultOverride, error)
+	StoreResultOverride(ctx context.Context, override ResultOverride) error
+	DeleteResultOverride(ctx context.Context, matchID string) error
+	RebuildMatchResults(ctx context.Context) error
+
+	// Team aliases
+	FetchTeamAliases(ctx context.Context) ([]TeamAlias, error)
+	StoreTeamAlias(ctx context.Context, alias TeamAlias) error
+	DeleteTeamAlias(ctx context.Context, alias string) error
+}
+
+// pipelineStore is the part of a backend the shared update pipelines (fetching from the data source, reconciling
+// overrides and rebuilding results) are written against, so they behave identically whatever the storage.
+type pipelineStore interface {
+	GetRound() string
+	StoreMatchResults(ctx context.Context, matchResult tournament.MatchResult) error
+	StoreMatchNodes(ctx context.Context, nodes []sources.MatchNode, kind tournament.Kind) error
+	FetchMatchNodesFromDb(ctx context.Context) ([]sources.MatchNode, tournament.Kind, error)
+	StoreMatchSchedule(ctx context.Context, matches []sources.ScheduledMatch) error
+	FetchResultOverrides(ctx context.Context) ([]ResultOverride, error)
+	DeleteResultOverride(ctx context.Context, matchID string) error
+	StoreAuditEntry(ctx context.Context, entry AuditEntry) error
 }
 
+// ErrNotFound is returned when a requested document does not exist. It is the same value as
+// mongo.ErrNoDocuments, so errors.Is matches either whichever backend produced it.
+var ErrNotFound = mongo.ErrNoDocuments
+
+// errNoFetcher is returned by the update pipelines when the store has no data source configured
+var errNoFetcher = errors.New("no data source configured")
+
 // Ping pings the database client to ensure its online
 func (s *Store) Ping(ctx context.Context) error {
 	return s.Client.Ping(ctx, nil)
@@ -46,6 +118,7 @@ func (s *Store) Ping(ctx context.Context) error {
 
 // Ensure Store implements Interface
 var _ Interface = (*Store)(nil)
+var _ pipelineStore = (*Store)(nil)
 
 // GetDatabase returns the database instance
 func (s *Store) GetDatabase() interface{ Name() string } {
@@ -6,7 +6,13 @@
 package store
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 )
 
 // Test getter methods
@@ -33,3 +39,90 @@ func TestStore_GetClient(t *testing.T) {
 	// Just test that method exists and returns (even if nil)
 	_ = result
 }
+
+func TestWithTimeout_AppliesTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected a deadline when a timeout is set")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("Expected deadline within a minute, got %v", remaining)
+	}
+}
+
+func TestWithTimeout_ZeroKeepsParentDeadline(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline when the timeout is zero")
+	}
+}
+
+func TestWithTimeout_InheritsCancellation(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := withTimeout(parent, time.Minute)
+	defer cancel()
+
+	cancelParent()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected the derived context to be cancelled with its parent, got %v", ctx.Err())
+	}
+}
+
+func TestUpsertOne_SendsUpsert(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sends a single update with upsert enabled", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := upsertOne(context.Background(), mt.Coll, bson.M{"round": "r"}, bson.M{"$set": bson.M{"round": "r"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		started := mt.GetStartedEvent()
+		if started.CommandName != "update" {
+			t.Fatalf("Expected an update command, got %s", started.CommandName)
+		}
+		update := started.Command.Lookup("updates").Array().Index(0).Value().Document()
+		if !update.Lookup("upsert").Boolean() {
+			t.Error("Expected the update to be an upsert")
+		}
+		if next := mt.GetStartedEvent(); next != nil {
+			t.Errorf("Expected a single command, got another %s", next.CommandName)
+		}
+	})
+}
+
+func TestUpsertOne_RetriesDuplicateKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	duplicate := mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key"})
+
+	mt.Run("retries once when a concurrent upsert inserted first", func(mt *mtest.T) {
+		mt.AddMockResponses(duplicate, mtest.CreateSuccessResponse())
+
+		if err := upsertOne(context.Background(), mt.Coll, bson.M{"round": "r"}, bson.M{"$set": bson.M{"round": "r"}}); err != nil {
+			t.Errorf("Expected the retry to succeed, got %v", err)
+		}
+	})
+
+	mt.Run("does not retry other errors", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 2, Message: "bad value"}), mtest.CreateSuccessResponse())
+
+		if err := upsertOne(context.Background(), mt.Coll, bson.M{"round": "r"}, bson.M{"$set": bson.M{"round": "r"}}); err == nil {
+			t.Error("Expected the error to be returned without a retry")
+		}
+	})
+
+	mt.Run("returns the error when the retry also fails", func(mt *mtest.T) {
+		mt.AddMockResponses(duplicate, duplicate)
+
+		if err := upsertOne(context.Background(), mt.Coll, bson.M{"round": "r"}, bson.M{"$set": bson.M{"round": "r"}}); err == nil {
+			t.Error("Expected an error after the retry failed")
+		}
+	})
+}
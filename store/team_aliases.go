@@ -0,0 +1,84 @@
+/* team_aliases.go
+ * Contains the methods for interacting with the team_aliases collection. Aliases map a name users or data
+ * sources write, such as "navi", to the team it stands for, and are consulted before fuzzy matching wherever a
+ * team name is resolved. They apply to every round.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/sources"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TeamAlias maps an alternative team name to the team it stands for
+type TeamAlias struct {
+	Alias     string    `bson:"alias"` // normalised with sources.NormalizeTeamName
+	Team      string    `bson:"team"`
+	SetBy     string    `bson:"set_by"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// AliasMap turns a list of aliases into the lookup used when resolving team names
+func AliasMap(aliases []TeamAlias) sources.TeamAliases {
+	m := make(sources.TeamAliases, len(aliases))
+	for _, a := range aliases {
+		m[a.Alias] = a.Team
+	}
+	return m
+}
+
+// FetchTeamAliases returns every team alias, sorted by alias.
+func (s *Store) FetchTeamAliases(ctx context.Context) ([]TeamAlias, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.TeamAliases.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "alias", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching team aliases from db: %w", err)
+	}
+	var aliases []TeamAlias
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of team aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// StoreTeamAlias stores an alias, replacing any existing alias with the same name.
+func (s *Store) StoreTeamAlias(ctx context.Context, alias TeamAlias) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"alias": alias.Alias}
+	doc, err := versioned(alias)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Collections.TeamAliases.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to store team alias: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeamAlias removes an alias by its normalised name. Returns ErrNotFound when there is no such
+// alias.
+func (s *Store) DeleteTeamAlias(ctx context.Context, alias string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	res, err := s.Collections.TeamAliases.DeleteOne(ctx, bson.M{"alias": alias})
+	if err != nil {
+		return fmt.Errorf("failed to delete team alias: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/* team_aliases_test.go
+ * Contains unit tests for team_aliases.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pickems-bot/sources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region AliasMap tests
+
+func TestAliasMap(t *testing.T) {
+	aliases := AliasMap([]TeamAlias{
+		{Alias: "navi", Team: "Natus Vincere"},
+		{Alias: "mousesports", Team: "MOUZ"},
+	})
+
+	assert.Equal(t, sources.TeamAliases{"navi": "Natus Vincere", "mousesports": "MOUZ"}, aliases)
+	assert.Equal(t, "Natus Vincere", aliases.Resolve("NaVi"))
+	assert.Equal(t, "Team Spirit", aliases.Resolve("Team Spirit"))
+	assert.Equal(t, aliases.Key("Natus Vincere"), aliases.Key("navi"))
+}
+
+// endregion
+
+// region FetchTeamAliases tests
+
+func TestFetchTeamAliases_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns every alias", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.team_aliases", mtest.FirstBatch,
+			bson.D{
+				{Key: "alias", Value: "navi"},
+				{Key: "team", Value: "Natus Vincere"},
+				{Key: "set_by", Value: "admin"},
+				{Key: "created_at", Value: time.Now()},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.team_aliases", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		aliases, err := store.FetchTeamAliases(context.Background())
+		require.NoError(t, err)
+		require.Len(t, aliases, 1)
+		assert.Equal(t, "navi", aliases[0].Alias)
+		assert.Equal(t, "Natus Vincere", aliases[0].Team)
+		assert.Equal(t, "admin", aliases[0].SetBy)
+	})
+}
+
+func TestFetchTeamAliases_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchTeamAliases(context.Background())
+		assert.ErrorContains(t, err, "error fetching team aliases")
+	})
+}
+
+// endregion
+
+// region StoreTeamAlias / DeleteTeamAlias tests
+
+func TestStoreTeamAlias(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts the alias", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		assert.NoError(t, store.StoreTeamAlias(context.Background(), TeamAlias{Alias: "navi", Team: "Natus Vincere"}))
+	})
+
+	mt.Run("returns error when replace fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.StoreTeamAlias(context.Background(), TeamAlias{Alias: "navi", Team: "Natus Vincere"})
+		assert.ErrorContains(t, err, "failed to store team alias")
+	})
+}
+
+func TestDeleteTeamAlias(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the alias", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+
+		assert.NoError(t, store.DeleteTeamAlias(context.Background(), "navi"))
+	})
+
+	mt.Run("returns ErrNoDocuments when there is no alias", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		assert.Equal(t, mongo.ErrNoDocuments, store.DeleteTeamAlias(context.Background(), "navi"))
+	})
+
+	mt.Run("returns error when delete fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{TeamAliases: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		assert.ErrorContains(t, store.DeleteTeamAlias(context.Background(), "navi"), "failed to delete team alias")
+	})
+}
+
+// endregion
@@ -19,50 +19,40 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// StoreUserPrediction inserts a new prediction for the given user, or replaces an existing one for the current round.
-func (s *Store) StoreUserPrediction(userID string, userPrediction models.Prediction) error {
+// StoreUserPrediction inserts a new prediction for the given user, or updates their existing one for the prediction's
+// round, in a single upsert.
+func (s *Store) StoreUserPrediction(ctx context.Context, userID string, userPrediction models.Prediction) error {
 	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
-	// Attempt to find an existing document
-	var result models.Prediction
-	err := s.Collections.Predictions.FindOne(context.TODO(), bson.M{"userid": userID, "round": userPrediction.Round}).Decode(&result)
-	notFound := errors.Is(err, mongo.ErrNoDocuments)
-
-	if err != nil && !notFound {
-		return fmt.Errorf("lookup for existing prediction failed: %w", err)
-	}
-
-	update := bson.M{
-		"$set": userPrediction,
-	}
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	filter := bson.M{
 		"userid": userID,
 		"round":  userPrediction.Round,
 	}
-
-	// The user currently does not have predictions stored so we create a new document
-	if notFound {
-		_, err := s.Collections.Predictions.InsertOne(context.TODO(), userPrediction)
-		if err != nil {
-			return fmt.Errorf("failed to insert new user prediction: %w", err)
-		}
-		return nil
-	}
-
-	// Else update the user's existing prediction
-	_, err = s.Collections.Predictions.UpdateOne(context.TODO(), filter, update)
+	doc, err := versioned(userPrediction)
 	if err != nil {
-		return fmt.Errorf("failed to update existing user prediction: %w", err)
+		return err
+	}
+	if err := upsertOne(ctx, s.Collections.Predictions, filter, bson.M{"$set": doc}); err != nil {
+		return fmt.Errorf("failed to store user prediction: %w", err)
 	}
 	return nil
 }
 
 // GetUserPrediction retrieves the stored prediction for the given user ID in the current round.
-func (s *Store) GetUserPrediction(userID string) (models.Prediction, error) {
+func (s *Store) GetUserPrediction(ctx context.Context, userID string) (models.Prediction, error) {
+	return s.GetUserPredictionForRound(ctx, userID, s.Round)
+}
+
+// GetUserPredictionForRound retrieves the stored prediction for the given user ID in the given round.
+func (s *Store) GetUserPredictionForRound(ctx context.Context, userID string, round string) (models.Prediction, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	opts := options.FindOne()
 
 	var result models.Prediction
-	err := s.Collections.Predictions.FindOne(context.TODO(), bson.M{"userid": userID, "round": s.Round}, opts).Decode(&result)
+	err := s.Collections.Predictions.FindOne(ctx, bson.M{"userid": userID, "round": round}, opts).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return models.Prediction{}, err
@@ -74,14 +64,22 @@ func (s *Store) GetUserPrediction(userID string) (models.Prediction, error) {
 }
 
 // GetUserPredictionByUsername retrieves the stored prediction for the given username (case-insensitive) in the current round.
-func (s *Store) GetUserPredictionByUsername(username string) (models.Prediction, error) {
+func (s *Store) GetUserPredictionByUsername(ctx context.Context, username string) (models.Prediction, error) {
+	return s.GetUserPredictionByUsernameForRound(ctx, username, s.Round)
+}
+
+// GetUserPredictionByUsernameForRound retrieves the stored prediction for the given username (case-insensitive) in
+// the given round.
+func (s *Store) GetUserPredictionByUsernameForRound(ctx context.Context, username string, round string) (models.Prediction, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	filter := bson.M{
-		"username": bson.M{"$regex": "^" + username + "$", "$options": "i"},
-		"round":    s.Round,
+		"username": usernameFilter(username),
+		"round":    round,
 	}
 	var result models.Prediction
-	err := s.Collections.Predictions.FindOne(context.TODO(), filter).Decode(&result)
+	err := s.Collections.Predictions.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return models.Prediction{}, err
@@ -91,14 +89,32 @@ func (s *Store) GetUserPredictionByUsername(username string) (models.Prediction,
 	return result, nil
 }
 
+// DeleteUserPrediction removes the given user's prediction for the current round. Returns ErrNotFound
+// when the user has no prediction stored.
+func (s *Store) DeleteUserPrediction(ctx context.Context, userID string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	res, err := s.Collections.Predictions.DeleteOne(ctx, bson.M{"userid": userID, "round": s.Round})
+	if err != nil {
+		return fmt.Errorf("failed to delete user prediction: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // GetAllUserPredictions returns all stored predictions for the current round.
-func (s *Store) GetAllUserPredictions() ([]models.Prediction, error) {
+func (s *Store) GetAllUserPredictions(ctx context.Context) ([]models.Prediction, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
 	// Filter query to match documents where the round is the round sting input to the function
 	filter := bson.D{{Key: "round", Value: s.Round}}
 
 	// Retrieves documents that match the filter
-	cursor, err := s.Collections.Predictions.Find(context.TODO(), filter)
+	cursor, err := s.Collections.Predictions.Find(ctx, filter)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, err
@@ -108,7 +124,7 @@ func (s *Store) GetAllUserPredictions() ([]models.Prediction, error) {
 
 	// Unpack the cursor into a slice
 	var results []models.Prediction
-	if err = cursor.All(context.TODO(), &results); err != nil {
+	if err = cursor.All(ctx, &results); err != nil {
 		return nil, fmt.Errorf("error unpacking cursor into slice of predictions: %w", err)
 	}
 
@@ -116,9 +132,9 @@ func (s *Store) GetAllUserPredictions() ([]models.Prediction, error) {
 }
 
 // GetValidTeams returns the valid team names and tournament format for the current round, derived from the stored match results.
-func (s *Store) GetValidTeams() ([]string, tournament.Kind, error) {
+func (s *Store) GetValidTeams(ctx context.Context) ([]string, tournament.Kind, error) {
 	// Get results stored in our db
-	dbResults, err := s.FetchMatchResultsFromDb()
+	dbResults, err := s.FetchMatchResultsFromDb(ctx)
 	if err != nil {
 		return nil, "", err
 	}
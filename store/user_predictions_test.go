@@ -33,9 +33,7 @@ func TestStoreUserPrediction_InsertNew(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents (new prediction)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
-		// Mock InsertOne success
+		// Mock the upsert inserting a new document
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
 		prediction := models.Prediction{
@@ -48,7 +46,7 @@ func TestStoreUserPrediction_InsertNew(t *testing.T) {
 			Lose:     []string{"Team E", "Team F"},
 		}
 
-		err := store.StoreUserPrediction("user123", prediction)
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
 		assert.NoError(t, err)
 	})
 }
@@ -66,18 +64,8 @@ func TestStoreUserPrediction_UpdateExisting(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning an existing document - need cursor response with getMore
-		first := mtest.CreateCursorResponse(1, "test.predictions", mtest.FirstBatch, bson.D{
-			{Key: "userid", Value: "user123"},
-			{Key: "round", Value: "test_round"},
-		})
-		getMore := mtest.CreateCursorResponse(0, "test.predictions", mtest.NextBatch)
-		// Mock UpdateOne success
-		updateSuccess := bson.D{
-			{Key: "ok", Value: 1},
-			{Key: "nModified", Value: 1},
-		}
-		mt.AddMockResponses(first, getMore, updateSuccess)
+		// Mock the upsert matching an existing document
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		prediction := models.Prediction{
 			UserID:   "user123",
@@ -87,15 +75,15 @@ func TestStoreUserPrediction_UpdateExisting(t *testing.T) {
 			Win:      []string{"Team X", "Team Y"},
 		}
 
-		err := store.StoreUserPrediction("user123", prediction)
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
 		assert.NoError(t, err)
 	})
 }
 
-func TestStoreUserPrediction_FindOneError(t *testing.T) {
+func TestStoreUserPrediction_UpsertError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when FindOne fails", func(mt *mtest.T) {
+	mt.Run("returns error when the upsert fails", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -105,9 +93,9 @@ func TestStoreUserPrediction_FindOneError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning an error
+		// Mock the upsert failing
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
-			Code:    11000,
+			Code:    2,
 			Message: "database error",
 		}))
 
@@ -116,16 +104,16 @@ func TestStoreUserPrediction_FindOneError(t *testing.T) {
 			Round:  "test_round",
 		}
 
-		err := store.StoreUserPrediction("user123", prediction)
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "lookup for existing prediction failed")
+		assert.Contains(t, err.Error(), "failed to store user prediction")
 	})
 }
 
-func TestStoreUserPrediction_InsertError(t *testing.T) {
+func TestStoreUserPrediction_DuplicateKeyTwice(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when insert fails", func(mt *mtest.T) {
+	mt.Run("returns error when the retried upsert also hits a duplicate key", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -135,9 +123,12 @@ func TestStoreUserPrediction_InsertError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning no documents
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
-		// Mock InsertOne error
+		// Mock both upsert attempts racing with a concurrent insert
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "duplicate key error",
+		}))
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
@@ -149,16 +140,16 @@ func TestStoreUserPrediction_InsertError(t *testing.T) {
 			Round:  "test_round",
 		}
 
-		err := store.StoreUserPrediction("user123", prediction)
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to insert new user prediction")
+		assert.Contains(t, err.Error(), "failed to store user prediction")
 	})
 }
 
-func TestStoreUserPrediction_UpdateError(t *testing.T) {
+func TestStoreUserPrediction_DuplicateKeyRetried(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns error when update fails", func(mt *mtest.T) {
+	mt.Run("retries the upsert after a duplicate key error", func(mt *mtest.T) {
 		store := &Store{
 			Client:             mt.Client,
 			TournamentDatabase: mt.DB,
@@ -168,27 +159,21 @@ func TestStoreUserPrediction_UpdateError(t *testing.T) {
 			},
 		}
 
-		// Mock FindOne returning an existing document
-		existingDoc := mtest.CreateCursorResponse(1, "test.predictions", mtest.FirstBatch, bson.D{
-			{Key: "userid", Value: "user123"},
-			{Key: "round", Value: "test_round"},
-		})
-		mt.AddMockResponses(existingDoc)
-		// Mock UpdateOne error
+		// Mock the first upsert losing the race to a concurrent insert, then the retry updating
 		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
 			Index:   0,
 			Code:    11000,
-			Message: "update failed",
+			Message: "duplicate key",
 		}))
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}, {Key: "nModified", Value: 1}})
 
 		prediction := models.Prediction{
 			UserID: "user123",
 			Round:  "test_round",
 		}
 
-		err := store.StoreUserPrediction("user123", prediction)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to update existing user prediction")
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
+		assert.NoError(t, err)
 	})
 }
 
@@ -217,7 +202,7 @@ func TestGetUserPrediction_Success(t *testing.T) {
 		})
 		mt.AddMockResponses(predictionDoc)
 
-		prediction, err := store.GetUserPrediction("user123")
+		prediction, err := store.GetUserPrediction(context.Background(), "user123")
 		require.NoError(t, err)
 		assert.Equal(t, "user123", prediction.UserID)
 		assert.Equal(t, "testuser", prediction.Username)
@@ -242,7 +227,7 @@ func TestGetUserPrediction_NotFound(t *testing.T) {
 		// Mock FindOne returning no documents
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
 
-		prediction, err := store.GetUserPrediction("nonexistent")
+		prediction, err := store.GetUserPrediction(context.Background(), "nonexistent")
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Equal(t, models.Prediction{}, prediction)
@@ -268,13 +253,73 @@ func TestGetUserPrediction_DatabaseError(t *testing.T) {
 			Message: "database error",
 		}))
 
-		prediction, err := store.GetUserPrediction("user123")
+		prediction, err := store.GetUserPrediction(context.Background(), "user123")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error fetching results from db")
 		assert.Equal(t, models.Prediction{}, prediction)
 	})
 }
 
+func TestGetUserPredictionByUsername_EscapesRegex(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("matches usernames with regex metacharacters literally", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.predictions", mtest.FirstBatch, bson.D{
+			{Key: "userid", Value: "user123"},
+			{Key: "username", Value: "x(y)*"},
+			{Key: "round", Value: "test_round"},
+		}))
+
+		prediction, err := store.GetUserPredictionByUsername(context.Background(), "x(y)*")
+		require.NoError(t, err)
+		assert.Equal(t, "user123", prediction.UserID)
+
+		started := mt.GetStartedEvent()
+		require.NotNil(t, started)
+		filter := started.Command.Lookup("filter").Document()
+		assert.Equal(t, `^x\(y\)\*$`, filter.Lookup("username", "$regex").StringValue())
+		assert.Equal(t, "test_round", filter.Lookup("round").StringValue())
+	})
+}
+
+func TestDeleteUserPrediction_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("deletes the prediction", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}})
+
+		err := store.DeleteUserPrediction(context.Background(), "user123")
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeleteUserPrediction_NotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns ErrNoDocuments when nothing was deleted", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}})
+
+		err := store.DeleteUserPrediction(context.Background(), "nonexistent")
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+}
+
+func TestDeleteUserPrediction_Error(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when delete fails", func(mt *mtest.T) {
+		store := &Store{Round: "test_round", Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.DeleteUserPrediction(context.Background(), "user123")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete user prediction")
+	})
+}
+
 func TestGetAllUserPredictions_Success(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
@@ -302,7 +347,7 @@ func TestGetAllUserPredictions_Success(t *testing.T) {
 		killCursors := mtest.CreateCursorResponse(0, "test.predictions", mtest.NextBatch)
 		mt.AddMockResponses(first, second, killCursors)
 
-		predictions, err := store.GetAllUserPredictions()
+		predictions, err := store.GetAllUserPredictions(context.Background())
 		require.NoError(t, err)
 		assert.Len(t, predictions, 2)
 		assert.Equal(t, "user1", predictions[0].UserID)
@@ -326,7 +371,7 @@ func TestGetAllUserPredictions_Empty(t *testing.T) {
 		// Mock Find returning empty cursor
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
 
-		predictions, err := store.GetAllUserPredictions()
+		predictions, err := store.GetAllUserPredictions(context.Background())
 		require.NoError(t, err)
 		assert.Empty(t, predictions)
 	})
@@ -351,7 +396,7 @@ func TestGetAllUserPredictions_FindError(t *testing.T) {
 			Message: "find failed",
 		}))
 
-		predictions, err := store.GetAllUserPredictions()
+		predictions, err := store.GetAllUserPredictions(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error fetching results from db")
 		assert.Nil(t, predictions)
@@ -383,7 +428,7 @@ func TestGetValidTeams_Swiss(t *testing.T) {
 		})
 		mt.AddMockResponses(swissDoc)
 
-		teams, kind, err := store.GetValidTeams()
+		teams, kind, err := store.GetValidTeams(context.Background())
 		require.NoError(t, err)
 		assert.Equal(t, tournament.Swiss, kind)
 		assert.Len(t, teams, 3)
@@ -423,7 +468,7 @@ func TestGetValidTeams_Elimination(t *testing.T) {
 		})
 		mt.AddMockResponses(elimDoc)
 
-		teams, kind, err := store.GetValidTeams()
+		teams, kind, err := store.GetValidTeams(context.Background())
 		require.NoError(t, err)
 		assert.Equal(t, tournament.SingleElim, kind)
 		assert.Len(t, teams, 2)
@@ -451,7 +496,7 @@ func TestGetValidTeams_FetchError(t *testing.T) {
 			Message: "fetch failed",
 		}))
 
-		teams, format, err := store.GetValidTeams()
+		teams, format, err := store.GetValidTeams(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, teams)
 		assert.Empty(t, format)
@@ -482,11 +527,10 @@ func TestStoreUserPrediction_Integration(t *testing.T) {
 			Lose:     []string{"Team I", "Team J"},
 		}
 
-		// Mock for StoreUserPrediction (FindOne + InsertOne)
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.predictions", mtest.FirstBatch))
+		// Mock for StoreUserPrediction (upsert)
 		mt.AddMockResponses(mtest.CreateSuccessResponse())
 
-		err := store.StoreUserPrediction("user123", prediction)
+		err := store.StoreUserPrediction(context.Background(), "user123", prediction)
 		require.NoError(t, err)
 
 		// Mock for GetUserPrediction
@@ -498,7 +542,7 @@ func TestStoreUserPrediction_Integration(t *testing.T) {
 			{Key: "win", Value: bson.A{"Team A", "Team B"}},
 		}))
 
-		retrieved, err := store.GetUserPrediction("user123")
+		retrieved, err := store.GetUserPrediction(context.Background(), "user123")
 		require.NoError(t, err)
 		assert.Equal(t, prediction.UserID, retrieved.UserID)
 		assert.Equal(t, prediction.Username, retrieved.Username)
@@ -532,22 +576,22 @@ func TestUserPredictions_WithRealDB(t *testing.T) {
 		prediction := CreateSamplePrediction("integration_user", "IntegrationUser", "swiss", store.Round)
 
 		// Store prediction
-		err := store.StoreUserPrediction(prediction.UserID, prediction)
+		err := store.StoreUserPrediction(context.Background(), prediction.UserID, prediction)
 		require.NoError(t, err)
 
 		// Retrieve prediction
-		retrieved, err := store.GetUserPrediction(prediction.UserID)
+		retrieved, err := store.GetUserPrediction(context.Background(), prediction.UserID)
 		require.NoError(t, err)
 		assert.Equal(t, prediction.UserID, retrieved.UserID)
 		assert.Equal(t, prediction.Username, retrieved.Username)
 
 		// Update prediction
 		prediction.Win = []string{"Updated Team A", "Updated Team B"}
-		err = store.StoreUserPrediction(prediction.UserID, prediction)
+		err = store.StoreUserPrediction(context.Background(), prediction.UserID, prediction)
 		require.NoError(t, err)
 
 		// Verify update
-		updated, err := store.GetUserPrediction(prediction.UserID)
+		updated, err := store.GetUserPrediction(context.Background(), prediction.UserID)
 		require.NoError(t, err)
 		assert.Equal(t, []string{"Updated Team A", "Updated Team B"}, updated.Win)
 
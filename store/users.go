@@ -0,0 +1,170 @@
+/* users.go
+ * Contains the methods for interacting with the users collection. A user document is created the first
+ * time someone interacts with the bot and refreshed on every interaction afterwards.
+ */
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"pickems-bot/metrics"
+	"pickems-bot/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserProfile represents a Discord user the bot has seen
+type UserProfile struct {
+	UserID       string    `bson:"userid"`
+	Username     string    `bson:"username"`
+	DisplayName  string    `bson:"display_name,omitempty"` // name shown in Discord, from the latest interaction
+	LastSeen     time.Time `bson:"last_seen"`
+	RemindersOff bool      `bson:"reminders_off"`
+	Locale       string    `bson:"locale,omitempty"`   // overrides the guild's locale for this user when set
+	Timezone     string    `bson:"timezone,omitempty"` // IANA timezone plain-text times are shown in
+	GuildID      string    `bson:"guild_id,omitempty"` // guild the user last used the bot in, for reminder DMs
+}
+
+// TrackUser records an interaction from the given user, creating their profile if it doesn't exist yet. The
+// profile's guild is only updated by interactions in a guild, so DMs keep the last one.
+func (s *Store) TrackUser(ctx context.Context, user models.User) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"userid": user.UserID}
+	set := bson.M{
+		"username":         user.Username,
+		"display_name":     user.DisplayName,
+		"last_seen":        time.Now().UTC(),
+		schemaVersionField: SchemaVersion,
+	}
+	if user.GuildID != "" {
+		set["guild_id"] = user.GuildID
+	}
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{"reminders_off": false},
+	}
+	if _, err := s.Collections.Users.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to track user: %w", err)
+	}
+	return nil
+}
+
+// SetRemindersEnabled opts the given user in to (or out of) pre-lock reminder DMs.
+func (s *Store) SetRemindersEnabled(ctx context.Context, userID string, enabled bool) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"userid": userID}
+	update := bson.M{"$set": bson.M{"reminders_off": !enabled, schemaVersionField: SchemaVersion}}
+	if _, err := s.Collections.Users.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to update reminder preference: %w", err)
+	}
+	return nil
+}
+
+// GetUserProfile returns the profile of the given user. Returns ErrNotFound if they have never been seen.
+func (s *Store) GetUserProfile(ctx context.Context, userID string) (UserProfile, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	var profile UserProfile
+	if err := s.Collections.Users.FindOne(ctx, bson.M{"userid": userID}).Decode(&profile); err != nil {
+		return UserProfile{}, err
+	}
+	return profile, nil
+}
+
+// GetUserProfileByUsername returns the profile of the user currently going by the given username (case-insensitive).
+// If several users have gone by it, the one seen most recently is returned. Returns ErrNotFound if nobody has.
+func (s *Store) GetUserProfileByUsername(ctx context.Context, username string) (UserProfile, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	opts := options.FindOne().SetSort(bson.D{{Key: "last_seen", Value: -1}})
+	var profile UserProfile
+	if err := s.Collections.Users.FindOne(ctx, bson.M{"username": usernameFilter(username)}, opts).Decode(&profile); err != nil {
+		return UserProfile{}, err
+	}
+	return profile, nil
+}
+
+// usernameFilter matches a username field equal to username, ignoring case. Usernames are escaped so regex
+// metacharacters in them are matched literally.
+func usernameFilter(username string) bson.M {
+	return bson.M{"$regex": "^" + regexp.QuoteMeta(username) + "$", "$options": "i"}
+}
+
+// SetUserLocale sets the locale bot responses to the given user are translated into. An empty locale clears it, so
+// the user falls back to their guild's locale.
+func (s *Store) SetUserLocale(ctx context.Context, userID string, locale string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"userid": userID}
+	update := bson.M{"$set": bson.M{"locale": locale, schemaVersionField: SchemaVersion}}
+	if locale == "" {
+		update = bson.M{"$unset": bson.M{"locale": ""}, "$set": bson.M{schemaVersionField: SchemaVersion}}
+	}
+	if _, err := s.Collections.Users.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to update user locale: %w", err)
+	}
+	return nil
+}
+
+// SetUserTimezone sets the IANA timezone plain-text times are shown to the given user in. An empty timezone clears
+// it.
+func (s *Store) SetUserTimezone(ctx context.Context, userID string, timezone string) error {
+	metrics.MongoOpsTotal.WithLabelValues("write").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	filter := bson.M{"userid": userID}
+	update := bson.M{"$set": bson.M{"timezone": timezone, schemaVersionField: SchemaVersion}}
+	if timezone == "" {
+		update = bson.M{"$unset": bson.M{"timezone": ""}, "$set": bson.M{schemaVersionField: SchemaVersion}}
+	}
+	if _, err := s.Collections.Users.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to update user timezone: %w", err)
+	}
+	return nil
+}
+
+// FetchUserProfiles returns every tracked user profile.
+func (s *Store) FetchUserProfiles(ctx context.Context) ([]UserProfile, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	cursor, err := s.Collections.Users.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user profiles from db: %w", err)
+	}
+	var results []UserProfile
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error unpacking cursor into slice of user profiles: %w", err)
+	}
+	return results, nil
+}
+
+// FetchPredictionUserIDs returns the distinct IDs of every user that has stored a prediction in any round.
+func (s *Store) FetchPredictionUserIDs(ctx context.Context) ([]string, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	ctx, cancel := withTimeout(ctx, s.OpTimeout)
+	defer cancel()
+	raw, err := s.Collections.Predictions.Distinct(ctx, "userid", bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching prediction user ids from db: %w", err)
+	}
+	ids := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if id, ok := v.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
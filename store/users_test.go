@@ -0,0 +1,277 @@
+/* users_test.go
+ * Contains unit tests for users.go
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pickems-bot/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// region TrackUser tests
+
+func TestTrackUser_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("upserts the user profile", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := store.TrackUser(context.Background(), models.User{UserID: "user1", Username: "alice"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestTrackUser_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when upsert fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"}))
+
+		err := store.TrackUser(context.Background(), models.User{UserID: "user1", Username: "alice"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to track user")
+	})
+}
+
+// endregion
+
+// region SetRemindersEnabled tests
+
+func TestSetRemindersEnabled_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("updates the reminder preference", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		assert.NoError(t, store.SetRemindersEnabled(context.Background(), "user1", false))
+	})
+}
+
+func TestSetRemindersEnabled_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when update fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.SetRemindersEnabled(context.Background(), "user1", true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update reminder preference")
+	})
+}
+
+// endregion
+
+// region GetUserProfile tests
+
+func TestGetUserProfile_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns the profile", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.users", mtest.FirstBatch,
+			bson.D{
+				{Key: "userid", Value: "user1"},
+				{Key: "username", Value: "alice"},
+				{Key: "locale", Value: "pt"},
+			},
+		))
+
+		profile, err := store.GetUserProfile(context.Background(), "user1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", profile.Username)
+		assert.Equal(t, "pt", profile.Locale)
+	})
+}
+
+func TestGetUserProfile_NotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns ErrNoDocuments for unknown users", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.users", mtest.FirstBatch))
+
+		_, err := store.GetUserProfile(context.Background(), "nobody")
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
+
+func TestGetUserProfileByUsername_EscapesAndSortsByLastSeen(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("matches the username literally", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.users", mtest.FirstBatch,
+			bson.D{{Key: "userid", Value: "user1"}, {Key: "username", Value: "a.b+c"}},
+		))
+
+		profile, err := store.GetUserProfileByUsername(context.Background(), "a.b+c")
+		require.NoError(t, err)
+		assert.Equal(t, "user1", profile.UserID)
+
+		started := mt.GetStartedEvent()
+		require.NotNil(t, started)
+		username := started.Command.Lookup("filter").Document().Lookup("username").Document()
+		assert.Equal(t, `^a\.b\+c$`, username.Lookup("$regex").StringValue())
+		assert.Equal(t, "i", username.Lookup("$options").StringValue())
+		sort := started.Command.Lookup("sort").Document()
+		assert.Equal(t, int32(-1), sort.Lookup("last_seen").Int32())
+	})
+}
+
+func TestGetUserProfileByUsername_NotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns ErrNoDocuments for unknown usernames", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.users", mtest.FirstBatch))
+
+		_, err := store.GetUserProfileByUsername(context.Background(), "nobody")
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
+
+// endregion
+
+// region SetUserLocale tests
+
+func TestSetUserLocale_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets and clears the locale", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		assert.NoError(t, store.SetUserLocale(context.Background(), "user1", "ru"))
+		assert.NoError(t, store.SetUserLocale(context.Background(), "user1", ""))
+	})
+}
+
+func TestSetUserLocale_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when update fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.SetUserLocale(context.Background(), "user1", "pl")
+		assert.ErrorContains(t, err, "failed to update user locale")
+	})
+}
+
+// endregion
+
+// region SetUserTimezone tests
+
+func TestSetUserTimezone_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sets and clears the timezone", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		assert.NoError(t, store.SetUserTimezone(context.Background(), "user1", "Europe/Warsaw"))
+		assert.NoError(t, store.SetUserTimezone(context.Background(), "user1", ""))
+	})
+}
+
+func TestSetUserTimezone_WriteError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when update fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		err := store.SetUserTimezone(context.Background(), "user1", "Asia/Tokyo")
+		assert.ErrorContains(t, err, "failed to update user timezone")
+	})
+}
+
+// endregion
+
+// region FetchUserProfiles tests
+
+func TestFetchUserProfiles_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns every profile", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		first := mtest.CreateCursorResponse(1, "test.users", mtest.FirstBatch,
+			bson.D{
+				{Key: "userid", Value: "user1"},
+				{Key: "username", Value: "alice"},
+				{Key: "last_seen", Value: time.Now()},
+				{Key: "reminders_off", Value: true},
+			},
+		)
+		killCursor := mtest.CreateCursorResponse(0, "test.users", mtest.NextBatch)
+		mt.AddMockResponses(first, killCursor)
+
+		profiles, err := store.FetchUserProfiles(context.Background())
+		require.NoError(t, err)
+		require.Len(t, profiles, 1)
+		assert.Equal(t, "user1", profiles[0].UserID)
+		assert.True(t, profiles[0].RemindersOff)
+	})
+}
+
+func TestFetchUserProfiles_FindError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when find fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Users: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchUserProfiles(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching user profiles")
+	})
+}
+
+// endregion
+
+// region FetchPredictionUserIDs tests
+
+func TestFetchPredictionUserIDs_Success(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns distinct user ids and skips blanks", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "values", Value: bson.A{"user1", "", "user2"}},
+		})
+
+		ids, err := store.FetchPredictionUserIDs(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user1", "user2"}, ids)
+	})
+}
+
+func TestFetchPredictionUserIDs_Error(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns error when distinct fails", func(mt *mtest.T) {
+		store := &Store{Collections: Collections{Predictions: mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+
+		_, err := store.FetchPredictionUserIDs(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error fetching prediction user ids")
+	})
+}
+
+// endregion
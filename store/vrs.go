@@ -1,13 +1,30 @@
+/* vrs.go
+ * Contains VRSEntry and the reads of the VRS world rankings. The rankings are synced into their own database by an
+ * external job, one snapshot per standings date, with a collection per year.
+ */
+
 package store
 
 import (
+	"cmp"
 	"context"
+	"fmt"
 	"pickems-bot/metrics"
+	"slices"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// DefaultVRSDatabase is the database the VRS sync job writes to
+const DefaultVRSDatabase = "VRS_RANKINGS"
+
+// VRSStandingsDateLayout is the layout of VRSEntry.StandingsDate. Dates in this layout sort chronologically as strings.
+const VRSStandingsDateLayout = "2006_01_02"
+
 // VRSEntry represents a single team's entry in the VRS world rankings database.
 type VRSEntry struct {
 	Standing      int       `bson:"standing"`
@@ -18,19 +35,118 @@ type VRSEntry struct {
 	SyncedAt      time.Time `bson:"synced_at"`
 }
 
-// FetchVrsDataFromDB retrieves all entries from the VRS rankings collection.
-// Note: fetches the entire collection on each call. This is acceptable given the
-// expected volume (~300 documents), but should be revisited if that changes.
-func (s *Store) FetchVrsDataFromDB() ([]VRSEntry, error) {
+// DefaultVRSCollections returns the collections rankings are read from when none are configured: the sync job names
+// them after the year, and last year's is kept so rank movement still works in January.
+func DefaultVRSCollections(now time.Time) []string {
+	year := now.UTC().Year()
+	return []string{strconv.Itoa(year - 1), strconv.Itoa(year)}
+}
+
+// vrsCollections returns the collections of the VRS database the rankings are read from
+func (s *Store) vrsCollections() []*mongo.Collection {
+	names := s.VRSCollections
+	if len(names) == 0 {
+		names = DefaultVRSCollections(time.Now())
+	}
+	collections := make([]*mongo.Collection, 0, len(names))
+	for _, name := range names {
+		collections = append(collections, s.VRSDatabase.Collection(name))
+	}
+	return collections
+}
+
+// FetchVrsStandingsDates returns the standings dates there are VRS snapshots of, oldest first.
+func (s *Store) FetchVrsStandingsDates(ctx context.Context) ([]string, error) {
 	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
-	var results []VRSEntry
-	cursor, err := s.Collections.VRS.Find(context.TODO(), bson.D{})
-	if err != nil {
-		return nil, err
+	var dates []string
+	for _, coll := range s.vrsCollections() {
+		distinctCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		values, err := coll.Distinct(distinctCtx, "standings_date", bson.D{})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching VRS standings dates from %s: %w", coll.Name(), err)
+		}
+		for _, value := range values {
+			if date, ok := value.(string); ok {
+				dates = append(dates, date)
+			}
+		}
 	}
+	return sortedStandingsDates(dates), nil
+}
 
-	if err = cursor.All(context.TODO(), &results); err != nil {
+// FetchVrsSnapshot returns the VRS rankings as of the given standings date, ordered by standing.
+func (s *Store) FetchVrsSnapshot(ctx context.Context, standingsDate string) ([]VRSEntry, error) {
+	metrics.MongoOpsTotal.WithLabelValues("read").Inc()
+	var entries []VRSEntry
+	for _, coll := range s.vrsCollections() {
+		findCtx, cancel := withTimeout(ctx, s.OpTimeout)
+		cursor, err := coll.Find(findCtx, bson.M{"standings_date": standingsDate}, options.Find().SetSort(bson.D{{Key: "standing", Value: 1}}))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		var results []VRSEntry
+		err = cursor.All(findCtx, &results)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, results...)
+	}
+	sortVRSEntries(entries)
+	return entries, nil
+}
+
+// FetchVrsDataFromDB returns the latest VRS rankings. CachedStore keeps repeat calls off the database.
+func (s *Store) FetchVrsDataFromDB(ctx context.Context) ([]VRSEntry, error) {
+	dates, err := s.FetchVrsStandingsDates(ctx)
+	if err != nil || len(dates) == 0 {
 		return nil, err
 	}
-	return results, err
+	return s.FetchVrsSnapshot(ctx, dates[len(dates)-1])
+}
+
+// sortedStandingsDates sorts standings dates oldest first and drops duplicates
+func sortedStandingsDates(dates []string) []string {
+	slices.Sort(dates)
+	return slices.Compact(dates)
+}
+
+// sortVRSEntries orders entries by standing, then team name
+func sortVRSEntries(entries []VRSEntry) {
+	slices.SortStableFunc(entries, func(a, b VRSEntry) int {
+		return cmp.Or(cmp.Compare(a.Standing, b.Standing), cmp.Compare(a.TeamName, b.TeamName))
+	})
+}
+
+// vrsStandingsDates returns the standings dates of entries, oldest first
+func vrsStandingsDates(entries []VRSEntry) []string {
+	dates := make([]string, 0, len(entries))
+	for _, e := range entries {
+		dates = append(dates, e.StandingsDate)
+	}
+	return sortedStandingsDates(dates)
+}
+
+// vrsSnapshot returns the entries of the given standings date, ordered by standing
+func vrsSnapshot(entries []VRSEntry, standingsDate string) []VRSEntry {
+	var snapshot []VRSEntry
+	for _, e := range entries {
+		if e.StandingsDate == standingsDate {
+			snapshot = append(snapshot, e)
+		}
+	}
+	sortVRSEntries(snapshot)
+	return snapshot
+}
+
+// replaceVRSSnapshots returns existing with the snapshots of every standings date in entries replaced by entries
+func replaceVRSSnapshots(existing []VRSEntry, entries []VRSEntry) []VRSEntry {
+	replaced := vrsStandingsDates(entries)
+	kept := slices.DeleteFunc(slices.Clone(existing), func(e VRSEntry) bool {
+		_, found := slices.BinarySearch(replaced, e.StandingsDate)
+		return found
+	})
+	return append(kept, entries...)
 }
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -10,15 +11,16 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 )
 
+// vrsTestStore returns a Store reading VRS rankings from the mock test collection
+func vrsTestStore(mt *mtest.T) *Store {
+	return &Store{VRSDatabase: mt.DB, VRSCollections: []string{mt.Coll.Name()}}
+}
+
 func TestFetchVrsDataFromDB_Success(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
-	mt.Run("returns VRS entries from database", func(mt *mtest.T) {
-		store := &Store{
-			Collections: Collections{
-				VRS: mt.Coll,
-			},
-		}
+	mt.Run("returns the latest VRS snapshot from database", func(mt *mtest.T) {
+		store := vrsTestStore(mt)
 
 		first := mtest.CreateCursorResponse(1, "test.vrs", mtest.FirstBatch,
 			bson.D{
@@ -41,9 +43,9 @@ func TestFetchVrsDataFromDB_Success(t *testing.T) {
 			},
 		)
 		killCursor := mtest.CreateCursorResponse(0, "test.vrs", mtest.NextBatch)
-		mt.AddMockResponses(first, second, killCursor)
+		mt.AddMockResponses(distinctResponse("2026_05_01", "2026_04_01"), first, second, killCursor)
 
-		entries, err := store.FetchVrsDataFromDB()
+		entries, err := store.FetchVrsDataFromDB(context.Background())
 		require.NoError(t, err)
 		require.Len(t, entries, 2)
 		assert.Equal(t, "Team Spirit", entries[0].TeamName)
@@ -58,13 +60,11 @@ func TestFetchVrsDataFromDB_Empty(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run("returns empty slice when no documents", func(mt *mtest.T) {
-		store := &Store{
-			Collections: Collections{VRS: mt.Coll},
-		}
+		store := vrsTestStore(mt)
 
-		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.vrs", mtest.FirstBatch))
+		mt.AddMockResponses(distinctResponse())
 
-		entries, err := store.FetchVrsDataFromDB()
+		entries, err := store.FetchVrsDataFromDB(context.Background())
 		require.NoError(t, err)
 		assert.Empty(t, entries)
 	})
@@ -74,17 +74,57 @@ func TestFetchVrsDataFromDB_DatabaseError(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run("returns error on database failure", func(mt *mtest.T) {
-		store := &Store{
-			Collections: Collections{VRS: mt.Coll},
-		}
+		store := vrsTestStore(mt)
 
 		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
 			Code:    11000,
 			Message: "database error",
 		}))
 
-		entries, err := store.FetchVrsDataFromDB()
+		entries, err := store.FetchVrsDataFromDB(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, entries)
 	})
 }
+
+func TestFetchVrsStandingsDates(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("merges the dates of every collection", func(mt *mtest.T) {
+		store := &Store{VRSDatabase: mt.DB, VRSCollections: []string{"2025", "2026"}}
+		mt.AddMockResponses(
+			distinctResponse("2025_12_01", "2025_11_01"),
+			distinctResponse("2026_01_05", "2025_12_01"),
+		)
+
+		dates, err := store.FetchVrsStandingsDates(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025_11_01", "2025_12_01", "2026_01_05"}, dates)
+	})
+}
+
+func TestFetchVrsSnapshot(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters by standings date", func(mt *mtest.T) {
+		store := vrsTestStore(mt)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.vrs", mtest.FirstBatch,
+			bson.D{{Key: "standing", Value: 1}, {Key: "team_name", Value: "Vitality"}, {Key: "standings_date", Value: "2026_04_01"}},
+		))
+
+		entries, err := store.FetchVrsSnapshot(context.Background(), "2026_04_01")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Vitality", entries[0].TeamName)
+
+		started := mt.GetStartedEvent()
+		require.NotNil(t, started)
+		assert.Equal(t, "find", started.CommandName)
+		filter := started.Command.Lookup("filter").Document()
+		assert.Equal(t, "2026_04_01", filter.Lookup("standings_date").StringValue())
+	})
+}
+
+func TestDefaultVRSCollections(t *testing.T) {
+	assert.Equal(t, []string{"2025", "2026"}, DefaultVRSCollections(time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)))
+}
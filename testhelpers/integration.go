@@ -7,6 +7,7 @@ package testhelpers
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -69,6 +71,29 @@ func MongoURI(t *testing.T) string {
 	return uri
 }
 
+// PostgresURI returns the PostgreSQL connection URL for integration tests from
+// POSTGRES_TEST_URI. If it is not set, or the server is not reachable within
+// 2 seconds, the test is skipped.
+func PostgresURI(t *testing.T) string {
+	t.Helper()
+	uri := os.Getenv("POSTGRES_TEST_URI")
+	if uri == "" {
+		t.Skip("skipping: POSTGRES_TEST_URI is not set")
+	}
+
+	db, err := sql.Open("pgx", uri)
+	if err != nil {
+		t.Skipf("skipping: could not open postgres connection: %v", err)
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		t.Skipf("skipping: PostgreSQL not reachable: %v", err)
+	}
+	return uri
+}
+
 // FireCallback asks the test server to POST a Liquipedia webhook payload to
 // callbackURL after delay seconds. Returns immediately (server responds 202).
 //
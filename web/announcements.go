@@ -0,0 +1,51 @@
+/* announcements.go
+ * Contains the hook the update pipelines use to announce newly decided matches without depending on the
+ * bot package or discordgo.
+ */
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"pickems-bot/app"
+)
+
+// Announcer publishes tournament updates, e.g. to Discord. *bot.Bot implements it.
+type Announcer interface {
+	// AnnounceResults posts newly decided match results.
+	AnnounceResults(ctx context.Context, announcement app.ResultsAnnouncement)
+	// RefreshMatchDay brings any live-updating match day messages up to date with the stored schedule.
+	RefreshMatchDay(ctx context.Context)
+}
+
+// resultsSnapshot takes a snapshot of the stored results before an update. ok is false when there is no
+// announcer or the snapshot could not be taken, in which case nothing should be announced.
+func resultsSnapshot(ctx context.Context, a *app.App, announcer Announcer, log *slog.Logger) (snapshot app.ResultsSnapshot, ok bool) {
+	if announcer == nil {
+		return app.ResultsSnapshot{}, false
+	}
+	snapshot, err := a.SnapshotResults(ctx)
+	if err != nil {
+		log.Warn("failed to snapshot results, skipping announcement", "error", fmt.Errorf("resultsSnapshot: %w", err))
+		return app.ResultsSnapshot{}, false
+	}
+	return snapshot, true
+}
+
+// announceNewResults diffs the stored results against before and hands any newly decided matches to the
+// announcer.
+func announceNewResults(ctx context.Context, a *app.App, announcer Announcer, before app.ResultsSnapshot, log *slog.Logger) {
+	announcement, err := a.ResultsAnnouncement(ctx, before)
+	if err != nil {
+		log.Warn("failed to build results announcement", "error", fmt.Errorf("announceNewResults: %w", err))
+		return
+	}
+	if len(announcement.Matches) == 0 {
+		return
+	}
+	log.Info("announcing new results", "matches", len(announcement.Matches), "picks_decided", announcement.PicksDecided)
+	announcer.AnnounceResults(ctx, announcement)
+}
@@ -0,0 +1,98 @@
+/* announcements_test.go
+ * Contains unit tests for announcements.go
+ */
+
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	apiPkg "pickems-bot/app"
+	"pickems-bot/sources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAnnouncer records every announcement and match day refresh it receives
+type recordingAnnouncer struct {
+	announcements []apiPkg.ResultsAnnouncement
+	refreshes     int
+}
+
+func (r *recordingAnnouncer) AnnounceResults(_ context.Context, announcement apiPkg.ResultsAnnouncement) {
+	r.announcements = append(r.announcements, announcement)
+}
+
+func (r *recordingAnnouncer) RefreshMatchDay(context.Context) {
+	r.refreshes++
+}
+
+// region announcement hook tests
+
+func TestResultsSnapshot_NoAnnouncer(t *testing.T) {
+	a := apiPkg.NewTestApp(apiPkg.NewMockStore("swiss", "test_round"))
+
+	_, ok := resultsSnapshot(context.Background(), a, nil, slog.Default())
+	assert.False(t, ok)
+}
+
+func TestResultsSnapshot_StoreError(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchNodesFromDbError = errors.New("db down")
+	a := apiPkg.NewTestApp(mockStore)
+
+	_, ok := resultsSnapshot(context.Background(), a, &recordingAnnouncer{}, slog.Default())
+	assert.False(t, ok)
+}
+
+func TestAnnounceNewResults_AnnouncesNewWinners(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B"}}
+	a := apiPkg.NewTestApp(mockStore)
+	announcer := &recordingAnnouncer{}
+
+	before, ok := resultsSnapshot(context.Background(), a, announcer, slog.Default())
+	require.True(t, ok)
+	mockStore.MatchNodes[0].Winner = "Team A"
+	announceNewResults(context.Background(), a, announcer, before, slog.Default())
+
+	require.Len(t, announcer.announcements, 1)
+	assert.Equal(t, "Team A", announcer.announcements[0].Matches[0].Winner)
+}
+
+func TestAnnounceNewResults_NothingNew(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	mockStore.MatchNodes = []sources.MatchNode{{ID: "m1", Team1: "Team A", Team2: "Team B"}}
+	a := apiPkg.NewTestApp(mockStore)
+	announcer := &recordingAnnouncer{}
+
+	before, _ := resultsSnapshot(context.Background(), a, announcer, slog.Default())
+	announceNewResults(context.Background(), a, announcer, before, slog.Default())
+
+	assert.Empty(t, announcer.announcements)
+}
+
+func TestAnnounceNewResults_StoreError(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	a := apiPkg.NewTestApp(mockStore)
+	announcer := &recordingAnnouncer{}
+
+	mockStore.FetchLeaderboardFromDBError = errors.New("db down")
+	announceNewResults(context.Background(), a, announcer, apiPkg.ResultsSnapshot{}, slog.Default())
+
+	assert.Empty(t, announcer.announcements)
+}
+
+func TestPoller_SetAnnouncer(t *testing.T) {
+	p := NewPoller(nil, 1, 0, "key", "", nil)
+	announcer := &recordingAnnouncer{}
+
+	p.SetAnnouncer(announcer)
+	assert.Equal(t, announcer, p.announcer)
+}
+
+// endregion
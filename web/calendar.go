@@ -0,0 +1,77 @@
+/* calendar.go
+ * Contains the calendar server, which serves the current round's schedule as an iCalendar feed that calendar
+ * apps can subscribe to.
+ */
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"pickems-bot/app"
+	"time"
+)
+
+// CalendarConfig holds the config for the calendar server
+type CalendarConfig struct {
+	Addr   string
+	App    *app.App
+	Logger *slog.Logger
+}
+
+// CalendarServer is the HTTP server that serves the calendar feed
+type CalendarServer struct {
+	app *app.App
+	log *slog.Logger
+}
+
+// StartCalendarServer initializes and starts the HTTP server with the passed config. It shuts down gracefully
+// once ctx is cancelled.
+func StartCalendarServer(ctx context.Context, cfg CalendarConfig) error {
+	s := &CalendarServer{app: cfg.App, log: cfg.Logger}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendar.ics", s.calendarHandler)
+
+	srv := &http.Server{
+		Addr:         cfg.Addr,
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+	}
+
+	s.logger().Info("calendar server listening", "addr", cfg.Addr)
+	return serve(ctx, srv)
+}
+
+// calendarHandler responds with the current round's schedule as text/calendar
+func (s *CalendarServer) calendarHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	calendar, err := s.app.GetCalendar(r.Context(), time.Now())
+	if err != nil {
+		s.logger().Error("failed to build calendar", "error", fmt.Errorf("calendarHandler: %w", err))
+		http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="pickems.ics"`)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(calendar)
+	}
+}
+
+func (s *CalendarServer) logger() *slog.Logger {
+	if s.log == nil {
+		return slog.Default()
+	}
+	return s.log
+}
@@ -0,0 +1,71 @@
+/* calendar_test.go
+ * Contains unit tests for calendar.go (CalendarServer feed handler)
+ */
+
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiPkg "pickems-bot/app"
+	"pickems-bot/sources"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCalendarServer builds a CalendarServer wired to the given MockStore
+func newTestCalendarServer(mockStore *apiPkg.MockStore) *CalendarServer {
+	return &CalendarServer{app: apiPkg.NewTestApp(mockStore)}
+}
+
+// region calendarHandler tests
+
+func TestCalendarHandler_ServesFeed(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	mockStore.SetScheduledMatches([]sources.ScheduledMatch{
+		{Team1: "Team A", Team2: "Team B", BestOf: "1", EpochTime: 1700000000},
+	})
+	s := newTestCalendarServer(mockStore)
+
+	w := httptest.NewRecorder()
+	s.calendarHandler(w, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "SUMMARY:Team A vs Team B (Bo1)")
+}
+
+func TestCalendarHandler_Head(t *testing.T) {
+	s := newTestCalendarServer(apiPkg.NewMockStore("swiss", "test_round"))
+
+	w := httptest.NewRecorder()
+	s.calendarHandler(w, httptest.NewRequest(http.MethodHead, "/calendar.ics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestCalendarHandler_MethodNotAllowed(t *testing.T) {
+	s := newTestCalendarServer(apiPkg.NewMockStore("swiss", "test_round"))
+
+	w := httptest.NewRecorder()
+	s.calendarHandler(w, httptest.NewRequest(http.MethodPost, "/calendar.ics", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestCalendarHandler_StoreError(t *testing.T) {
+	mockStore := apiPkg.NewMockStore("swiss", "test_round")
+	mockStore.FetchMatchScheduleError = errors.New("db down")
+	s := newTestCalendarServer(mockStore)
+
+	w := httptest.NewRecorder()
+	s.calendarHandler(w, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// endregion
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"pickems-bot/app"
 	"pickems-bot/metrics"
 	"pickems-bot/sources"
+	"pickems-bot/store"
 	"pickems-bot/tournament"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -61,18 +63,28 @@ func (s *Server) LiquipediaWebhookHandler(w http.ResponseWriter, r *http.Request
 
 	// Kick async pipeline – call into your existing packages (/api, /bot, etc)
 	go func(e LiquipediaEvent) {
-		if err := s.api.UpdateMatchSchedule(); err != nil {
+		ctx := s.context()
+		if err := s.api.UpdateMatchSchedule(ctx); err != nil {
 			s.logger().Warn("update match schedule failed", "error", fmt.Errorf("webhook pipeline: %w", err))
 		}
-		if err := s.api.UpdateMatchResults(); err != nil {
+		s.api.InvalidateCache(store.CacheSchedule)
+		before, announce := resultsSnapshot(ctx, s.api, s.announcer, s.logger())
+		if err := s.api.UpdateMatchResults(ctx); err != nil {
 			s.logger().Error("update match results failed", "error", fmt.Errorf("webhook pipeline: %w", err))
 			return
 		}
-		if err := s.api.GenerateLeaderboard(); err != nil {
+		s.api.InvalidateCache(store.CacheResults)
+		if err := s.api.GenerateLeaderboard(ctx); err != nil {
 			s.logger().Error("generate leaderboard failed", "error", fmt.Errorf("webhook pipeline: %w", err))
 			return
 		}
-		if err := RenderResultsImage(s.api); err != nil {
+		if announce {
+			announceNewResults(ctx, s.api, s.announcer, before, s.logger())
+		}
+		if s.announcer != nil {
+			s.announcer.RefreshMatchDay(ctx)
+		}
+		if err := RenderResultsImage(ctx, s.api); err != nil {
 			s.logger().Error("render results image failed", "error", fmt.Errorf("webhook pipeline: %w", err))
 		}
 	}(event)
@@ -82,16 +94,17 @@ func (s *Server) LiquipediaWebhookHandler(w http.ResponseWriter, r *http.Request
 
 const resultImagePath = "resources/result.png"
 
-// RenderResultsImage fetches match nodes from the DB and regenerates the result image on disk.
+// RenderResultsImage fetches match nodes from the DB, applies any result overrides and regenerates the result
+// image on disk.
 // It is called at startup and after each webhook update to ensure the image is always current.
-func RenderResultsImage(a *app.App) error {
+func RenderResultsImage(ctx context.Context, a *app.App) error {
 	timer := prometheus.NewTimer(metrics.ImageRenderDuration)
 	defer timer.ObserveDuration()
 
 	if err := os.MkdirAll("resources", 0755); err != nil {
 		return fmt.Errorf("failed to create resources directory: %w", err)
 	}
-	nodes, kind, err := a.Store.FetchMatchNodesFromDb()
+	nodes, kind, err := a.MatchNodes(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch match nodes: %w", err)
 	}
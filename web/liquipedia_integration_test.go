@@ -33,7 +33,7 @@ func newLiquipediaTestApp(t *testing.T, cfg config.Config, mongoURI string) *app
 	t.Helper()
 	t.Setenv("LIQUIDPEDIADB_API_KEY", "pickems-test-key")
 
-	a, err := app.NewApp(cfg, mongoURI, slog.Default())
+	a, err := app.NewApp(context.Background(), cfg, mongoURI, slog.Default())
 	require.NoError(t, err)
 
 	app.SetRateLimiterForTesting(a, newUnlimitedLimiter())
@@ -79,10 +79,10 @@ func TestLiquipedia_WebhookTriggersUpdate(t *testing.T) {
 	a := newLiquipediaTestApp(t, cfg, mongoURI)
 
 	// Populate initial match data so the store is not empty.
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
 	// Record the current match state before the webhook fires.
-	nodesBefore, _, err := a.Store.FetchMatchNodesFromDb()
+	nodesBefore, _, err := a.Store.FetchMatchNodesFromDb(context.Background())
 	require.NoError(t, err)
 
 	// Start the bot's webhook handler.
@@ -98,7 +98,7 @@ func TestLiquipedia_WebhookTriggersUpdate(t *testing.T) {
 
 	// Poll the store for up to 15 seconds waiting for the pipeline to update.
 	updated := pollUntil(15*time.Second, 500*time.Millisecond, func() bool {
-		nodesAfter, _, err := a.Store.FetchMatchNodesFromDb()
+		nodesAfter, _, err := a.Store.FetchMatchNodesFromDb(context.Background())
 		if err != nil || len(nodesAfter) == 0 {
 			return false
 		}
@@ -116,7 +116,7 @@ func TestLiquipedia_IrrelevantWebhookFiltered(t *testing.T) {
 
 	cfg := loadLiquipediaTestCfg(t)
 	a := newLiquipediaTestApp(t, cfg, mongoURI)
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
 	ts := newWebhookServer(t, a, cfg.Liquipedia.Page)
 
@@ -135,6 +135,6 @@ func TestLiquipedia_IrrelevantWebhookFiltered(t *testing.T) {
 	// If the handler leaked the update through, FetchMatchNodesFromDb would
 	// still return the original data — any change here would be a bug.
 	// We just verify the call did not panic and the server is still responsive.
-	_, _, err = a.Store.FetchMatchNodesFromDb()
+	_, _, err = a.Store.FetchMatchNodesFromDb(context.Background())
 	assert.NoError(t, err, "store should be readable after irrelevant webhook")
 }
@@ -7,6 +7,7 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -253,7 +254,7 @@ func TestRenderResultsImage_FetchError(t *testing.T) {
 	mockStore.FetchMatchNodesFromDbError = fmt.Errorf("db error")
 	a := apiPkg.NewTestApp(mockStore)
 
-	err := RenderResultsImage(a)
+	err := RenderResultsImage(context.Background(), a)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to fetch match nodes")
@@ -264,7 +265,7 @@ func TestRenderResultsImage_EmptyKind(t *testing.T) {
 	// default mock returns kind="" — should get "kind was empty" error
 	a := apiPkg.NewTestApp(mockStore)
 
-	err := RenderResultsImage(a)
+	err := RenderResultsImage(context.Background(), a)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "kind was empty")
@@ -276,7 +277,7 @@ func TestRenderResultsImage_SwissKind_ReachesRenderer(t *testing.T) {
 	a := apiPkg.NewTestApp(mockStore)
 
 	// Just verify the path runs without panicking; RenderBracket may succeed or fail with empty nodes.
-	_ = RenderResultsImage(a)
+	_ = RenderResultsImage(context.Background(), a)
 }
 
 func TestRenderResultsImage_SingleElimKind_CoversNormalisationPath(t *testing.T) {
@@ -284,7 +285,7 @@ func TestRenderResultsImage_SingleElimKind_CoversNormalisationPath(t *testing.T)
 	mockStore.MatchKind = "single-elimination"
 	a := apiPkg.NewTestApp(mockStore)
 
-	_ = RenderResultsImage(a)
+	_ = RenderResultsImage(context.Background(), a)
 }
 
 // endregion
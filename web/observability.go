@@ -29,8 +29,9 @@ type TelemetryServer struct {
 	log       *slog.Logger
 }
 
-// StartTelemetryServer initializes and starts the HTTP server with the passed config
-func StartTelemetryServer(cfg TelemetryConfig) error {
+// StartTelemetryServer initializes and starts the HTTP server with the passed config. It shuts down gracefully
+// once ctx is cancelled.
+func StartTelemetryServer(ctx context.Context, cfg TelemetryConfig) error {
 	s := &TelemetryServer{
 		app:       cfg.App,
 		discord:   cfg.Discord,
@@ -50,7 +51,7 @@ func StartTelemetryServer(cfg TelemetryConfig) error {
 	}
 
 	s.logger().Info("observability server listening", "addr", cfg.Addr)
-	return srv.ListenAndServe()
+	return serve(ctx, srv)
 }
 
 // HealthResponse is the JSON response body returned by the /health endpoint.
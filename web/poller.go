@@ -1,12 +1,14 @@
 package web
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"pickems-bot/app"
 	"pickems-bot/metrics"
 	"pickems-bot/sources"
+	"pickems-bot/store"
 	"sort"
 	"strings"
 	"time"
@@ -21,8 +23,11 @@ type Poller struct {
 	apiKey           string
 	apiURL           string
 	interval         time.Duration
+	fetchTimeout     time.Duration
+	maxPages         int
 	knownStatus      map[string]string // matchID -> last known status
 	knownScheduleKey string            // fingerprint of last stored schedule
+	announcer        Announcer
 	log              *slog.Logger
 }
 
@@ -53,28 +58,59 @@ func NewPoller(a *app.App, seriesID int, tournamentID int, apiKey string, apiURL
 	}
 }
 
-// Start runs the poller. Note this runs for the lifetime of the program
-func (p *Poller) Start() {
+// SetAnnouncer registers an Announcer that receives newly decided matches after each results update.
+func (p *Poller) SetAnnouncer(announcer Announcer) {
+	p.announcer = announcer
+}
+
+// SetFetchTimeout bounds each PandaScore request. Zero leaves requests bounded only by the poller's context.
+func (p *Poller) SetFetchTimeout(timeout time.Duration) {
+	p.fetchTimeout = timeout
+}
+
+// SetMaxPages caps the number of pages of matches fetched per tick. Zero uses sources.DefaultMaxPages.
+func (p *Poller) SetMaxPages(maxPages int) {
+	p.maxPages = maxPages
+}
+
+// Start runs the poller until ctx is cancelled or an unrecoverable error occurs.
+func (p *Poller) Start(ctx context.Context) {
 	ticker := time.NewTicker(p.interval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		if !p.tick() {
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			if !p.tick(ctx) {
+				return
+			}
 		}
 	}
 }
 
+// fetch retrieves the raw PandaScore matches, bounded by the configured fetch timeout. Every page takes a token
+// from the app's rate limiter.
+func (p *Poller) fetch(ctx context.Context) (string, error) {
+	if p.fetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.fetchTimeout)
+		defer cancel()
+	}
+	return sources.DefaultClient.WithLimiter(p.app.RateLimiter()).GetPandaScoreMatches(ctx, p.apiURL, p.apiKey, p.seriesID, p.tournamentID, p.maxPages)
+}
+
 // tick is the logic that happens per tick of the poller.
 // Returns false if the poller should stop, true if it should continue.
-func (p *Poller) tick() bool {
+func (p *Poller) tick(ctx context.Context) bool {
 	// make sure we are not exceeding our rate limiter limitation
 	if !p.app.Allow() {
 		p.logger().Warn("rate limit reached, skipping tick")
 		return true
 	}
 
-	raw, err := sources.GetPandaScoreMatches(p.apiURL, p.apiKey, p.seriesID, p.tournamentID)
+	raw, err := p.fetch(ctx)
 	if err != nil {
 		if errors.Is(err, sources.ErrUnrecoverable) {
 			p.logger().Error("unrecoverable fetch error, stopping poller", "error", fmt.Errorf("poller.tick: %w", err))
@@ -102,15 +138,18 @@ func (p *Poller) tick() bool {
 		p.knownStatus[matchNode.ID] = matchNode.Status
 	}
 
+	scheduleChanged := false
 	scheduledMatches, err := sources.ParsePandaScoreSchedule(raw, p.tournamentID)
 	if err != nil {
 		p.logger().Warn("failed to parse PandaScore schedule, skipping schedule update", "error", fmt.Errorf("poller.tick: %w", err))
 	} else if key := scheduleKey(scheduledMatches); key != p.knownScheduleKey {
-		if err := p.app.StoreSchedule(scheduledMatches); err != nil {
+		if err := p.app.StoreSchedule(ctx, scheduledMatches); err != nil {
 			p.logger().Warn("failed to store match schedule", "error", fmt.Errorf("poller.tick: %w", err))
 		} else {
 			p.logger().Info("match schedule updated", "matches", len(scheduledMatches))
+			p.app.InvalidateCache(store.CacheSchedule)
 			p.knownScheduleKey = key
+			scheduleChanged = true
 		}
 	}
 
@@ -118,31 +157,41 @@ func (p *Poller) tick() bool {
 	metrics.PollerTicksTotal.Inc()
 
 	if finishedTransition {
-		if err := p.app.UpdateMatchResults(); err != nil {
+		before, announce := resultsSnapshot(ctx, p.app, p.announcer, p.logger())
+		if err := p.app.UpdateMatchResults(ctx); err != nil {
 			p.logger().Warn("failed to update match results", "error", fmt.Errorf("poller.tick: %w", err))
 		}
-		if err := p.app.GenerateLeaderboard(); err != nil {
+		p.app.InvalidateCache(store.CacheResults)
+		if err := p.app.GenerateLeaderboard(ctx); err != nil {
 			p.logger().Warn("failed to generate leaderboard", "error", fmt.Errorf("poller.tick: %w", err))
 		}
-		if err := RenderResultsImage(p.app); err != nil {
+		if announce {
+			announceNewResults(ctx, p.app, p.announcer, before, p.logger())
+		}
+		if err := RenderResultsImage(ctx, p.app); err != nil {
 			p.logger().Warn("failed to render results image", "error", fmt.Errorf("poller.tick: %w", err))
 		}
 	}
 
+	if (scheduleChanged || finishedTransition) && p.announcer != nil {
+		p.announcer.RefreshMatchDay(ctx)
+	}
+
 	return true
 }
 
 // scheduleKey returns a fingerprint of a scheduled match slice. Two slices with the
-// same teams and start times (regardless of order) produce the same key, so the
-// poller can detect real changes without writing to the DB every tick.
+// same teams, start times and live/finished state (regardless of order) produce the
+// same key, so the poller can detect real changes without writing to the DB every tick.
 func scheduleKey(matches []sources.ScheduledMatch) string {
 	type entry struct {
-		team1, team2 string
-		epoch        int64
+		team1, team2   string
+		epoch          int64
+		live, finished bool
 	}
 	entries := make([]entry, len(matches))
 	for i, m := range matches {
-		entries[i] = entry{m.Team1, m.Team2, m.EpochTime}
+		entries[i] = entry{m.Team1, m.Team2, m.EpochTime, m.Live, m.Finished}
 	}
 	sort.Slice(entries, func(i, j int) bool {
 		if entries[i].team1 != entries[j].team1 {
@@ -152,7 +201,7 @@ func scheduleKey(matches []sources.ScheduledMatch) string {
 	})
 	var b strings.Builder
 	for _, e := range entries {
-		fmt.Fprintf(&b, "%s|%s|%d;", e.team1, e.team2, e.epoch)
+		fmt.Fprintf(&b, "%s|%s|%d|%t|%t;", e.team1, e.team2, e.epoch, e.live, e.finished)
 	}
 	return b.String()
 }
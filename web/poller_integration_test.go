@@ -29,7 +29,7 @@ func newPollerTestApp(t *testing.T, cfg config.Config, mongoURI string) *app.App
 	t.Helper()
 	t.Setenv("PANDASCORE_API_KEY", "pickems-test-key")
 
-	a, err := app.NewApp(cfg, mongoURI, slog.Default())
+	a, err := app.NewApp(context.Background(), cfg, mongoURI, slog.Default())
 	require.NoError(t, err)
 
 	// Override to unlimited so rate limiting doesn't interfere with test ticks.
@@ -57,13 +57,13 @@ func TestPoller_NoOp(t *testing.T) {
 	a := newPollerTestApp(t, cfg, mongoURI)
 
 	// Populate so the App's store has data and rate limiter is primed.
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
 	p := newTestPoller(t, a, cfg)
 
 	// First tick: establishes the knownStatus baseline; no previous state to
 	// compare against so no transition is detected.
-	assert.True(t, p.tick(), "first tick should return true (keep going)")
+	assert.True(t, p.tick(context.Background()), "first tick should return true (keep going)")
 	assert.NotEmpty(t, p.knownStatus, "knownStatus should be populated after first tick")
 	baselineStatus := make(map[string]string, len(p.knownStatus))
 	for k, v := range p.knownStatus {
@@ -72,7 +72,7 @@ func TestPoller_NoOp(t *testing.T) {
 
 	// Second tick: same state — no match transitioned to finished, so
 	// UpdateMatchResults must NOT be called and the poller must keep going.
-	assert.True(t, p.tick(), "second tick (no change) should return true")
+	assert.True(t, p.tick(context.Background()), "second tick (no change) should return true")
 	assert.Equal(t, baselineStatus, p.knownStatus,
 		"knownStatus should be unchanged when server state has not changed")
 }
@@ -85,12 +85,12 @@ func TestPoller_Transition(t *testing.T) {
 
 	cfg := loadPollerTestCfg(t)
 	a := newPollerTestApp(t, cfg, mongoURI)
-	require.NoError(t, a.PopulateMatches(false))
+	require.NoError(t, a.PopulateMatches(context.Background(), false))
 
 	p := newTestPoller(t, a, cfg)
 
 	// First tick: establish baseline. State is "ongoing".
-	assert.True(t, p.tick(), "first tick should return true")
+	assert.True(t, p.tick(context.Background()), "first tick should return true")
 	assert.NotEmpty(t, p.knownStatus)
 
 	// Advance to "complete": one additional match moves from not_started/running
@@ -98,7 +98,7 @@ func TestPoller_Transition(t *testing.T) {
 	testhelpers.SetState(t, "pandascore", "complete")
 
 	// Second tick: detects the transition and updates match results.
-	assert.True(t, p.tick(), "second tick (with transition) should return true")
+	assert.True(t, p.tick(context.Background()), "second tick (with transition) should return true")
 
 	// Verify at least one match is now "finished" in knownStatus.
 	finishedCount := 0
@@ -6,6 +6,7 @@
 package web
 
 import (
+	"context"
 	"log/slog"
 	"pickems-bot/sources"
 	"testing"
@@ -41,6 +42,29 @@ func TestNewPoller_KnownStatusInitialised(t *testing.T) {
 
 // endregion
 
+// region Start tests
+
+func TestPoller_Start_StopsOnCancel(t *testing.T) {
+	p := NewPoller(nil, 1, 0, "key", "", nil)
+	p.interval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		p.Start(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after its context was cancelled")
+	}
+}
+
+// endregion
+
 // region logger tests
 
 func TestPoller_Logger_NilLog_ReturnsDefault(t *testing.T) {
@@ -171,3 +195,19 @@ func TestScheduleKey_SameTeam1_SortsByTeam2(t *testing.T) {
 }
 
 // endregion
+
+// region scheduleKey live/finished tests
+
+func TestScheduleKey_DetectsLiveChange(t *testing.T) {
+	before := []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", EpochTime: 1000}}
+	after := []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", EpochTime: 1000, Live: true}}
+	assert.NotEqual(t, scheduleKey(before), scheduleKey(after))
+}
+
+func TestScheduleKey_DetectsFinishedChange(t *testing.T) {
+	before := []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", EpochTime: 1000, Live: true}}
+	after := []sources.ScheduledMatch{{Team1: "Team A", Team2: "Team B", EpochTime: 1000, Finished: true}}
+	assert.NotEqual(t, scheduleKey(before), scheduleKey(after))
+}
+
+// endregion
@@ -7,7 +7,10 @@
 package web
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"pickems-bot/app"
 	"time"
@@ -19,15 +22,24 @@ type Config struct {
 	API    *app.App
 	Page   string // Liquipedia page path, used for webhook filtering
 	Logger *slog.Logger
+	// Announcer receives newly decided matches after each update. May be nil.
+	Announcer Announcer
 }
 
 // Server is the HTTP server that handles webhook requests
 type Server struct {
-	api  *app.App
-	page string // Liquipedia page path, used for webhook filtering
-	log  *slog.Logger
+	api       *app.App
+	page      string // Liquipedia page path, used for webhook filtering
+	log       *slog.Logger
+	announcer Announcer
+	// baseCtx is cancelled on shutdown; webhook pipelines run under it rather than under the request context,
+	// which ends as soon as the handler returns.
+	baseCtx context.Context
 }
 
+// shutdownTimeout bounds how long a server waits for in-flight requests once its context is cancelled.
+const shutdownTimeout = 10 * time.Second
+
 // logger returns the server's logger, falling back to the global default when none was injected.
 func (s *Server) logger() *slog.Logger {
 	if s.log == nil {
@@ -36,16 +48,28 @@ func (s *Server) logger() *slog.Logger {
 	return s.log
 }
 
-// Start initializes and starts the HTTP server with the given configuration
-func Start(cfg Config) error {
+// context returns the context background work should run under, falling back to context.Background() for
+// servers constructed without Start.
+func (s *Server) context() context.Context {
+	if s.baseCtx == nil {
+		return context.Background()
+	}
+	return s.baseCtx
+}
+
+// Start initializes and starts the HTTP server with the given configuration. It shuts down gracefully once ctx
+// is cancelled.
+func Start(ctx context.Context, cfg Config) error {
 	var serverLog *slog.Logger
 	if cfg.Logger != nil {
 		serverLog = cfg.Logger.With("component", "web")
 	}
 	s := &Server{
-		api:  cfg.API,
-		page: cfg.Page,
-		log:  serverLog,
+		api:       cfg.API,
+		page:      cfg.Page,
+		log:       serverLog,
+		announcer: cfg.Announcer,
+		baseCtx:   ctx,
 	}
 
 	mux := http.NewServeMux()
@@ -59,5 +83,29 @@ func Start(cfg Config) error {
 	}
 
 	s.logger().Info("HTTP server listening", "addr", cfg.Addr)
-	return srv.ListenAndServe()
+	return serve(ctx, srv)
+}
+
+// serve runs srv until it fails or ctx is cancelled, in which case it stops accepting connections and waits up
+// to shutdownTimeout for in-flight requests. Requests inherit ctx, so long-running handlers see the shutdown too.
+func serve(ctx context.Context, srv *http.Server) error {
+	srv.BaseContext = func(net.Listener) context.Context { return ctx }
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
 }
@@ -6,7 +6,10 @@
 package web
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +48,22 @@ func TestServer_NewServer(t *testing.T) {
 	assert.Nil(t, s.api)
 }
 
+func TestServe_ShutsDownOnCancel(t *testing.T) {
+	srv := &http.Server{Addr: "127.0.0.1:0", Handler: http.NotFoundHandler()}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- serve(ctx, srv) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("serve did not return after its context was cancelled")
+	}
+}
+
 // endregion
 
 // Note: Start() function cannot be easily unit tested as it blocks on ListenAndServe
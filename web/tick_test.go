@@ -5,10 +5,12 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"pickems-bot/app"
 	"pickems-bot/sources"
@@ -61,7 +63,7 @@ func TestTick_RateLimitReached_ReturnsTrue(t *testing.T) {
 	a := &app.App{Store: mockStore} // rateLimiter is nil → Allow() returns false
 	p := newMockPoller(a, "http://localhost")
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.True(t, result, "tick() must return true when rate-limited (poller keeps running)")
 }
 
@@ -76,7 +78,7 @@ func TestTick_UnrecoverableError_ReturnsFalse(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.False(t, result, "tick() must return false on unrecoverable error")
 }
 
@@ -91,10 +93,31 @@ func TestTick_RetriableError_ReturnsTrue(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.True(t, result, "tick() must return true on retriable error")
 }
 
+func TestTick_FetchTimeout_ReturnsTrue(t *testing.T) {
+	// PandaScore hangs past the fetch timeout → retriable error → tick() returns true.
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	mockStore := app.NewMockStore("swiss", "test_round")
+	a := app.NewTestApp(mockStore)
+	p := newMockPoller(a, srv.URL)
+	p.SetFetchTimeout(20 * time.Millisecond)
+
+	result := p.tick(context.Background())
+	assert.True(t, result, "tick() must return true when the fetch times out (will retry)")
+}
+
 func TestTick_ParseError_ReturnsTrue(t *testing.T) {
 	// PandaScore returns invalid JSON → parse error → tick() returns true.
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -107,7 +130,7 @@ func TestTick_ParseError_ReturnsTrue(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.True(t, result, "tick() must return true on parse error (will retry)")
 }
 
@@ -123,7 +146,7 @@ func TestTick_NoTransition_ReturnsTrue(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.True(t, result)
 	assert.Equal(t, "not_started", p.knownStatus["1"])
 }
@@ -149,7 +172,7 @@ func TestTick_FinishedTransition_TriggersUpdateAndReturnsTrue(t *testing.T) {
 	// Seed knownStatus so the match looks like it was "running" before this tick.
 	p.knownStatus["1"] = "running"
 
-	result := p.tick()
+	result := p.tick(context.Background())
 	assert.True(t, result, "tick() must return true after processing a finished transition")
 	assert.Equal(t, "finished", p.knownStatus["1"])
 }
@@ -166,7 +189,7 @@ func TestTick_ScheduleChanged_StoresSchedule(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 
 	assert.True(t, result)
 	assert.NotEmpty(t, p.knownScheduleKey)
@@ -184,10 +207,10 @@ func TestTick_ScheduleUnchanged_DoesNotStoreAgain(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	p.tick() // first tick — stores schedule
+	p.tick(context.Background()) // first tick — stores schedule
 	writeCountAfterFirst := mockStore.StoreMatchScheduleCallCount
 
-	p.tick() // second tick — key unchanged, should not store again
+	p.tick(context.Background()) // second tick — key unchanged, should not store again
 	assert.Equal(t, writeCountAfterFirst, mockStore.StoreMatchScheduleCallCount)
 }
 
@@ -205,7 +228,7 @@ func TestTick_ScheduleStoreError_KeyNotUpdated(t *testing.T) {
 	a := app.NewTestApp(mockStore)
 	p := newMockPoller(a, srv.URL)
 
-	result := p.tick()
+	result := p.tick(context.Background())
 
 	assert.True(t, result)
 	assert.Empty(t, p.knownScheduleKey)